@@ -0,0 +1,1525 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.9
+// 	protoc        v5.29.3
+// source: ci/v1/builds.proto
+
+package civ1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Build struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Id                string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	RepoUrl           string                 `protobuf:"bytes,2,opt,name=repo_url,json=repoUrl,proto3" json:"repo_url,omitempty"`
+	Ref               string                 `protobuf:"bytes,3,opt,name=ref,proto3" json:"ref,omitempty"`
+	Command           string                 `protobuf:"bytes,4,opt,name=command,proto3" json:"command,omitempty"`
+	Status            string                 `protobuf:"bytes,5,opt,name=status,proto3" json:"status,omitempty"`
+	ExitCode          int32                  `protobuf:"varint,6,opt,name=exit_code,json=exitCode,proto3" json:"exit_code,omitempty"`
+	Error             string                 `protobuf:"bytes,7,opt,name=error,proto3" json:"error,omitempty"`
+	Attempts          int32                  `protobuf:"varint,8,opt,name=attempts,proto3" json:"attempts,omitempty"`
+	Env               map[string]string      `protobuf:"bytes,9,rep,name=env,proto3" json:"env,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Artifacts         []string               `protobuf:"bytes,10,rep,name=artifacts,proto3" json:"artifacts,omitempty"`
+	Caches            []*Cache               `protobuf:"bytes,11,rep,name=caches,proto3" json:"caches,omitempty"`
+	TriggeredBy       string                 `protobuf:"bytes,12,opt,name=triggered_by,json=triggeredBy,proto3" json:"triggered_by,omitempty"`
+	ParentId          string                 `protobuf:"bytes,13,opt,name=parent_id,json=parentId,proto3" json:"parent_id,omitempty"`
+	MatrixValues      map[string]string      `protobuf:"bytes,14,rep,name=matrix_values,json=matrixValues,proto3" json:"matrix_values,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Jobs              []*Job                 `protobuf:"bytes,15,rep,name=jobs,proto3" json:"jobs,omitempty"`
+	ChildIds          []string               `protobuf:"bytes,16,rep,name=child_ids,json=childIds,proto3" json:"child_ids,omitempty"`
+	CreatedAt         *timestamppb.Timestamp `protobuf:"bytes,17,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt         *timestamppb.Timestamp `protobuf:"bytes,18,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	FinishedAt        *timestamppb.Timestamp `protobuf:"bytes,19,opt,name=finished_at,json=finishedAt,proto3" json:"finished_at,omitempty"`
+	CancelRequestedAt *timestamppb.Timestamp `protobuf:"bytes,20,opt,name=cancel_requested_at,json=cancelRequestedAt,proto3" json:"cancel_requested_at,omitempty"`
+	Priority          int32                  `protobuf:"varint,21,opt,name=priority,proto3" json:"priority,omitempty"`
+	RunsOn            []string               `protobuf:"bytes,22,rep,name=runs_on,json=runsOn,proto3" json:"runs_on,omitempty"`
+	// unschedulable is set on pending builds no online worker can run.
+	Unschedulable     bool                   `protobuf:"varint,23,opt,name=unschedulable,proto3" json:"unschedulable,omitempty"`
+	ConcurrencyGroup  string                 `protobuf:"bytes,24,opt,name=concurrency_group,json=concurrencyGroup,proto3" json:"concurrency_group,omitempty"`
+	MaxConcurrency    int32                  `protobuf:"varint,25,opt,name=max_concurrency,json=maxConcurrency,proto3" json:"max_concurrency,omitempty"`
+	ConcurrencyPolicy string                 `protobuf:"bytes,26,opt,name=concurrency_policy,json=concurrencyPolicy,proto3" json:"concurrency_policy,omitempty"`
+	NotBefore         *timestamppb.Timestamp `protobuf:"bytes,27,opt,name=not_before,json=notBefore,proto3" json:"not_before,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *Build) Reset() {
+	*x = Build{}
+	mi := &file_ci_v1_builds_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Build) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Build) ProtoMessage() {}
+
+func (x *Build) ProtoReflect() protoreflect.Message {
+	mi := &file_ci_v1_builds_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Build.ProtoReflect.Descriptor instead.
+func (*Build) Descriptor() ([]byte, []int) {
+	return file_ci_v1_builds_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Build) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Build) GetRepoUrl() string {
+	if x != nil {
+		return x.RepoUrl
+	}
+	return ""
+}
+
+func (x *Build) GetRef() string {
+	if x != nil {
+		return x.Ref
+	}
+	return ""
+}
+
+func (x *Build) GetCommand() string {
+	if x != nil {
+		return x.Command
+	}
+	return ""
+}
+
+func (x *Build) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *Build) GetExitCode() int32 {
+	if x != nil {
+		return x.ExitCode
+	}
+	return 0
+}
+
+func (x *Build) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *Build) GetAttempts() int32 {
+	if x != nil {
+		return x.Attempts
+	}
+	return 0
+}
+
+func (x *Build) GetEnv() map[string]string {
+	if x != nil {
+		return x.Env
+	}
+	return nil
+}
+
+func (x *Build) GetArtifacts() []string {
+	if x != nil {
+		return x.Artifacts
+	}
+	return nil
+}
+
+func (x *Build) GetCaches() []*Cache {
+	if x != nil {
+		return x.Caches
+	}
+	return nil
+}
+
+func (x *Build) GetTriggeredBy() string {
+	if x != nil {
+		return x.TriggeredBy
+	}
+	return ""
+}
+
+func (x *Build) GetParentId() string {
+	if x != nil {
+		return x.ParentId
+	}
+	return ""
+}
+
+func (x *Build) GetMatrixValues() map[string]string {
+	if x != nil {
+		return x.MatrixValues
+	}
+	return nil
+}
+
+func (x *Build) GetJobs() []*Job {
+	if x != nil {
+		return x.Jobs
+	}
+	return nil
+}
+
+func (x *Build) GetChildIds() []string {
+	if x != nil {
+		return x.ChildIds
+	}
+	return nil
+}
+
+func (x *Build) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Build) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+func (x *Build) GetFinishedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.FinishedAt
+	}
+	return nil
+}
+
+func (x *Build) GetCancelRequestedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CancelRequestedAt
+	}
+	return nil
+}
+
+func (x *Build) GetPriority() int32 {
+	if x != nil {
+		return x.Priority
+	}
+	return 0
+}
+
+func (x *Build) GetRunsOn() []string {
+	if x != nil {
+		return x.RunsOn
+	}
+	return nil
+}
+
+func (x *Build) GetUnschedulable() bool {
+	if x != nil {
+		return x.Unschedulable
+	}
+	return false
+}
+
+func (x *Build) GetConcurrencyGroup() string {
+	if x != nil {
+		return x.ConcurrencyGroup
+	}
+	return ""
+}
+
+func (x *Build) GetMaxConcurrency() int32 {
+	if x != nil {
+		return x.MaxConcurrency
+	}
+	return 0
+}
+
+func (x *Build) GetConcurrencyPolicy() string {
+	if x != nil {
+		return x.ConcurrencyPolicy
+	}
+	return ""
+}
+
+func (x *Build) GetNotBefore() *timestamppb.Timestamp {
+	if x != nil {
+		return x.NotBefore
+	}
+	return nil
+}
+
+type Job struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Command       string                 `protobuf:"bytes,3,opt,name=command,proto3" json:"command,omitempty"`
+	Needs         []string               `protobuf:"bytes,4,rep,name=needs,proto3" json:"needs,omitempty"`
+	Artifacts     []string               `protobuf:"bytes,5,rep,name=artifacts,proto3" json:"artifacts,omitempty"`
+	Status        string                 `protobuf:"bytes,6,opt,name=status,proto3" json:"status,omitempty"`
+	LockedBy      string                 `protobuf:"bytes,7,opt,name=locked_by,json=lockedBy,proto3" json:"locked_by,omitempty"`
+	ExitCode      int32                  `protobuf:"varint,8,opt,name=exit_code,json=exitCode,proto3" json:"exit_code,omitempty"`
+	Error         string                 `protobuf:"bytes,9,opt,name=error,proto3" json:"error,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	FinishedAt    *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=finished_at,json=finishedAt,proto3" json:"finished_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Job) Reset() {
+	*x = Job{}
+	mi := &file_ci_v1_builds_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Job) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Job) ProtoMessage() {}
+
+func (x *Job) ProtoReflect() protoreflect.Message {
+	mi := &file_ci_v1_builds_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Job.ProtoReflect.Descriptor instead.
+func (*Job) Descriptor() ([]byte, []int) {
+	return file_ci_v1_builds_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Job) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Job) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Job) GetCommand() string {
+	if x != nil {
+		return x.Command
+	}
+	return ""
+}
+
+func (x *Job) GetNeeds() []string {
+	if x != nil {
+		return x.Needs
+	}
+	return nil
+}
+
+func (x *Job) GetArtifacts() []string {
+	if x != nil {
+		return x.Artifacts
+	}
+	return nil
+}
+
+func (x *Job) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *Job) GetLockedBy() string {
+	if x != nil {
+		return x.LockedBy
+	}
+	return ""
+}
+
+func (x *Job) GetExitCode() int32 {
+	if x != nil {
+		return x.ExitCode
+	}
+	return 0
+}
+
+func (x *Job) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *Job) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Job) GetFinishedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.FinishedAt
+	}
+	return nil
+}
+
+type Cache struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Key           string                 `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Paths         []string               `protobuf:"bytes,2,rep,name=paths,proto3" json:"paths,omitempty"`
+	RestoreKeys   []string               `protobuf:"bytes,3,rep,name=restore_keys,json=restoreKeys,proto3" json:"restore_keys,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Cache) Reset() {
+	*x = Cache{}
+	mi := &file_ci_v1_builds_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Cache) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Cache) ProtoMessage() {}
+
+func (x *Cache) ProtoReflect() protoreflect.Message {
+	mi := &file_ci_v1_builds_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Cache.ProtoReflect.Descriptor instead.
+func (*Cache) Descriptor() ([]byte, []int) {
+	return file_ci_v1_builds_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Cache) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *Cache) GetPaths() []string {
+	if x != nil {
+		return x.Paths
+	}
+	return nil
+}
+
+func (x *Cache) GetRestoreKeys() []string {
+	if x != nil {
+		return x.RestoreKeys
+	}
+	return nil
+}
+
+type JobSpec struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Command       string                 `protobuf:"bytes,2,opt,name=command,proto3" json:"command,omitempty"`
+	Needs         []string               `protobuf:"bytes,3,rep,name=needs,proto3" json:"needs,omitempty"`
+	Artifacts     []string               `protobuf:"bytes,4,rep,name=artifacts,proto3" json:"artifacts,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *JobSpec) Reset() {
+	*x = JobSpec{}
+	mi := &file_ci_v1_builds_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *JobSpec) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*JobSpec) ProtoMessage() {}
+
+func (x *JobSpec) ProtoReflect() protoreflect.Message {
+	mi := &file_ci_v1_builds_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use JobSpec.ProtoReflect.Descriptor instead.
+func (*JobSpec) Descriptor() ([]byte, []int) {
+	return file_ci_v1_builds_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *JobSpec) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *JobSpec) GetCommand() string {
+	if x != nil {
+		return x.Command
+	}
+	return ""
+}
+
+func (x *JobSpec) GetNeeds() []string {
+	if x != nil {
+		return x.Needs
+	}
+	return nil
+}
+
+func (x *JobSpec) GetArtifacts() []string {
+	if x != nil {
+		return x.Artifacts
+	}
+	return nil
+}
+
+type Matrix struct {
+	state         protoimpl.MessageState    `protogen:"open.v1"`
+	Axes          map[string]*Matrix_Values `protobuf:"bytes,1,rep,name=axes,proto3" json:"axes,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Include       []*Matrix_Combination     `protobuf:"bytes,2,rep,name=include,proto3" json:"include,omitempty"`
+	Exclude       []*Matrix_Combination     `protobuf:"bytes,3,rep,name=exclude,proto3" json:"exclude,omitempty"`
+	FailFast      bool                      `protobuf:"varint,4,opt,name=fail_fast,json=failFast,proto3" json:"fail_fast,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Matrix) Reset() {
+	*x = Matrix{}
+	mi := &file_ci_v1_builds_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Matrix) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Matrix) ProtoMessage() {}
+
+func (x *Matrix) ProtoReflect() protoreflect.Message {
+	mi := &file_ci_v1_builds_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Matrix.ProtoReflect.Descriptor instead.
+func (*Matrix) Descriptor() ([]byte, []int) {
+	return file_ci_v1_builds_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *Matrix) GetAxes() map[string]*Matrix_Values {
+	if x != nil {
+		return x.Axes
+	}
+	return nil
+}
+
+func (x *Matrix) GetInclude() []*Matrix_Combination {
+	if x != nil {
+		return x.Include
+	}
+	return nil
+}
+
+func (x *Matrix) GetExclude() []*Matrix_Combination {
+	if x != nil {
+		return x.Exclude
+	}
+	return nil
+}
+
+func (x *Matrix) GetFailFast() bool {
+	if x != nil {
+		return x.FailFast
+	}
+	return false
+}
+
+type CreateBuildRequest struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	RepoUrl   string                 `protobuf:"bytes,1,opt,name=repo_url,json=repoUrl,proto3" json:"repo_url,omitempty"`
+	Ref       string                 `protobuf:"bytes,2,opt,name=ref,proto3" json:"ref,omitempty"`
+	Command   string                 `protobuf:"bytes,3,opt,name=command,proto3" json:"command,omitempty"`
+	Env       map[string]string      `protobuf:"bytes,4,rep,name=env,proto3" json:"env,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Artifacts []string               `protobuf:"bytes,5,rep,name=artifacts,proto3" json:"artifacts,omitempty"`
+	Caches    []*Cache               `protobuf:"bytes,6,rep,name=caches,proto3" json:"caches,omitempty"`
+	Jobs      []*JobSpec             `protobuf:"bytes,7,rep,name=jobs,proto3" json:"jobs,omitempty"`
+	Matrix    *Matrix                `protobuf:"bytes,8,opt,name=matrix,proto3" json:"matrix,omitempty"`
+	// priority is -1000 to 1000; each point counts as one minute of waiting.
+	Priority int32 `protobuf:"varint,9,opt,name=priority,proto3" json:"priority,omitempty"`
+	// runs_on lists the labels a worker needs to run the build's jobs.
+	RunsOn []string `protobuf:"bytes,10,rep,name=runs_on,json=runsOn,proto3" json:"runs_on,omitempty"`
+	// Builds sharing a concurrency_group run at most max_concurrency (default
+	// 1) at a time; concurrency_policy is queue (default), cancel_pending or
+	// cancel_in_progress.
+	ConcurrencyGroup  string `protobuf:"bytes,11,opt,name=concurrency_group,json=concurrencyGroup,proto3" json:"concurrency_group,omitempty"`
+	MaxConcurrency    int32  `protobuf:"varint,12,opt,name=max_concurrency,json=maxConcurrency,proto3" json:"max_concurrency,omitempty"`
+	ConcurrencyPolicy string `protobuf:"bytes,13,opt,name=concurrency_policy,json=concurrencyPolicy,proto3" json:"concurrency_policy,omitempty"`
+	// not_before delays the build's jobs until the given time.
+	NotBefore     *timestamppb.Timestamp `protobuf:"bytes,14,opt,name=not_before,json=notBefore,proto3" json:"not_before,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateBuildRequest) Reset() {
+	*x = CreateBuildRequest{}
+	mi := &file_ci_v1_builds_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateBuildRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateBuildRequest) ProtoMessage() {}
+
+func (x *CreateBuildRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ci_v1_builds_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateBuildRequest.ProtoReflect.Descriptor instead.
+func (*CreateBuildRequest) Descriptor() ([]byte, []int) {
+	return file_ci_v1_builds_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *CreateBuildRequest) GetRepoUrl() string {
+	if x != nil {
+		return x.RepoUrl
+	}
+	return ""
+}
+
+func (x *CreateBuildRequest) GetRef() string {
+	if x != nil {
+		return x.Ref
+	}
+	return ""
+}
+
+func (x *CreateBuildRequest) GetCommand() string {
+	if x != nil {
+		return x.Command
+	}
+	return ""
+}
+
+func (x *CreateBuildRequest) GetEnv() map[string]string {
+	if x != nil {
+		return x.Env
+	}
+	return nil
+}
+
+func (x *CreateBuildRequest) GetArtifacts() []string {
+	if x != nil {
+		return x.Artifacts
+	}
+	return nil
+}
+
+func (x *CreateBuildRequest) GetCaches() []*Cache {
+	if x != nil {
+		return x.Caches
+	}
+	return nil
+}
+
+func (x *CreateBuildRequest) GetJobs() []*JobSpec {
+	if x != nil {
+		return x.Jobs
+	}
+	return nil
+}
+
+func (x *CreateBuildRequest) GetMatrix() *Matrix {
+	if x != nil {
+		return x.Matrix
+	}
+	return nil
+}
+
+func (x *CreateBuildRequest) GetPriority() int32 {
+	if x != nil {
+		return x.Priority
+	}
+	return 0
+}
+
+func (x *CreateBuildRequest) GetRunsOn() []string {
+	if x != nil {
+		return x.RunsOn
+	}
+	return nil
+}
+
+func (x *CreateBuildRequest) GetConcurrencyGroup() string {
+	if x != nil {
+		return x.ConcurrencyGroup
+	}
+	return ""
+}
+
+func (x *CreateBuildRequest) GetMaxConcurrency() int32 {
+	if x != nil {
+		return x.MaxConcurrency
+	}
+	return 0
+}
+
+func (x *CreateBuildRequest) GetConcurrencyPolicy() string {
+	if x != nil {
+		return x.ConcurrencyPolicy
+	}
+	return ""
+}
+
+func (x *CreateBuildRequest) GetNotBefore() *timestamppb.Timestamp {
+	if x != nil {
+		return x.NotBefore
+	}
+	return nil
+}
+
+type GetBuildRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetBuildRequest) Reset() {
+	*x = GetBuildRequest{}
+	mi := &file_ci_v1_builds_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetBuildRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetBuildRequest) ProtoMessage() {}
+
+func (x *GetBuildRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ci_v1_builds_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetBuildRequest.ProtoReflect.Descriptor instead.
+func (*GetBuildRequest) Descriptor() ([]byte, []int) {
+	return file_ci_v1_builds_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *GetBuildRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type ListBuildsRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Statuses       []string               `protobuf:"bytes,1,rep,name=statuses,proto3" json:"statuses,omitempty"`
+	RepoUrl        string                 `protobuf:"bytes,2,opt,name=repo_url,json=repoUrl,proto3" json:"repo_url,omitempty"`
+	Ref            string                 `protobuf:"bytes,3,opt,name=ref,proto3" json:"ref,omitempty"`
+	Worker         string                 `protobuf:"bytes,4,opt,name=worker,proto3" json:"worker,omitempty"`
+	Query          string                 `protobuf:"bytes,5,opt,name=query,proto3" json:"query,omitempty"`
+	CreatedAfter   *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=created_after,json=createdAfter,proto3" json:"created_after,omitempty"`
+	CreatedBefore  *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=created_before,json=createdBefore,proto3" json:"created_before,omitempty"`
+	FinishedAfter  *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=finished_after,json=finishedAfter,proto3" json:"finished_after,omitempty"`
+	FinishedBefore *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=finished_before,json=finishedBefore,proto3" json:"finished_before,omitempty"`
+	Limit          int32                  `protobuf:"varint,10,opt,name=limit,proto3" json:"limit,omitempty"`
+	Cursor         string                 `protobuf:"bytes,11,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ListBuildsRequest) Reset() {
+	*x = ListBuildsRequest{}
+	mi := &file_ci_v1_builds_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListBuildsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListBuildsRequest) ProtoMessage() {}
+
+func (x *ListBuildsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ci_v1_builds_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListBuildsRequest.ProtoReflect.Descriptor instead.
+func (*ListBuildsRequest) Descriptor() ([]byte, []int) {
+	return file_ci_v1_builds_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ListBuildsRequest) GetStatuses() []string {
+	if x != nil {
+		return x.Statuses
+	}
+	return nil
+}
+
+func (x *ListBuildsRequest) GetRepoUrl() string {
+	if x != nil {
+		return x.RepoUrl
+	}
+	return ""
+}
+
+func (x *ListBuildsRequest) GetRef() string {
+	if x != nil {
+		return x.Ref
+	}
+	return ""
+}
+
+func (x *ListBuildsRequest) GetWorker() string {
+	if x != nil {
+		return x.Worker
+	}
+	return ""
+}
+
+func (x *ListBuildsRequest) GetQuery() string {
+	if x != nil {
+		return x.Query
+	}
+	return ""
+}
+
+func (x *ListBuildsRequest) GetCreatedAfter() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAfter
+	}
+	return nil
+}
+
+func (x *ListBuildsRequest) GetCreatedBefore() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedBefore
+	}
+	return nil
+}
+
+func (x *ListBuildsRequest) GetFinishedAfter() *timestamppb.Timestamp {
+	if x != nil {
+		return x.FinishedAfter
+	}
+	return nil
+}
+
+func (x *ListBuildsRequest) GetFinishedBefore() *timestamppb.Timestamp {
+	if x != nil {
+		return x.FinishedBefore
+	}
+	return nil
+}
+
+func (x *ListBuildsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ListBuildsRequest) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+type ListBuildsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Builds        []*Build               `protobuf:"bytes,1,rep,name=builds,proto3" json:"builds,omitempty"`
+	NextCursor    string                 `protobuf:"bytes,2,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListBuildsResponse) Reset() {
+	*x = ListBuildsResponse{}
+	mi := &file_ci_v1_builds_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListBuildsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListBuildsResponse) ProtoMessage() {}
+
+func (x *ListBuildsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_ci_v1_builds_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListBuildsResponse.ProtoReflect.Descriptor instead.
+func (*ListBuildsResponse) Descriptor() ([]byte, []int) {
+	return file_ci_v1_builds_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *ListBuildsResponse) GetBuilds() []*Build {
+	if x != nil {
+		return x.Builds
+	}
+	return nil
+}
+
+func (x *ListBuildsResponse) GetNextCursor() string {
+	if x != nil {
+		return x.NextCursor
+	}
+	return ""
+}
+
+type CancelBuildRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelBuildRequest) Reset() {
+	*x = CancelBuildRequest{}
+	mi := &file_ci_v1_builds_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelBuildRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelBuildRequest) ProtoMessage() {}
+
+func (x *CancelBuildRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ci_v1_builds_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelBuildRequest.ProtoReflect.Descriptor instead.
+func (*CancelBuildRequest) Descriptor() ([]byte, []int) {
+	return file_ci_v1_builds_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *CancelBuildRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type CancelBuildResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelBuildResponse) Reset() {
+	*x = CancelBuildResponse{}
+	mi := &file_ci_v1_builds_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelBuildResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelBuildResponse) ProtoMessage() {}
+
+func (x *CancelBuildResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_ci_v1_builds_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelBuildResponse.ProtoReflect.Descriptor instead.
+func (*CancelBuildResponse) Descriptor() ([]byte, []int) {
+	return file_ci_v1_builds_proto_rawDescGZIP(), []int{10}
+}
+
+type StreamLogsRequest struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	BuildId string                 `protobuf:"bytes,1,opt,name=build_id,json=buildId,proto3" json:"build_id,omitempty"`
+	// after_seq resumes a stream after the last line a client has seen.
+	AfterSeq      int64 `protobuf:"varint,2,opt,name=after_seq,json=afterSeq,proto3" json:"after_seq,omitempty"`
+	Follow        bool  `protobuf:"varint,3,opt,name=follow,proto3" json:"follow,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StreamLogsRequest) Reset() {
+	*x = StreamLogsRequest{}
+	mi := &file_ci_v1_builds_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StreamLogsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamLogsRequest) ProtoMessage() {}
+
+func (x *StreamLogsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ci_v1_builds_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreamLogsRequest.ProtoReflect.Descriptor instead.
+func (*StreamLogsRequest) Descriptor() ([]byte, []int) {
+	return file_ci_v1_builds_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *StreamLogsRequest) GetBuildId() string {
+	if x != nil {
+		return x.BuildId
+	}
+	return ""
+}
+
+func (x *StreamLogsRequest) GetAfterSeq() int64 {
+	if x != nil {
+		return x.AfterSeq
+	}
+	return 0
+}
+
+func (x *StreamLogsRequest) GetFollow() bool {
+	if x != nil {
+		return x.Follow
+	}
+	return false
+}
+
+type LogLine struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Seq           int64                  `protobuf:"varint,1,opt,name=seq,proto3" json:"seq,omitempty"`
+	JobId         string                 `protobuf:"bytes,2,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
+	Stream        string                 `protobuf:"bytes,3,opt,name=stream,proto3" json:"stream,omitempty"`
+	Line          string                 `protobuf:"bytes,4,opt,name=line,proto3" json:"line,omitempty"`
+	Time          *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=time,proto3" json:"time,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LogLine) Reset() {
+	*x = LogLine{}
+	mi := &file_ci_v1_builds_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LogLine) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LogLine) ProtoMessage() {}
+
+func (x *LogLine) ProtoReflect() protoreflect.Message {
+	mi := &file_ci_v1_builds_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LogLine.ProtoReflect.Descriptor instead.
+func (*LogLine) Descriptor() ([]byte, []int) {
+	return file_ci_v1_builds_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *LogLine) GetSeq() int64 {
+	if x != nil {
+		return x.Seq
+	}
+	return 0
+}
+
+func (x *LogLine) GetJobId() string {
+	if x != nil {
+		return x.JobId
+	}
+	return ""
+}
+
+func (x *LogLine) GetStream() string {
+	if x != nil {
+		return x.Stream
+	}
+	return ""
+}
+
+func (x *LogLine) GetLine() string {
+	if x != nil {
+		return x.Line
+	}
+	return ""
+}
+
+func (x *LogLine) GetTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Time
+	}
+	return nil
+}
+
+type Matrix_Values struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Values        []string               `protobuf:"bytes,1,rep,name=values,proto3" json:"values,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Matrix_Values) Reset() {
+	*x = Matrix_Values{}
+	mi := &file_ci_v1_builds_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Matrix_Values) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Matrix_Values) ProtoMessage() {}
+
+func (x *Matrix_Values) ProtoReflect() protoreflect.Message {
+	mi := &file_ci_v1_builds_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Matrix_Values.ProtoReflect.Descriptor instead.
+func (*Matrix_Values) Descriptor() ([]byte, []int) {
+	return file_ci_v1_builds_proto_rawDescGZIP(), []int{4, 0}
+}
+
+func (x *Matrix_Values) GetValues() []string {
+	if x != nil {
+		return x.Values
+	}
+	return nil
+}
+
+type Matrix_Combination struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Values        map[string]string      `protobuf:"bytes,1,rep,name=values,proto3" json:"values,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Matrix_Combination) Reset() {
+	*x = Matrix_Combination{}
+	mi := &file_ci_v1_builds_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Matrix_Combination) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Matrix_Combination) ProtoMessage() {}
+
+func (x *Matrix_Combination) ProtoReflect() protoreflect.Message {
+	mi := &file_ci_v1_builds_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Matrix_Combination.ProtoReflect.Descriptor instead.
+func (*Matrix_Combination) Descriptor() ([]byte, []int) {
+	return file_ci_v1_builds_proto_rawDescGZIP(), []int{4, 1}
+}
+
+func (x *Matrix_Combination) GetValues() map[string]string {
+	if x != nil {
+		return x.Values
+	}
+	return nil
+}
+
+var File_ci_v1_builds_proto protoreflect.FileDescriptor
+
+const file_ci_v1_builds_proto_rawDesc = "" +
+	"\n" +
+	"\x12ci/v1/builds.proto\x12\x05ci.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"\x87\t\n" +
+	"\x05Build\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x19\n" +
+	"\brepo_url\x18\x02 \x01(\tR\arepoUrl\x12\x10\n" +
+	"\x03ref\x18\x03 \x01(\tR\x03ref\x12\x18\n" +
+	"\acommand\x18\x04 \x01(\tR\acommand\x12\x16\n" +
+	"\x06status\x18\x05 \x01(\tR\x06status\x12\x1b\n" +
+	"\texit_code\x18\x06 \x01(\x05R\bexitCode\x12\x14\n" +
+	"\x05error\x18\a \x01(\tR\x05error\x12\x1a\n" +
+	"\battempts\x18\b \x01(\x05R\battempts\x12'\n" +
+	"\x03env\x18\t \x03(\v2\x15.ci.v1.Build.EnvEntryR\x03env\x12\x1c\n" +
+	"\tartifacts\x18\n" +
+	" \x03(\tR\tartifacts\x12$\n" +
+	"\x06caches\x18\v \x03(\v2\f.ci.v1.CacheR\x06caches\x12!\n" +
+	"\ftriggered_by\x18\f \x01(\tR\vtriggeredBy\x12\x1b\n" +
+	"\tparent_id\x18\r \x01(\tR\bparentId\x12C\n" +
+	"\rmatrix_values\x18\x0e \x03(\v2\x1e.ci.v1.Build.MatrixValuesEntryR\fmatrixValues\x12\x1e\n" +
+	"\x04jobs\x18\x0f \x03(\v2\n" +
+	".ci.v1.JobR\x04jobs\x12\x1b\n" +
+	"\tchild_ids\x18\x10 \x03(\tR\bchildIds\x129\n" +
+	"\n" +
+	"created_at\x18\x11 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\x12 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12;\n" +
+	"\vfinished_at\x18\x13 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"finishedAt\x12J\n" +
+	"\x13cancel_requested_at\x18\x14 \x01(\v2\x1a.google.protobuf.TimestampR\x11cancelRequestedAt\x12\x1a\n" +
+	"\bpriority\x18\x15 \x01(\x05R\bpriority\x12\x17\n" +
+	"\aruns_on\x18\x16 \x03(\tR\x06runsOn\x12$\n" +
+	"\runschedulable\x18\x17 \x01(\bR\runschedulable\x12+\n" +
+	"\x11concurrency_group\x18\x18 \x01(\tR\x10concurrencyGroup\x12'\n" +
+	"\x0fmax_concurrency\x18\x19 \x01(\x05R\x0emaxConcurrency\x12-\n" +
+	"\x12concurrency_policy\x18\x1a \x01(\tR\x11concurrencyPolicy\x129\n" +
+	"\n" +
+	"not_before\x18\x1b \x01(\v2\x1a.google.protobuf.TimestampR\tnotBefore\x1a6\n" +
+	"\bEnvEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\x1a?\n" +
+	"\x11MatrixValuesEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xd7\x02\n" +
+	"\x03Job\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x18\n" +
+	"\acommand\x18\x03 \x01(\tR\acommand\x12\x14\n" +
+	"\x05needs\x18\x04 \x03(\tR\x05needs\x12\x1c\n" +
+	"\tartifacts\x18\x05 \x03(\tR\tartifacts\x12\x16\n" +
+	"\x06status\x18\x06 \x01(\tR\x06status\x12\x1b\n" +
+	"\tlocked_by\x18\a \x01(\tR\blockedBy\x12\x1b\n" +
+	"\texit_code\x18\b \x01(\x05R\bexitCode\x12\x14\n" +
+	"\x05error\x18\t \x01(\tR\x05error\x129\n" +
+	"\n" +
+	"created_at\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12;\n" +
+	"\vfinished_at\x18\v \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"finishedAt\"R\n" +
+	"\x05Cache\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05paths\x18\x02 \x03(\tR\x05paths\x12!\n" +
+	"\frestore_keys\x18\x03 \x03(\tR\vrestoreKeys\"k\n" +
+	"\aJobSpec\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x18\n" +
+	"\acommand\x18\x02 \x01(\tR\acommand\x12\x14\n" +
+	"\x05needs\x18\x03 \x03(\tR\x05needs\x12\x1c\n" +
+	"\tartifacts\x18\x04 \x03(\tR\tartifacts\"\xb7\x03\n" +
+	"\x06Matrix\x12+\n" +
+	"\x04axes\x18\x01 \x03(\v2\x17.ci.v1.Matrix.AxesEntryR\x04axes\x123\n" +
+	"\ainclude\x18\x02 \x03(\v2\x19.ci.v1.Matrix.CombinationR\ainclude\x123\n" +
+	"\aexclude\x18\x03 \x03(\v2\x19.ci.v1.Matrix.CombinationR\aexclude\x12\x1b\n" +
+	"\tfail_fast\x18\x04 \x01(\bR\bfailFast\x1a \n" +
+	"\x06Values\x12\x16\n" +
+	"\x06values\x18\x01 \x03(\tR\x06values\x1a\x87\x01\n" +
+	"\vCombination\x12=\n" +
+	"\x06values\x18\x01 \x03(\v2%.ci.v1.Matrix.Combination.ValuesEntryR\x06values\x1a9\n" +
+	"\vValuesEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\x1aM\n" +
+	"\tAxesEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12*\n" +
+	"\x05value\x18\x02 \x01(\v2\x14.ci.v1.Matrix.ValuesR\x05value:\x028\x01\"\xcd\x04\n" +
+	"\x12CreateBuildRequest\x12\x19\n" +
+	"\brepo_url\x18\x01 \x01(\tR\arepoUrl\x12\x10\n" +
+	"\x03ref\x18\x02 \x01(\tR\x03ref\x12\x18\n" +
+	"\acommand\x18\x03 \x01(\tR\acommand\x124\n" +
+	"\x03env\x18\x04 \x03(\v2\".ci.v1.CreateBuildRequest.EnvEntryR\x03env\x12\x1c\n" +
+	"\tartifacts\x18\x05 \x03(\tR\tartifacts\x12$\n" +
+	"\x06caches\x18\x06 \x03(\v2\f.ci.v1.CacheR\x06caches\x12\"\n" +
+	"\x04jobs\x18\a \x03(\v2\x0e.ci.v1.JobSpecR\x04jobs\x12%\n" +
+	"\x06matrix\x18\b \x01(\v2\r.ci.v1.MatrixR\x06matrix\x12\x1a\n" +
+	"\bpriority\x18\t \x01(\x05R\bpriority\x12\x17\n" +
+	"\aruns_on\x18\n" +
+	" \x03(\tR\x06runsOn\x12+\n" +
+	"\x11concurrency_group\x18\v \x01(\tR\x10concurrencyGroup\x12'\n" +
+	"\x0fmax_concurrency\x18\f \x01(\x05R\x0emaxConcurrency\x12-\n" +
+	"\x12concurrency_policy\x18\r \x01(\tR\x11concurrencyPolicy\x129\n" +
+	"\n" +
+	"not_before\x18\x0e \x01(\v2\x1a.google.protobuf.TimestampR\tnotBefore\x1a6\n" +
+	"\bEnvEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"!\n" +
+	"\x0fGetBuildRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\xc4\x03\n" +
+	"\x11ListBuildsRequest\x12\x1a\n" +
+	"\bstatuses\x18\x01 \x03(\tR\bstatuses\x12\x19\n" +
+	"\brepo_url\x18\x02 \x01(\tR\arepoUrl\x12\x10\n" +
+	"\x03ref\x18\x03 \x01(\tR\x03ref\x12\x16\n" +
+	"\x06worker\x18\x04 \x01(\tR\x06worker\x12\x14\n" +
+	"\x05query\x18\x05 \x01(\tR\x05query\x12?\n" +
+	"\rcreated_after\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\fcreatedAfter\x12A\n" +
+	"\x0ecreated_before\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\rcreatedBefore\x12A\n" +
+	"\x0efinished_after\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\rfinishedAfter\x12C\n" +
+	"\x0ffinished_before\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\x0efinishedBefore\x12\x14\n" +
+	"\x05limit\x18\n" +
+	" \x01(\x05R\x05limit\x12\x16\n" +
+	"\x06cursor\x18\v \x01(\tR\x06cursor\"[\n" +
+	"\x12ListBuildsResponse\x12$\n" +
+	"\x06builds\x18\x01 \x03(\v2\f.ci.v1.BuildR\x06builds\x12\x1f\n" +
+	"\vnext_cursor\x18\x02 \x01(\tR\n" +
+	"nextCursor\"$\n" +
+	"\x12CancelBuildRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\x15\n" +
+	"\x13CancelBuildResponse\"c\n" +
+	"\x11StreamLogsRequest\x12\x19\n" +
+	"\bbuild_id\x18\x01 \x01(\tR\abuildId\x12\x1b\n" +
+	"\tafter_seq\x18\x02 \x01(\x03R\bafterSeq\x12\x16\n" +
+	"\x06follow\x18\x03 \x01(\bR\x06follow\"\x8e\x01\n" +
+	"\aLogLine\x12\x10\n" +
+	"\x03seq\x18\x01 \x01(\x03R\x03seq\x12\x15\n" +
+	"\x06job_id\x18\x02 \x01(\tR\x05jobId\x12\x16\n" +
+	"\x06stream\x18\x03 \x01(\tR\x06stream\x12\x12\n" +
+	"\x04line\x18\x04 \x01(\tR\x04line\x12.\n" +
+	"\x04time\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\x04time2\xbb\x02\n" +
+	"\fBuildService\x126\n" +
+	"\vCreateBuild\x12\x19.ci.v1.CreateBuildRequest\x1a\f.ci.v1.Build\x120\n" +
+	"\bGetBuild\x12\x16.ci.v1.GetBuildRequest\x1a\f.ci.v1.Build\x12A\n" +
+	"\n" +
+	"ListBuilds\x12\x18.ci.v1.ListBuildsRequest\x1a\x19.ci.v1.ListBuildsResponse\x12D\n" +
+	"\vCancelBuild\x12\x19.ci.v1.CancelBuildRequest\x1a\x1a.ci.v1.CancelBuildResponse\x128\n" +
+	"\n" +
+	"StreamLogs\x12\x18.ci.v1.StreamLogsRequest\x1a\x0e.ci.v1.LogLine0\x01B4Z2github.com/H3nSte1n/ci-orchestrator/api/ci/v1;civ1b\x06proto3"
+
+var (
+	file_ci_v1_builds_proto_rawDescOnce sync.Once
+	file_ci_v1_builds_proto_rawDescData []byte
+)
+
+func file_ci_v1_builds_proto_rawDescGZIP() []byte {
+	file_ci_v1_builds_proto_rawDescOnce.Do(func() {
+		file_ci_v1_builds_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_ci_v1_builds_proto_rawDesc), len(file_ci_v1_builds_proto_rawDesc)))
+	})
+	return file_ci_v1_builds_proto_rawDescData
+}
+
+var file_ci_v1_builds_proto_msgTypes = make([]protoimpl.MessageInfo, 20)
+var file_ci_v1_builds_proto_goTypes = []any{
+	(*Build)(nil),                 // 0: ci.v1.Build
+	(*Job)(nil),                   // 1: ci.v1.Job
+	(*Cache)(nil),                 // 2: ci.v1.Cache
+	(*JobSpec)(nil),               // 3: ci.v1.JobSpec
+	(*Matrix)(nil),                // 4: ci.v1.Matrix
+	(*CreateBuildRequest)(nil),    // 5: ci.v1.CreateBuildRequest
+	(*GetBuildRequest)(nil),       // 6: ci.v1.GetBuildRequest
+	(*ListBuildsRequest)(nil),     // 7: ci.v1.ListBuildsRequest
+	(*ListBuildsResponse)(nil),    // 8: ci.v1.ListBuildsResponse
+	(*CancelBuildRequest)(nil),    // 9: ci.v1.CancelBuildRequest
+	(*CancelBuildResponse)(nil),   // 10: ci.v1.CancelBuildResponse
+	(*StreamLogsRequest)(nil),     // 11: ci.v1.StreamLogsRequest
+	(*LogLine)(nil),               // 12: ci.v1.LogLine
+	nil,                           // 13: ci.v1.Build.EnvEntry
+	nil,                           // 14: ci.v1.Build.MatrixValuesEntry
+	(*Matrix_Values)(nil),         // 15: ci.v1.Matrix.Values
+	(*Matrix_Combination)(nil),    // 16: ci.v1.Matrix.Combination
+	nil,                           // 17: ci.v1.Matrix.AxesEntry
+	nil,                           // 18: ci.v1.Matrix.Combination.ValuesEntry
+	nil,                           // 19: ci.v1.CreateBuildRequest.EnvEntry
+	(*timestamppb.Timestamp)(nil), // 20: google.protobuf.Timestamp
+}
+var file_ci_v1_builds_proto_depIdxs = []int32{
+	13, // 0: ci.v1.Build.env:type_name -> ci.v1.Build.EnvEntry
+	2,  // 1: ci.v1.Build.caches:type_name -> ci.v1.Cache
+	14, // 2: ci.v1.Build.matrix_values:type_name -> ci.v1.Build.MatrixValuesEntry
+	1,  // 3: ci.v1.Build.jobs:type_name -> ci.v1.Job
+	20, // 4: ci.v1.Build.created_at:type_name -> google.protobuf.Timestamp
+	20, // 5: ci.v1.Build.updated_at:type_name -> google.protobuf.Timestamp
+	20, // 6: ci.v1.Build.finished_at:type_name -> google.protobuf.Timestamp
+	20, // 7: ci.v1.Build.cancel_requested_at:type_name -> google.protobuf.Timestamp
+	20, // 8: ci.v1.Build.not_before:type_name -> google.protobuf.Timestamp
+	20, // 9: ci.v1.Job.created_at:type_name -> google.protobuf.Timestamp
+	20, // 10: ci.v1.Job.finished_at:type_name -> google.protobuf.Timestamp
+	17, // 11: ci.v1.Matrix.axes:type_name -> ci.v1.Matrix.AxesEntry
+	16, // 12: ci.v1.Matrix.include:type_name -> ci.v1.Matrix.Combination
+	16, // 13: ci.v1.Matrix.exclude:type_name -> ci.v1.Matrix.Combination
+	19, // 14: ci.v1.CreateBuildRequest.env:type_name -> ci.v1.CreateBuildRequest.EnvEntry
+	2,  // 15: ci.v1.CreateBuildRequest.caches:type_name -> ci.v1.Cache
+	3,  // 16: ci.v1.CreateBuildRequest.jobs:type_name -> ci.v1.JobSpec
+	4,  // 17: ci.v1.CreateBuildRequest.matrix:type_name -> ci.v1.Matrix
+	20, // 18: ci.v1.CreateBuildRequest.not_before:type_name -> google.protobuf.Timestamp
+	20, // 19: ci.v1.ListBuildsRequest.created_after:type_name -> google.protobuf.Timestamp
+	20, // 20: ci.v1.ListBuildsRequest.created_before:type_name -> google.protobuf.Timestamp
+	20, // 21: ci.v1.ListBuildsRequest.finished_after:type_name -> google.protobuf.Timestamp
+	20, // 22: ci.v1.ListBuildsRequest.finished_before:type_name -> google.protobuf.Timestamp
+	0,  // 23: ci.v1.ListBuildsResponse.builds:type_name -> ci.v1.Build
+	20, // 24: ci.v1.LogLine.time:type_name -> google.protobuf.Timestamp
+	18, // 25: ci.v1.Matrix.Combination.values:type_name -> ci.v1.Matrix.Combination.ValuesEntry
+	15, // 26: ci.v1.Matrix.AxesEntry.value:type_name -> ci.v1.Matrix.Values
+	5,  // 27: ci.v1.BuildService.CreateBuild:input_type -> ci.v1.CreateBuildRequest
+	6,  // 28: ci.v1.BuildService.GetBuild:input_type -> ci.v1.GetBuildRequest
+	7,  // 29: ci.v1.BuildService.ListBuilds:input_type -> ci.v1.ListBuildsRequest
+	9,  // 30: ci.v1.BuildService.CancelBuild:input_type -> ci.v1.CancelBuildRequest
+	11, // 31: ci.v1.BuildService.StreamLogs:input_type -> ci.v1.StreamLogsRequest
+	0,  // 32: ci.v1.BuildService.CreateBuild:output_type -> ci.v1.Build
+	0,  // 33: ci.v1.BuildService.GetBuild:output_type -> ci.v1.Build
+	8,  // 34: ci.v1.BuildService.ListBuilds:output_type -> ci.v1.ListBuildsResponse
+	10, // 35: ci.v1.BuildService.CancelBuild:output_type -> ci.v1.CancelBuildResponse
+	12, // 36: ci.v1.BuildService.StreamLogs:output_type -> ci.v1.LogLine
+	32, // [32:37] is the sub-list for method output_type
+	27, // [27:32] is the sub-list for method input_type
+	27, // [27:27] is the sub-list for extension type_name
+	27, // [27:27] is the sub-list for extension extendee
+	0,  // [0:27] is the sub-list for field type_name
+}
+
+func init() { file_ci_v1_builds_proto_init() }
+func file_ci_v1_builds_proto_init() {
+	if File_ci_v1_builds_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_ci_v1_builds_proto_rawDesc), len(file_ci_v1_builds_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   20,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_ci_v1_builds_proto_goTypes,
+		DependencyIndexes: file_ci_v1_builds_proto_depIdxs,
+		MessageInfos:      file_ci_v1_builds_proto_msgTypes,
+	}.Build()
+	File_ci_v1_builds_proto = out.File
+	file_ci_v1_builds_proto_goTypes = nil
+	file_ci_v1_builds_proto_depIdxs = nil
+}
@@ -0,0 +1,4 @@
+// Package civ1 contains the generated gRPC bindings of the build API.
+package civ1
+
+//go:generate protoc -I ../.. --go_out=../.. --go_opt=paths=source_relative --go-grpc_out=../.. --go-grpc_opt=paths=source_relative ci/v1/builds.proto
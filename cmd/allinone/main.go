@@ -0,0 +1,208 @@
+// Command allinone runs the API, the gRPC API, the scheduler and a pool of
+// workers in one process. Workers share the API's build service and are
+// woken as soon as a build is created instead of waiting for their next poll.
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/H3nSte1n/ci-orchestrator/internal/adapters/grpc"
+	"github.com/H3nSte1n/ci-orchestrator/internal/adapters/http"
+	"github.com/H3nSte1n/ci-orchestrator/internal/adapters/notify"
+	"github.com/H3nSte1n/ci-orchestrator/internal/adapters/queue"
+	"github.com/H3nSte1n/ci-orchestrator/internal/adapters/repositories"
+	"github.com/H3nSte1n/ci-orchestrator/internal/adapters/runner"
+	"github.com/H3nSte1n/ci-orchestrator/internal/adapters/scheduler"
+	"github.com/H3nSte1n/ci-orchestrator/internal/adapters/storage"
+	"github.com/H3nSte1n/ci-orchestrator/internal/adapters/vcs"
+	"github.com/H3nSte1n/ci-orchestrator/internal/adapters/worker"
+	"github.com/H3nSte1n/ci-orchestrator/internal/core/domain"
+	"github.com/H3nSte1n/ci-orchestrator/internal/core/ports"
+	"github.com/H3nSte1n/ci-orchestrator/internal/core/service"
+	"github.com/H3nSte1n/ci-orchestrator/internal/platform/config"
+	"github.com/H3nSte1n/ci-orchestrator/internal/platform/db"
+	"net"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+)
+
+// version is reported when the worker slots register; release builds set it
+// with -ldflags "-X main.version=...".
+var version = "dev"
+
+func main() {
+	env := os.Getenv("APP_ENV")
+	if env == "" {
+		env = "development"
+	}
+
+	cfg, err := config.LoadConfig(env)
+	if err != nil {
+		panic(err)
+	}
+
+	if err := db.Migrate(cfg); err != nil {
+		panic(err)
+	}
+
+	dbConnection, err := db.NewConnection(cfg)
+	if err != nil {
+		panic(err)
+	}
+
+	artifactStore, err := storage.NewArtifactStore(cfg.Artifacts)
+	if err != nil {
+		panic(err)
+	}
+
+	cacheStore, err := storage.NewCacheStore(cfg.Cache)
+	if err != nil {
+		panic(err)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	// On Postgres, workers of other processes are woken too; wake-ups then
+	// depend on the LISTEN connection.
+	notifier := notify.NewLocalNotifier()
+	connected := func() bool { return true }
+	if dbConnection.Dialector.Name() == "postgres" {
+		pgNotifier := notify.NewPostgresNotifier(dbConnection)
+		go pgNotifier.Listen(ctx)
+		notifier = pgNotifier
+		connected = pgNotifier.Connected
+	}
+
+	buildRepository := repositories.NewBuildRepository(dbConnection)
+	workerRepository := repositories.NewWorkerRepository(dbConnection)
+	jobQueue, err := queue.NewQueue(ctx, cfg.Queue, buildRepository)
+	if err != nil {
+		panic(err)
+	}
+
+	tokenService := service.NewAPITokenService(repositories.NewAPITokenRepository(dbConnection))
+	buildService := service.NewNotifyingBuildService(service.NewBuildService(buildRepository, workerRepository, jobQueue), notifier)
+	buildLogService := service.NewBuildLogService(repositories.NewBuildLogRepository(dbConnection))
+	artifactService := service.NewArtifactService(repositories.NewArtifactRepository(dbConnection), artifactStore)
+	cacheService := service.NewCacheService(cacheStore)
+	workerService := service.NewWorkerService(workerRepository)
+
+	buildController := http.NewBuildController(buildService)
+	artifactController := http.NewArtifactController(artifactService)
+	logController := http.NewLogController(buildService, buildLogService)
+	tokenController := http.NewTokenController(tokenService)
+	workerController := http.NewWorkerController(buildService, buildLogService, artifactService, workerService)
+	fleetController := http.NewFleetController(workerService)
+	limitController := http.NewRepoLimitController(service.NewRepoLimitService(repositories.NewRepoLimitRepository(dbConnection)))
+	scheduleService := service.NewScheduleService(repositories.NewScheduleRepository(dbConnection), buildService)
+	scheduleController := http.NewScheduleController(scheduleService)
+	router := http.NewRouter(buildController, artifactController, logController, tokenController, workerController, fleetController, limitController, scheduleController, tokenService)
+
+	// Every replica runs the scheduler; a schedule run is taken by one of
+	// them only, see ports.ScheduleRepository.Advance.
+	scheduleInterval := cfg.Scheduler.Interval
+	if scheduleInterval <= 0 {
+		scheduleInterval = 15 * time.Second
+	}
+	go scheduler.NewScheduler(scheduleService, scheduleInterval).Run(ctx)
+
+	// Jobs of workers that died mid-job are requeued once their lease
+	// expires; replicas requeue each job once, see RequeueExpired.
+	lease := cfg.Queue.Lease
+	if lease <= 0 {
+		lease = queue.DefaultLease
+	}
+	go scheduler.NewReaper(buildService, lease).Run(ctx)
+
+	errCh := make(chan error, 2)
+
+	if cfg.ApiServiceConfig.GrpcPort != "" {
+		listener, err := net.Listen("tcp", ":"+cfg.ApiServiceConfig.GrpcPort)
+		if err != nil {
+			panic(err)
+		}
+		grpcServer := grpc.NewServer(grpc.NewBuildServer(buildService, buildLogService), tokenService)
+		go func() {
+			errCh <- grpcServer.Serve(listener)
+		}()
+	}
+
+	go func() {
+		errCh <- router.Run(":" + cfg.ApiServiceConfig.Port)
+	}()
+
+	hostname, err := os.Hostname()
+	if err != nil {
+		panic(err)
+	}
+
+	workerId := cfg.Worker.ID
+	if workerId == "" {
+		workerId = hostname
+	}
+
+	slots := cfg.Worker.Slots
+	if slots <= 0 {
+		slots = 2
+	}
+
+	interval := cfg.Worker.PollInterval
+	if interval <= 0 {
+		interval = 2 * time.Second
+	}
+
+	fallback := cfg.Worker.FallbackPollInterval
+	if fallback <= 0 {
+		fallback = 30 * time.Second
+	}
+
+	heartbeat := cfg.Worker.HeartbeatInterval
+	if heartbeat <= 0 {
+		heartbeat = 10 * time.Second
+	}
+
+	var wg sync.WaitGroup
+	for slot := 1; slot <= slots; slot++ {
+		slotId := fmt.Sprintf("%s-%d", workerId, slot)
+		registration := &domain.Worker{ID: slotId, Hostname: hostname, Version: version, Labels: cfg.Worker.Labels, Slots: 1}
+		if err := workerService.Register(ctx, registration); err != nil {
+			panic(err)
+		}
+
+		w := worker.NewWorker(slotId, buildService, buildLogService, interval, heartbeat, runner.NewHostRunner(), vcs.NewGitVCS(), artifactService, cacheService)
+		w.WakeOn(notifier.Subscribe(ctx), connected, fallback)
+		w.ReportTo(workerService)
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer deregister(workerService, slotId)
+			if err := w.Run(ctx); err != nil && !errors.Is(err, context.Canceled) {
+				errCh <- err
+			}
+		}()
+	}
+
+	select {
+	case <-ctx.Done():
+		wg.Wait()
+	case err := <-errCh:
+		panic(err)
+	}
+}
+
+// deregister marks a worker slot offline; ctx is already canceled on
+// shutdown.
+func deregister(workerService ports.WorkerService, workerId string) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := workerService.Deregister(ctx, workerId); err != nil {
+		fmt.Println("Error deregistering worker:", err)
+	}
+}
@@ -1,12 +1,21 @@
 package main
 
 import (
+	"context"
+	"fmt"
+	"github.com/H3nSte1n/ci-orchestrator/internal/adapters/grpc"
 	"github.com/H3nSte1n/ci-orchestrator/internal/adapters/http"
+	"github.com/H3nSte1n/ci-orchestrator/internal/adapters/notify"
+	"github.com/H3nSte1n/ci-orchestrator/internal/adapters/queue"
 	"github.com/H3nSte1n/ci-orchestrator/internal/adapters/repositories"
+	"github.com/H3nSte1n/ci-orchestrator/internal/adapters/scheduler"
+	"github.com/H3nSte1n/ci-orchestrator/internal/adapters/storage"
 	"github.com/H3nSte1n/ci-orchestrator/internal/core/service"
 	"github.com/H3nSte1n/ci-orchestrator/internal/platform/config"
 	"github.com/H3nSte1n/ci-orchestrator/internal/platform/db"
+	"net"
 	"os"
+	"time"
 )
 
 func main() {
@@ -24,15 +33,81 @@ func main() {
 		panic(err)
 	}
 
-	dbConnection, err := db.NewPostgresConnection(cfg)
+	dbConnection, err := db.NewConnection(cfg)
+	if err != nil {
+		panic(err)
+	}
+
+	tokenService := service.NewAPITokenService(repositories.NewAPITokenRepository(dbConnection))
+
+	if len(os.Args) > 1 && os.Args[1] == "token" {
+		if err := runTokenCommand(context.Background(), tokenService, os.Args[2:], os.Stdout); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	artifactStore, err := storage.NewArtifactStore(cfg.Artifacts)
 	if err != nil {
 		panic(err)
 	}
 
 	buildRepository := repositories.NewBuildRepository(dbConnection)
-	buildService := service.NewBuildService(buildRepository)
+	workerRepository := repositories.NewWorkerRepository(dbConnection)
+	artifactRepository := repositories.NewArtifactRepository(dbConnection)
+	jobQueue, err := queue.NewQueue(context.Background(), cfg.Queue, buildRepository)
+	if err != nil {
+		panic(err)
+	}
+
+	buildService := service.NewBuildService(buildRepository, workerRepository, jobQueue)
+	if dbConnection.Dialector.Name() == "postgres" {
+		// Wakes workers listening on the same database, see cmd/worker.
+		buildService = service.NewNotifyingBuildService(buildService, notify.NewPostgresNotifier(dbConnection))
+	}
+	buildLogService := service.NewBuildLogService(repositories.NewBuildLogRepository(dbConnection))
+	artifactService := service.NewArtifactService(artifactRepository, artifactStore)
+	workerService := service.NewWorkerService(workerRepository)
 	buildController := http.NewBuildController(buildService)
-	router := http.NewRouter(buildController)
+	artifactController := http.NewArtifactController(artifactService)
+	logController := http.NewLogController(buildService, buildLogService)
+	tokenController := http.NewTokenController(tokenService)
+	workerController := http.NewWorkerController(buildService, buildLogService, artifactService, workerService)
+	fleetController := http.NewFleetController(workerService)
+	limitController := http.NewRepoLimitController(service.NewRepoLimitService(repositories.NewRepoLimitRepository(dbConnection)))
+	scheduleService := service.NewScheduleService(repositories.NewScheduleRepository(dbConnection), buildService)
+	scheduleController := http.NewScheduleController(scheduleService)
+	router := http.NewRouter(buildController, artifactController, logController, tokenController, workerController, fleetController, limitController, scheduleController, tokenService)
+
+	// Every replica runs the scheduler; a schedule run is taken by one of
+	// them only, see ports.ScheduleRepository.Advance.
+	scheduleInterval := cfg.Scheduler.Interval
+	if scheduleInterval <= 0 {
+		scheduleInterval = 15 * time.Second
+	}
+	go scheduler.NewScheduler(scheduleService, scheduleInterval).Run(context.Background())
+
+	// Jobs of workers that died mid-job are requeued once their lease
+	// expires; replicas requeue each job once, see RequeueExpired.
+	lease := cfg.Queue.Lease
+	if lease <= 0 {
+		lease = queue.DefaultLease
+	}
+	go scheduler.NewReaper(buildService, lease).Run(context.Background())
+
+	if cfg.ApiServiceConfig.GrpcPort != "" {
+		listener, err := net.Listen("tcp", ":"+cfg.ApiServiceConfig.GrpcPort)
+		if err != nil {
+			panic(err)
+		}
+		grpcServer := grpc.NewServer(grpc.NewBuildServer(buildService, buildLogService), tokenService)
+		go func() {
+			if err := grpcServer.Serve(listener); err != nil {
+				panic(err)
+			}
+		}()
+	}
 
 	if err := router.Run(":" + cfg.ApiServiceConfig.Port); err != nil {
 		panic(err)
@@ -0,0 +1,73 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"github.com/H3nSte1n/ci-orchestrator/internal/core/ports"
+	"io"
+	"strings"
+	"text/tabwriter"
+)
+
+const tokenUsage = `usage:
+  api token create -name NAME -scopes SCOPE[,SCOPE...]
+  api token list
+  api token revoke ID`
+
+// runTokenCommand manages API tokens from the command line, which is how the
+// first admin token is created.
+func runTokenCommand(ctx context.Context, tokens ports.APITokenService, args []string, out io.Writer) error {
+	if len(args) == 0 {
+		return fmt.Errorf("missing token subcommand\n%s", tokenUsage)
+	}
+
+	switch args[0] {
+	case "create":
+		fs := flag.NewFlagSet("token create", flag.ContinueOnError)
+		name := fs.String("name", "", "token name, recorded as triggered_by on builds")
+		scopes := fs.String("scopes", "", "comma separated scopes: builds:read, builds:write, builds:cancel, admin, worker")
+		if err := fs.Parse(args[1:]); err != nil {
+			return err
+		}
+
+		token, secret, err := tokens.Create(ctx, *name, splitScopes(*scopes))
+		if err != nil {
+			return err
+		}
+		_, _ = fmt.Fprintf(out, "created token %s (%s)\n%s\n", token.Name, token.ID, secret)
+		return nil
+	case "list":
+		list, err := tokens.List(ctx)
+		if err != nil {
+			return err
+		}
+		w := tabwriter.NewWriter(out, 0, 4, 2, ' ', 0)
+		_, _ = fmt.Fprintln(w, "ID\tNAME\tPREFIX\tSCOPES\tREVOKED")
+		for _, token := range list {
+			_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%t\n", token.ID, token.Name, token.Prefix, strings.Join(token.Scopes, ","), token.RevokedAt != nil)
+		}
+		return w.Flush()
+	case "revoke":
+		if len(args) != 2 {
+			return fmt.Errorf("revoke takes exactly one token id\n%s", tokenUsage)
+		}
+		if err := tokens.Revoke(ctx, args[1]); err != nil {
+			return err
+		}
+		_, _ = fmt.Fprintf(out, "revoked token %s\n", args[1])
+		return nil
+	default:
+		return fmt.Errorf("unknown token subcommand %q\n%s", args[0], tokenUsage)
+	}
+}
+
+func splitScopes(s string) []string {
+	var scopes []string
+	for _, scope := range strings.Split(s, ",") {
+		if scope = strings.TrimSpace(scope); scope != "" {
+			scopes = append(scopes, scope)
+		}
+	}
+	return scopes
+}
@@ -0,0 +1,298 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/H3nSte1n/ci-orchestrator/internal/core/domain"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// requestTimeout bounds a single API call; waiting and following are made
+// of many short calls.
+const requestTimeout = 30 * time.Second
+
+// Client talks to the public REST API of the orchestrator.
+type Client struct {
+	baseURL    string
+	token      string
+	httpClient *http.Client
+}
+
+func NewClient(baseURL string, token string) *Client {
+	return &Client{
+		baseURL:    strings.TrimRight(baseURL, "/") + "/api/v1",
+		token:      token,
+		httpClient: &http.Client{},
+	}
+}
+
+// APIError is an error envelope returned by the API.
+type APIError struct {
+	StatusCode int
+	Code       string          `json:"code"`
+	Message    string          `json:"message"`
+	RequestID  string          `json:"request_id"`
+	Details    json.RawMessage `json:"details"`
+}
+
+func (e *APIError) Error() string {
+	msg := e.Message
+	if e.Code != "" {
+		msg = e.Code + ": " + msg
+	}
+	if len(e.Details) > 0 {
+		var fieldErrors []domain.FieldError
+		if err := json.Unmarshal(e.Details, &fieldErrors); err == nil && len(fieldErrors) > 0 {
+			for _, fe := range fieldErrors {
+				msg += fmt.Sprintf("\n  %s: %s", fe.Field, fe.Message)
+			}
+		} else {
+			msg += " " + string(e.Details)
+		}
+	}
+	if e.RequestID != "" {
+		msg += fmt.Sprintf(" (request id %s)", e.RequestID)
+	}
+	return msg
+}
+
+type createBuildRequest struct {
+	RepoUrl   string            `json:"repo_url"`
+	Ref       string            `json:"ref"`
+	Command   string            `json:"command"`
+	Priority  int               `json:"priority,omitempty"`
+	NotBefore *time.Time        `json:"not_before,omitempty"`
+	RunsOn    []string          `json:"runs_on,omitempty"`
+	Env       map[string]string `json:"env,omitempty"`
+
+	ConcurrencyGroup  string `json:"concurrency_group,omitempty"`
+	MaxConcurrency    int    `json:"max_concurrency,omitempty"`
+	ConcurrencyPolicy string `json:"concurrency_policy,omitempty"`
+}
+
+type updatePriorityRequest struct {
+	Priority int `json:"priority"`
+}
+
+type listBuildsResponse struct {
+	Builds     []domain.Build `json:"builds"`
+	NextCursor string         `json:"next_cursor,omitempty"`
+}
+
+type listLogsResponse struct {
+	Logs         []domain.BuildLog  `json:"logs"`
+	NextAfterSeq int64              `json:"next_after_seq"`
+	BuildStatus  domain.BuildStatus `json:"build_status"`
+}
+
+type listWorkersResponse struct {
+	Workers []domain.Worker `json:"workers"`
+}
+
+type setRepoLimitRequest struct {
+	RepoUrl          string `json:"repo_url"`
+	MaxRunningBuilds int    `json:"max_running_builds"`
+}
+
+type listRepoLimitsResponse struct {
+	Limits []domain.RepoLimit `json:"limits"`
+}
+
+type createScheduleRequest struct {
+	Name            string            `json:"name"`
+	RepoUrl         string            `json:"repo_url"`
+	Ref             string            `json:"ref"`
+	Command         string            `json:"command"`
+	Env             map[string]string `json:"env,omitempty"`
+	Cron            string            `json:"cron"`
+	Timezone        string            `json:"timezone,omitempty"`
+	MissedRunPolicy string            `json:"missed_run_policy,omitempty"`
+}
+
+type listSchedulesResponse struct {
+	Schedules []domain.Schedule `json:"schedules"`
+}
+
+type messageResponse struct {
+	Message string `json:"message"`
+}
+
+func (c *Client) CreateBuild(ctx context.Context, req createBuildRequest) (*domain.Build, error) {
+	var build domain.Build
+	if err := c.do(ctx, http.MethodPost, "/builds", nil, req, &build); err != nil {
+		return nil, err
+	}
+	return &build, nil
+}
+
+func (c *Client) GetBuild(ctx context.Context, buildId string) (*domain.Build, error) {
+	var build domain.Build
+	if err := c.do(ctx, http.MethodGet, "/builds/"+url.PathEscape(buildId), nil, nil, &build); err != nil {
+		return nil, err
+	}
+	return &build, nil
+}
+
+func (c *Client) ListBuilds(ctx context.Context, query url.Values) (*listBuildsResponse, error) {
+	var resp listBuildsResponse
+	if err := c.do(ctx, http.MethodGet, "/builds", query, nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+func (c *Client) CancelBuild(ctx context.Context, buildId string) (*messageResponse, error) {
+	var resp messageResponse
+	if err := c.do(ctx, http.MethodPost, "/builds/"+url.PathEscape(buildId)+"/cancel", nil, nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+func (c *Client) RetryBuild(ctx context.Context, buildId string) (*domain.Build, error) {
+	var build domain.Build
+	if err := c.do(ctx, http.MethodPost, "/builds/"+url.PathEscape(buildId)+"/retry", nil, nil, &build); err != nil {
+		return nil, err
+	}
+	return &build, nil
+}
+
+func (c *Client) UpdatePriority(ctx context.Context, buildId string, priority int) (*domain.Build, error) {
+	var build domain.Build
+	if err := c.do(ctx, http.MethodPut, "/builds/"+url.PathEscape(buildId)+"/priority", nil, updatePriorityRequest{Priority: priority}, &build); err != nil {
+		return nil, err
+	}
+	return &build, nil
+}
+
+func (c *Client) ListWorkers(ctx context.Context) (*listWorkersResponse, error) {
+	var resp listWorkersResponse
+	if err := c.do(ctx, http.MethodGet, "/workers", nil, nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// SetWorkerState sends one of the worker actions drain, pause or resume.
+func (c *Client) SetWorkerState(ctx context.Context, workerId string, action string) (*domain.Worker, error) {
+	var worker domain.Worker
+	if err := c.do(ctx, http.MethodPost, "/workers/"+url.PathEscape(workerId)+"/"+action, nil, nil, &worker); err != nil {
+		return nil, err
+	}
+	return &worker, nil
+}
+
+func (c *Client) ListRepoLimits(ctx context.Context) (*listRepoLimitsResponse, error) {
+	var resp listRepoLimitsResponse
+	if err := c.do(ctx, http.MethodGet, "/repo-limits", nil, nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+func (c *Client) SetRepoLimit(ctx context.Context, repoUrl string, maxRunning int) (*domain.RepoLimit, error) {
+	var limit domain.RepoLimit
+	req := setRepoLimitRequest{RepoUrl: repoUrl, MaxRunningBuilds: maxRunning}
+	if err := c.do(ctx, http.MethodPut, "/repo-limits", nil, req, &limit); err != nil {
+		return nil, err
+	}
+	return &limit, nil
+}
+
+func (c *Client) CreateSchedule(ctx context.Context, req createScheduleRequest) (*domain.Schedule, error) {
+	var schedule domain.Schedule
+	if err := c.do(ctx, http.MethodPost, "/schedules", nil, req, &schedule); err != nil {
+		return nil, err
+	}
+	return &schedule, nil
+}
+
+func (c *Client) ListSchedules(ctx context.Context) (*listSchedulesResponse, error) {
+	var resp listSchedulesResponse
+	if err := c.do(ctx, http.MethodGet, "/schedules", nil, nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+func (c *Client) DeleteSchedule(ctx context.Context, scheduleId string) error {
+	return c.do(ctx, http.MethodDelete, "/schedules/"+url.PathEscape(scheduleId), nil, nil, nil)
+}
+
+func (c *Client) ListLogs(ctx context.Context, buildId string, afterSeq int64) (*listLogsResponse, error) {
+	query := url.Values{}
+	if afterSeq > 0 {
+		query.Set("after_seq", fmt.Sprint(afterSeq))
+	}
+
+	var resp listLogsResponse
+	if err := c.do(ctx, http.MethodGet, "/builds/"+url.PathEscape(buildId)+"/logs", query, nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+func (c *Client) do(ctx context.Context, method string, path string, query url.Values, in interface{}, out interface{}) error {
+	ctx, cancel := context.WithTimeout(ctx, requestTimeout)
+	defer cancel()
+
+	var body io.Reader
+	if in != nil {
+		b, err := json.Marshal(in)
+		if err != nil {
+			return err
+		}
+		body = bytes.NewReader(b)
+	}
+
+	target := c.baseURL + path
+	if len(query) > 0 {
+		target += "?" + query.Encode()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, target, body)
+	if err != nil {
+		return err
+	}
+	if in != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		return decodeError(resp)
+	}
+	if out == nil || resp.StatusCode == http.StatusNoContent {
+		return nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("decode %s %s: %w", method, path, err)
+	}
+	return nil
+}
+
+func decodeError(resp *http.Response) error {
+	var envelope struct {
+		Error APIError `json:"error"`
+	}
+	data, _ := io.ReadAll(io.LimitReader(resp.Body, 64*1024))
+	if err := json.Unmarshal(data, &envelope); err != nil || envelope.Error.Message == "" {
+		return &APIError{StatusCode: resp.StatusCode, Message: fmt.Sprintf("unexpected response %s", resp.Status)}
+	}
+	envelope.Error.StatusCode = resp.StatusCode
+	return &envelope.Error
+}
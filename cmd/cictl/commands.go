@@ -0,0 +1,714 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"github.com/H3nSte1n/ci-orchestrator/internal/core/domain"
+	"io"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const usage = `usage: cictl COMMAND [FLAGS] [ARGS]
+
+commands:
+  submit -repo URL [-ref REF] -command CMD [-priority N] [-runs-on L[,L...]] [-not-before TIME|DURATION]
+         [-concurrency-group G [-max-concurrency N] [-concurrency-policy P]] [-env KEY=VALUE ...] [-wait] [-follow]
+  get ID
+  list [-status S[,S...]] [-repo URL] [-ref REF] [-worker ID] [-since TIME] [-until TIME] [-q TEXT] [-limit N] [-cursor C]
+  cancel ID
+  priority ID N
+  workers
+  drain|pause|resume WORKER
+  repo-limits
+  repo-limit URL N
+  schedules
+  schedule-create -name NAME -repo URL [-ref REF] -command CMD -cron EXPR [-timezone TZ]
+                  [-missed-runs skip|run_once|run_all] [-env KEY=VALUE ...]
+  schedule-delete ID
+  logs [-follow] ID
+  retry [-wait] [-follow] ID
+  run-local [-repo PATH|URL] [-ref REF] -command CMD [-env KEY=VALUE ...]
+
+every command except run-local accepts:
+  -api-url URL   API base URL (default $CICTL_API_URL, the config file or ` + defaultAPIURL + `)
+  -token TOKEN   API token (default $CICTL_TOKEN or the config file)
+  -config PATH   config file with api_url and token (default $CICTL_CONFIG or cictl/config.yaml in the user config directory)
+  -o FORMAT      output format: table or json
+
+Flags go before the build id. With -wait or -follow the exit code mirrors
+the build: 0 success, 1 failed, 3 canceled; 2 is a usage error and 4 any
+other error. run-local runs the command on this machine without an API and
+exits with the command's exit code.`
+
+// pollInterval is how often waiting commands ask for news.
+var pollInterval = 2 * time.Second
+
+// errUsage marks errors caused by invalid command line arguments.
+var errUsage = errors.New("usage error")
+
+func usageError(format string, args ...interface{}) error {
+	return fmt.Errorf("%w: %s", errUsage, fmt.Sprintf(format, args...))
+}
+
+// cli carries the state shared by the commands.
+type cli struct {
+	out          io.Writer
+	errOut       io.Writer
+	getenv       func(string) string
+	pollInterval time.Duration
+
+	// Set by parse from the common flags.
+	client *Client
+	json   bool
+}
+
+type command func(c *cli, ctx context.Context, args []string) (int, error)
+
+var commands = map[string]command{
+	"submit":    (*cli).submit,
+	"get":       (*cli).get,
+	"list":      (*cli).list,
+	"cancel":    (*cli).cancel,
+	"priority":  (*cli).priority,
+	"logs":      (*cli).logs,
+	"retry":     (*cli).retry,
+	"run-local": (*cli).runLocal,
+	"workers":   (*cli).workers,
+	"drain":     workerAction("drain", domain.WorkerStateDraining),
+	"pause":     workerAction("pause", domain.WorkerStatePaused),
+	"resume":    workerAction("resume", domain.WorkerStateActive),
+
+	"repo-limits": (*cli).repoLimits,
+	"repo-limit":  (*cli).repoLimit,
+
+	"schedules":       (*cli).schedules,
+	"schedule-create": (*cli).scheduleCreate,
+	"schedule-delete": (*cli).scheduleDelete,
+}
+
+// run executes one command and returns the process exit code.
+func run(ctx context.Context, args []string, out io.Writer, errOut io.Writer, getenv func(string) string) int {
+	if len(args) == 0 {
+		_, _ = fmt.Fprintln(errOut, usage)
+		return exitUsage
+	}
+	if args[0] == "help" || args[0] == "-h" || args[0] == "--help" {
+		_, _ = fmt.Fprintln(out, usage)
+		return exitOK
+	}
+
+	cmd, ok := commands[args[0]]
+	if !ok {
+		_, _ = fmt.Fprintf(errOut, "cictl: unknown command %q\n\n%s\n", args[0], usage)
+		return exitUsage
+	}
+
+	c := &cli{out: out, errOut: errOut, getenv: getenv, pollInterval: pollInterval}
+	code, err := cmd(c, ctx, args[1:])
+	switch {
+	case err == nil:
+		return code
+	case errors.Is(err, flag.ErrHelp):
+		return exitOK
+	case errors.Is(err, errUsage):
+		_, _ = fmt.Fprintln(errOut, "cictl:", err)
+		return exitUsage
+	default:
+		_, _ = fmt.Fprintln(errOut, "cictl:", err)
+		return exitError
+	}
+}
+
+// parse adds the common flags to fs, parses args and connects the client.
+// It returns the positional arguments.
+func (c *cli) parse(fs *flag.FlagSet, args []string) ([]string, error) {
+	var apiURL, token, configPath, output string
+	fs.StringVar(&apiURL, "api-url", "", "API base URL")
+	fs.StringVar(&token, "token", "", "API token")
+	fs.StringVar(&configPath, "config", "", "config file with api_url and token")
+	fs.StringVar(&output, "o", "table", "output format: table or json")
+	fs.SetOutput(c.errOut)
+
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("%w: %v", errUsage, err)
+	}
+	if output != "table" && output != "json" {
+		return nil, usageError("unknown output format %q", output)
+	}
+
+	cfg, err := loadConfig(configPath, c.getenv)
+	if err != nil {
+		return nil, err
+	}
+	if apiURL != "" {
+		cfg.APIURL = apiURL
+	}
+	if token != "" {
+		cfg.Token = token
+	}
+
+	c.client = NewClient(cfg.APIURL, cfg.Token)
+	c.json = output == "json"
+	return fs.Args(), nil
+}
+
+// buildID returns the single positional build id of a command.
+func buildID(args []string) (string, error) {
+	if len(args) != 1 || args[0] == "" {
+		return "", usageError("expected exactly one build id")
+	}
+	return args[0], nil
+}
+
+// envFlag collects repeated -env KEY=VALUE flags.
+type envFlag map[string]string
+
+func (e envFlag) String() string {
+	return ""
+}
+
+func (e envFlag) Set(value string) error {
+	key, val, ok := strings.Cut(value, "=")
+	if !ok || key == "" {
+		return fmt.Errorf("expected KEY=VALUE, got %q", value)
+	}
+	e[key] = val
+	return nil
+}
+
+func (c *cli) submit(ctx context.Context, args []string) (int, error) {
+	fs := flag.NewFlagSet("submit", flag.ContinueOnError)
+	req := createBuildRequest{Env: envFlag{}}
+	fs.StringVar(&req.RepoUrl, "repo", "", "repository URL")
+	fs.StringVar(&req.Ref, "ref", "main", "git ref to build")
+	fs.StringVar(&req.Command, "command", "", "command to run")
+	fs.IntVar(&req.Priority, "priority", 0, "build priority; higher runs first")
+	runsOn := fs.String("runs-on", "", "comma separated labels a worker needs to run the build")
+	notBefore := fs.String("not-before", "", "RFC 3339 time or duration from now before which the build does not start")
+	fs.StringVar(&req.ConcurrencyGroup, "concurrency-group", "", "group whose builds run at most -max-concurrency at a time")
+	fs.IntVar(&req.MaxConcurrency, "max-concurrency", 0, "builds of the concurrency group that may run at once (default 1)")
+	fs.StringVar(&req.ConcurrencyPolicy, "concurrency-policy", "", "queue, cancel_pending or cancel_in_progress (default queue)")
+	fs.Var(envFlag(req.Env), "env", "environment variable KEY=VALUE; may be repeated")
+	wait := fs.Bool("wait", false, "wait for the build to finish")
+	follow := fs.Bool("follow", false, "print the logs until the build finishes; implies -wait")
+
+	rest, err := c.parse(fs, args)
+	if err != nil {
+		return 0, err
+	}
+	if len(rest) > 0 {
+		return 0, usageError("submit takes no arguments")
+	}
+	if req.RepoUrl == "" || req.Command == "" {
+		return 0, usageError("-repo and -command are required")
+	}
+	for _, label := range strings.Split(*runsOn, ",") {
+		if label = strings.TrimSpace(label); label != "" {
+			req.RunsOn = append(req.RunsOn, label)
+		}
+	}
+	if *notBefore != "" {
+		at, err := parseNotBefore(*notBefore, time.Now())
+		if err != nil {
+			return 0, err
+		}
+		req.NotBefore = &at
+	}
+
+	build, err := c.client.CreateBuild(ctx, req)
+	if err != nil {
+		return 0, err
+	}
+
+	return c.finish(ctx, build, *wait, *follow)
+}
+
+// parseNotBefore reads an RFC 3339 time or a duration from now, such as 30m.
+func parseNotBefore(value string, now time.Time) (time.Time, error) {
+	if d, err := time.ParseDuration(value); err == nil {
+		return now.Add(d), nil
+	}
+	at, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, usageError("invalid -not-before %q: expected an RFC 3339 time or a duration", value)
+	}
+	return at, nil
+}
+
+func (c *cli) retry(ctx context.Context, args []string) (int, error) {
+	fs := flag.NewFlagSet("retry", flag.ContinueOnError)
+	wait := fs.Bool("wait", false, "wait for the new build to finish")
+	follow := fs.Bool("follow", false, "print the logs until the new build finishes; implies -wait")
+
+	rest, err := c.parse(fs, args)
+	if err != nil {
+		return 0, err
+	}
+	id, err := buildID(rest)
+	if err != nil {
+		return 0, err
+	}
+
+	build, err := c.client.RetryBuild(ctx, id)
+	if err != nil {
+		return 0, err
+	}
+
+	return c.finish(ctx, build, *wait, *follow)
+}
+
+// finish reports a newly created build and, if asked to, waits for it.
+func (c *cli) finish(ctx context.Context, build *domain.Build, wait bool, follow bool) (int, error) {
+	if !wait && !follow {
+		if c.json {
+			return exitOK, writeJSON(c.out, build)
+		}
+		_, _ = fmt.Fprintf(c.out, "build %s created (%s)\n", build.ID, build.Status)
+		return exitOK, nil
+	}
+
+	if !c.json {
+		_, _ = fmt.Fprintf(c.errOut, "build %s created, waiting for it to finish\n", build.ID)
+	}
+
+	var err error
+	if follow && len(build.Children) == 0 {
+		_, err = c.followLogs(ctx, build, true)
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	build, err = c.waitForBuild(ctx, build.ID)
+	if err != nil {
+		return 0, err
+	}
+
+	if c.json {
+		return exitCodeFor(build.Status), writeJSON(c.out, build)
+	}
+	_, _ = fmt.Fprintf(c.out, "build %s finished: %s\n", build.ID, describeResult(build))
+	return exitCodeFor(build.Status), nil
+}
+
+func (c *cli) get(ctx context.Context, args []string) (int, error) {
+	rest, err := c.parse(flag.NewFlagSet("get", flag.ContinueOnError), args)
+	if err != nil {
+		return 0, err
+	}
+	id, err := buildID(rest)
+	if err != nil {
+		return 0, err
+	}
+
+	build, err := c.client.GetBuild(ctx, id)
+	if err != nil {
+		return 0, err
+	}
+
+	if c.json {
+		return exitOK, writeJSON(c.out, build)
+	}
+	return exitOK, writeBuild(c.out, build)
+}
+
+func (c *cli) list(ctx context.Context, args []string) (int, error) {
+	fs := flag.NewFlagSet("list", flag.ContinueOnError)
+	query := url.Values{}
+	params := []struct {
+		flag, param, help string
+	}{
+		{"status", "status", "comma separated statuses"},
+		{"repo", "repo_url", "repository URL"},
+		{"ref", "ref", "git ref"},
+		{"worker", "worker", "worker holding the build"},
+		{"since", "created_after", "only builds created at or after this RFC 3339 time"},
+		{"until", "created_before", "only builds created before this RFC 3339 time"},
+		{"q", "q", "search in the command"},
+		{"limit", "limit", "page size"},
+		{"cursor", "cursor", "next cursor of a previous page"},
+	}
+	values := make([]*string, len(params))
+	for i, p := range params {
+		values[i] = fs.String(p.flag, "", p.help)
+	}
+
+	rest, err := c.parse(fs, args)
+	if err != nil {
+		return 0, err
+	}
+	if len(rest) > 0 {
+		return 0, usageError("list takes no arguments")
+	}
+	for i, p := range params {
+		if *values[i] != "" {
+			query.Set(p.param, *values[i])
+		}
+	}
+
+	page, err := c.client.ListBuilds(ctx, query)
+	if err != nil {
+		return 0, err
+	}
+
+	if c.json {
+		return exitOK, writeJSON(c.out, page)
+	}
+	if err := writeBuildTable(c.out, page.Builds); err != nil {
+		return 0, err
+	}
+	if page.NextCursor != "" {
+		_, _ = fmt.Fprintf(c.errOut, "more builds: -cursor %s\n", page.NextCursor)
+	}
+	return exitOK, nil
+}
+
+func (c *cli) cancel(ctx context.Context, args []string) (int, error) {
+	rest, err := c.parse(flag.NewFlagSet("cancel", flag.ContinueOnError), args)
+	if err != nil {
+		return 0, err
+	}
+	id, err := buildID(rest)
+	if err != nil {
+		return 0, err
+	}
+
+	resp, err := c.client.CancelBuild(ctx, id)
+	if err != nil {
+		return 0, err
+	}
+
+	if c.json {
+		return exitOK, writeJSON(c.out, resp)
+	}
+	_, _ = fmt.Fprintf(c.out, "cancellation of build %s requested\n", id)
+	return exitOK, nil
+}
+
+// priority changes the priority of a build that has not started yet.
+func (c *cli) priority(ctx context.Context, args []string) (int, error) {
+	rest, err := c.parse(flag.NewFlagSet("priority", flag.ContinueOnError), args)
+	if err != nil {
+		return 0, err
+	}
+	if len(rest) != 2 || rest[0] == "" {
+		return 0, usageError("expected a build id and a priority")
+	}
+	priority, err := strconv.Atoi(rest[1])
+	if err != nil {
+		return 0, usageError("invalid priority %q", rest[1])
+	}
+
+	build, err := c.client.UpdatePriority(ctx, rest[0], priority)
+	if err != nil {
+		return 0, err
+	}
+
+	if c.json {
+		return exitOK, writeJSON(c.out, build)
+	}
+	_, _ = fmt.Fprintf(c.out, "build %s priority set to %d\n", build.ID, build.Priority)
+	return exitOK, nil
+}
+
+// workers shows the fleet: every registered worker with its status and the
+// builds it runs.
+func (c *cli) workers(ctx context.Context, args []string) (int, error) {
+	rest, err := c.parse(flag.NewFlagSet("workers", flag.ContinueOnError), args)
+	if err != nil {
+		return 0, err
+	}
+	if len(rest) > 0 {
+		return 0, usageError("workers takes no arguments")
+	}
+
+	resp, err := c.client.ListWorkers(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	if c.json {
+		return exitOK, writeJSON(c.out, resp)
+	}
+	return exitOK, writeWorkerTable(c.out, resp.Workers)
+}
+
+// workerAction returns the command that drains, pauses or resumes the
+// worker named by its argument.
+func workerAction(action string, state domain.WorkerState) command {
+	return func(c *cli, ctx context.Context, args []string) (int, error) {
+		rest, err := c.parse(flag.NewFlagSet(action, flag.ContinueOnError), args)
+		if err != nil {
+			return 0, err
+		}
+		if len(rest) != 1 || rest[0] == "" {
+			return 0, usageError("expected exactly one worker id")
+		}
+
+		worker, err := c.client.SetWorkerState(ctx, rest[0], action)
+		if err != nil {
+			return 0, err
+		}
+
+		if c.json {
+			return exitOK, writeJSON(c.out, worker)
+		}
+		_, _ = fmt.Fprintf(c.out, "worker %s is %s\n", worker.ID, state)
+		return exitOK, nil
+	}
+}
+
+// repoLimits shows the repositories whose running builds are capped.
+func (c *cli) repoLimits(ctx context.Context, args []string) (int, error) {
+	rest, err := c.parse(flag.NewFlagSet("repo-limits", flag.ContinueOnError), args)
+	if err != nil {
+		return 0, err
+	}
+	if len(rest) > 0 {
+		return 0, usageError("repo-limits takes no arguments")
+	}
+
+	resp, err := c.client.ListRepoLimits(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	if c.json {
+		return exitOK, writeJSON(c.out, resp)
+	}
+	return exitOK, writeRepoLimitTable(c.out, resp.Limits)
+}
+
+// repoLimit caps the running builds of a repository; 0 removes the cap.
+func (c *cli) repoLimit(ctx context.Context, args []string) (int, error) {
+	rest, err := c.parse(flag.NewFlagSet("repo-limit", flag.ContinueOnError), args)
+	if err != nil {
+		return 0, err
+	}
+	if len(rest) != 2 || rest[0] == "" {
+		return 0, usageError("expected a repository URL and a maximum")
+	}
+	maxRunning, err := strconv.Atoi(rest[1])
+	if err != nil {
+		return 0, usageError("invalid maximum %q", rest[1])
+	}
+
+	limit, err := c.client.SetRepoLimit(ctx, rest[0], maxRunning)
+	if err != nil {
+		return 0, err
+	}
+
+	if c.json {
+		return exitOK, writeJSON(c.out, limit)
+	}
+	if limit.MaxRunningBuilds == 0 {
+		_, _ = fmt.Fprintf(c.out, "%s runs any number of builds\n", limit.RepoUrl)
+		return exitOK, nil
+	}
+	_, _ = fmt.Fprintf(c.out, "%s runs at most %d builds at once\n", limit.RepoUrl, limit.MaxRunningBuilds)
+	return exitOK, nil
+}
+
+// schedules lists the schedules with their next and last run.
+func (c *cli) schedules(ctx context.Context, args []string) (int, error) {
+	rest, err := c.parse(flag.NewFlagSet("schedules", flag.ContinueOnError), args)
+	if err != nil {
+		return 0, err
+	}
+	if len(rest) > 0 {
+		return 0, usageError("schedules takes no arguments")
+	}
+
+	resp, err := c.client.ListSchedules(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	if c.json {
+		return exitOK, writeJSON(c.out, resp)
+	}
+	return exitOK, writeScheduleTable(c.out, resp.Schedules)
+}
+
+func (c *cli) scheduleCreate(ctx context.Context, args []string) (int, error) {
+	fs := flag.NewFlagSet("schedule-create", flag.ContinueOnError)
+	req := createScheduleRequest{Env: envFlag{}}
+	fs.StringVar(&req.Name, "name", "", "schedule name")
+	fs.StringVar(&req.RepoUrl, "repo", "", "repository URL")
+	fs.StringVar(&req.Ref, "ref", "main", "git ref to build")
+	fs.StringVar(&req.Command, "command", "", "command to run")
+	fs.StringVar(&req.Cron, "cron", "", "cron expression, such as \"0 3 * * *\" or @daily")
+	fs.StringVar(&req.Timezone, "timezone", "", "IANA time zone the cron expression is read in (default UTC)")
+	fs.StringVar(&req.MissedRunPolicy, "missed-runs", "", "skip, run_once or run_all (default run_once)")
+	fs.Var(envFlag(req.Env), "env", "environment variable KEY=VALUE; may be repeated")
+
+	rest, err := c.parse(fs, args)
+	if err != nil {
+		return 0, err
+	}
+	if len(rest) > 0 {
+		return 0, usageError("schedule-create takes no arguments")
+	}
+	if req.Name == "" || req.RepoUrl == "" || req.Command == "" || req.Cron == "" {
+		return 0, usageError("-name, -repo, -command and -cron are required")
+	}
+
+	schedule, err := c.client.CreateSchedule(ctx, req)
+	if err != nil {
+		return 0, err
+	}
+
+	if c.json {
+		return exitOK, writeJSON(c.out, schedule)
+	}
+	_, _ = fmt.Fprintf(c.out, "schedule %s created, next run %s\n", schedule.ID, formatTime(&schedule.NextRunAt))
+	return exitOK, nil
+}
+
+func (c *cli) scheduleDelete(ctx context.Context, args []string) (int, error) {
+	rest, err := c.parse(flag.NewFlagSet("schedule-delete", flag.ContinueOnError), args)
+	if err != nil {
+		return 0, err
+	}
+	if len(rest) != 1 || rest[0] == "" {
+		return 0, usageError("expected exactly one schedule id")
+	}
+
+	if err := c.client.DeleteSchedule(ctx, rest[0]); err != nil {
+		return 0, err
+	}
+
+	if c.json {
+		return exitOK, writeJSON(c.out, messageResponse{Message: "schedule deleted"})
+	}
+	_, _ = fmt.Fprintf(c.out, "schedule %s deleted\n", rest[0])
+	return exitOK, nil
+}
+
+func (c *cli) logs(ctx context.Context, args []string) (int, error) {
+	fs := flag.NewFlagSet("logs", flag.ContinueOnError)
+	follow := fs.Bool("follow", false, "keep printing new lines until the build finishes")
+
+	rest, err := c.parse(fs, args)
+	if err != nil {
+		return 0, err
+	}
+	id, err := buildID(rest)
+	if err != nil {
+		return 0, err
+	}
+
+	build, err := c.client.GetBuild(ctx, id)
+	if err != nil {
+		return 0, err
+	}
+	if len(build.Children) > 0 {
+		ids := make([]string, 0, len(build.Children))
+		for _, child := range build.Children {
+			ids = append(ids, child.ID)
+		}
+		return 0, fmt.Errorf("build %s is a matrix build; its logs belong to the child builds %s", id, strings.Join(ids, ", "))
+	}
+
+	status, err := c.followLogs(ctx, build, *follow)
+	if err != nil {
+		return 0, err
+	}
+	if *follow {
+		return exitCodeFor(status), nil
+	}
+	return exitOK, nil
+}
+
+// followLogs prints the log lines of build. When following it polls until the
+// build has finished and every line was printed, and returns the final
+// status.
+func (c *cli) followLogs(ctx context.Context, build *domain.Build, follow bool) (domain.BuildStatus, error) {
+	jobNames := map[string]string{}
+	for _, job := range build.Jobs {
+		jobNames[job.ID] = job.Name
+	}
+	prefix := len(build.Jobs) > 1
+
+	var afterSeq int64
+	for {
+		page, err := c.client.ListLogs(ctx, build.ID, afterSeq)
+		if err != nil {
+			return "", err
+		}
+		for _, line := range page.Logs {
+			if err := c.writeLogLine(line, jobNames, prefix); err != nil {
+				return "", err
+			}
+		}
+		afterSeq = page.NextAfterSeq
+
+		if len(page.Logs) >= domain.MaxLogPageSize {
+			continue
+		}
+		if !follow || page.BuildStatus.IsTerminal() {
+			return page.BuildStatus, nil
+		}
+
+		if err := c.sleep(ctx); err != nil {
+			return "", err
+		}
+	}
+}
+
+func (c *cli) writeLogLine(line domain.BuildLog, jobNames map[string]string, prefix bool) error {
+	if c.json {
+		return writeJSONLine(c.out, line)
+	}
+	if prefix && line.JobID != nil {
+		_, err := fmt.Fprintf(c.out, "[%s] %s\n", jobNames[*line.JobID], line.Content)
+		return err
+	}
+	_, err := fmt.Fprintln(c.out, line.Content)
+	return err
+}
+
+func (c *cli) waitForBuild(ctx context.Context, buildId string) (*domain.Build, error) {
+	for {
+		build, err := c.client.GetBuild(ctx, buildId)
+		if err != nil {
+			return nil, err
+		}
+		if build.Status.IsTerminal() {
+			return build, nil
+		}
+		if err := c.sleep(ctx); err != nil {
+			return nil, err
+		}
+	}
+}
+
+func (c *cli) sleep(ctx context.Context) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-time.After(c.pollInterval):
+		return nil
+	}
+}
+
+// exitCodeFor maps the result of a build onto the exit code of a command
+// that waited for it.
+func exitCodeFor(status domain.BuildStatus) int {
+	switch status {
+	case domain.BuildStatusFailed:
+		return exitBuildFailed
+	case domain.BuildStatusCanceled:
+		return exitBuildCanceled
+	default:
+		return exitOK
+	}
+}
@@ -0,0 +1,577 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/H3nSte1n/ci-orchestrator/internal/core/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeAPI records requests and answers them with canned handlers.
+type fakeAPI struct {
+	*httptest.Server
+	mu       sync.Mutex
+	requests []*http.Request
+	bodies   [][]byte
+}
+
+func newFakeAPI(t *testing.T, routes map[string]http.HandlerFunc) *fakeAPI {
+	api := &fakeAPI{}
+	mux := http.NewServeMux()
+	for pattern, handler := range routes {
+		handler := handler
+		mux.HandleFunc(pattern, func(w http.ResponseWriter, r *http.Request) {
+			var body bytes.Buffer
+			_, _ = body.ReadFrom(r.Body)
+			api.mu.Lock()
+			api.requests = append(api.requests, r)
+			api.bodies = append(api.bodies, body.Bytes())
+			api.mu.Unlock()
+			handler(w, r)
+		})
+	}
+	api.Server = httptest.NewServer(mux)
+	t.Cleanup(api.Close)
+	return api
+}
+
+func respondJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+type result struct {
+	code   int
+	stdout string
+	stderr string
+}
+
+func runCLI(t *testing.T, api *fakeAPI, args ...string) result {
+	t.Helper()
+	var stdout, stderr bytes.Buffer
+	// An empty config file keeps the tests independent of the user's config.
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(configPath, nil, 0o600))
+	env := map[string]string{"CICTL_API_URL": api.URL, "CICTL_TOKEN": "cio_test", "CICTL_CONFIG": configPath}
+
+	code := run(context.Background(), args, &stdout, &stderr, func(k string) string { return env[k] })
+	return result{code: code, stdout: stdout.String(), stderr: stderr.String()}
+}
+
+func TestMain(m *testing.M) {
+	pollInterval = time.Millisecond
+	os.Exit(m.Run())
+}
+
+func TestSubmit(t *testing.T) {
+	api := newFakeAPI(t, map[string]http.HandlerFunc{
+		"POST /api/v1/builds": func(w http.ResponseWriter, r *http.Request) {
+			respondJSON(w, http.StatusCreated, domain.Build{ID: "b1", Status: domain.BuildStatusPending})
+		},
+	})
+
+	res := runCLI(t, api, "submit", "-repo", "https://github.com/test/repo", "-command", "make", "-env", "GOFLAGS=-v", "-env", "CGO_ENABLED=0")
+
+	assert.Equal(t, exitOK, res.code)
+	assert.Equal(t, "build b1 created (pending)\n", res.stdout)
+	require.Len(t, api.requests, 1)
+	assert.Equal(t, "Bearer cio_test", api.requests[0].Header.Get("Authorization"))
+	assert.JSONEq(t, `{"repo_url":"https://github.com/test/repo","ref":"main","command":"make","env":{"GOFLAGS":"-v","CGO_ENABLED":"0"}}`, string(api.bodies[0]))
+}
+
+func TestSubmit_RunsOn(t *testing.T) {
+	api := newFakeAPI(t, map[string]http.HandlerFunc{
+		"POST /api/v1/builds": func(w http.ResponseWriter, r *http.Request) {
+			respondJSON(w, http.StatusCreated, domain.Build{ID: "b1", Status: domain.BuildStatusPending})
+		},
+	})
+
+	res := runCLI(t, api, "submit", "-repo", "https://github.com/test/repo", "-command", "make", "-runs-on", "linux, arm64")
+
+	assert.Equal(t, exitOK, res.code)
+	require.Len(t, api.bodies, 1)
+	assert.JSONEq(t, `{"repo_url":"https://github.com/test/repo","ref":"main","command":"make","runs_on":["linux","arm64"]}`, string(api.bodies[0]))
+}
+
+func TestSubmit_ConcurrencyGroup(t *testing.T) {
+	api := newFakeAPI(t, map[string]http.HandlerFunc{
+		"POST /api/v1/builds": func(w http.ResponseWriter, r *http.Request) {
+			respondJSON(w, http.StatusCreated, domain.Build{ID: "b1", Status: domain.BuildStatusPending})
+		},
+	})
+
+	res := runCLI(t, api, "submit", "-repo", "https://github.com/test/repo", "-command", "make deploy",
+		"-concurrency-group", "deploy-prod", "-max-concurrency", "2", "-concurrency-policy", "cancel_pending")
+
+	assert.Equal(t, exitOK, res.code)
+	require.Len(t, api.bodies, 1)
+	assert.JSONEq(t, `{"repo_url":"https://github.com/test/repo","ref":"main","command":"make deploy",
+		"concurrency_group":"deploy-prod","max_concurrency":2,"concurrency_policy":"cancel_pending"}`, string(api.bodies[0]))
+}
+
+func TestSubmit_NotBefore(t *testing.T) {
+	api := newFakeAPI(t, map[string]http.HandlerFunc{
+		"POST /api/v1/builds": func(w http.ResponseWriter, r *http.Request) {
+			respondJSON(w, http.StatusCreated, domain.Build{ID: "b1", Status: domain.BuildStatusPending})
+		},
+	})
+
+	res := runCLI(t, api, "submit", "-repo", "https://github.com/test/repo", "-command", "make", "-not-before", "2026-10-20T03:00:00Z")
+
+	assert.Equal(t, exitOK, res.code)
+	require.Len(t, api.bodies, 1)
+	assert.JSONEq(t, `{"repo_url":"https://github.com/test/repo","ref":"main","command":"make","not_before":"2026-10-20T03:00:00Z"}`, string(api.bodies[0]))
+}
+
+func TestSubmit_InvalidNotBefore(t *testing.T) {
+	api := newFakeAPI(t, nil)
+
+	res := runCLI(t, api, "submit", "-repo", "https://github.com/test/repo", "-command", "make", "-not-before", "tomorrow")
+
+	assert.Equal(t, exitUsage, res.code)
+	assert.Contains(t, res.stderr, `invalid -not-before "tomorrow"`)
+	assert.Empty(t, api.requests)
+}
+
+func TestParseNotBefore(t *testing.T) {
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+
+	at, err := parseNotBefore("90m", now)
+	require.NoError(t, err)
+	assert.Equal(t, now.Add(90*time.Minute), at)
+
+	at, err = parseNotBefore("2026-10-20T03:00:00+02:00", now)
+	require.NoError(t, err)
+	assert.True(t, at.Equal(time.Date(2026, 10, 20, 1, 0, 0, 0, time.UTC)))
+}
+
+func TestSubmit_RequiresRepoAndCommand(t *testing.T) {
+	api := newFakeAPI(t, nil)
+
+	res := runCLI(t, api, "submit", "-command", "make")
+
+	assert.Equal(t, exitUsage, res.code)
+	assert.Contains(t, res.stderr, "-repo and -command are required")
+	assert.Empty(t, api.requests)
+}
+
+func TestSubmit_WaitMirrorsBuildResult(t *testing.T) {
+	tests := []struct {
+		status domain.BuildStatus
+		code   int
+	}{
+		{domain.BuildStatusSuccess, exitOK},
+		{domain.BuildStatusFailed, exitBuildFailed},
+		{domain.BuildStatusCanceled, exitBuildCanceled},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.status), func(t *testing.T) {
+			polls := 0
+			api := newFakeAPI(t, map[string]http.HandlerFunc{
+				"POST /api/v1/builds": func(w http.ResponseWriter, r *http.Request) {
+					respondJSON(w, http.StatusCreated, domain.Build{ID: "b1", Status: domain.BuildStatusPending})
+				},
+				"GET /api/v1/builds/b1": func(w http.ResponseWriter, r *http.Request) {
+					polls++
+					status := domain.BuildStatusRunning
+					if polls > 1 {
+						status = tt.status
+					}
+					respondJSON(w, http.StatusOK, domain.Build{ID: "b1", Status: status, ExitCode: 2})
+				},
+			})
+
+			res := runCLI(t, api, "submit", "-repo", "https://github.com/test/repo", "-command", "make", "-wait")
+
+			assert.Equal(t, tt.code, res.code)
+			assert.Contains(t, res.stdout, "build b1 finished: "+string(tt.status))
+			assert.Equal(t, 2, polls)
+		})
+	}
+}
+
+func TestSubmit_ValidationError(t *testing.T) {
+	api := newFakeAPI(t, map[string]http.HandlerFunc{
+		"POST /api/v1/builds": func(w http.ResponseWriter, r *http.Request) {
+			respondJSON(w, http.StatusBadRequest, map[string]interface{}{"error": map[string]interface{}{
+				"code": "invalid_argument", "message": "validation failed", "request_id": "r1",
+				"details": []domain.FieldError{{Field: "repo_url", Message: "must use https, ssh or git"}},
+			}})
+		},
+	})
+
+	res := runCLI(t, api, "submit", "-repo", "file:///etc", "-command", "make")
+
+	assert.Equal(t, exitError, res.code)
+	assert.Contains(t, res.stderr, "invalid_argument: validation failed")
+	assert.Contains(t, res.stderr, "repo_url: must use https, ssh or git")
+	assert.Contains(t, res.stderr, "request id r1")
+}
+
+func TestGet_JSON(t *testing.T) {
+	api := newFakeAPI(t, map[string]http.HandlerFunc{
+		"GET /api/v1/builds/b1": func(w http.ResponseWriter, r *http.Request) {
+			respondJSON(w, http.StatusOK, domain.Build{ID: "b1", Status: domain.BuildStatusSuccess})
+		},
+	})
+
+	res := runCLI(t, api, "get", "-o", "json", "b1")
+
+	require.Equal(t, exitOK, res.code)
+	var build domain.Build
+	require.NoError(t, json.Unmarshal([]byte(res.stdout), &build))
+	assert.Equal(t, domain.BuildStatusSuccess, build.Status)
+}
+
+func TestGet_Table(t *testing.T) {
+	api := newFakeAPI(t, map[string]http.HandlerFunc{
+		"GET /api/v1/builds/b1": func(w http.ResponseWriter, r *http.Request) {
+			respondJSON(w, http.StatusOK, domain.Build{
+				ID: "b1", Status: domain.BuildStatusFailed, ExitCode: 2, RepoUrl: "https://github.com/test/repo",
+				Jobs: []domain.Job{{Name: "build", Status: domain.JobStatusFailed, ExitCode: 2}},
+			})
+		},
+	})
+
+	res := runCLI(t, api, "get", "b1")
+
+	require.Equal(t, exitOK, res.code)
+	assert.Contains(t, res.stdout, "failed (exit code 2)")
+	assert.Contains(t, res.stdout, "JOB")
+	assert.Contains(t, res.stdout, "build")
+}
+
+func TestGet_Unschedulable(t *testing.T) {
+	api := newFakeAPI(t, map[string]http.HandlerFunc{
+		"GET /api/v1/builds/b1": func(w http.ResponseWriter, r *http.Request) {
+			respondJSON(w, http.StatusOK, domain.Build{ID: "b1", Status: domain.BuildStatusPending, RunsOn: domain.StringList{"gpu"}, Unschedulable: true})
+		},
+	})
+
+	res := runCLI(t, api, "get", "b1")
+
+	require.Equal(t, exitOK, res.code)
+	assert.Contains(t, res.stdout, "pending (no online worker has labels gpu)")
+}
+
+func TestGet_ConcurrencyGroup(t *testing.T) {
+	group := "deploy-prod"
+	api := newFakeAPI(t, map[string]http.HandlerFunc{
+		"GET /api/v1/builds/b1": func(w http.ResponseWriter, r *http.Request) {
+			respondJSON(w, http.StatusOK, domain.Build{
+				ID: "b1", Status: domain.BuildStatusPending,
+				ConcurrencyGroup: &group, MaxConcurrency: 1, ConcurrencyPolicy: domain.ConcurrencyQueue,
+			})
+		},
+	})
+
+	res := runCLI(t, api, "get", "b1")
+
+	require.Equal(t, exitOK, res.code)
+	assert.Contains(t, res.stdout, "deploy-prod (max 1, queue)")
+}
+
+func TestGet_RequiresID(t *testing.T) {
+	res := runCLI(t, newFakeAPI(t, nil), "get")
+
+	assert.Equal(t, exitUsage, res.code)
+}
+
+func TestList_PassesFilters(t *testing.T) {
+	api := newFakeAPI(t, map[string]http.HandlerFunc{
+		"GET /api/v1/builds": func(w http.ResponseWriter, r *http.Request) {
+			respondJSON(w, http.StatusOK, listBuildsResponse{
+				Builds:     []domain.Build{{ID: "b1", Status: domain.BuildStatusFailed, Ref: "main"}},
+				NextCursor: "c2",
+			})
+		},
+	})
+
+	res := runCLI(t, api, "list", "-status", "failed,canceled", "-repo", "https://github.com/test/repo", "-limit", "5")
+
+	require.Equal(t, exitOK, res.code)
+	query := api.requests[0].URL.Query()
+	assert.Equal(t, "failed,canceled", query.Get("status"))
+	assert.Equal(t, "https://github.com/test/repo", query.Get("repo_url"))
+	assert.Equal(t, "5", query.Get("limit"))
+	assert.Contains(t, res.stdout, "b1")
+	assert.Contains(t, res.stderr, "-cursor c2")
+}
+
+func TestCancel(t *testing.T) {
+	api := newFakeAPI(t, map[string]http.HandlerFunc{
+		"POST /api/v1/builds/b1/cancel": func(w http.ResponseWriter, r *http.Request) {
+			respondJSON(w, http.StatusOK, messageResponse{Message: "Build canceled successfully"})
+		},
+	})
+
+	res := runCLI(t, api, "cancel", "b1")
+
+	assert.Equal(t, exitOK, res.code)
+	assert.Equal(t, "cancellation of build b1 requested\n", res.stdout)
+}
+
+func TestCancel_NotFound(t *testing.T) {
+	api := newFakeAPI(t, map[string]http.HandlerFunc{
+		"POST /api/v1/builds/b1/cancel": func(w http.ResponseWriter, r *http.Request) {
+			respondJSON(w, http.StatusNotFound, map[string]interface{}{"error": map[string]string{"code": "not_found", "message": "build not found"}})
+		},
+	})
+
+	res := runCLI(t, api, "cancel", "b1")
+
+	assert.Equal(t, exitError, res.code)
+	assert.Contains(t, res.stderr, "not_found: build not found")
+}
+
+func TestPriority(t *testing.T) {
+	api := newFakeAPI(t, map[string]http.HandlerFunc{
+		"PUT /api/v1/builds/b1/priority": func(w http.ResponseWriter, r *http.Request) {
+			respondJSON(w, http.StatusOK, domain.Build{ID: "b1", Status: domain.BuildStatusPending, Priority: 10})
+		},
+	})
+
+	res := runCLI(t, api, "priority", "b1", "10")
+
+	assert.Equal(t, exitOK, res.code)
+	assert.Equal(t, "build b1 priority set to 10\n", res.stdout)
+	require.Len(t, api.bodies, 1)
+	assert.JSONEq(t, `{"priority":10}`, string(api.bodies[0]))
+}
+
+func TestPriority_InvalidValue(t *testing.T) {
+	api := newFakeAPI(t, nil)
+
+	res := runCLI(t, api, "priority", "b1", "high")
+
+	assert.Equal(t, exitUsage, res.code)
+	assert.Contains(t, res.stderr, `invalid priority "high"`)
+	assert.Empty(t, api.requests)
+}
+
+func TestWorkers(t *testing.T) {
+	api := newFakeAPI(t, map[string]http.HandlerFunc{
+		"GET /api/v1/workers": func(w http.ResponseWriter, r *http.Request) {
+			respondJSON(w, http.StatusOK, listWorkersResponse{Workers: []domain.Worker{{
+				ID:            "w1",
+				Hostname:      "host-1",
+				Version:       "v1.2.0",
+				Slots:         1,
+				Labels:        domain.StringList{"linux", "arm64"},
+				Status:        domain.WorkerStatusOnline,
+				CurrentBuilds: []string{"b1"},
+			}}})
+		},
+	})
+
+	res := runCLI(t, api, "workers")
+
+	assert.Equal(t, exitOK, res.code)
+	assert.Contains(t, res.stdout, "STATUS")
+	assert.Regexp(t, `w1\s+online\s+host-1\s+v1.2.0\s+1\s+linux,arm64\s+b1`, res.stdout)
+}
+
+func TestDrain(t *testing.T) {
+	api := newFakeAPI(t, map[string]http.HandlerFunc{
+		"POST /api/v1/workers/w1/drain": func(w http.ResponseWriter, r *http.Request) {
+			respondJSON(w, http.StatusOK, domain.Worker{ID: "w1", State: domain.WorkerStateDraining})
+		},
+	})
+
+	res := runCLI(t, api, "drain", "w1")
+
+	assert.Equal(t, exitOK, res.code)
+	assert.Equal(t, "worker w1 is draining\n", res.stdout)
+}
+
+func TestResume_MissingWorker(t *testing.T) {
+	api := newFakeAPI(t, nil)
+
+	res := runCLI(t, api, "resume")
+
+	assert.Equal(t, exitUsage, res.code)
+	assert.Contains(t, res.stderr, "expected exactly one worker id")
+	assert.Empty(t, api.requests)
+}
+
+func TestRepoLimits(t *testing.T) {
+	api := newFakeAPI(t, map[string]http.HandlerFunc{
+		"GET /api/v1/repo-limits": func(w http.ResponseWriter, r *http.Request) {
+			respondJSON(w, http.StatusOK, listRepoLimitsResponse{Limits: []domain.RepoLimit{
+				{RepoUrl: "https://github.com/test/busy", MaxRunningBuilds: 2},
+				{RepoUrl: "https://github.com/test/quiet"},
+			}})
+		},
+	})
+
+	res := runCLI(t, api, "repo-limits")
+
+	assert.Equal(t, exitOK, res.code)
+	assert.Regexp(t, `https://github.com/test/busy\s+2\s`, res.stdout)
+	assert.Regexp(t, `https://github.com/test/quiet\s+unlimited\s`, res.stdout)
+}
+
+func TestRepoLimit(t *testing.T) {
+	api := newFakeAPI(t, map[string]http.HandlerFunc{
+		"PUT /api/v1/repo-limits": func(w http.ResponseWriter, r *http.Request) {
+			respondJSON(w, http.StatusOK, domain.RepoLimit{RepoUrl: "https://github.com/test/busy", MaxRunningBuilds: 2})
+		},
+	})
+
+	res := runCLI(t, api, "repo-limit", "https://github.com/test/busy", "2")
+
+	assert.Equal(t, exitOK, res.code)
+	assert.Equal(t, "https://github.com/test/busy runs at most 2 builds at once\n", res.stdout)
+	require.Len(t, api.bodies, 1)
+	assert.JSONEq(t, `{"repo_url":"https://github.com/test/busy","max_running_builds":2}`, string(api.bodies[0]))
+}
+
+func TestRepoLimit_InvalidMaximum(t *testing.T) {
+	api := newFakeAPI(t, nil)
+
+	res := runCLI(t, api, "repo-limit", "https://github.com/test/busy", "two")
+
+	assert.Equal(t, exitUsage, res.code)
+	assert.Contains(t, res.stderr, `invalid maximum "two"`)
+	assert.Empty(t, api.requests)
+}
+
+func TestSchedules(t *testing.T) {
+	lastRun := time.Date(2026, 10, 19, 3, 0, 0, 0, time.UTC)
+	api := newFakeAPI(t, map[string]http.HandlerFunc{
+		"GET /api/v1/schedules": func(w http.ResponseWriter, r *http.Request) {
+			respondJSON(w, http.StatusOK, listSchedulesResponse{Schedules: []domain.Schedule{{
+				ID: "s1", Name: "nightly", Cron: "0 3 * * *", Timezone: "Europe/Berlin", Ref: "main",
+				RepoUrl: "https://github.com/test/repo", NextRunAt: lastRun.AddDate(0, 0, 1), LastRunAt: &lastRun,
+			}}})
+		},
+	})
+
+	res := runCLI(t, api, "schedules")
+
+	assert.Equal(t, exitOK, res.code)
+	assert.Contains(t, res.stdout, "NEXT RUN")
+	assert.Regexp(t, `s1\s+nightly\s+0 3 \* \* \*\s+Europe/Berlin\s+main\s+https://github.com/test/repo`, res.stdout)
+}
+
+func TestScheduleCreate(t *testing.T) {
+	api := newFakeAPI(t, map[string]http.HandlerFunc{
+		"POST /api/v1/schedules": func(w http.ResponseWriter, r *http.Request) {
+			respondJSON(w, http.StatusCreated, domain.Schedule{ID: "s1", Name: "nightly"})
+		},
+	})
+
+	res := runCLI(t, api, "schedule-create", "-name", "nightly", "-repo", "https://github.com/test/repo", "-command", "make test",
+		"-cron", "0 3 * * *", "-timezone", "Europe/Berlin", "-missed-runs", "skip", "-env", "SUITE=full")
+
+	assert.Equal(t, exitOK, res.code)
+	assert.Contains(t, res.stdout, "schedule s1 created")
+	require.Len(t, api.bodies, 1)
+	assert.JSONEq(t, `{"name":"nightly","repo_url":"https://github.com/test/repo","ref":"main","command":"make test",
+		"cron":"0 3 * * *","timezone":"Europe/Berlin","missed_run_policy":"skip","env":{"SUITE":"full"}}`, string(api.bodies[0]))
+}
+
+func TestScheduleCreate_RequiresCron(t *testing.T) {
+	api := newFakeAPI(t, nil)
+
+	res := runCLI(t, api, "schedule-create", "-name", "nightly", "-repo", "https://github.com/test/repo", "-command", "make test")
+
+	assert.Equal(t, exitUsage, res.code)
+	assert.Contains(t, res.stderr, "-name, -repo, -command and -cron are required")
+	assert.Empty(t, api.requests)
+}
+
+func TestScheduleDelete(t *testing.T) {
+	api := newFakeAPI(t, map[string]http.HandlerFunc{
+		"DELETE /api/v1/schedules/s1": func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusNoContent)
+		},
+	})
+
+	res := runCLI(t, api, "schedule-delete", "s1")
+
+	assert.Equal(t, exitOK, res.code)
+	assert.Equal(t, "schedule s1 deleted\n", res.stdout)
+}
+
+func TestLogs_Follow(t *testing.T) {
+	jobBuild, jobTest := "j1", "j2"
+	pages := []listLogsResponse{
+		{Logs: []domain.BuildLog{{Seq: 1, JobID: &jobBuild, Content: "compiling"}}, NextAfterSeq: 1, BuildStatus: domain.BuildStatusRunning},
+		{Logs: []domain.BuildLog{}, NextAfterSeq: 1, BuildStatus: domain.BuildStatusRunning},
+		{Logs: []domain.BuildLog{{Seq: 2, JobID: &jobTest, Content: "FAIL"}}, NextAfterSeq: 2, BuildStatus: domain.BuildStatusFailed},
+	}
+	var afterSeqs []string
+	api := newFakeAPI(t, map[string]http.HandlerFunc{
+		"GET /api/v1/builds/b1": func(w http.ResponseWriter, r *http.Request) {
+			respondJSON(w, http.StatusOK, domain.Build{ID: "b1", Status: domain.BuildStatusRunning, Jobs: []domain.Job{
+				{ID: "j1", Name: "build"}, {ID: "j2", Name: "test"},
+			}})
+		},
+		"GET /api/v1/builds/b1/logs": func(w http.ResponseWriter, r *http.Request) {
+			afterSeqs = append(afterSeqs, r.URL.Query().Get("after_seq"))
+			respondJSON(w, http.StatusOK, pages[len(afterSeqs)-1])
+		},
+	})
+
+	res := runCLI(t, api, "logs", "-follow", "b1")
+
+	assert.Equal(t, exitBuildFailed, res.code)
+	assert.Equal(t, "[build] compiling\n[test] FAIL\n", res.stdout)
+	assert.Equal(t, []string{"", "1", "1"}, afterSeqs)
+}
+
+func TestLogs_MatrixBuild(t *testing.T) {
+	api := newFakeAPI(t, map[string]http.HandlerFunc{
+		"GET /api/v1/builds/b1": func(w http.ResponseWriter, r *http.Request) {
+			respondJSON(w, http.StatusOK, domain.Build{ID: "b1", Children: []domain.Build{{ID: "c1"}, {ID: "c2"}}})
+		},
+	})
+
+	res := runCLI(t, api, "logs", "b1")
+
+	assert.Equal(t, exitError, res.code)
+	assert.Contains(t, res.stderr, "c1, c2")
+}
+
+func TestRetry(t *testing.T) {
+	api := newFakeAPI(t, map[string]http.HandlerFunc{
+		"POST /api/v1/builds/b1/retry": func(w http.ResponseWriter, r *http.Request) {
+			respondJSON(w, http.StatusCreated, domain.Build{ID: "b2", Status: domain.BuildStatusPending})
+		},
+	})
+
+	res := runCLI(t, api, "retry", "b1")
+
+	assert.Equal(t, exitOK, res.code)
+	assert.Equal(t, "build b2 created (pending)\n", res.stdout)
+}
+
+func TestRun_UnknownCommand(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+
+	code := run(context.Background(), []string{"deploy"}, &stdout, &stderr, func(string) string { return "" })
+
+	assert.Equal(t, exitUsage, code)
+	assert.Contains(t, stderr.String(), `unknown command "deploy"`)
+}
+
+func TestRun_InvalidOutputFormat(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+
+	code := run(context.Background(), []string{"get", "-o", "yaml", "b1"}, &stdout, &stderr, func(string) string { return "" })
+
+	assert.Equal(t, exitUsage, code)
+}
@@ -0,0 +1,60 @@
+package main
+
+import (
+	"fmt"
+	"github.com/spf13/viper"
+	"os"
+	"path/filepath"
+)
+
+const defaultAPIURL = "http://localhost:8000"
+
+// Config holds the connection settings. They are read from the config file,
+// then from CICTL_API_URL and CICTL_TOKEN, then from the command line flags,
+// each overriding the previous one.
+type Config struct {
+	APIURL string `mapstructure:"api_url"`
+	Token  string `mapstructure:"token"`
+}
+
+// loadConfig reads path, or $CICTL_CONFIG, or cictl/config.yaml in the user
+// config directory. Only an explicitly named file has to exist.
+func loadConfig(path string, getenv func(string) string) (Config, error) {
+	cfg := Config{APIURL: defaultAPIURL}
+
+	if path == "" {
+		path = getenv("CICTL_CONFIG")
+	}
+	explicit := path != ""
+	if !explicit {
+		if dir, err := os.UserConfigDir(); err == nil {
+			path = filepath.Join(dir, "cictl", "config.yaml")
+		}
+	}
+
+	if path != "" {
+		if _, err := os.Stat(path); err == nil || explicit {
+			v := viper.New()
+			v.SetConfigFile(path)
+			v.SetConfigType("yaml")
+			if err := v.ReadInConfig(); err != nil {
+				return cfg, fmt.Errorf("read config %s: %w", path, err)
+			}
+			if err := v.Unmarshal(&cfg); err != nil {
+				return cfg, fmt.Errorf("parse config %s: %w", path, err)
+			}
+		}
+	}
+
+	if value := getenv("CICTL_API_URL"); value != "" {
+		cfg.APIURL = value
+	}
+	if value := getenv("CICTL_TOKEN"); value != "" {
+		cfg.Token = value
+	}
+	if cfg.APIURL == "" {
+		cfg.APIURL = defaultAPIURL
+	}
+
+	return cfg, nil
+}
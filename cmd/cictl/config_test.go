@@ -0,0 +1,54 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeConfig(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+func envOf(values map[string]string) func(string) string {
+	return func(key string) string { return values[key] }
+}
+
+func TestLoadConfig_File(t *testing.T) {
+	path := writeConfig(t, "api_url: https://ci.example.com\ntoken: cio_file\n")
+
+	cfg, err := loadConfig(path, envOf(nil))
+
+	require.NoError(t, err)
+	assert.Equal(t, Config{APIURL: "https://ci.example.com", Token: "cio_file"}, cfg)
+}
+
+func TestLoadConfig_EnvOverridesFile(t *testing.T) {
+	path := writeConfig(t, "api_url: https://ci.example.com\ntoken: cio_file\n")
+
+	cfg, err := loadConfig("", envOf(map[string]string{"CICTL_CONFIG": path, "CICTL_TOKEN": "cio_env"}))
+
+	require.NoError(t, err)
+	assert.Equal(t, Config{APIURL: "https://ci.example.com", Token: "cio_env"}, cfg)
+}
+
+func TestLoadConfig_Defaults(t *testing.T) {
+	path := writeConfig(t, "")
+
+	cfg, err := loadConfig(path, envOf(nil))
+
+	require.NoError(t, err)
+	assert.Equal(t, Config{APIURL: defaultAPIURL}, cfg)
+}
+
+func TestLoadConfig_MissingExplicitFile(t *testing.T) {
+	_, err := loadConfig(filepath.Join(t.TempDir(), "missing.yaml"), envOf(nil))
+
+	assert.Error(t, err)
+}
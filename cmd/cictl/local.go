@@ -0,0 +1,113 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"github.com/H3nSte1n/ci-orchestrator/internal/adapters/runner"
+	"github.com/H3nSte1n/ci-orchestrator/internal/adapters/vcs"
+	"github.com/H3nSte1n/ci-orchestrator/internal/adapters/worker"
+	"github.com/H3nSte1n/ci-orchestrator/internal/core/domain"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// runLocal runs a command through the worker's checkout, runner and log
+// pipeline on this machine, without an API or a database. The exit code is
+// the command's own.
+func (c *cli) runLocal(ctx context.Context, args []string) (int, error) {
+	fs := flag.NewFlagSet("run-local", flag.ContinueOnError)
+	fs.SetOutput(c.errOut)
+	env := envFlag{}
+	repo := fs.String("repo", ".", "repository path or URL")
+	ref := fs.String("ref", "HEAD", "git ref to check out")
+	command := fs.String("command", "", "command to run")
+	fs.Var(env, "env", "environment variable KEY=VALUE; may be repeated")
+
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return 0, err
+		}
+		return 0, fmt.Errorf("%w: %v", errUsage, err)
+	}
+	if fs.NArg() > 0 {
+		return 0, usageError("run-local takes no arguments")
+	}
+	if *command == "" {
+		return 0, usageError("-command is required")
+	}
+
+	repoUrl := *repo
+	if info, err := os.Stat(repoUrl); err == nil && info.IsDir() {
+		if repoUrl, err = filepath.Abs(repoUrl); err != nil {
+			return 0, err
+		}
+	}
+
+	id := fmt.Sprintf("local-%d", time.Now().UnixNano())
+	job := &domain.Job{
+		ID:      id,
+		BuildID: id,
+		Name:    domain.DefaultJobName,
+		Command: *command,
+		Build: &domain.Build{
+			ID:      id,
+			RepoUrl: repoUrl,
+			Ref:     *ref,
+			Command: *command,
+			Env:     domain.StringMap(env),
+		},
+	}
+
+	logs := &terminalLogService{out: c.out, errOut: c.errOut}
+	w := worker.NewWorker("local", nil, logs, 0, 0, runner.NewHostRunner(), vcs.NewGitVCS(), nil, nil)
+
+	exitCode, err := w.Execute(ctx, job)
+	if ctx.Err() != nil {
+		_, _ = fmt.Fprintln(c.errOut, "cictl: run canceled")
+		return exitBuildCanceled, nil
+	}
+	if exitCode < 0 {
+		return 0, err
+	}
+	if err != nil && exitCode == 0 {
+		return 0, err
+	}
+	return exitCode, nil
+}
+
+// terminalLogService prints the lines a job produces instead of storing
+// them.
+type terminalLogService struct {
+	mu     sync.Mutex
+	out    io.Writer
+	errOut io.Writer
+}
+
+func (t *terminalLogService) AppendLog(ctx context.Context, buildId string, jobId string, logEvent domain.LogEvent) error {
+	return t.AppendLogs(ctx, buildId, jobId, []domain.LogEvent{logEvent})
+}
+
+func (t *terminalLogService) AppendLogs(ctx context.Context, buildId string, jobId string, logEvents []domain.LogEvent) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	for _, ev := range logEvents {
+		w := t.out
+		if ev.Stream == domain.LogStderr {
+			w = t.errOut
+		}
+		if _, err := fmt.Fprintln(w, ev.Line); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (t *terminalLogService) ListLogs(ctx context.Context, buildId string, afterSeq int64, limit int) ([]domain.BuildLog, error) {
+	return nil, errors.ErrUnsupported
+}
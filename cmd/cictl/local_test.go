@@ -0,0 +1,75 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// initRepo creates a git repository with one commit containing hello.txt.
+func initRepo(t *testing.T) string {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "hello.txt"), []byte("hello from the repo\n"), 0o644))
+	for _, args := range [][]string{
+		{"init", "--quiet"},
+		{"add", "hello.txt"},
+		{"-c", "user.name=test", "-c", "user.email=test@example.com", "commit", "--quiet", "-m", "init"},
+	} {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		out, err := cmd.CombinedOutput()
+		require.NoError(t, err, string(out))
+	}
+	return dir
+}
+
+func runLocalCLI(args ...string) result {
+	var stdout, stderr bytes.Buffer
+	code := run(context.Background(), append([]string{"run-local"}, args...), &stdout, &stderr, func(string) string { return "" })
+	return result{code: code, stdout: stdout.String(), stderr: stderr.String()}
+}
+
+func TestRunLocal(t *testing.T) {
+	repo := initRepo(t)
+
+	res := runLocalCLI("-repo", repo, "-command", `cat hello.txt; echo "$GREETING"; echo oops >&2; exit 3`, "-env", "GREETING=hi")
+
+	assert.Equal(t, 3, res.code)
+	assert.Contains(t, res.stdout, "hello from the repo\nhi\n")
+	assert.Contains(t, res.stderr, "oops\n")
+}
+
+func TestRunLocal_Success(t *testing.T) {
+	repo := initRepo(t)
+
+	res := runLocalCLI("-repo", repo, "-command", "test -f hello.txt")
+
+	assert.Equal(t, exitOK, res.code, res.stderr)
+}
+
+func TestRunLocal_UnknownRef(t *testing.T) {
+	repo := initRepo(t)
+
+	res := runLocalCLI("-repo", repo, "-ref", "no-such-branch", "-command", "true")
+
+	assert.Equal(t, exitError, res.code)
+	assert.Contains(t, res.stderr, "checkout repo")
+}
+
+func TestRunLocal_RequiresCommand(t *testing.T) {
+	res := runLocalCLI("-repo", ".")
+
+	assert.Equal(t, exitUsage, res.code)
+	assert.Contains(t, res.stderr, "-command is required")
+}
@@ -0,0 +1,26 @@
+// Command cictl is a command line client for the orchestrator's REST API.
+package main
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"syscall"
+)
+
+// Exit codes. When a command waits for a build, the exit code mirrors the
+// build result.
+const (
+	exitOK            = 0
+	exitBuildFailed   = 1
+	exitUsage         = 2
+	exitBuildCanceled = 3
+	exitError         = 4
+)
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	code := run(ctx, os.Args[1:], os.Stdout, os.Stderr, os.Getenv)
+	stop()
+	os.Exit(code)
+}
@@ -0,0 +1,165 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/H3nSte1n/ci-orchestrator/internal/core/domain"
+	"io"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+)
+
+const timeFormat = "2006-01-02 15:04:05"
+
+func writeJSON(w io.Writer, v interface{}) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
+
+// writeJSONLine writes v as one line, so followed logs form JSON lines.
+func writeJSONLine(w io.Writer, v interface{}) error {
+	return json.NewEncoder(w).Encode(v)
+}
+
+func writeBuildTable(w io.Writer, builds []domain.Build) error {
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	_, _ = fmt.Fprintln(tw, "ID\tSTATUS\tREF\tREPO\tCREATED\tTRIGGERED BY")
+	for _, build := range builds {
+		_, _ = fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\n",
+			build.ID, build.Status, build.Ref, build.RepoUrl, formatTime(&build.CreatedAt), stringValue(build.TriggeredBy))
+	}
+	return tw.Flush()
+}
+
+func writeWorkerTable(w io.Writer, workers []domain.Worker) error {
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	_, _ = fmt.Fprintln(tw, "ID\tSTATUS\tHOST\tVERSION\tSLOTS\tLABELS\tBUILDS\tLAST SEEN")
+	for _, worker := range workers {
+		_, _ = fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%d\t%s\t%s\t%s\n",
+			worker.ID, worker.Status, worker.Hostname, worker.Version, worker.Slots,
+			strings.Join(worker.Labels, ","), strings.Join(worker.CurrentBuilds, ","), formatTime(&worker.LastSeenAt))
+	}
+	return tw.Flush()
+}
+
+func writeRepoLimitTable(w io.Writer, limits []domain.RepoLimit) error {
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	_, _ = fmt.Fprintln(tw, "REPO\tMAX RUNNING\tUPDATED")
+	for _, limit := range limits {
+		maxRunning := "unlimited"
+		if limit.MaxRunningBuilds > 0 {
+			maxRunning = fmt.Sprint(limit.MaxRunningBuilds)
+		}
+		_, _ = fmt.Fprintf(tw, "%s\t%s\t%s\n", limit.RepoUrl, maxRunning, formatTime(&limit.UpdatedAt))
+	}
+	return tw.Flush()
+}
+
+func writeScheduleTable(w io.Writer, schedules []domain.Schedule) error {
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	_, _ = fmt.Fprintln(tw, "ID\tNAME\tCRON\tTIMEZONE\tREF\tREPO\tNEXT RUN\tLAST RUN")
+	for _, schedule := range schedules {
+		_, _ = fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+			schedule.ID, schedule.Name, schedule.Cron, schedule.Timezone, schedule.Ref, schedule.RepoUrl,
+			formatTime(&schedule.NextRunAt), formatTime(schedule.LastRunAt))
+	}
+	return tw.Flush()
+}
+
+// describeConcurrency shows the concurrency group with its limit and policy.
+func describeConcurrency(build *domain.Build) string {
+	if build.ConcurrencyGroup == nil {
+		return ""
+	}
+	return fmt.Sprintf("%s (max %d, %s)", *build.ConcurrencyGroup, build.MaxConcurrency, build.ConcurrencyPolicy)
+}
+
+func writeBuild(w io.Writer, build *domain.Build) error {
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	rows := [][2]string{
+		{"ID", build.ID},
+		{"Status", describeResult(build)},
+		{"Repo", build.RepoUrl},
+		{"Ref", build.Ref},
+		{"Command", build.Command},
+		{"Runs on", strings.Join(build.RunsOn, ", ")},
+		{"Concurrency", describeConcurrency(build)},
+		{"Triggered by", stringValue(build.TriggeredBy)},
+		{"Not before", formatTime(build.NotBefore)},
+		{"Created", formatTime(&build.CreatedAt)},
+		{"Finished", formatTime(build.FinishedAt)},
+	}
+	if build.ParentID != nil {
+		rows = append(rows, [2]string{"Parent", *build.ParentID})
+	}
+	for _, key := range sortedKeys(build.MatrixValues) {
+		rows = append(rows, [2]string{"Matrix " + key, build.MatrixValues[key]})
+	}
+	for _, row := range rows {
+		if row[1] != "" {
+			_, _ = fmt.Fprintf(tw, "%s:\t%s\n", row[0], row[1])
+		}
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+
+	if len(build.Jobs) > 0 {
+		_, _ = fmt.Fprintln(w)
+		tw = tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+		_, _ = fmt.Fprintln(tw, "JOB\tSTATUS\tEXIT\tNEEDS\tWORKER")
+		for _, job := range build.Jobs {
+			_, _ = fmt.Fprintf(tw, "%s\t%s\t%d\t%v\t%s\n", job.Name, job.Status, job.ExitCode, []string(job.Needs), stringValue(job.LockedBy))
+		}
+		if err := tw.Flush(); err != nil {
+			return err
+		}
+	}
+
+	if len(build.Children) > 0 {
+		_, _ = fmt.Fprintln(w)
+		return writeBuildTable(w, build.Children)
+	}
+	return nil
+}
+
+// describeResult renders the status with the exit code or error of a
+// finished build.
+func describeResult(build *domain.Build) string {
+	switch {
+	case build.Status == domain.BuildStatusFailed && build.Error != "":
+		return fmt.Sprintf("%s (%s)", build.Status, build.Error)
+	case build.Status == domain.BuildStatusFailed:
+		return fmt.Sprintf("%s (exit code %d)", build.Status, build.ExitCode)
+	case build.Unschedulable:
+		return fmt.Sprintf("%s (no online worker has labels %s)", build.Status, strings.Join(build.RunsOn, ", "))
+	default:
+		return string(build.Status)
+	}
+}
+
+func formatTime(t *time.Time) string {
+	if t == nil || t.IsZero() {
+		return ""
+	}
+	return t.Local().Format(timeFormat)
+}
+
+func stringValue(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -1,5 +1,145 @@
 package main
 
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/H3nSte1n/ci-orchestrator/internal/adapters/notify"
+	"github.com/H3nSte1n/ci-orchestrator/internal/adapters/queue"
+	"github.com/H3nSte1n/ci-orchestrator/internal/adapters/repositories"
+	"github.com/H3nSte1n/ci-orchestrator/internal/adapters/runner"
+	"github.com/H3nSte1n/ci-orchestrator/internal/adapters/storage"
+	"github.com/H3nSte1n/ci-orchestrator/internal/adapters/vcs"
+	"github.com/H3nSte1n/ci-orchestrator/internal/adapters/worker"
+	"github.com/H3nSte1n/ci-orchestrator/internal/adapters/workerapi"
+	"github.com/H3nSte1n/ci-orchestrator/internal/core/domain"
+	"github.com/H3nSte1n/ci-orchestrator/internal/core/ports"
+	"github.com/H3nSte1n/ci-orchestrator/internal/core/service"
+	"github.com/H3nSte1n/ci-orchestrator/internal/platform/config"
+	"github.com/H3nSte1n/ci-orchestrator/internal/platform/db"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+)
+
+// version is reported when the worker registers; release builds set it with
+// -ldflags "-X main.version=...".
+var version = "dev"
+
 func main() {
+	env := os.Getenv("APP_ENV")
+	if env == "" {
+		env = "development"
+	}
+
+	cfg, err := config.LoadConfig(env)
+	if err != nil {
+		panic(err)
+	}
+
+	cacheStore, err := storage.NewCacheStore(cfg.Cache)
+	if err != nil {
+		panic(err)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	var (
+		buildService    ports.BuildService
+		buildLogService ports.BuildLogService
+		artifactService ports.ArtifactService
+		workerService   ports.WorkerService
+		wake            <-chan struct{}
+		connected       func() bool
+	)
+
+	if cfg.Worker.ApiURL != "" {
+		client := workerapi.NewClient(cfg.Worker.ApiURL, cfg.Worker.ApiToken)
+		buildService = workerapi.NewBuildService(client)
+		buildLogService = workerapi.NewBuildLogService(client)
+		artifactService = workerapi.NewArtifactService(client)
+		workerService = workerapi.NewWorkerService(client)
+	} else {
+		dbConnection, err := db.NewConnection(cfg)
+		if err != nil {
+			panic(err)
+		}
+
+		artifactStore, err := storage.NewArtifactStore(cfg.Artifacts)
+		if err != nil {
+			panic(err)
+		}
+
+		buildRepository := repositories.NewBuildRepository(dbConnection)
+		jobQueue, err := queue.NewQueue(ctx, cfg.Queue, buildRepository)
+		if err != nil {
+			panic(err)
+		}
+
+		workerRepository := repositories.NewWorkerRepository(dbConnection)
+		buildService = service.NewBuildService(buildRepository, workerRepository, jobQueue)
+		buildLogService = service.NewBuildLogService(repositories.NewBuildLogRepository(dbConnection))
+		artifactService = service.NewArtifactService(repositories.NewArtifactRepository(dbConnection), artifactStore)
+		workerService = service.NewWorkerService(workerRepository)
+
+		if dbConnection.Dialector.Name() == "postgres" {
+			notifier := notify.NewPostgresNotifier(dbConnection)
+			wake = notifier.Subscribe(ctx)
+			connected = notifier.Connected
+			go notifier.Listen(ctx)
+			buildService = service.NewNotifyingBuildService(buildService, notifier)
+		}
+	}
+
+	cacheService := service.NewCacheService(cacheStore)
+
+	hostname, err := os.Hostname()
+	if err != nil {
+		panic(err)
+	}
+
+	workerId := cfg.Worker.ID
+	if workerId == "" {
+		workerId = hostname
+	}
+
+	registration := &domain.Worker{ID: workerId, Hostname: hostname, Version: version, Labels: cfg.Worker.Labels, Slots: 1}
+	if err := workerService.Register(ctx, registration); err != nil {
+		panic(err)
+	}
+	defer deregister(workerService, workerId)
+
+	interval := cfg.Worker.PollInterval
+	if interval <= 0 {
+		interval = 2 * time.Second
+	}
+
+	fallback := cfg.Worker.FallbackPollInterval
+	if fallback <= 0 {
+		fallback = 30 * time.Second
+	}
+
+	heartbeat := cfg.Worker.HeartbeatInterval
+	if heartbeat <= 0 {
+		heartbeat = 10 * time.Second
+	}
+
+	w := worker.NewWorker(workerId, buildService, buildLogService, interval, heartbeat, runner.NewHostRunner(), vcs.NewGitVCS(), artifactService, cacheService)
+	w.WakeOn(wake, connected, fallback)
+	w.ReportTo(workerService)
+	if err := w.Run(ctx); err != nil && !errors.Is(err, context.Canceled) {
+		panic(err)
+	}
+}
+
+// deregister marks the worker offline; ctx is already canceled on shutdown.
+func deregister(workerService ports.WorkerService, workerId string) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
 
+	if err := workerService.Deregister(ctx, workerId); err != nil {
+		fmt.Println("Error deregistering worker:", err)
+	}
 }
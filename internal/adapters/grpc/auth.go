@@ -0,0 +1,134 @@
+package grpc
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	civ1 "github.com/H3nSte1n/ci-orchestrator/api/ci/v1"
+	"github.com/H3nSte1n/ci-orchestrator/internal/core/domain"
+	"github.com/H3nSte1n/ci-orchestrator/internal/core/ports"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"strings"
+)
+
+// methodScopes lists the scope each RPC requires; it matches the scopes of
+// the corresponding HTTP routes. Methods missing here are rejected.
+var methodScopes = map[string]domain.Scope{
+	civ1.BuildService_CreateBuild_FullMethodName: domain.ScopeBuildsWrite,
+	civ1.BuildService_GetBuild_FullMethodName:    domain.ScopeBuildsRead,
+	civ1.BuildService_ListBuilds_FullMethodName:  domain.ScopeBuildsRead,
+	civ1.BuildService_CancelBuild_FullMethodName: domain.ScopeBuildsCancel,
+	civ1.BuildService_StreamLogs_FullMethodName:  domain.ScopeBuildsRead,
+}
+
+type contextKey int
+
+const (
+	apiTokenKey contextKey = iota
+	requestIDKey
+)
+
+const requestIDMetadata = "x-request-id"
+
+// authorize tags the call with a request id, resolves its bearer token and
+// checks the scope required by method.
+func authorize(ctx context.Context, tokens ports.APITokenService, method string) (context.Context, error) {
+	requestId := incomingRequestID(ctx)
+	ctx = context.WithValue(ctx, requestIDKey, requestId)
+	_ = grpc.SetHeader(ctx, metadata.Pairs(requestIDMetadata, requestId))
+
+	secret, ok := bearerToken(ctx)
+	if !ok {
+		return ctx, domain.ErrMissingToken
+	}
+
+	token, err := tokens.Authenticate(ctx, secret)
+	if err != nil {
+		return ctx, err
+	}
+
+	scope, ok := methodScopes[method]
+	if !ok {
+		return ctx, domain.NewError(domain.ErrPermissionDenied, "method is not available")
+	}
+	if !token.HasScope(scope) {
+		return ctx, domain.ErrInsufficientScope
+	}
+
+	return context.WithValue(ctx, apiTokenKey, token), nil
+}
+
+// UnaryAuthInterceptor authenticates unary calls and translates the errors
+// of the handlers into gRPC statuses.
+func UnaryAuthInterceptor(tokens ports.APITokenService) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		ctx, err := authorize(ctx, tokens, info.FullMethod)
+		if err != nil {
+			return nil, toStatus(info.FullMethod, requestID(ctx), err)
+		}
+
+		resp, err := handler(ctx, req)
+		if err != nil {
+			return nil, toStatus(info.FullMethod, requestID(ctx), err)
+		}
+		return resp, nil
+	}
+}
+
+// StreamAuthInterceptor is the streaming counterpart of UnaryAuthInterceptor.
+func StreamAuthInterceptor(tokens ports.APITokenService) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, err := authorize(ss.Context(), tokens, info.FullMethod)
+		if err != nil {
+			return toStatus(info.FullMethod, requestID(ctx), err)
+		}
+
+		if err := handler(srv, &authorizedStream{ServerStream: ss, ctx: ctx}); err != nil {
+			return toStatus(info.FullMethod, requestID(ctx), err)
+		}
+		return nil
+	}
+}
+
+type authorizedStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *authorizedStream) Context() context.Context {
+	return s.ctx
+}
+
+func bearerToken(ctx context.Context) (string, bool) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	for _, value := range md.Get("authorization") {
+		scheme, secret, ok := strings.Cut(value, " ")
+		if ok && strings.EqualFold(scheme, "Bearer") && strings.TrimSpace(secret) != "" {
+			return strings.TrimSpace(secret), true
+		}
+	}
+	return "", false
+}
+
+func currentToken(ctx context.Context) *domain.APIToken {
+	token, _ := ctx.Value(apiTokenKey).(*domain.APIToken)
+	return token
+}
+
+func requestID(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey).(string)
+	return id
+}
+
+// incomingRequestID reuses a well-formed id sent by the client, like the
+// RequestID middleware of the HTTP API.
+func incomingRequestID(ctx context.Context) string {
+	md, _ := metadata.FromIncomingContext(ctx)
+	if values := md.Get(requestIDMetadata); len(values) > 0 && domain.ValidRequestID(values[0]) {
+		return values[0]
+	}
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,191 @@
+package grpc
+
+import (
+	"fmt"
+	civ1 "github.com/H3nSte1n/ci-orchestrator/api/ci/v1"
+	"github.com/H3nSte1n/ci-orchestrator/internal/core/domain"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"time"
+)
+
+func buildToProto(build *domain.Build) *civ1.Build {
+	pb := &civ1.Build{
+		Id:                build.ID,
+		RepoUrl:           build.RepoUrl,
+		Ref:               build.Ref,
+		Command:           build.Command,
+		Status:            string(build.Status),
+		ExitCode:          int32(build.ExitCode),
+		Error:             build.Error,
+		Attempts:          int32(build.Attempts),
+		Env:               build.Env,
+		Artifacts:         build.Artifacts,
+		TriggeredBy:       stringValue(build.TriggeredBy),
+		ParentId:          stringValue(build.ParentID),
+		MatrixValues:      build.MatrixValues,
+		CreatedAt:         timestamp(&build.CreatedAt),
+		UpdatedAt:         timestamp(&build.UpdatedAt),
+		FinishedAt:        timestamp(build.FinishedAt),
+		CancelRequestedAt: timestamp(build.CancelRequestedAt),
+		Priority:          int32(build.Priority),
+		RunsOn:            build.RunsOn,
+		Unschedulable:     build.Unschedulable,
+		ConcurrencyGroup:  stringValue(build.ConcurrencyGroup),
+		MaxConcurrency:    int32(build.MaxConcurrency),
+		ConcurrencyPolicy: string(build.ConcurrencyPolicy),
+		NotBefore:         timestamp(build.NotBefore),
+	}
+
+	for _, cache := range build.Caches {
+		pb.Caches = append(pb.Caches, &civ1.Cache{Key: cache.Key, Paths: cache.Paths, RestoreKeys: cache.RestoreKeys})
+	}
+	for i := range build.Jobs {
+		pb.Jobs = append(pb.Jobs, jobToProto(&build.Jobs[i]))
+	}
+	for _, child := range build.Children {
+		pb.ChildIds = append(pb.ChildIds, child.ID)
+	}
+
+	return pb
+}
+
+func jobToProto(job *domain.Job) *civ1.Job {
+	return &civ1.Job{
+		Id:         job.ID,
+		Name:       job.Name,
+		Command:    job.Command,
+		Needs:      job.Needs,
+		Artifacts:  job.Artifacts,
+		Status:     string(job.Status),
+		LockedBy:   stringValue(job.LockedBy),
+		ExitCode:   int32(job.ExitCode),
+		Error:      job.Error,
+		CreatedAt:  timestamp(&job.CreatedAt),
+		FinishedAt: timestamp(job.FinishedAt),
+	}
+}
+
+// createRequestToDomain builds the domain build described by a request. Like
+// the HTTP DTO it only carries client settable fields.
+func createRequestToDomain(req *civ1.CreateBuildRequest) *domain.Build {
+	build := &domain.Build{
+		RepoUrl:           req.GetRepoUrl(),
+		Ref:               req.GetRef(),
+		Command:           req.GetCommand(),
+		Env:               req.GetEnv(),
+		Artifacts:         req.GetArtifacts(),
+		Priority:          int(req.GetPriority()),
+		RunsOn:            req.GetRunsOn(),
+		MaxConcurrency:    int(req.GetMaxConcurrency()),
+		ConcurrencyPolicy: domain.ConcurrencyPolicy(req.GetConcurrencyPolicy()),
+		NotBefore:         timeValue(req.GetNotBefore()),
+	}
+	if group := req.GetConcurrencyGroup(); group != "" {
+		build.ConcurrencyGroup = &group
+	}
+
+	for _, cache := range req.GetCaches() {
+		build.Caches = append(build.Caches, domain.Cache{
+			Key:         cache.GetKey(),
+			Paths:       cache.GetPaths(),
+			RestoreKeys: cache.GetRestoreKeys(),
+		})
+	}
+	for _, job := range req.GetJobs() {
+		build.Jobs = append(build.Jobs, domain.Job{
+			Name:      job.GetName(),
+			Command:   job.GetCommand(),
+			Needs:     job.GetNeeds(),
+			Artifacts: job.GetArtifacts(),
+		})
+	}
+	if m := req.GetMatrix(); m != nil {
+		matrix := &domain.Matrix{Axes: make(map[string][]string, len(m.GetAxes())), FailFast: m.GetFailFast()}
+		for name, values := range m.GetAxes() {
+			matrix.Axes[name] = values.GetValues()
+		}
+		for _, combination := range m.GetInclude() {
+			matrix.Include = append(matrix.Include, combination.GetValues())
+		}
+		for _, combination := range m.GetExclude() {
+			matrix.Exclude = append(matrix.Exclude, combination.GetValues())
+		}
+		build.Matrix = matrix
+	}
+
+	return build
+}
+
+// listRequestToFilter validates a list request the same way the HTTP query
+// parameters are validated.
+func listRequestToFilter(req *civ1.ListBuildsRequest) (domain.BuildFilter, error) {
+	filter := domain.BuildFilter{
+		RepoUrl:        req.GetRepoUrl(),
+		Ref:            req.GetRef(),
+		LockedBy:       req.GetWorker(),
+		Query:          req.GetQuery(),
+		CreatedAfter:   timeValue(req.GetCreatedAfter()),
+		CreatedBefore:  timeValue(req.GetCreatedBefore()),
+		FinishedAfter:  timeValue(req.GetFinishedAfter()),
+		FinishedBefore: timeValue(req.GetFinishedBefore()),
+	}
+
+	for _, s := range req.GetStatuses() {
+		status := domain.BuildStatus(s)
+		switch status {
+		case domain.BuildStatusPending, domain.BuildStatusRunning, domain.BuildStatusSuccess, domain.BuildStatusFailed, domain.BuildStatusCanceled:
+			filter.Statuses = append(filter.Statuses, status)
+		default:
+			return filter, invalidArgument(fmt.Sprintf("invalid status %q", s))
+		}
+	}
+
+	if limit := req.GetLimit(); limit != 0 {
+		if limit < 1 || limit > domain.MaxBuildPageSize {
+			return filter, invalidArgument(fmt.Sprintf("limit must be between 1 and %d", domain.MaxBuildPageSize))
+		}
+		filter.Limit = int(limit)
+	}
+
+	if cursor := req.GetCursor(); cursor != "" {
+		after, err := domain.DecodeBuildCursor(cursor)
+		if err != nil {
+			return filter, err
+		}
+		filter.After = after
+	}
+
+	return filter, nil
+}
+
+func logToProto(log *domain.BuildLog) *civ1.LogLine {
+	return &civ1.LogLine{
+		Seq:    log.Seq,
+		JobId:  stringValue(log.JobID),
+		Stream: string(log.Stream),
+		Line:   log.Content,
+		Time:   timestamppb.New(log.CreatedAt),
+	}
+}
+
+func timestamp(t *time.Time) *timestamppb.Timestamp {
+	if t == nil || t.IsZero() {
+		return nil
+	}
+	return timestamppb.New(*t)
+}
+
+func timeValue(ts *timestamppb.Timestamp) *time.Time {
+	if ts == nil {
+		return nil
+	}
+	t := ts.AsTime()
+	return &t
+}
+
+func stringValue(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
@@ -0,0 +1,122 @@
+package grpc
+
+import (
+	"testing"
+	"time"
+
+	civ1 "github.com/H3nSte1n/ci-orchestrator/api/ci/v1"
+	"github.com/H3nSte1n/ci-orchestrator/internal/core/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestCreateRequestToDomain(t *testing.T) {
+	notBefore := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	req := &civ1.CreateBuildRequest{
+		RepoUrl: "https://github.com/test/repo",
+		Ref:     "main",
+		Jobs: []*civ1.JobSpec{
+			{Name: "build", Command: "make"},
+			{Name: "test", Command: "make test", Needs: []string{"build"}},
+		},
+		Caches:            []*civ1.Cache{{Key: "go", Paths: []string{".cache"}}},
+		Priority:          30,
+		RunsOn:            []string{"linux", "arm64"},
+		ConcurrencyGroup:  "deploy-prod",
+		ConcurrencyPolicy: "cancel_pending",
+		NotBefore:         timestamppb.New(notBefore),
+		Matrix: &civ1.Matrix{
+			Axes:     map[string]*civ1.Matrix_Values{"go": {Values: []string{"1.24", "1.25"}}},
+			Exclude:  []*civ1.Matrix_Combination{{Values: map[string]string{"go": "1.24"}}},
+			FailFast: true,
+		},
+	}
+
+	build := createRequestToDomain(req)
+
+	assert.Equal(t, "https://github.com/test/repo", build.RepoUrl)
+	require.Len(t, build.Jobs, 2)
+	assert.Equal(t, domain.StringList{"build"}, build.Jobs[1].Needs)
+	assert.Equal(t, domain.CacheList{{Key: "go", Paths: domain.StringList{".cache"}}}, build.Caches)
+	require.NotNil(t, build.Matrix)
+	assert.Equal(t, []string{"1.24", "1.25"}, build.Matrix.Axes["go"])
+	assert.Equal(t, []domain.StringMap{{"go": "1.24"}}, build.Matrix.Exclude)
+	assert.True(t, build.Matrix.FailFast)
+	assert.Equal(t, 30, build.Priority)
+	assert.Equal(t, domain.StringList{"linux", "arm64"}, build.RunsOn)
+	require.NotNil(t, build.ConcurrencyGroup)
+	assert.Equal(t, "deploy-prod", *build.ConcurrencyGroup)
+	assert.Equal(t, domain.ConcurrencyCancelPending, build.ConcurrencyPolicy)
+	require.NotNil(t, build.NotBefore)
+	assert.Equal(t, notBefore, *build.NotBefore)
+	assert.Empty(t, build.ID)
+	assert.Empty(t, build.Status)
+}
+
+func TestListRequestToFilter(t *testing.T) {
+	after := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	cursor := domain.BuildCursor{CreatedAt: after, ID: "b1"}
+
+	filter, err := listRequestToFilter(&civ1.ListBuildsRequest{
+		Statuses:     []string{"running", "failed"},
+		Worker:       "worker-1",
+		CreatedAfter: timestamppb.New(after),
+		Limit:        5,
+		Cursor:       cursor.Encode(),
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, []domain.BuildStatus{domain.BuildStatusRunning, domain.BuildStatusFailed}, filter.Statuses)
+	assert.Equal(t, "worker-1", filter.LockedBy)
+	assert.Equal(t, after, *filter.CreatedAfter)
+	assert.Equal(t, 5, filter.Limit)
+	assert.Equal(t, "b1", filter.After.ID)
+}
+
+func TestListRequestToFilter_Invalid(t *testing.T) {
+	tests := []struct {
+		name string
+		req  *civ1.ListBuildsRequest
+	}{
+		{"status", &civ1.ListBuildsRequest{Statuses: []string{"done"}}},
+		{"limit", &civ1.ListBuildsRequest{Limit: domain.MaxBuildPageSize + 1}},
+		{"cursor", &civ1.ListBuildsRequest{Cursor: "%%%"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := listRequestToFilter(tt.req)
+			assert.ErrorIs(t, err, domain.ErrInvalidArgument)
+		})
+	}
+}
+
+func TestBuildToProto(t *testing.T) {
+	parent := "p1"
+	finished := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	build := &domain.Build{
+		ID:         "b1",
+		Status:     domain.BuildStatusSuccess,
+		ParentID:   &parent,
+		FinishedAt: &finished,
+		NotBefore:  &finished,
+		Priority:   -5,
+		RunsOn:     domain.StringList{"gpu"},
+		Jobs:       []domain.Job{{ID: "j1", Name: "build", Status: domain.JobStatusSuccess}},
+		Children:   []domain.Build{{ID: "c1"}},
+	}
+
+	pb := buildToProto(build)
+
+	assert.Equal(t, "success", pb.GetStatus())
+	assert.Equal(t, "p1", pb.GetParentId())
+	assert.Equal(t, finished, pb.GetFinishedAt().AsTime())
+	assert.Nil(t, pb.GetCreatedAt())
+	assert.Equal(t, finished, pb.GetNotBefore().AsTime())
+	assert.Equal(t, int32(-5), pb.GetPriority())
+	assert.Equal(t, []string{"gpu"}, pb.GetRunsOn())
+	require.Len(t, pb.GetJobs(), 1)
+	assert.Equal(t, "build", pb.GetJobs()[0].GetName())
+	assert.Equal(t, []string{"c1"}, pb.GetChildIds())
+}
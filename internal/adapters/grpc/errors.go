@@ -0,0 +1,77 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"github.com/H3nSte1n/ci-orchestrator/internal/core/domain"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"log"
+)
+
+// validationError carries the field errors of a rejected request so they can
+// be sent as a BadRequest detail.
+type validationError struct {
+	fieldErrors []domain.FieldError
+}
+
+func (e *validationError) Error() string {
+	return "validation failed"
+}
+
+func (e *validationError) Unwrap() error {
+	return domain.ErrInvalidArgument
+}
+
+func invalidArgument(message string) error {
+	return domain.NewError(domain.ErrInvalidArgument, message)
+}
+
+// toStatus maps domain error kinds onto gRPC status codes, mirroring the
+// HTTP error envelope. Unknown errors are logged and replaced by a generic
+// internal error so that driver messages never reach clients.
+func toStatus(method string, requestId string, err error) error {
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+
+	var code codes.Code
+	switch {
+	case errors.Is(err, domain.ErrInvalidArgument):
+		code = codes.InvalidArgument
+	case errors.Is(err, domain.ErrNotFound):
+		code = codes.NotFound
+	case errors.Is(err, domain.ErrConflict):
+		code = codes.AlreadyExists
+	case errors.Is(err, domain.ErrUnauthenticated):
+		code = codes.Unauthenticated
+	case errors.Is(err, domain.ErrPermissionDenied):
+		code = codes.PermissionDenied
+	case errors.Is(err, context.Canceled):
+		return status.Error(codes.Canceled, err.Error())
+	case errors.Is(err, context.DeadlineExceeded):
+		return status.Error(codes.DeadlineExceeded, err.Error())
+	default:
+		log.Printf("request %s: %s: %v", requestId, method, err)
+		return status.Error(codes.Internal, "internal server error")
+	}
+
+	st := status.New(code, err.Error())
+
+	var invalid *validationError
+	if errors.As(err, &invalid) {
+		badRequest := &errdetails.BadRequest{}
+		for _, fe := range invalid.fieldErrors {
+			badRequest.FieldViolations = append(badRequest.FieldViolations, &errdetails.BadRequest_FieldViolation{
+				Field:       fe.Field,
+				Description: fe.Message,
+			})
+		}
+		if detailed, detailErr := st.WithDetails(badRequest); detailErr == nil {
+			st = detailed
+		}
+	}
+
+	return st.Err()
+}
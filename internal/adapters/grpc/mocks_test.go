@@ -0,0 +1,149 @@
+package grpc
+
+import (
+	"context"
+	"time"
+
+	"github.com/H3nSte1n/ci-orchestrator/internal/core/domain"
+	"github.com/stretchr/testify/mock"
+)
+
+type mockBuildService struct {
+	mock.Mock
+}
+
+func (m *mockBuildService) CreateBuild(ctx context.Context, build *domain.Build) error {
+	args := m.Called(ctx, build)
+	return args.Error(0)
+}
+
+func (m *mockBuildService) RetryBuild(ctx context.Context, buildId string, triggeredBy *string) (*domain.Build, error) {
+	args := m.Called(ctx, buildId, triggeredBy)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Build), args.Error(1)
+}
+
+func (m *mockBuildService) CancelBuild(ctx context.Context, buildId string) error {
+	args := m.Called(ctx, buildId)
+	return args.Error(0)
+}
+
+func (m *mockBuildService) UpdateStatus(ctx context.Context, buildId string, status domain.BuildStatus) error {
+	args := m.Called(ctx, buildId, status)
+	return args.Error(0)
+}
+
+func (m *mockBuildService) UpdatePriority(ctx context.Context, buildId string, priority int) (*domain.Build, error) {
+	args := m.Called(ctx, buildId, priority)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Build), args.Error(1)
+}
+
+func (m *mockBuildService) GetBuild(ctx context.Context, buildId string) (*domain.Build, error) {
+	args := m.Called(ctx, buildId)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Build), args.Error(1)
+}
+
+func (m *mockBuildService) ListBuilds(ctx context.Context, filter domain.BuildFilter) (*domain.BuildPage, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.BuildPage), args.Error(1)
+}
+
+func (m *mockBuildService) ClaimNext(ctx context.Context, workerId string) (*domain.Job, error) {
+	args := m.Called(ctx, workerId)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Job), args.Error(1)
+}
+
+func (m *mockBuildService) GetJob(ctx context.Context, jobId string) (*domain.Job, error) {
+	args := m.Called(ctx, jobId)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Job), args.Error(1)
+}
+
+func (m *mockBuildService) Heartbeat(ctx context.Context, jobId string, workerId string) error {
+	args := m.Called(ctx, jobId, workerId)
+	return args.Error(0)
+}
+
+func (m *mockBuildService) CancelRequested(ctx context.Context, jobId string) (bool, error) {
+	args := m.Called(ctx, jobId)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *mockBuildService) CompleteJob(ctx context.Context, jobId string, workerId string, exitCode int, finishedAt *time.Time, error error) error {
+	args := m.Called(ctx, jobId, workerId, exitCode, finishedAt, error)
+	return args.Error(0)
+}
+
+func (m *mockBuildService) RequeueExpired(ctx context.Context, lease time.Duration) (int, error) {
+	args := m.Called(ctx, lease)
+	return args.Int(0), args.Error(1)
+}
+
+type mockBuildLogService struct {
+	mock.Mock
+}
+
+func (m *mockBuildLogService) AppendLog(ctx context.Context, buildId string, jobId string, logEvent domain.LogEvent) error {
+	args := m.Called(ctx, buildId, jobId, logEvent)
+	return args.Error(0)
+}
+
+func (m *mockBuildLogService) AppendLogs(ctx context.Context, buildId string, jobId string, logEvents []domain.LogEvent) error {
+	args := m.Called(ctx, buildId, jobId, logEvents)
+	return args.Error(0)
+}
+
+func (m *mockBuildLogService) ListLogs(ctx context.Context, buildId string, afterSeq int64, limit int) ([]domain.BuildLog, error) {
+	args := m.Called(ctx, buildId, afterSeq, limit)
+	logs, _ := args.Get(0).([]domain.BuildLog)
+	return logs, args.Error(1)
+}
+
+type mockAPITokenService struct {
+	mock.Mock
+}
+
+func (m *mockAPITokenService) Create(ctx context.Context, name string, scopes []string) (*domain.APIToken, string, error) {
+	args := m.Called(ctx, name, scopes)
+	if args.Get(0) == nil {
+		return nil, "", args.Error(2)
+	}
+	return args.Get(0).(*domain.APIToken), args.String(1), args.Error(2)
+}
+
+func (m *mockAPITokenService) Authenticate(ctx context.Context, secret string) (*domain.APIToken, error) {
+	args := m.Called(ctx, secret)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.APIToken), args.Error(1)
+}
+
+func (m *mockAPITokenService) List(ctx context.Context) ([]domain.APIToken, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.APIToken), args.Error(1)
+}
+
+func (m *mockAPITokenService) Revoke(ctx context.Context, tokenId string) error {
+	args := m.Called(ctx, tokenId)
+	return args.Error(0)
+}
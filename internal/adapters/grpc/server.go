@@ -0,0 +1,156 @@
+package grpc
+
+import (
+	"context"
+	civ1 "github.com/H3nSte1n/ci-orchestrator/api/ci/v1"
+	"github.com/H3nSte1n/ci-orchestrator/internal/core/domain"
+	"github.com/H3nSte1n/ci-orchestrator/internal/core/ports"
+	"google.golang.org/grpc"
+	"time"
+)
+
+// logPageSize is the number of log lines read per query while streaming.
+const logPageSize = 500
+
+// defaultLogPollInterval is how long StreamLogs waits for new lines of a
+// running build before querying again.
+const defaultLogPollInterval = 500 * time.Millisecond
+
+type BuildServer struct {
+	civ1.UnimplementedBuildServiceServer
+
+	buildService    ports.BuildService
+	buildLogService ports.BuildLogService
+	pollInterval    time.Duration
+}
+
+func NewBuildServer(buildService ports.BuildService, buildLogService ports.BuildLogService) *BuildServer {
+	return &BuildServer{
+		buildService:    buildService,
+		buildLogService: buildLogService,
+		pollInterval:    defaultLogPollInterval,
+	}
+}
+
+// NewServer returns a gRPC server that exposes the build API and
+// authenticates every call with tokens.
+func NewServer(buildServer *BuildServer, tokens ports.APITokenService) *grpc.Server {
+	server := grpc.NewServer(
+		grpc.UnaryInterceptor(UnaryAuthInterceptor(tokens)),
+		grpc.StreamInterceptor(StreamAuthInterceptor(tokens)),
+	)
+	civ1.RegisterBuildServiceServer(server, buildServer)
+	return server
+}
+
+func (s *BuildServer) CreateBuild(ctx context.Context, req *civ1.CreateBuildRequest) (*civ1.Build, error) {
+	build := createRequestToDomain(req)
+	if fieldErrors := domain.ValidateNewBuild(build); len(fieldErrors) > 0 {
+		return nil, &validationError{fieldErrors: fieldErrors}
+	}
+
+	if token := currentToken(ctx); token != nil {
+		build.TriggeredBy = &token.Name
+	}
+	if err := s.buildService.CreateBuild(ctx, build); err != nil {
+		return nil, err
+	}
+
+	return buildToProto(build), nil
+}
+
+func (s *BuildServer) GetBuild(ctx context.Context, req *civ1.GetBuildRequest) (*civ1.Build, error) {
+	if req.GetId() == "" {
+		return nil, invalidArgument("build id is required")
+	}
+
+	build, err := s.buildService.GetBuild(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	return buildToProto(build), nil
+}
+
+func (s *BuildServer) ListBuilds(ctx context.Context, req *civ1.ListBuildsRequest) (*civ1.ListBuildsResponse, error) {
+	filter, err := listRequestToFilter(req)
+	if err != nil {
+		return nil, err
+	}
+
+	page, err := s.buildService.ListBuilds(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &civ1.ListBuildsResponse{
+		Builds:     make([]*civ1.Build, 0, len(page.Builds)),
+		NextCursor: page.NextCursor,
+	}
+	for i := range page.Builds {
+		resp.Builds = append(resp.Builds, buildToProto(&page.Builds[i]))
+	}
+
+	return resp, nil
+}
+
+func (s *BuildServer) CancelBuild(ctx context.Context, req *civ1.CancelBuildRequest) (*civ1.CancelBuildResponse, error) {
+	if req.GetId() == "" {
+		return nil, invalidArgument("build id is required")
+	}
+
+	if err := s.buildService.CancelBuild(ctx, req.GetId()); err != nil {
+		return nil, err
+	}
+
+	return &civ1.CancelBuildResponse{}, nil
+}
+
+// StreamLogs sends the stored lines of a build and, when following, polls
+// for new ones until the build has finished and every line was sent.
+func (s *BuildServer) StreamLogs(req *civ1.StreamLogsRequest, stream civ1.BuildService_StreamLogsServer) error {
+	ctx := stream.Context()
+	if req.GetBuildId() == "" {
+		return invalidArgument("build id is required")
+	}
+
+	build, err := s.buildService.GetBuild(ctx, req.GetBuildId())
+	if err != nil {
+		return err
+	}
+
+	afterSeq := req.GetAfterSeq()
+	for {
+		// Read the status before the logs so lines written just before the
+		// build finished are not missed.
+		finished := build.Status.IsTerminal()
+
+		logs, err := s.buildLogService.ListLogs(ctx, build.ID, afterSeq, logPageSize)
+		if err != nil {
+			return err
+		}
+		for i := range logs {
+			if err := stream.Send(logToProto(&logs[i])); err != nil {
+				return err
+			}
+			afterSeq = logs[i].Seq
+		}
+
+		if len(logs) == logPageSize {
+			continue
+		}
+		if !req.GetFollow() || finished {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(s.pollInterval):
+		}
+
+		if build, err = s.buildService.GetBuild(ctx, build.ID); err != nil {
+			return err
+		}
+	}
+}
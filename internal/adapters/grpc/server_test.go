@@ -0,0 +1,280 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"testing"
+	"time"
+
+	civ1 "github.com/H3nSte1n/ci-orchestrator/api/ci/v1"
+	"github.com/H3nSte1n/ci-orchestrator/internal/core/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+const testSecret = "cio_secret"
+
+type testServer struct {
+	builds *mockBuildService
+	logs   *mockBuildLogService
+	tokens *mockAPITokenService
+	client civ1.BuildServiceClient
+}
+
+// newTestServer serves the build API over an in-memory listener. The test
+// secret authenticates as a token named "ci-bot" with the given scopes.
+func newTestServer(t *testing.T, scopes ...string) *testServer {
+	ts := &testServer{
+		builds: new(mockBuildService),
+		logs:   new(mockBuildLogService),
+		tokens: new(mockAPITokenService),
+	}
+	ts.tokens.On("Authenticate", mock.Anything, testSecret).
+		Return(&domain.APIToken{Name: "ci-bot", Scopes: domain.StringList(scopes)}, nil).Maybe()
+	ts.tokens.On("Authenticate", mock.Anything, mock.Anything).Return(nil, domain.ErrInvalidToken).Maybe()
+
+	buildServer := NewBuildServer(ts.builds, ts.logs)
+	buildServer.pollInterval = time.Millisecond
+	server := NewServer(buildServer, ts.tokens)
+
+	listener := bufconn.Listen(1 << 20)
+	go func() { _ = server.Serve(listener) }()
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+
+	ts.client = civ1.NewBuildServiceClient(conn)
+	return ts
+}
+
+func authorized(secret string) context.Context {
+	return metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer "+secret)
+}
+
+func TestBuildServer_CreateBuild(t *testing.T) {
+	ts := newTestServer(t, "builds:write")
+	ts.builds.On("CreateBuild", mock.Anything, mock.MatchedBy(func(b *domain.Build) bool {
+		return b.RepoUrl == "https://github.com/test/repo" && b.Command == "make" &&
+			b.TriggeredBy != nil && *b.TriggeredBy == "ci-bot"
+	})).Run(func(args mock.Arguments) {
+		args.Get(1).(*domain.Build).ID = "b1"
+	}).Return(nil)
+
+	build, err := ts.client.CreateBuild(authorized(testSecret), &civ1.CreateBuildRequest{
+		RepoUrl: "https://github.com/test/repo",
+		Ref:     "main",
+		Command: "make",
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, "b1", build.GetId())
+	assert.Equal(t, "ci-bot", build.GetTriggeredBy())
+	ts.builds.AssertExpectations(t)
+}
+
+func TestBuildServer_CreateBuild_ValidationFailed(t *testing.T) {
+	ts := newTestServer(t, "builds:write")
+
+	_, err := ts.client.CreateBuild(authorized(testSecret), &civ1.CreateBuildRequest{
+		RepoUrl: "file:///etc",
+		Ref:     "main",
+		Command: "make",
+	})
+
+	st := status.Convert(err)
+	assert.Equal(t, codes.InvalidArgument, st.Code())
+	assert.Equal(t, "validation failed", st.Message())
+	require.Len(t, st.Details(), 1)
+	badRequest, ok := st.Details()[0].(*errdetails.BadRequest)
+	require.True(t, ok)
+	assert.Equal(t, "repo_url", badRequest.GetFieldViolations()[0].GetField())
+	ts.builds.AssertNotCalled(t, "CreateBuild", mock.Anything, mock.Anything)
+}
+
+func TestBuildServer_MissingToken(t *testing.T) {
+	ts := newTestServer(t, "builds:read")
+
+	_, err := ts.client.GetBuild(context.Background(), &civ1.GetBuildRequest{Id: "b1"})
+
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+	assert.Equal(t, "missing bearer token", status.Convert(err).Message())
+}
+
+func TestBuildServer_InvalidToken(t *testing.T) {
+	ts := newTestServer(t, "builds:read")
+
+	_, err := ts.client.GetBuild(authorized("cio_other"), &civ1.GetBuildRequest{Id: "b1"})
+
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+}
+
+func TestBuildServer_InsufficientScope(t *testing.T) {
+	ts := newTestServer(t, "builds:read")
+
+	_, err := ts.client.CancelBuild(authorized(testSecret), &civ1.CancelBuildRequest{Id: "b1"})
+
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	ts.builds.AssertNotCalled(t, "CancelBuild", mock.Anything, mock.Anything)
+}
+
+func TestBuildServer_EchoesRequestID(t *testing.T) {
+	ts := newTestServer(t, "builds:read")
+	ts.builds.On("GetBuild", mock.Anything, "b1").Return(&domain.Build{ID: "b1"}, nil)
+
+	var header metadata.MD
+	ctx := metadata.AppendToOutgoingContext(authorized(testSecret), "x-request-id", "req-42")
+	_, err := ts.client.GetBuild(ctx, &civ1.GetBuildRequest{Id: "b1"}, grpc.Header(&header))
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"req-42"}, header.Get("x-request-id"))
+}
+
+func TestBuildServer_GetBuild_NotFound(t *testing.T) {
+	ts := newTestServer(t, "builds:read")
+	ts.builds.On("GetBuild", mock.Anything, "missing").Return(nil, domain.ErrBuildNotFound)
+
+	_, err := ts.client.GetBuild(authorized(testSecret), &civ1.GetBuildRequest{Id: "missing"})
+
+	assert.Equal(t, codes.NotFound, status.Code(err))
+	assert.Equal(t, "build not found", status.Convert(err).Message())
+}
+
+func TestBuildServer_GetBuild_InternalErrorIsHidden(t *testing.T) {
+	ts := newTestServer(t, "builds:read")
+	ts.builds.On("GetBuild", mock.Anything, "b1").Return(nil, errors.New("pq: connection refused"))
+
+	_, err := ts.client.GetBuild(authorized(testSecret), &civ1.GetBuildRequest{Id: "b1"})
+
+	assert.Equal(t, codes.Internal, status.Code(err))
+	assert.Equal(t, "internal server error", status.Convert(err).Message())
+}
+
+func TestBuildServer_ListBuilds(t *testing.T) {
+	ts := newTestServer(t, "builds:read")
+	ts.builds.On("ListBuilds", mock.Anything, mock.MatchedBy(func(f domain.BuildFilter) bool {
+		return len(f.Statuses) == 1 && f.Statuses[0] == domain.BuildStatusFailed && f.Limit == 10 && f.RepoUrl == "https://github.com/test/repo"
+	})).Return(&domain.BuildPage{Builds: []domain.Build{{ID: "b1"}, {ID: "b2"}}, NextCursor: "next"}, nil)
+
+	resp, err := ts.client.ListBuilds(authorized(testSecret), &civ1.ListBuildsRequest{
+		Statuses: []string{"failed"},
+		RepoUrl:  "https://github.com/test/repo",
+		Limit:    10,
+	})
+
+	require.NoError(t, err)
+	require.Len(t, resp.GetBuilds(), 2)
+	assert.Equal(t, "next", resp.GetNextCursor())
+}
+
+func TestBuildServer_ListBuilds_InvalidStatus(t *testing.T) {
+	ts := newTestServer(t, "builds:read")
+
+	_, err := ts.client.ListBuilds(authorized(testSecret), &civ1.ListBuildsRequest{Statuses: []string{"done"}})
+
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestBuildServer_CancelBuild(t *testing.T) {
+	ts := newTestServer(t, "builds:cancel")
+	ts.builds.On("CancelBuild", mock.Anything, "b1").Return(nil)
+
+	_, err := ts.client.CancelBuild(authorized(testSecret), &civ1.CancelBuildRequest{Id: "b1"})
+
+	require.NoError(t, err)
+	ts.builds.AssertExpectations(t)
+}
+
+func receiveAll(t *testing.T, stream civ1.BuildService_StreamLogsClient) ([]*civ1.LogLine, error) {
+	t.Helper()
+	var lines []*civ1.LogLine
+	for {
+		line, err := stream.Recv()
+		if err == io.EOF {
+			return lines, nil
+		}
+		if err != nil {
+			return lines, err
+		}
+		lines = append(lines, line)
+	}
+}
+
+func TestBuildServer_StreamLogs(t *testing.T) {
+	ts := newTestServer(t, "builds:read")
+	jobId := "j1"
+	ts.builds.On("GetBuild", mock.Anything, "b1").Return(&domain.Build{ID: "b1", Status: domain.BuildStatusSuccess}, nil)
+	ts.logs.On("ListLogs", mock.Anything, "b1", int64(2), logPageSize).Return([]domain.BuildLog{
+		{Seq: 3, JobID: &jobId, Stream: domain.LogStdout, Content: "hello"},
+		{Seq: 4, JobID: &jobId, Stream: domain.LogStderr, Content: "world"},
+	}, nil)
+
+	stream, err := ts.client.StreamLogs(authorized(testSecret), &civ1.StreamLogsRequest{BuildId: "b1", AfterSeq: 2})
+	require.NoError(t, err)
+
+	lines, err := receiveAll(t, stream)
+
+	require.NoError(t, err)
+	require.Len(t, lines, 2)
+	assert.Equal(t, int64(3), lines[0].GetSeq())
+	assert.Equal(t, "j1", lines[0].GetJobId())
+	assert.Equal(t, "stderr", lines[1].GetStream())
+	assert.Equal(t, "world", lines[1].GetLine())
+}
+
+func TestBuildServer_StreamLogs_FollowUntilFinished(t *testing.T) {
+	ts := newTestServer(t, "builds:read")
+	ts.builds.On("GetBuild", mock.Anything, "b1").Return(&domain.Build{ID: "b1", Status: domain.BuildStatusRunning}, nil).Once()
+	ts.builds.On("GetBuild", mock.Anything, "b1").Return(&domain.Build{ID: "b1", Status: domain.BuildStatusSuccess}, nil)
+	ts.logs.On("ListLogs", mock.Anything, "b1", int64(0), logPageSize).Return([]domain.BuildLog{{Seq: 1, Content: "start"}}, nil).Once()
+	ts.logs.On("ListLogs", mock.Anything, "b1", int64(1), logPageSize).Return([]domain.BuildLog{{Seq: 2, Content: "done"}}, nil).Once()
+	ts.logs.On("ListLogs", mock.Anything, "b1", int64(2), logPageSize).Return([]domain.BuildLog{}, nil)
+
+	stream, err := ts.client.StreamLogs(authorized(testSecret), &civ1.StreamLogsRequest{BuildId: "b1", Follow: true})
+	require.NoError(t, err)
+
+	lines, err := receiveAll(t, stream)
+
+	require.NoError(t, err)
+	require.Len(t, lines, 2)
+	assert.Equal(t, "done", lines[1].GetLine())
+}
+
+func TestBuildServer_StreamLogs_BuildNotFound(t *testing.T) {
+	ts := newTestServer(t, "builds:read")
+	ts.builds.On("GetBuild", mock.Anything, "missing").Return(nil, domain.ErrBuildNotFound)
+
+	stream, err := ts.client.StreamLogs(authorized(testSecret), &civ1.StreamLogsRequest{BuildId: "missing"})
+	require.NoError(t, err)
+
+	_, err = receiveAll(t, stream)
+
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func TestBuildServer_StreamLogs_RequiresToken(t *testing.T) {
+	ts := newTestServer(t, "builds:read")
+
+	stream, err := ts.client.StreamLogs(context.Background(), &civ1.StreamLogsRequest{BuildId: "b1"})
+	require.NoError(t, err)
+
+	_, err = receiveAll(t, stream)
+
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+}
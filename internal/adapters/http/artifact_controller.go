@@ -0,0 +1,106 @@
+package http
+
+import (
+	"fmt"
+	"github.com/H3nSte1n/ci-orchestrator/internal/core/domain"
+	"github.com/H3nSte1n/ci-orchestrator/internal/core/ports"
+	"github.com/gin-gonic/gin"
+	"mime"
+	"net/http"
+	"path"
+	"strings"
+)
+
+type ArtifactController struct {
+	artifactService ports.ArtifactService
+}
+
+func NewArtifactController(artifactService ports.ArtifactService) *ArtifactController {
+	return &ArtifactController{
+		artifactService: artifactService,
+	}
+}
+
+type listArtifactsResponse struct {
+	Artifacts []domain.Artifact `json:"artifacts"`
+}
+
+func (ac *ArtifactController) ListArtifacts(c *gin.Context) {
+	buildId := c.Param("id")
+
+	if buildId == "" {
+		_ = c.Error(invalidArgument("build id is required"))
+		return
+	}
+
+	if format := c.Query("archive"); format != "" {
+		ac.downloadArchive(c, buildId, domain.ArchiveFormat(format))
+		return
+	}
+
+	artifacts, err := ac.artifactService.List(c.Request.Context(), buildId)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, listArtifactsResponse{Artifacts: artifacts})
+}
+
+func (ac *ArtifactController) DownloadArtifact(c *gin.Context) {
+	buildId := c.Param("id")
+	name := strings.TrimPrefix(c.Param("path"), "/")
+
+	if buildId == "" || name == "" {
+		_ = c.Error(invalidArgument("build id and artifact path are required"))
+		return
+	}
+
+	artifact, err := ac.artifactService.Get(c.Request.Context(), buildId, name)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	reader, err := ac.artifactService.Open(c.Request.Context(), artifact)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	defer reader.Close()
+
+	filename := path.Base(artifact.Name)
+	c.Header("Content-Type", artifact.ContentType)
+	c.Header("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": filename}))
+	c.Header("ETag", fmt.Sprintf("%q", artifact.Checksum))
+
+	// ServeContent answers Range, If-Range and If-None-Match requests.
+	http.ServeContent(c.Writer, c.Request, filename, artifact.CreatedAt, reader)
+}
+
+func (ac *ArtifactController) downloadArchive(c *gin.Context, buildId string, format domain.ArchiveFormat) {
+	var contentType string
+	switch format {
+	case domain.ArchiveZip:
+		contentType = "application/zip"
+	case domain.ArchiveTarGz:
+		contentType = "application/gzip"
+	default:
+		_ = c.Error(withDetails(domain.ErrUnsupportedArchive, gin.H{"valid_formats": []string{
+			string(domain.ArchiveZip),
+			string(domain.ArchiveTarGz),
+		}}))
+		return
+	}
+
+	filename := fmt.Sprintf("%s-artifacts.%s", buildId, format)
+	c.Header("Content-Type", contentType)
+	c.Header("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": filename}))
+	c.Status(http.StatusOK)
+
+	// Headers are already sent once streaming starts; a failure can only be
+	// recorded and the response cut short.
+	if err := ac.artifactService.WriteArchive(c.Request.Context(), buildId, format, c.Writer); err != nil {
+		_ = c.Error(err)
+	}
+}
@@ -0,0 +1,195 @@
+package http
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/H3nSte1n/ci-orchestrator/internal/core/domain"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type mockArtifactService struct {
+	mock.Mock
+}
+
+func (m *mockArtifactService) Store(ctx context.Context, job *domain.Job, name string, r io.Reader, size int64) (*domain.Artifact, error) {
+	args := m.Called(ctx, job, name, r, size)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Artifact), args.Error(1)
+}
+
+func (m *mockArtifactService) List(ctx context.Context, buildId string) ([]domain.Artifact, error) {
+	args := m.Called(ctx, buildId)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.Artifact), args.Error(1)
+}
+
+func (m *mockArtifactService) Get(ctx context.Context, buildId string, name string) (*domain.Artifact, error) {
+	args := m.Called(ctx, buildId, name)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Artifact), args.Error(1)
+}
+
+func (m *mockArtifactService) Open(ctx context.Context, artifact *domain.Artifact) (io.ReadSeekCloser, error) {
+	args := m.Called(ctx, artifact)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(io.ReadSeekCloser), args.Error(1)
+}
+
+func (m *mockArtifactService) WriteArchive(ctx context.Context, buildId string, format domain.ArchiveFormat, w io.Writer) error {
+	args := m.Called(ctx, buildId, format, w)
+	if args.Error(0) == nil {
+		zw := zip.NewWriter(w)
+		entry, _ := zw.Create("out.txt")
+		entry.Write([]byte("hello"))
+		zw.Close()
+	}
+	return args.Error(0)
+}
+
+type nopSeekCloser struct {
+	io.ReadSeeker
+}
+
+func (nopSeekCloser) Close() error { return nil }
+
+func artifactRouter(ac *ArtifactController) *gin.Engine {
+	router := newTestRouter()
+	router.GET("/builds/:id/artifacts", ac.ListArtifacts)
+	router.GET("/builds/:id/artifacts/*path", ac.DownloadArtifact)
+	return router
+}
+
+func artifactTestData() *domain.Artifact {
+	return &domain.Artifact{
+		ID:          "artifact-id",
+		BuildID:     "ci-id",
+		Name:        "dist/out.txt",
+		Size:        11,
+		Checksum:    "sha256:abc",
+		ContentType: "text/plain; charset=utf-8",
+		StorageKey:  "builds/ci-id/dist/out.txt",
+		CreatedAt:   time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+}
+
+func TestArtifactController_ListArtifacts_Success(t *testing.T) {
+	mockArtifactService := new(mockArtifactService)
+	mockArtifactService.On("List", mock.Anything, "ci-id").Return([]domain.Artifact{*artifactTestData()}, nil)
+
+	w := httptest.NewRecorder()
+	artifactRouter(NewArtifactController(mockArtifactService)).ServeHTTP(w, httptest.NewRequest("GET", "/builds/ci-id/artifacts", nil))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response struct {
+		Artifacts []domain.Artifact `json:"artifacts"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	require.Len(t, response.Artifacts, 1)
+	assert.Equal(t, "dist/out.txt", response.Artifacts[0].Name)
+}
+
+func TestArtifactController_ListArtifacts_Archive(t *testing.T) {
+	mockArtifactService := new(mockArtifactService)
+	mockArtifactService.On("WriteArchive", mock.Anything, "ci-id", domain.ArchiveZip, mock.Anything).Return(nil)
+
+	w := httptest.NewRecorder()
+	artifactRouter(NewArtifactController(mockArtifactService)).ServeHTTP(w, httptest.NewRequest("GET", "/builds/ci-id/artifacts?archive=zip", nil))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "application/zip", w.Header().Get("Content-Type"))
+	assert.Equal(t, "attachment; filename=ci-id-artifacts.zip", w.Header().Get("Content-Disposition"))
+
+	zr, err := zip.NewReader(bytes.NewReader(w.Body.Bytes()), int64(w.Body.Len()))
+	require.NoError(t, err)
+	require.Len(t, zr.File, 1)
+	assert.Equal(t, "out.txt", zr.File[0].Name)
+}
+
+func TestArtifactController_ListArtifacts_InvalidArchiveFormat(t *testing.T) {
+	mockArtifactService := new(mockArtifactService)
+
+	w := httptest.NewRecorder()
+	artifactRouter(NewArtifactController(mockArtifactService)).ServeHTTP(w, httptest.NewRequest("GET", "/builds/ci-id/artifacts?archive=rar", nil))
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	mockArtifactService.AssertNotCalled(t, "WriteArchive", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestArtifactController_DownloadArtifact_Success(t *testing.T) {
+	artifact := artifactTestData()
+	mockArtifactService := new(mockArtifactService)
+	mockArtifactService.On("Get", mock.Anything, "ci-id", "dist/out.txt").Return(artifact, nil)
+	mockArtifactService.On("Open", mock.Anything, artifact).Return(nopSeekCloser{strings.NewReader("hello world")}, nil)
+
+	w := httptest.NewRecorder()
+	artifactRouter(NewArtifactController(mockArtifactService)).ServeHTTP(w, httptest.NewRequest("GET", "/builds/ci-id/artifacts/dist/out.txt", nil))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "hello world", w.Body.String())
+	assert.Equal(t, "text/plain; charset=utf-8", w.Header().Get("Content-Type"))
+	assert.Equal(t, `"sha256:abc"`, w.Header().Get("ETag"))
+	assert.Equal(t, "attachment; filename=out.txt", w.Header().Get("Content-Disposition"))
+}
+
+func TestArtifactController_DownloadArtifact_Range(t *testing.T) {
+	artifact := artifactTestData()
+	mockArtifactService := new(mockArtifactService)
+	mockArtifactService.On("Get", mock.Anything, "ci-id", "dist/out.txt").Return(artifact, nil)
+	mockArtifactService.On("Open", mock.Anything, artifact).Return(nopSeekCloser{strings.NewReader("hello world")}, nil)
+
+	req := httptest.NewRequest("GET", "/builds/ci-id/artifacts/dist/out.txt", nil)
+	req.Header.Set("Range", "bytes=6-")
+	w := httptest.NewRecorder()
+	artifactRouter(NewArtifactController(mockArtifactService)).ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusPartialContent, w.Code)
+	assert.Equal(t, "world", w.Body.String())
+	assert.Equal(t, "bytes 6-10/11", w.Header().Get("Content-Range"))
+}
+
+func TestArtifactController_DownloadArtifact_NotModified(t *testing.T) {
+	artifact := artifactTestData()
+	mockArtifactService := new(mockArtifactService)
+	mockArtifactService.On("Get", mock.Anything, "ci-id", "dist/out.txt").Return(artifact, nil)
+	mockArtifactService.On("Open", mock.Anything, artifact).Return(nopSeekCloser{strings.NewReader("hello world")}, nil)
+
+	req := httptest.NewRequest("GET", "/builds/ci-id/artifacts/dist/out.txt", nil)
+	req.Header.Set("If-None-Match", `"sha256:abc"`)
+	w := httptest.NewRecorder()
+	artifactRouter(NewArtifactController(mockArtifactService)).ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNotModified, w.Code)
+	assert.Empty(t, w.Body.String())
+}
+
+func TestArtifactController_DownloadArtifact_NotFound(t *testing.T) {
+	mockArtifactService := new(mockArtifactService)
+	mockArtifactService.On("Get", mock.Anything, "ci-id", "missing.txt").Return(nil, domain.ErrArtifactNotFound)
+
+	w := httptest.NewRecorder()
+	artifactRouter(NewArtifactController(mockArtifactService)).ServeHTTP(w, httptest.NewRequest("GET", "/builds/ci-id/artifacts/missing.txt", nil))
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	mockArtifactService.AssertNotCalled(t, "Open", mock.Anything, mock.Anything)
+}
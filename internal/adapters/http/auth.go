@@ -0,0 +1,63 @@
+package http
+
+import (
+	"github.com/H3nSte1n/ci-orchestrator/internal/core/domain"
+	"github.com/H3nSte1n/ci-orchestrator/internal/core/ports"
+	"github.com/gin-gonic/gin"
+	"strings"
+)
+
+const apiTokenKey = "api_token"
+
+// Authenticate resolves the bearer token of a request and stores it in the
+// context for RequireScope and the handlers.
+func Authenticate(tokens ports.APITokenService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		scheme, secret, ok := strings.Cut(c.GetHeader("Authorization"), " ")
+		if !ok || !strings.EqualFold(scheme, "Bearer") || strings.TrimSpace(secret) == "" {
+			c.Header("WWW-Authenticate", `Bearer realm="ci-orchestrator"`)
+			_ = c.Error(domain.ErrMissingToken)
+			c.Abort()
+			return
+		}
+
+		token, err := tokens.Authenticate(c.Request.Context(), strings.TrimSpace(secret))
+		if err != nil {
+			c.Header("WWW-Authenticate", `Bearer realm="ci-orchestrator", error="invalid_token"`)
+			_ = c.Error(err)
+			c.Abort()
+			return
+		}
+
+		c.Set(apiTokenKey, token)
+		c.Next()
+	}
+}
+
+// RequireScope rejects requests whose token does not grant scope. It must run
+// after Authenticate.
+func RequireScope(scope domain.Scope) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		token := currentToken(c)
+		if token == nil {
+			_ = c.Error(domain.ErrMissingToken)
+			c.Abort()
+			return
+		}
+		if !token.HasScope(scope) {
+			_ = c.Error(withDetails(domain.ErrInsufficientScope, map[string]string{"required_scope": string(scope)}))
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
+
+func currentToken(c *gin.Context) *domain.APIToken {
+	value, ok := c.Get(apiTokenKey)
+	if !ok {
+		return nil
+	}
+	token, _ := value.(*domain.APIToken)
+	return token
+}
@@ -0,0 +1,118 @@
+package http
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/H3nSte1n/ci-orchestrator/internal/core/domain"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type mockAPITokenService struct {
+	mock.Mock
+}
+
+func (m *mockAPITokenService) Create(ctx context.Context, name string, scopes []string) (*domain.APIToken, string, error) {
+	args := m.Called(ctx, name, scopes)
+	if args.Get(0) == nil {
+		return nil, "", args.Error(2)
+	}
+	return args.Get(0).(*domain.APIToken), args.String(1), args.Error(2)
+}
+
+func (m *mockAPITokenService) Authenticate(ctx context.Context, secret string) (*domain.APIToken, error) {
+	args := m.Called(ctx, secret)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.APIToken), args.Error(1)
+}
+
+func (m *mockAPITokenService) List(ctx context.Context) ([]domain.APIToken, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.APIToken), args.Error(1)
+}
+
+func (m *mockAPITokenService) Revoke(ctx context.Context, tokenId string) error {
+	args := m.Called(ctx, tokenId)
+	return args.Error(0)
+}
+
+func newAuthTestRouter(tokens *mockAPITokenService, scope domain.Scope) *gin.Engine {
+	router := newTestRouter()
+	router.GET("/protected", Authenticate(tokens), RequireScope(scope), func(c *gin.Context) {
+		c.String(http.StatusOK, currentToken(c).Name)
+	})
+	return router
+}
+
+func TestAuthenticate_MissingToken(t *testing.T) {
+	tokens := new(mockAPITokenService)
+	router := newAuthTestRouter(tokens, domain.ScopeBuildsRead)
+
+	for _, header := range []string{"", "Basic dXNlcjpwYXNz", "Bearer "} {
+		req := httptest.NewRequest("GET", "/protected", nil)
+		if header != "" {
+			req.Header.Set("Authorization", header)
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusUnauthorized, w.Code, header)
+		assert.Contains(t, w.Body.String(), `"code":"unauthenticated"`)
+		assert.NotEmpty(t, w.Header().Get("WWW-Authenticate"))
+	}
+	tokens.AssertNotCalled(t, "Authenticate", mock.Anything, mock.Anything)
+}
+
+func TestAuthenticate_InvalidToken(t *testing.T) {
+	tokens := new(mockAPITokenService)
+	tokens.On("Authenticate", mock.Anything, "cio_bad").Return(nil, domain.ErrInvalidToken)
+	router := newAuthTestRouter(tokens, domain.ScopeBuildsRead)
+
+	req := httptest.NewRequest("GET", "/protected", nil)
+	req.Header.Set("Authorization", "Bearer cio_bad")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+	assert.Contains(t, w.Body.String(), "invalid or revoked token")
+}
+
+func TestRequireScope_Granted(t *testing.T) {
+	tokens := new(mockAPITokenService)
+	tokens.On("Authenticate", mock.Anything, "cio_good").
+		Return(&domain.APIToken{Name: "reader", Scopes: domain.StringList{"builds:read"}}, nil)
+	router := newAuthTestRouter(tokens, domain.ScopeBuildsRead)
+
+	req := httptest.NewRequest("GET", "/protected", nil)
+	req.Header.Set("Authorization", "bearer cio_good")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "reader", w.Body.String())
+}
+
+func TestRequireScope_Denied(t *testing.T) {
+	tokens := new(mockAPITokenService)
+	tokens.On("Authenticate", mock.Anything, "cio_good").
+		Return(&domain.APIToken{Name: "reader", Scopes: domain.StringList{"builds:read"}}, nil)
+	router := newAuthTestRouter(tokens, domain.ScopeBuildsCancel)
+
+	req := httptest.NewRequest("GET", "/protected", nil)
+	req.Header.Set("Authorization", "Bearer cio_good")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	assert.Contains(t, w.Body.String(), `"code":"permission_denied"`)
+	assert.Contains(t, w.Body.String(), `"required_scope":"builds:cancel"`)
+}
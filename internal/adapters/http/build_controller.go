@@ -1,12 +1,18 @@
 package http
 
 import (
+	"fmt"
 	"github.com/H3nSte1n/ci-orchestrator/internal/core/domain"
 	"github.com/H3nSte1n/ci-orchestrator/internal/core/ports"
 	"github.com/gin-gonic/gin"
 	"net/http"
+	"strconv"
+	"strings"
+	"time"
 )
 
+const buildsPath = "/api/v1/builds/"
+
 type BuildController struct {
 	buildService ports.BuildService
 }
@@ -17,96 +23,269 @@ func NewBuildController(buildService ports.BuildService) *BuildController {
 	}
 }
 
+type buildResponse struct {
+	*domain.Build
+	Links buildLinks `json:"links"`
+}
+
+type messageResponse struct {
+	Message string `json:"message"`
+}
+
+type updateStatusRequest struct {
+	Status domain.BuildStatus `json:"status" binding:"required"`
+}
+
+type updatePriorityRequest struct {
+	Priority *int `json:"priority" binding:"required"`
+}
+
+type buildLinks struct {
+	Self     string   `json:"self"`
+	Parent   string   `json:"parent,omitempty"`
+	Children []string `json:"children,omitempty"`
+}
+
+func newBuildResponse(build *domain.Build) buildResponse {
+	links := buildLinks{Self: buildsPath + build.ID}
+	if build.ParentID != nil {
+		links.Parent = buildsPath + *build.ParentID
+	}
+	for _, child := range build.Children {
+		links.Children = append(links.Children, buildsPath+child.ID)
+	}
+
+	return buildResponse{Build: build, Links: links}
+}
+
 func (bc *BuildController) CreateBuild(c *gin.Context) {
-	var build domain.Build
+	req, err := decodeCreateBuildRequest(c.Request.Body)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
 
-	if err := c.ShouldBindJSON(&build); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body", "details": err.Error()})
+	if fieldErrors := req.validate(); len(fieldErrors) > 0 {
+		_ = c.Error(validationFailed(fieldErrors))
 		return
 	}
 
-	if build.RepoUrl == "" || build.Ref == "" || build.Command == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "missing required fields: repo_url, ref, command"})
+	build := req.toDomain()
+	if token := currentToken(c); token != nil {
+		build.TriggeredBy = &token.Name
+	}
+	if err := bc.buildService.CreateBuild(c.Request.Context(), build); err != nil {
+		_ = c.Error(err)
 		return
 	}
 
-	if err := bc.buildService.CreateBuild(c.Request.Context(), &build); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to create build", "details": err.Error()})
+	c.JSON(http.StatusCreated, newBuildResponse(build))
+}
+
+func (bc *BuildController) RetryBuild(c *gin.Context) {
+	buildId := c.Param("id")
+
+	if buildId == "" {
+		_ = c.Error(invalidArgument("build id is required"))
 		return
 	}
 
-	c.JSON(http.StatusCreated, build)
+	var triggeredBy *string
+	if token := currentToken(c); token != nil {
+		triggeredBy = &token.Name
+	}
+
+	build, err := bc.buildService.RetryBuild(c.Request.Context(), buildId, triggeredBy)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, newBuildResponse(build))
 }
 
 func (bc *BuildController) CancelBuild(c *gin.Context) {
 	buildId := c.Param("id")
 
 	if buildId == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "build id is required"})
+		_ = c.Error(invalidArgument("build id is required"))
 		return
 	}
 
 	if err := bc.buildService.CancelBuild(c.Request.Context(), buildId); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to cancel build", "details": err.Error()})
+		_ = c.Error(err)
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"message": "Build canceled successfully"})
+	c.JSON(http.StatusOK, messageResponse{Message: "Build canceled successfully"})
 }
 
 func (bc *BuildController) UpdateStatus(c *gin.Context) {
 	buildId := c.Param("id")
 
 	if buildId == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "build id is required"})
+		_ = c.Error(invalidArgument("build id is required"))
 		return
 	}
 
-	var req struct {
-		Status string `json:"status" binding:"required"`
-	}
+	var req updateStatusRequest
 
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body", "details": err.Error()})
+		_ = c.Error(withDetails(invalidArgument("invalid request body"), err.Error()))
 		return
 	}
 
-	status := domain.BuildStatus(req.Status)
+	status := req.Status
 
 	switch status {
 	case domain.BuildStatusPending, domain.BuildStatusRunning, domain.BuildStatusSuccess, domain.BuildStatusFailed, domain.BuildStatusCanceled:
 	default:
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid status", "valid_statuses": []string{
+		_ = c.Error(withDetails(invalidArgument("invalid status"), gin.H{"valid_statuses": []string{
 			string(domain.BuildStatusPending),
 			string(domain.BuildStatusRunning),
 			string(domain.BuildStatusSuccess),
 			string(domain.BuildStatusFailed),
 			string(domain.BuildStatusCanceled),
-		}})
+		}}))
 		return
 	}
 
 	if err := bc.buildService.UpdateStatus(c.Request.Context(), buildId, status); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to update status", "details": err.Error()})
+		_ = c.Error(err)
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"message": "Build status updated successfully"})
+	c.JSON(http.StatusOK, messageResponse{Message: "Build status updated successfully"})
+}
+
+func (bc *BuildController) UpdatePriority(c *gin.Context) {
+	buildId := c.Param("id")
+
+	if buildId == "" {
+		_ = c.Error(invalidArgument("build id is required"))
+		return
+	}
+
+	var req updatePriorityRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		_ = c.Error(withDetails(invalidArgument("invalid request body"), err.Error()))
+		return
+	}
+
+	build, err := bc.buildService.UpdatePriority(c.Request.Context(), buildId, *req.Priority)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, newBuildResponse(build))
 }
 
 func (bc *BuildController) GetBuild(c *gin.Context) {
 	buildId := c.Param("id")
 
 	if buildId == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "build id is required"})
+		_ = c.Error(invalidArgument("build id is required"))
 		return
 	}
 
 	build, err := bc.buildService.GetBuild(c.Request.Context(), buildId)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to get build", "details": err.Error()})
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, newBuildResponse(build))
+}
+
+type listBuildsResponse struct {
+	Builds     []buildResponse `json:"builds"`
+	NextCursor string          `json:"next_cursor,omitempty"`
+}
+
+func (bc *BuildController) ListBuilds(c *gin.Context) {
+	filter, err := parseBuildFilter(c)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	page, err := bc.buildService.ListBuilds(c.Request.Context(), filter)
+	if err != nil {
+		_ = c.Error(err)
 		return
 	}
 
-	c.JSON(http.StatusOK, build)
+	response := listBuildsResponse{
+		Builds:     make([]buildResponse, 0, len(page.Builds)),
+		NextCursor: page.NextCursor,
+	}
+	for i := range page.Builds {
+		response.Builds = append(response.Builds, newBuildResponse(&page.Builds[i]))
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// parseBuildFilter reads the list query parameters. status accepts a comma
+// separated list and may be repeated; time bounds are RFC 3339.
+func parseBuildFilter(c *gin.Context) (domain.BuildFilter, error) {
+	filter := domain.BuildFilter{
+		RepoUrl:  c.Query("repo_url"),
+		Ref:      c.Query("ref"),
+		LockedBy: c.Query("worker"),
+		Query:    c.Query("q"),
+	}
+
+	for _, value := range c.QueryArray("status") {
+		for _, s := range strings.Split(value, ",") {
+			status := domain.BuildStatus(strings.TrimSpace(s))
+			switch status {
+			case domain.BuildStatusPending, domain.BuildStatusRunning, domain.BuildStatusSuccess, domain.BuildStatusFailed, domain.BuildStatusCanceled:
+				filter.Statuses = append(filter.Statuses, status)
+			default:
+				return filter, invalidArgument(fmt.Sprintf("invalid status %q", s))
+			}
+		}
+	}
+
+	times := []struct {
+		param string
+		dest  **time.Time
+	}{
+		{"created_after", &filter.CreatedAfter},
+		{"created_before", &filter.CreatedBefore},
+		{"finished_after", &filter.FinishedAfter},
+		{"finished_before", &filter.FinishedBefore},
+	}
+	for _, t := range times {
+		value := c.Query(t.param)
+		if value == "" {
+			continue
+		}
+		parsed, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			return filter, invalidArgument(t.param + " must be an RFC 3339 timestamp")
+		}
+		*t.dest = &parsed
+	}
+
+	if value := c.Query("limit"); value != "" {
+		limit, err := strconv.Atoi(value)
+		if err != nil || limit < 1 || limit > domain.MaxBuildPageSize {
+			return filter, invalidArgument(fmt.Sprintf("limit must be between 1 and %d", domain.MaxBuildPageSize))
+		}
+		filter.Limit = limit
+	}
+
+	if value := c.Query("cursor"); value != "" {
+		cursor, err := domain.DecodeBuildCursor(value)
+		if err != nil {
+			return filter, err
+		}
+		filter.After = cursor
+	}
+
+	return filter, nil
 }
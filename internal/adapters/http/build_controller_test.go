@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"github.com/H3nSte1n/ci-orchestrator/internal/core/domain"
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
@@ -19,12 +20,12 @@ type mockBuildService struct {
 	Error error
 }
 
-func (m *mockBuildService) ClaimNext(ctx context.Context, workerId string) (*domain.Build, error) {
+func (m *mockBuildService) ClaimNext(ctx context.Context, workerId string) (*domain.Job, error) {
 	args := m.Called(ctx, workerId)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
-	return args.Get(0).(*domain.Build), args.Error(1)
+	return args.Get(0).(*domain.Job), args.Error(1)
 }
 
 func (m *mockBuildService) CreateBuild(ctx context.Context, build *domain.Build) error {
@@ -32,6 +33,14 @@ func (m *mockBuildService) CreateBuild(ctx context.Context, build *domain.Build)
 	return args.Error(0)
 }
 
+func (m *mockBuildService) RetryBuild(ctx context.Context, buildId string, triggeredBy *string) (*domain.Build, error) {
+	args := m.Called(ctx, buildId, triggeredBy)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Build), args.Error(1)
+}
+
 func (m *mockBuildService) CancelBuild(ctx context.Context, buildId string) error {
 	args := m.Called(ctx, buildId)
 	return args.Error(0)
@@ -42,6 +51,14 @@ func (m *mockBuildService) UpdateStatus(ctx context.Context, buildId string, sta
 	return args.Error(0)
 }
 
+func (m *mockBuildService) UpdatePriority(ctx context.Context, buildId string, priority int) (*domain.Build, error) {
+	args := m.Called(ctx, buildId, priority)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Build), args.Error(1)
+}
+
 func (m *mockBuildService) GetBuild(ctx context.Context, buildId string) (*domain.Build, error) {
 	args := m.Called(ctx, buildId)
 	if args.Get(0) == nil {
@@ -50,15 +67,52 @@ func (m *mockBuildService) GetBuild(ctx context.Context, buildId string) (*domai
 	return args.Get(0).(*domain.Build), args.Error(1)
 }
 
-func (m *mockBuildService) CompleteBuild(ctx context.Context, buildId string, exitCode int, finishedAt *time.Time, error error) error {
-	args := m.Called(ctx, buildId, exitCode, finishedAt, error)
+func (m *mockBuildService) ListBuilds(ctx context.Context, filter domain.BuildFilter) (*domain.BuildPage, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.BuildPage), args.Error(1)
+}
+
+func (m *mockBuildService) GetJob(ctx context.Context, jobId string) (*domain.Job, error) {
+	args := m.Called(ctx, jobId)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Job), args.Error(1)
+}
+
+func (m *mockBuildService) Heartbeat(ctx context.Context, jobId string, workerId string) error {
+	args := m.Called(ctx, jobId, workerId)
+	return args.Error(0)
+}
+
+func (m *mockBuildService) CancelRequested(ctx context.Context, jobId string) (bool, error) {
+	args := m.Called(ctx, jobId)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *mockBuildService) CompleteJob(ctx context.Context, jobId string, workerId string, exitCode int, finishedAt *time.Time, error error) error {
+	args := m.Called(ctx, jobId, workerId, exitCode, finishedAt, error)
 	return args.Error(0)
 }
 
+func (m *mockBuildService) RequeueExpired(ctx context.Context, lease time.Duration) (int, error) {
+	args := m.Called(ctx, lease)
+	return args.Int(0), args.Error(1)
+}
+
 func (m *mockBuildService) GetError() error {
 	return m.Error
 }
 
+func newTestRouter() *gin.Engine {
+	router := gin.New()
+	router.Use(RequestID(), ErrorHandler())
+	return router
+}
+
 func TestBuildController_CreateBuild_Success(t *testing.T) {
 	mockBuildService := new(mockBuildService)
 	mockBuildService.On("CreateBuild", mock.Anything, mock.MatchedBy(func(b *domain.Build) bool {
@@ -68,7 +122,7 @@ func TestBuildController_CreateBuild_Success(t *testing.T) {
 	})).Return(nil)
 	bc := NewBuildController(mockBuildService)
 
-	router := gin.New()
+	router := newTestRouter()
 	router.POST("/builds", bc.CreateBuild)
 
 	body := []byte(`{"repo_url": "https://github.com/test/repo","ref": "main","command": "npm test"}`)
@@ -89,13 +143,34 @@ func TestBuildController_CreateBuild_Success(t *testing.T) {
 	mockBuildService.AssertExpectations(t)
 }
 
+func TestBuildController_CreateBuild_RecordsTriggeringToken(t *testing.T) {
+	mockBuildService := new(mockBuildService)
+	mockBuildService.On("CreateBuild", mock.Anything, mock.MatchedBy(func(b *domain.Build) bool {
+		return b.TriggeredBy != nil && *b.TriggeredBy == "deploy-bot"
+	})).Return(nil)
+	bc := NewBuildController(mockBuildService)
+
+	router := newTestRouter()
+	router.POST("/builds", func(c *gin.Context) {
+		c.Set(apiTokenKey, &domain.APIToken{Name: "deploy-bot"})
+	}, bc.CreateBuild)
+
+	body := []byte(`{"repo_url": "https://github.com/test/repo","ref": "main","command": "npm test"}`)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("POST", "/builds", bytes.NewReader(body)))
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+	assert.Contains(t, w.Body.String(), `"triggered_by":"deploy-bot"`)
+	mockBuildService.AssertExpectations(t)
+}
+
 func TestBuildController_CreateBuild_Error(t *testing.T) {
 	mockBuildService := new(mockBuildService)
 	mockBuildService.On("CreateBuild", mock.Anything, mock.Anything).Return(assert.AnError)
 
 	bc := NewBuildController(mockBuildService)
 
-	router := gin.New()
+	router := newTestRouter()
 	router.POST("/builds", bc.CreateBuild)
 
 	body := []byte(`{"repo_url": "https://github.com/test/repo","ref": "main","command": "npm test"}`)
@@ -105,7 +180,8 @@ func TestBuildController_CreateBuild_Error(t *testing.T) {
 	w := httptest.NewRecorder()
 	router.ServeHTTP(w, req)
 
-	assert.Contains(t, w.Body.String(), "failed to create build")
+	assert.Contains(t, w.Body.String(), `"code":"internal"`)
+	assert.NotContains(t, w.Body.String(), assert.AnError.Error())
 	assert.Equal(t, http.StatusInternalServerError, w.Code)
 	mockBuildService.AssertExpectations(t)
 }
@@ -115,7 +191,7 @@ func TestBuildController_CreateBuild_MissingRequiredFields(t *testing.T) {
 
 	bc := NewBuildController(mockBuildService)
 
-	router := gin.New()
+	router := newTestRouter()
 	router.POST("/builds", bc.CreateBuild)
 
 	body := []byte(`{"repo_url": "https://github.com/test/repo"}`)
@@ -126,7 +202,97 @@ func TestBuildController_CreateBuild_MissingRequiredFields(t *testing.T) {
 	router.ServeHTTP(w, req)
 
 	assert.Equal(t, http.StatusBadRequest, w.Code)
-	assert.Contains(t, w.Body.String(), "missing required fields: repo_url, ref, command")
+	assert.Contains(t, w.Body.String(), `{"field":"ref","message":"is required"}`)
+	assert.Contains(t, w.Body.String(), `{"field":"command","message":"is required unless jobs are given"}`)
+	mockBuildService.AssertNotCalled(t, "CreateBuild", mock.Anything, mock.Anything)
+}
+
+func TestBuildController_CreateBuild_WithJobs(t *testing.T) {
+	mockBuildService := new(mockBuildService)
+	mockBuildService.On("CreateBuild", mock.Anything, mock.MatchedBy(func(b *domain.Build) bool {
+		return len(b.Jobs) == 2 && b.Jobs[1].Needs[0] == "build"
+	})).Return(nil)
+
+	bc := NewBuildController(mockBuildService)
+
+	router := newTestRouter()
+	router.POST("/builds", bc.CreateBuild)
+
+	body := []byte(`{"repo_url": "https://github.com/test/repo","ref": "main","jobs": [
+		{"name": "build", "command": "make"},
+		{"name": "test", "command": "make test", "needs": ["build"]}
+	]}`)
+	req := httptest.NewRequest("POST", "/builds", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+	mockBuildService.AssertExpectations(t)
+}
+
+func TestBuildController_CreateBuild_InvalidJobGraph(t *testing.T) {
+	mockBuildService := new(mockBuildService)
+
+	bc := NewBuildController(mockBuildService)
+
+	router := newTestRouter()
+	router.POST("/builds", bc.CreateBuild)
+
+	body := []byte(`{"repo_url": "https://github.com/test/repo","ref": "main","jobs": [
+		{"name": "build", "command": "make", "needs": ["test"]},
+		{"name": "test", "command": "make test", "needs": ["build"]}
+	]}`)
+	req := httptest.NewRequest("POST", "/builds", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), "invalid job graph")
+	mockBuildService.AssertNotCalled(t, "CreateBuild", mock.Anything, mock.Anything)
+}
+
+func TestBuildController_CreateBuild_InvalidMatrix(t *testing.T) {
+	mockBuildService := new(mockBuildService)
+
+	bc := NewBuildController(mockBuildService)
+
+	router := newTestRouter()
+	router.POST("/builds", bc.CreateBuild)
+
+	body := []byte(`{"repo_url": "https://github.com/test/repo","ref": "main","command": "go test ./...","matrix": {"go": []}}`)
+	req := httptest.NewRequest("POST", "/builds", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), "invalid matrix")
+	mockBuildService.AssertNotCalled(t, "CreateBuild", mock.Anything, mock.Anything)
+}
+
+func TestBuildController_CreateBuild_InvalidCaches(t *testing.T) {
+	mockBuildService := new(mockBuildService)
+
+	bc := NewBuildController(mockBuildService)
+
+	router := newTestRouter()
+	router.POST("/builds", bc.CreateBuild)
+
+	body := []byte(`{"repo_url": "https://github.com/test/repo","ref": "main","command": "npm ci","caches": [{"key": "npm", "paths": ["../outside"]}]}`)
+	req := httptest.NewRequest("POST", "/builds", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), "invalid cache")
+	mockBuildService.AssertNotCalled(t, "CreateBuild", mock.Anything, mock.Anything)
 }
 
 func TestBuildController_CreateBuild_InvalidJSON(t *testing.T) {
@@ -134,7 +300,7 @@ func TestBuildController_CreateBuild_InvalidJSON(t *testing.T) {
 
 	bc := NewBuildController(mockBuildService)
 
-	router := gin.New()
+	router := newTestRouter()
 	router.POST("/builds", bc.CreateBuild)
 
 	body := []byte(`{"repo_url": invalid json}`)
@@ -154,7 +320,7 @@ func TestBuildController_CancelBuild_Success(t *testing.T) {
 
 	bc := NewBuildController(mockBuildService)
 
-	router := gin.New()
+	router := newTestRouter()
 	router.POST("/builds/:id/cancel", bc.CancelBuild)
 
 	req := httptest.NewRequest("POST", "/builds/test-id/cancel", nil)
@@ -172,7 +338,7 @@ func TestBuildController_CancelBuild_Error(t *testing.T) {
 
 	bc := NewBuildController(mockBuildService)
 
-	router := gin.New()
+	router := newTestRouter()
 	router.POST("/builds/:id/cancel", bc.CancelBuild)
 
 	req := httptest.NewRequest("POST", "/builds/test-id/cancel", nil)
@@ -183,6 +349,45 @@ func TestBuildController_CancelBuild_Error(t *testing.T) {
 	mockBuildService.AssertExpectations(t)
 }
 
+func TestBuildController_RetryBuild(t *testing.T) {
+	mockBuildService := new(mockBuildService)
+	mockBuildService.On("RetryBuild", mock.Anything, "test-id", mock.MatchedBy(func(by *string) bool {
+		return by != nil && *by == "deploy-bot"
+	})).Return(&domain.Build{ID: "new-id", RepoUrl: "https://github.com/test/repo"}, nil)
+
+	bc := NewBuildController(mockBuildService)
+
+	router := newTestRouter()
+	router.POST("/builds/:id/retry", func(c *gin.Context) {
+		c.Set(apiTokenKey, &domain.APIToken{Name: "deploy-bot"})
+	}, bc.RetryBuild)
+
+	req := httptest.NewRequest("POST", "/builds/test-id/retry", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+	assert.Contains(t, w.Body.String(), `"self":"/api/v1/builds/new-id"`)
+	mockBuildService.AssertExpectations(t)
+}
+
+func TestBuildController_RetryBuild_NotFinished(t *testing.T) {
+	mockBuildService := new(mockBuildService)
+	mockBuildService.On("RetryBuild", mock.Anything, "test-id", (*string)(nil)).Return(nil, domain.ErrBuildNotFinished)
+
+	bc := NewBuildController(mockBuildService)
+
+	router := newTestRouter()
+	router.POST("/builds/:id/retry", bc.RetryBuild)
+
+	req := httptest.NewRequest("POST", "/builds/test-id/retry", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusConflict, w.Code)
+	assert.Contains(t, w.Body.String(), "build has not finished")
+}
+
 func TestBuildController_GetBuild_Success(t *testing.T) {
 	expectedBuild := &domain.Build{
 		ID:      "test-id",
@@ -195,7 +400,7 @@ func TestBuildController_GetBuild_Success(t *testing.T) {
 
 	bc := NewBuildController(mockBuildService)
 
-	router := gin.New()
+	router := newTestRouter()
 	router.GET("/builds/:id", bc.GetBuild)
 
 	req := httptest.NewRequest("GET", "/builds/test-id", nil)
@@ -215,13 +420,56 @@ func TestBuildController_GetBuild_Success(t *testing.T) {
 	mockBuildService.AssertExpectations(t)
 }
 
+func TestBuildController_GetBuild_MatrixParentLinksChildren(t *testing.T) {
+	parentID := "parent-id"
+	expectedBuild := &domain.Build{
+		ID:      parentID,
+		RepoUrl: "https://github.com/test/repo",
+		Ref:     "main",
+		Command: "go test ./...",
+		Matrix:  &domain.Matrix{Axes: map[string][]string{"go": {"1.24", "1.25"}}},
+		Children: []domain.Build{
+			{ID: "child-1", ParentID: &parentID, MatrixValues: domain.StringMap{"go": "1.24"}},
+			{ID: "child-2", ParentID: &parentID, MatrixValues: domain.StringMap{"go": "1.25"}},
+		},
+	}
+	mockBuildService := new(mockBuildService)
+	mockBuildService.On("GetBuild", mock.Anything, parentID).Return(expectedBuild, nil)
+
+	bc := NewBuildController(mockBuildService)
+
+	router := newTestRouter()
+	router.GET("/builds/:id", bc.GetBuild)
+
+	req := httptest.NewRequest("GET", "/builds/parent-id", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var got struct {
+		ID       string         `json:"id"`
+		Children []domain.Build `json:"children"`
+		Links    struct {
+			Self     string   `json:"self"`
+			Children []string `json:"children"`
+		} `json:"links"`
+	}
+	err := json.Unmarshal(w.Body.Bytes(), &got)
+	assert.NoError(t, err)
+	assert.Equal(t, "/api/v1/builds/parent-id", got.Links.Self)
+	assert.Equal(t, []string{"/api/v1/builds/child-1", "/api/v1/builds/child-2"}, got.Links.Children)
+	assert.Len(t, got.Children, 2)
+	mockBuildService.AssertExpectations(t)
+}
+
 func TestBuildController_GetBuild_Error(t *testing.T) {
 	mockBuildService := new(mockBuildService)
 	mockBuildService.On("GetBuild", mock.Anything, "test-id").Return(nil, assert.AnError)
 
 	bc := NewBuildController(mockBuildService)
 
-	router := gin.New()
+	router := newTestRouter()
 	router.GET("/builds/:id", bc.GetBuild)
 
 	req := httptest.NewRequest("GET", "/builds/test-id", nil)
@@ -232,13 +480,69 @@ func TestBuildController_GetBuild_Error(t *testing.T) {
 	mockBuildService.AssertExpectations(t)
 }
 
+func TestBuildController_GetBuild_NotFound(t *testing.T) {
+	mockBuildService := new(mockBuildService)
+	mockBuildService.On("GetBuild", mock.Anything, "3f1b2c1e-7d4a-4a57-9a61-8a4f0e6c9d10").Return(nil, domain.ErrBuildNotFound)
+
+	bc := NewBuildController(mockBuildService)
+
+	router := newTestRouter()
+	router.GET("/builds/:id", bc.GetBuild)
+
+	req := httptest.NewRequest("GET", "/builds/3f1b2c1e-7d4a-4a57-9a61-8a4f0e6c9d10", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	var response errorEnvelope
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, "not_found", response.Error.Code)
+	assert.Equal(t, "build not found", response.Error.Message)
+	assert.Equal(t, w.Header().Get("X-Request-ID"), response.Error.RequestID)
+}
+
+func TestBuildController_GetBuild_MalformedID(t *testing.T) {
+	mockBuildService := new(mockBuildService)
+	mockBuildService.On("GetBuild", mock.Anything, "not-a-uuid").
+		Return(nil, domain.NewError(domain.ErrInvalidArgument, "malformed value").WithCause(errors.New(`invalid input syntax for type uuid: "not-a-uuid"`)))
+
+	bc := NewBuildController(mockBuildService)
+
+	router := newTestRouter()
+	router.GET("/builds/:id", bc.GetBuild)
+
+	req := httptest.NewRequest("GET", "/builds/not-a-uuid", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), `"code":"invalid_argument"`)
+	assert.NotContains(t, w.Body.String(), "syntax")
+}
+
+func TestBuildController_CancelBuild_NotFound(t *testing.T) {
+	mockBuildService := new(mockBuildService)
+	mockBuildService.On("CancelBuild", mock.Anything, "missing").Return(domain.ErrBuildNotFound)
+
+	bc := NewBuildController(mockBuildService)
+
+	router := newTestRouter()
+	router.POST("/builds/:id/cancel", bc.CancelBuild)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("POST", "/builds/missing/cancel", nil))
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
 func TestBuildController_UpdateStatus_Success(t *testing.T) {
 	mockBuildService := new(mockBuildService)
 	mockBuildService.On("UpdateStatus", mock.Anything, "test-id", domain.BuildStatusRunning).Return(nil)
 
 	bc := NewBuildController(mockBuildService)
 
-	router := gin.New()
+	router := newTestRouter()
 	router.PATCH("/builds/:id/status", bc.UpdateStatus)
 
 	body := []byte(`{"status": "running"}`)
@@ -258,7 +562,7 @@ func TestBuildController_UpdateStatus_Error(t *testing.T) {
 
 	bc := NewBuildController(mockBuildService)
 
-	router := gin.New()
+	router := newTestRouter()
 	router.PATCH("/builds/:id/status", bc.UpdateStatus)
 
 	body := []byte(`{"status": "running"}`)
@@ -277,7 +581,7 @@ func TestBuildController_UpdateStatus_InvalidStatus(t *testing.T) {
 
 	bc := NewBuildController(mockBuildService)
 
-	router := gin.New()
+	router := newTestRouter()
 	router.PATCH("/builds/:id/status", bc.UpdateStatus)
 
 	body := []byte(`{"status": "invalid_status"}`)
@@ -297,7 +601,7 @@ func TestBuildController_UpdateStatus_MissingStatusField(t *testing.T) {
 
 	bc := NewBuildController(mockBuildService)
 
-	router := gin.New()
+	router := newTestRouter()
 	router.PATCH("/builds/:id/status", bc.UpdateStatus)
 
 	body := []byte(`{}`)
@@ -310,3 +614,129 @@ func TestBuildController_UpdateStatus_MissingStatusField(t *testing.T) {
 	assert.Equal(t, http.StatusBadRequest, w.Code)
 	assert.Contains(t, w.Body.String(), "invalid request body")
 }
+
+func TestBuildController_UpdatePriority_Success(t *testing.T) {
+	mockBuildService := new(mockBuildService)
+	mockBuildService.On("UpdatePriority", mock.Anything, "test-id", 0).Return(&domain.Build{ID: "test-id", Status: domain.BuildStatusPending}, nil)
+
+	bc := NewBuildController(mockBuildService)
+
+	router := newTestRouter()
+	router.PUT("/builds/:id/priority", bc.UpdatePriority)
+
+	body := []byte(`{"priority": 0}`)
+	req := httptest.NewRequest("PUT", "/builds/test-id/priority", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"priority":0`)
+	mockBuildService.AssertExpectations(t)
+}
+
+func TestBuildController_UpdatePriority_NotPending(t *testing.T) {
+	mockBuildService := new(mockBuildService)
+	mockBuildService.On("UpdatePriority", mock.Anything, "test-id", 5).Return(nil, domain.ErrBuildNotPending)
+
+	bc := NewBuildController(mockBuildService)
+
+	router := newTestRouter()
+	router.PUT("/builds/:id/priority", bc.UpdatePriority)
+
+	body := []byte(`{"priority": 5}`)
+	req := httptest.NewRequest("PUT", "/builds/test-id/priority", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusConflict, w.Code)
+	mockBuildService.AssertExpectations(t)
+}
+
+func TestBuildController_UpdatePriority_MissingPriority(t *testing.T) {
+	mockBuildService := new(mockBuildService)
+
+	bc := NewBuildController(mockBuildService)
+
+	router := newTestRouter()
+	router.PUT("/builds/:id/priority", bc.UpdatePriority)
+
+	body := []byte(`{}`)
+	req := httptest.NewRequest("PUT", "/builds/test-id/priority", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), "invalid request body")
+}
+
+func TestBuildController_ListBuilds_Success(t *testing.T) {
+	mockBuildService := new(mockBuildService)
+	mockBuildService.On("ListBuilds", mock.Anything, mock.MatchedBy(func(f domain.BuildFilter) bool {
+		return len(f.Statuses) == 2 &&
+			f.Statuses[0] == domain.BuildStatusFailed &&
+			f.Statuses[1] == domain.BuildStatusCanceled &&
+			f.RepoUrl == "https://github.com/test/repo" &&
+			f.LockedBy == "worker-1" &&
+			f.Query == "npm" &&
+			f.Limit == 10 &&
+			f.CreatedAfter != nil && f.CreatedAfter.Equal(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)) &&
+			f.After != nil && f.After.ID == "b0"
+	})).Return(&domain.BuildPage{
+		Builds:     []domain.Build{{ID: "b1", Status: domain.BuildStatusFailed}},
+		NextCursor: "next",
+	}, nil)
+
+	bc := NewBuildController(mockBuildService)
+
+	router := newTestRouter()
+	router.GET("/builds", bc.ListBuilds)
+
+	cursor := domain.BuildCursor{CreatedAt: time.Now(), ID: "b0"}.Encode()
+	req := httptest.NewRequest("GET", "/builds?status=failed,canceled&repo_url=https://github.com/test/repo&worker=worker-1&q=npm&limit=10&created_after=2025-01-01T00:00:00Z&cursor="+cursor, nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response struct {
+		Builds []struct {
+			ID    string `json:"id"`
+			Links struct {
+				Self string `json:"self"`
+			} `json:"links"`
+		} `json:"builds"`
+		NextCursor string `json:"next_cursor"`
+	}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Len(t, response.Builds, 1)
+	assert.Equal(t, "/api/v1/builds/b1", response.Builds[0].Links.Self)
+	assert.Equal(t, "next", response.NextCursor)
+	mockBuildService.AssertExpectations(t)
+}
+
+func TestBuildController_ListBuilds_InvalidQuery(t *testing.T) {
+	mockBuildService := new(mockBuildService)
+	bc := NewBuildController(mockBuildService)
+
+	router := newTestRouter()
+	router.GET("/builds", bc.ListBuilds)
+
+	for _, query := range []string{
+		"status=unknown",
+		"created_after=yesterday",
+		"limit=0",
+		"limit=1000",
+		"cursor=garbage",
+	} {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest("GET", "/builds?"+query, nil))
+		assert.Equal(t, http.StatusBadRequest, w.Code, query)
+	}
+	mockBuildService.AssertNotCalled(t, "ListBuilds", mock.Anything, mock.Anything)
+}
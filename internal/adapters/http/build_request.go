@@ -0,0 +1,122 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/H3nSte1n/ci-orchestrator/internal/core/domain"
+	"io"
+	"strings"
+	"time"
+)
+
+// serverManagedFields are build fields that only the server sets.
+var serverManagedFields = []string{
+	"id", "status", "attempts", "locked_by", "locked_at", "finished_at", "cancel_requested_at",
+	"exit_code", "error", "parent_id", "matrix_values", "children", "triggered_by", "unschedulable", "created_at", "updated_at",
+}
+
+type createBuildRequest struct {
+	RepoUrl   string             `json:"repo_url"`
+	Ref       string             `json:"ref"`
+	Command   string             `json:"command"`
+	Priority  int                `json:"priority"`
+	NotBefore *time.Time         `json:"not_before"`
+	Env       map[string]string  `json:"env"`
+	Artifacts []string           `json:"artifacts"`
+	Caches    []domain.Cache     `json:"caches"`
+	RunsOn    []string           `json:"runs_on"`
+	Matrix    *domain.Matrix     `json:"matrix"`
+	Jobs      []createJobRequest `json:"jobs"`
+
+	ConcurrencyGroup  *string                  `json:"concurrency_group"`
+	MaxConcurrency    int                      `json:"max_concurrency"`
+	ConcurrencyPolicy domain.ConcurrencyPolicy `json:"concurrency_policy"`
+}
+
+type createJobRequest struct {
+	Name      string   `json:"name"`
+	Command   string   `json:"command"`
+	Needs     []string `json:"needs"`
+	Artifacts []string `json:"artifacts"`
+}
+
+// decodeCreateBuildRequest strictly decodes the body: server managed and
+// unknown fields are reported as field errors instead of being ignored.
+func decodeCreateBuildRequest(body io.Reader) (*createBuildRequest, error) {
+	data, err := io.ReadAll(body)
+	if err != nil {
+		return nil, withDetails(invalidArgument("invalid request body"), err.Error())
+	}
+
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, withDetails(invalidArgument("invalid request body"), err.Error())
+	}
+
+	var fieldErrors []domain.FieldError
+	for _, field := range serverManagedFields {
+		if _, ok := raw[field]; ok {
+			fieldErrors = append(fieldErrors, domain.FieldError{Field: field, Message: "is managed by the server and cannot be set"})
+		}
+	}
+	if len(fieldErrors) > 0 {
+		return nil, validationFailed(fieldErrors)
+	}
+
+	dec := json.NewDecoder(strings.NewReader(string(data)))
+	dec.DisallowUnknownFields()
+
+	var req createBuildRequest
+	if err := dec.Decode(&req); err != nil {
+		var typeErr *json.UnmarshalTypeError
+		switch {
+		case errors.As(err, &typeErr):
+			return nil, validationFailed([]domain.FieldError{{Field: typeErr.Field, Message: "must be of type " + typeErr.Type.String()}})
+		case strings.HasPrefix(err.Error(), "json: unknown field "):
+			field := strings.Trim(strings.TrimPrefix(err.Error(), "json: unknown field "), `"`)
+			return nil, validationFailed([]domain.FieldError{{Field: field, Message: "is not a known field"}})
+		default:
+			return nil, withDetails(invalidArgument("invalid request body"), err.Error())
+		}
+	}
+
+	return &req, nil
+}
+
+func validationFailed(fieldErrors []domain.FieldError) error {
+	return withDetails(invalidArgument("validation failed"), fieldErrors)
+}
+
+func (r *createBuildRequest) validate() []domain.FieldError {
+	return domain.ValidateNewBuild(r.toDomain())
+}
+
+func (r *createBuildRequest) toDomain() *domain.Build {
+	build := &domain.Build{
+		RepoUrl:   r.RepoUrl,
+		Ref:       r.Ref,
+		Command:   r.Command,
+		Priority:  r.Priority,
+		NotBefore: r.NotBefore,
+		Env:       domain.StringMap(r.Env),
+		Artifacts: domain.StringList(r.Artifacts),
+		Caches:    domain.CacheList(r.Caches),
+		RunsOn:    domain.StringList(r.RunsOn),
+		Matrix:    r.Matrix,
+
+		ConcurrencyGroup:  r.ConcurrencyGroup,
+		MaxConcurrency:    r.MaxConcurrency,
+		ConcurrencyPolicy: r.ConcurrencyPolicy,
+	}
+
+	for _, job := range r.Jobs {
+		build.Jobs = append(build.Jobs, domain.Job{
+			Name:      job.Name,
+			Command:   job.Command,
+			Needs:     domain.StringList(job.Needs),
+			Artifacts: domain.StringList(job.Artifacts),
+		})
+	}
+
+	return build
+}
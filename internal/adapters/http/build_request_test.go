@@ -0,0 +1,104 @@
+package http
+
+import (
+	"errors"
+	"github.com/H3nSte1n/ci-orchestrator/internal/core/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"strings"
+	"testing"
+	"time"
+)
+
+func detailsOf(t *testing.T, err error) []domain.FieldError {
+	var detailed *detailedError
+	require.True(t, errors.As(err, &detailed))
+	fieldErrors, ok := detailed.details.([]domain.FieldError)
+	require.True(t, ok)
+	return fieldErrors
+}
+
+func TestDecodeCreateBuildRequest_Success(t *testing.T) {
+	body := `{"repo_url":"https://github.com/test/repo","ref":"main","command":"make","env":{"GOFLAGS":"-v"},"jobs":[{"name":"build","command":"make"}]}`
+
+	req, err := decodeCreateBuildRequest(strings.NewReader(body))
+
+	require.NoError(t, err)
+	assert.Equal(t, "https://github.com/test/repo", req.RepoUrl)
+	assert.Equal(t, "-v", req.Env["GOFLAGS"])
+	assert.Len(t, req.Jobs, 1)
+}
+
+func TestDecodeCreateBuildRequest_RejectsServerManagedFields(t *testing.T) {
+	body := `{"repo_url":"https://github.com/test/repo","ref":"main","command":"make","status":"success","locked_by":"worker-1"}`
+
+	_, err := decodeCreateBuildRequest(strings.NewReader(body))
+
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, domain.ErrInvalidArgument))
+	assert.ElementsMatch(t, []domain.FieldError{
+		{Field: "status", Message: "is managed by the server and cannot be set"},
+		{Field: "locked_by", Message: "is managed by the server and cannot be set"},
+	}, detailsOf(t, err))
+}
+
+func TestDecodeCreateBuildRequest_RejectsUnknownFields(t *testing.T) {
+	_, err := decodeCreateBuildRequest(strings.NewReader(`{"repo_url":"https://github.com/test/repo","branch":"main"}`))
+
+	require.Error(t, err)
+	assert.Equal(t, []domain.FieldError{{Field: "branch", Message: "is not a known field"}}, detailsOf(t, err))
+}
+
+func TestDecodeCreateBuildRequest_TypeMismatch(t *testing.T) {
+	_, err := decodeCreateBuildRequest(strings.NewReader(`{"repo_url":"https://github.com/test/repo","ref":42}`))
+
+	require.Error(t, err)
+	assert.Equal(t, []domain.FieldError{{Field: "ref", Message: "must be of type string"}}, detailsOf(t, err))
+}
+
+func TestDecodeCreateBuildRequest_InvalidJSON(t *testing.T) {
+	_, err := decodeCreateBuildRequest(strings.NewReader(`{"repo_url":`))
+
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, domain.ErrInvalidArgument))
+	assert.Equal(t, "invalid request body", err.Error())
+}
+
+func TestCreateBuildRequest_ToDomain(t *testing.T) {
+	group := "deploy-prod"
+	req := createBuildRequest{
+		RepoUrl:   "https://github.com/test/repo",
+		Ref:       "main",
+		Artifacts: []string{"dist/*"},
+		Jobs: []createJobRequest{
+			{Name: "build", Command: "make"},
+			{Name: "test", Command: "make test", Needs: []string{"build"}},
+		},
+		ConcurrencyGroup:  &group,
+		MaxConcurrency:    2,
+		ConcurrencyPolicy: domain.ConcurrencyCancelPending,
+	}
+
+	build := req.toDomain()
+
+	assert.Equal(t, "https://github.com/test/repo", build.RepoUrl)
+	assert.Equal(t, domain.StringList{"dist/*"}, build.Artifacts)
+	require.Len(t, build.Jobs, 2)
+	assert.Equal(t, domain.StringList{"build"}, build.Jobs[1].Needs)
+	assert.Equal(t, &group, build.ConcurrencyGroup)
+	assert.Equal(t, 2, build.MaxConcurrency)
+	assert.Equal(t, domain.ConcurrencyCancelPending, build.ConcurrencyPolicy)
+	assert.Empty(t, build.ID)
+	assert.Empty(t, build.Status)
+}
+
+func TestDecodeCreateBuildRequest_NotBefore(t *testing.T) {
+	body := `{"repo_url":"https://github.com/test/repo","ref":"main","command":"make","not_before":"2026-10-20T03:00:00+02:00"}`
+
+	req, err := decodeCreateBuildRequest(strings.NewReader(body))
+
+	require.NoError(t, err)
+	build := req.toDomain()
+	require.NotNil(t, build.NotBefore)
+	assert.True(t, time.Date(2026, 10, 20, 1, 0, 0, 0, time.UTC).Equal(*build.NotBefore))
+}
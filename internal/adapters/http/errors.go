@@ -0,0 +1,80 @@
+package http
+
+import (
+	"errors"
+	"github.com/H3nSte1n/ci-orchestrator/internal/core/domain"
+	"net/http"
+)
+
+// Error codes of the JSON error envelope.
+const (
+	codeInvalidArgument  = "invalid_argument"
+	codeNotFound         = "not_found"
+	codeConflict         = "conflict"
+	codeUnauthenticated  = "unauthenticated"
+	codePermissionDenied = "permission_denied"
+	codeInternal         = "internal"
+)
+
+type errorEnvelope struct {
+	Error errorBody `json:"error"`
+}
+
+type errorBody struct {
+	Code      string      `json:"code"`
+	Message   string      `json:"message"`
+	RequestID string      `json:"request_id,omitempty"`
+	Details   interface{} `json:"details,omitempty"`
+}
+
+// detailedError attaches client facing details to an error.
+type detailedError struct {
+	err     error
+	details interface{}
+}
+
+func (e *detailedError) Error() string {
+	return e.err.Error()
+}
+
+func (e *detailedError) Unwrap() error {
+	return e.err
+}
+
+func withDetails(err error, details interface{}) error {
+	return &detailedError{err: err, details: details}
+}
+
+func invalidArgument(message string) error {
+	return domain.NewError(domain.ErrInvalidArgument, message)
+}
+
+// classifyError maps domain error kinds onto a status code and an envelope.
+// Unknown errors become a generic internal error so that driver messages
+// never reach clients.
+func classifyError(err error) (int, errorBody) {
+	body := errorBody{Message: err.Error()}
+
+	var status int
+	switch {
+	case errors.Is(err, domain.ErrInvalidArgument):
+		status, body.Code = http.StatusBadRequest, codeInvalidArgument
+	case errors.Is(err, domain.ErrNotFound):
+		status, body.Code = http.StatusNotFound, codeNotFound
+	case errors.Is(err, domain.ErrConflict):
+		status, body.Code = http.StatusConflict, codeConflict
+	case errors.Is(err, domain.ErrUnauthenticated):
+		status, body.Code = http.StatusUnauthorized, codeUnauthenticated
+	case errors.Is(err, domain.ErrPermissionDenied):
+		status, body.Code = http.StatusForbidden, codePermissionDenied
+	default:
+		return http.StatusInternalServerError, errorBody{Code: codeInternal, Message: "internal server error"}
+	}
+
+	var detailed *detailedError
+	if errors.As(err, &detailed) {
+		body.Details = detailed.details
+	}
+
+	return status, body
+}
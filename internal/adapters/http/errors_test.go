@@ -0,0 +1,44 @@
+package http
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/H3nSte1n/ci-orchestrator/internal/core/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClassifyError(t *testing.T) {
+	tests := []struct {
+		err     error
+		status  int
+		code    string
+		message string
+	}{
+		{domain.ErrBuildNotFound, http.StatusNotFound, "not_found", "build not found"},
+		{fmt.Errorf("%w: dependency cycle", domain.ErrInvalidJobGraph), http.StatusBadRequest, "invalid_argument", "invalid job graph: dependency cycle"},
+		{domain.NewError(domain.ErrConflict, "record already exists"), http.StatusConflict, "conflict", "record already exists"},
+		{domain.ErrInvalidToken, http.StatusUnauthorized, "unauthenticated", "invalid or revoked token"},
+		{domain.ErrInsufficientScope, http.StatusForbidden, "permission_denied", "token lacks the required scope"},
+		{errors.New("dial tcp: connection refused"), http.StatusInternalServerError, "internal", "internal server error"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.err.Error(), func(t *testing.T) {
+			status, body := classifyError(tt.err)
+
+			assert.Equal(t, tt.status, status)
+			assert.Equal(t, tt.code, body.Code)
+			assert.Equal(t, tt.message, body.Message)
+		})
+	}
+}
+
+func TestClassifyError_Details(t *testing.T) {
+	_, body := classifyError(withDetails(invalidArgument("invalid status"), []string{"pending"}))
+
+	assert.Equal(t, "invalid_argument", body.Code)
+	assert.Equal(t, []string{"pending"}, body.Details)
+}
@@ -0,0 +1,56 @@
+package http
+
+import (
+	"github.com/H3nSte1n/ci-orchestrator/internal/core/domain"
+	"github.com/H3nSte1n/ci-orchestrator/internal/core/ports"
+	"github.com/gin-gonic/gin"
+	"net/http"
+)
+
+// FleetController shows the registered workers and lets admins drain,
+// pause and resume them.
+type FleetController struct {
+	workerService ports.WorkerService
+}
+
+func NewFleetController(workerService ports.WorkerService) *FleetController {
+	return &FleetController{
+		workerService: workerService,
+	}
+}
+
+type listWorkersResponse struct {
+	Workers []domain.Worker `json:"workers"`
+}
+
+func (fc *FleetController) ListWorkers(c *gin.Context) {
+	workers, err := fc.workerService.ListWorkers(c.Request.Context())
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, listWorkersResponse{Workers: workers})
+}
+
+func (fc *FleetController) DrainWorker(c *gin.Context) {
+	fc.setState(c, domain.WorkerStateDraining)
+}
+
+func (fc *FleetController) PauseWorker(c *gin.Context) {
+	fc.setState(c, domain.WorkerStatePaused)
+}
+
+func (fc *FleetController) ResumeWorker(c *gin.Context) {
+	fc.setState(c, domain.WorkerStateActive)
+}
+
+func (fc *FleetController) setState(c *gin.Context, state domain.WorkerState) {
+	worker, err := fc.workerService.SetState(c.Request.Context(), c.Param("id"), state)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, worker)
+}
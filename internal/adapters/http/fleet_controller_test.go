@@ -0,0 +1,76 @@
+package http
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/H3nSte1n/ci-orchestrator/internal/core/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestFleetController_ListWorkers(t *testing.T) {
+	workerService := new(mockWorkerService)
+	workerService.On("ListWorkers", mock.Anything).Return([]domain.Worker{{
+		ID:            "worker-1",
+		Hostname:      "host-1",
+		State:         domain.WorkerStateActive,
+		Status:        domain.WorkerStatusOnline,
+		CurrentBuilds: []string{"ci-id"},
+	}}, nil)
+
+	router := newTestRouter()
+	router.GET("/workers", NewFleetController(workerService).ListWorkers)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/workers", nil))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"workers":[{"id":"worker-1","hostname":"host-1"`)
+	assert.Contains(t, w.Body.String(), `"status":"online","current_builds":["ci-id"]`)
+}
+
+func TestFleetController_SetState(t *testing.T) {
+	cases := []struct {
+		path  string
+		state domain.WorkerState
+	}{
+		{"/workers/worker-1/drain", domain.WorkerStateDraining},
+		{"/workers/worker-1/pause", domain.WorkerStatePaused},
+		{"/workers/worker-1/resume", domain.WorkerStateActive},
+	}
+
+	for _, tc := range cases {
+		t.Run(string(tc.state), func(t *testing.T) {
+			workerService := new(mockWorkerService)
+			workerService.On("SetState", mock.Anything, "worker-1", tc.state).Return(&domain.Worker{ID: "worker-1", State: tc.state}, nil)
+
+			fc := NewFleetController(workerService)
+			router := newTestRouter()
+			router.POST("/workers/:id/drain", fc.DrainWorker)
+			router.POST("/workers/:id/pause", fc.PauseWorker)
+			router.POST("/workers/:id/resume", fc.ResumeWorker)
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest("POST", tc.path, nil))
+
+			assert.Equal(t, http.StatusOK, w.Code)
+			assert.Contains(t, w.Body.String(), `"state":"`+string(tc.state)+`"`)
+			workerService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestFleetController_SetState_NotFound(t *testing.T) {
+	workerService := new(mockWorkerService)
+	workerService.On("SetState", mock.Anything, "missing", domain.WorkerStatePaused).Return(nil, domain.ErrWorkerNotFound)
+
+	router := newTestRouter()
+	router.POST("/workers/:id/pause", NewFleetController(workerService).PauseWorker)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("POST", "/workers/missing/pause", nil))
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
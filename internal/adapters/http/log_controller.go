@@ -0,0 +1,80 @@
+package http
+
+import (
+	"fmt"
+	"github.com/H3nSte1n/ci-orchestrator/internal/core/domain"
+	"github.com/H3nSte1n/ci-orchestrator/internal/core/ports"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"strconv"
+)
+
+type LogController struct {
+	buildService    ports.BuildService
+	buildLogService ports.BuildLogService
+}
+
+func NewLogController(buildService ports.BuildService, buildLogService ports.BuildLogService) *LogController {
+	return &LogController{
+		buildService:    buildService,
+		buildLogService: buildLogService,
+	}
+}
+
+// listLogsResponse carries the build status next to the lines so clients can
+// poll with next_after_seq until the build is finished and no lines are left.
+type listLogsResponse struct {
+	Logs         []domain.BuildLog  `json:"logs"`
+	NextAfterSeq int64              `json:"next_after_seq"`
+	BuildStatus  domain.BuildStatus `json:"build_status"`
+}
+
+func (lc *LogController) ListLogs(c *gin.Context) {
+	buildId := c.Param("id")
+
+	if buildId == "" {
+		_ = c.Error(invalidArgument("build id is required"))
+		return
+	}
+
+	var afterSeq int64
+	if value := c.Query("after_seq"); value != "" {
+		parsed, err := strconv.ParseInt(value, 10, 64)
+		if err != nil || parsed < 0 {
+			_ = c.Error(invalidArgument("after_seq must be a non-negative integer"))
+			return
+		}
+		afterSeq = parsed
+	}
+
+	limit := domain.MaxLogPageSize
+	if value := c.Query("limit"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed < 1 || parsed > domain.MaxLogPageSize {
+			_ = c.Error(invalidArgument(fmt.Sprintf("limit must be between 1 and %d", domain.MaxLogPageSize)))
+			return
+		}
+		limit = parsed
+	}
+
+	// Read the status before the logs so a client that sees a finished build
+	// has also been sent every line written before it finished.
+	build, err := lc.buildService.GetBuild(c.Request.Context(), buildId)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	logs, err := lc.buildLogService.ListLogs(c.Request.Context(), buildId, afterSeq, limit)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	response := listLogsResponse{Logs: logs, NextAfterSeq: afterSeq, BuildStatus: build.Status}
+	if len(logs) > 0 {
+		response.NextAfterSeq = logs[len(logs)-1].Seq
+	}
+
+	c.JSON(http.StatusOK, response)
+}
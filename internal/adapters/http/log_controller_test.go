@@ -0,0 +1,72 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/H3nSte1n/ci-orchestrator/internal/core/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func newLogTestRouter(lc *LogController) http.Handler {
+	router := newTestRouter()
+	router.GET("/builds/:id/logs", lc.ListLogs)
+	return router
+}
+
+func TestLogController_ListLogs(t *testing.T) {
+	builds := new(mockBuildService)
+	logs := new(mockBuildLogService)
+	builds.On("GetBuild", mock.Anything, "b1").Return(&domain.Build{ID: "b1", Status: domain.BuildStatusRunning}, nil)
+	logs.On("ListLogs", mock.Anything, "b1", int64(4), 2).Return([]domain.BuildLog{
+		{Seq: 5, Stream: domain.LogStdout, Content: "one"},
+		{Seq: 7, Stream: domain.LogStderr, Content: "two"},
+	}, nil)
+
+	w := httptest.NewRecorder()
+	newLogTestRouter(NewLogController(builds, logs)).ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/builds/b1/logs?after_seq=4&limit=2", nil))
+
+	require.Equal(t, http.StatusOK, w.Code)
+	var resp listLogsResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Len(t, resp.Logs, 2)
+	assert.Equal(t, int64(7), resp.NextAfterSeq)
+	assert.Equal(t, domain.BuildStatusRunning, resp.BuildStatus)
+}
+
+func TestLogController_ListLogs_NoNewLines(t *testing.T) {
+	builds := new(mockBuildService)
+	logs := new(mockBuildLogService)
+	builds.On("GetBuild", mock.Anything, "b1").Return(&domain.Build{ID: "b1", Status: domain.BuildStatusSuccess}, nil)
+	logs.On("ListLogs", mock.Anything, "b1", int64(9), domain.MaxLogPageSize).Return([]domain.BuildLog{}, nil)
+
+	w := httptest.NewRecorder()
+	newLogTestRouter(NewLogController(builds, logs)).ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/builds/b1/logs?after_seq=9", nil))
+
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"logs":[],"next_after_seq":9,"build_status":"success"}`, w.Body.String())
+}
+
+func TestLogController_ListLogs_InvalidQuery(t *testing.T) {
+	for _, query := range []string{"after_seq=-1", "after_seq=x", "limit=0", "limit=100000"} {
+		w := httptest.NewRecorder()
+		newLogTestRouter(NewLogController(new(mockBuildService), new(mockBuildLogService))).
+			ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/builds/b1/logs?"+query, nil))
+
+		assert.Equal(t, http.StatusBadRequest, w.Code, query)
+	}
+}
+
+func TestLogController_ListLogs_BuildNotFound(t *testing.T) {
+	builds := new(mockBuildService)
+	builds.On("GetBuild", mock.Anything, "b1").Return(nil, domain.ErrBuildNotFound)
+
+	w := httptest.NewRecorder()
+	newLogTestRouter(NewLogController(builds, new(mockBuildLogService))).ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/builds/b1/logs", nil))
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
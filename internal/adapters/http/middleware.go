@@ -0,0 +1,63 @@
+package http
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"github.com/H3nSte1n/ci-orchestrator/internal/core/domain"
+	"github.com/gin-gonic/gin"
+	"log"
+	"net/http"
+)
+
+const (
+	requestIDHeader = "X-Request-ID"
+	requestIDKey    = "request_id"
+)
+
+// RequestID tags every request with an id, reusing a well-formed id sent by
+// the client, and echoes it in the response header.
+func RequestID() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id := c.GetHeader(requestIDHeader)
+		if !domain.ValidRequestID(id) {
+			id = newRequestID()
+		}
+
+		c.Set(requestIDKey, id)
+		c.Header(requestIDHeader, id)
+		c.Next()
+	}
+}
+
+// ErrorHandler renders the last error a handler recorded with c.Error as a
+// JSON error envelope. Internal errors are logged with the request id and
+// replaced by a generic message.
+func ErrorHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Next()
+
+		if len(c.Errors) == 0 || c.Writer.Written() {
+			return
+		}
+
+		err := c.Errors.Last().Err
+		status, body := classifyError(err)
+		body.RequestID = c.GetString(requestIDKey)
+
+		if status >= http.StatusInternalServerError {
+			log.Printf("request %s: %s %s: %v", body.RequestID, c.Request.Method, c.Request.URL.Path, err)
+		}
+
+		c.JSON(status, errorEnvelope{Error: body})
+	}
+}
+
+func routeNotFound(c *gin.Context) {
+	_ = c.Error(domain.NewError(domain.ErrNotFound, "route not found"))
+}
+
+func newRequestID() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
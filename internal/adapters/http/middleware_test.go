@@ -0,0 +1,88 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRequestID_GeneratesAndEchoesID(t *testing.T) {
+	router := newTestRouter()
+	router.GET("/ping", func(c *gin.Context) {
+		c.String(http.StatusOK, c.GetString(requestIDKey))
+	})
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/ping", nil))
+
+	id := w.Header().Get("X-Request-ID")
+	assert.Len(t, id, 32)
+	assert.Equal(t, id, w.Body.String())
+}
+
+func TestRequestID_ReusesClientID(t *testing.T) {
+	router := newTestRouter()
+	router.GET("/ping", func(c *gin.Context) { c.Status(http.StatusNoContent) })
+
+	req := httptest.NewRequest("GET", "/ping", nil)
+	req.Header.Set("X-Request-ID", "client-123")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, "client-123", w.Header().Get("X-Request-ID"))
+
+	req = httptest.NewRequest("GET", "/ping", nil)
+	req.Header.Set("X-Request-ID", "bad id\nwith newline")
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.NotEqual(t, "bad id\nwith newline", w.Header().Get("X-Request-ID"))
+	assert.NotEmpty(t, w.Header().Get("X-Request-ID"))
+}
+
+func TestErrorHandler_HidesInternalErrors(t *testing.T) {
+	router := newTestRouter()
+	router.GET("/boom", func(c *gin.Context) {
+		_ = c.Error(errors.New(`pq: relation "builds" does not exist`))
+	})
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/boom", nil))
+
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+
+	var response errorEnvelope
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, "internal", response.Error.Code)
+	assert.Equal(t, "internal server error", response.Error.Message)
+	assert.NotEmpty(t, response.Error.RequestID)
+}
+
+func TestErrorHandler_LeavesWrittenResponsesAlone(t *testing.T) {
+	router := newTestRouter()
+	router.GET("/stream", func(c *gin.Context) {
+		c.String(http.StatusOK, "partial")
+		_ = c.Error(errors.New("stream interrupted"))
+	})
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/stream", nil))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "partial", w.Body.String())
+}
+
+func TestRouteNotFound(t *testing.T) {
+	router := newTestRouter()
+	router.NoRoute(routeNotFound)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/nope", nil))
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	assert.Contains(t, w.Body.String(), `"code":"not_found"`)
+}
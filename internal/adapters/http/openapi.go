@@ -0,0 +1,590 @@
+package http
+
+import (
+	"encoding/json"
+	"github.com/H3nSte1n/ci-orchestrator/internal/core/domain"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const openAPIPath = "/api/v1/openapi.json"
+
+// apiOperation describes one route of the router for the OpenAPI document.
+// Request and response bodies are given as zero values of the Go types the
+// handlers bind and render, so their schemas follow the code.
+type apiOperation struct {
+	Method      string
+	Path        string
+	ID          string
+	Summary     string
+	Tag         string
+	Scope       domain.Scope
+	Public      bool
+	Query       []apiParam
+	Request     interface{}
+	RequestType string
+	Responses   []apiResponse
+}
+
+type apiParam struct {
+	Name        string
+	Description string
+	Schema      *jsonSchema
+}
+
+type apiResponse struct {
+	Status      int
+	Description string
+	Body        interface{}
+	ContentType string
+}
+
+func stringParam(name, description string) apiParam {
+	return apiParam{Name: name, Description: description, Schema: &jsonSchema{Type: "string"}}
+}
+
+func timeParam(name, description string) apiParam {
+	return apiParam{Name: name, Description: description, Schema: &jsonSchema{Type: "string", Format: "date-time"}}
+}
+
+// apiOperations must list every route registered in Router.RegisterRoutes;
+// TestOpenAPI_CoversRegisteredRoutes enforces it.
+var apiOperations = []apiOperation{
+	{
+		Method: http.MethodGet, Path: openAPIPath, ID: "getOpenAPI", Tag: "meta", Public: true,
+		Summary:   "This OpenAPI document",
+		Responses: []apiResponse{{Status: http.StatusOK, Description: "OpenAPI 3 document", Body: map[string]interface{}{}}},
+	},
+	{
+		Method: http.MethodPost, Path: "/api/v1/builds", ID: "createBuild", Tag: "builds", Scope: domain.ScopeBuildsWrite,
+		Summary: "Create a build",
+		Request: createBuildRequest{},
+		Responses: []apiResponse{
+			{Status: http.StatusCreated, Description: "The created build", Body: buildResponse{}},
+		},
+	},
+	{
+		Method: http.MethodGet, Path: "/api/v1/builds", ID: "listBuilds", Tag: "builds", Scope: domain.ScopeBuildsRead,
+		Summary: "List builds, newest first",
+		Query: []apiParam{
+			{Name: "status", Description: "Comma separated statuses; may be repeated", Schema: &jsonSchema{Type: "string"}},
+			stringParam("repo_url", "Exact repository URL"),
+			stringParam("ref", "Exact git ref"),
+			stringParam("worker", "Worker holding the build"),
+			timeParam("created_after", "RFC 3339 lower bound of created_at"),
+			timeParam("created_before", "RFC 3339 upper bound of created_at"),
+			timeParam("finished_after", "RFC 3339 lower bound of finished_at"),
+			timeParam("finished_before", "RFC 3339 upper bound of finished_at"),
+			stringParam("q", "Case-insensitive search in the command"),
+			{Name: "limit", Description: "Page size", Schema: &jsonSchema{Type: "integer", Minimum: intPtr(1), Maximum: intPtr(domain.MaxBuildPageSize)}},
+			stringParam("cursor", "next_cursor of the previous page"),
+		},
+		Responses: []apiResponse{{Status: http.StatusOK, Description: "A page of builds", Body: listBuildsResponse{}}},
+	},
+	{
+		Method: http.MethodGet, Path: "/api/v1/builds/:id", ID: "getBuild", Tag: "builds", Scope: domain.ScopeBuildsRead,
+		Summary:   "Get a build with its jobs",
+		Responses: []apiResponse{{Status: http.StatusOK, Description: "The build", Body: buildResponse{}}},
+	},
+	{
+		Method: http.MethodPatch, Path: "/api/v1/builds/:id/status", ID: "updateBuildStatus", Tag: "builds", Scope: domain.ScopeAdmin,
+		Summary:   "Set the status of a build (development only)",
+		Request:   updateStatusRequest{},
+		Responses: []apiResponse{{Status: http.StatusOK, Description: "Status updated", Body: messageResponse{}}},
+	},
+	{
+		Method: http.MethodPut, Path: "/api/v1/builds/:id/priority", ID: "updateBuildPriority", Tag: "builds", Scope: domain.ScopeBuildsWrite,
+		Summary:   "Change the priority of a build that has not started",
+		Request:   updatePriorityRequest{},
+		Responses: []apiResponse{{Status: http.StatusOK, Description: "The build", Body: buildResponse{}}},
+	},
+	{
+		Method: http.MethodPost, Path: "/api/v1/builds/:id/cancel", ID: "cancelBuild", Tag: "builds", Scope: domain.ScopeBuildsCancel,
+		Summary:   "Request cancellation of a build",
+		Responses: []apiResponse{{Status: http.StatusOK, Description: "Cancellation requested", Body: messageResponse{}}},
+	},
+	{
+		Method: http.MethodPost, Path: "/api/v1/builds/:id/retry", ID: "retryBuild", Tag: "builds", Scope: domain.ScopeBuildsWrite,
+		Summary:   "Run a finished build again as a new build",
+		Responses: []apiResponse{{Status: http.StatusCreated, Description: "The new build", Body: buildResponse{}}},
+	},
+	{
+		Method: http.MethodGet, Path: "/api/v1/builds/:id/logs", ID: "listBuildLogs", Tag: "builds", Scope: domain.ScopeBuildsRead,
+		Summary: "Read the log lines of a build in order",
+		Query: []apiParam{
+			{Name: "after_seq", Description: "Only return lines after this seq; pass next_after_seq to continue", Schema: &jsonSchema{Type: "integer", Format: "int64", Minimum: intPtr(0)}},
+			{Name: "limit", Description: "Maximum number of lines", Schema: &jsonSchema{Type: "integer", Minimum: intPtr(1), Maximum: intPtr(domain.MaxLogPageSize)}},
+		},
+		Responses: []apiResponse{{Status: http.StatusOK, Description: "Log lines and the build status", Body: listLogsResponse{}}},
+	},
+	{
+		Method: http.MethodGet, Path: "/api/v1/builds/:id/artifacts", ID: "listArtifacts", Tag: "artifacts", Scope: domain.ScopeBuildsRead,
+		Summary: "List the artifacts of a build or download them as one archive",
+		Query: []apiParam{
+			{Name: "archive", Description: "Stream all artifacts as an archive instead", Schema: &jsonSchema{Type: "string", Enum: []string{string(domain.ArchiveZip), string(domain.ArchiveTarGz)}}},
+		},
+		Responses: []apiResponse{
+			{Status: http.StatusOK, Description: "The artifacts, or the archive when archive is set", Body: listArtifactsResponse{}},
+		},
+	},
+	{
+		Method: http.MethodGet, Path: "/api/v1/builds/:id/artifacts/*path", ID: "downloadArtifact", Tag: "artifacts", Scope: domain.ScopeBuildsRead,
+		Summary: "Download one artifact; supports Range and If-None-Match",
+		Responses: []apiResponse{
+			{Status: http.StatusOK, Description: "The artifact", ContentType: "application/octet-stream"},
+			{Status: http.StatusPartialContent, Description: "The requested range", ContentType: "application/octet-stream"},
+			{Status: http.StatusNotModified, Description: "The artifact matches If-None-Match"},
+		},
+	},
+	{
+		Method: http.MethodPost, Path: "/api/v1/tokens", ID: "createToken", Tag: "tokens", Scope: domain.ScopeAdmin,
+		Summary: "Create an API token; the secret is only returned here",
+		Request: createTokenRequest{},
+		Responses: []apiResponse{
+			{Status: http.StatusCreated, Description: "The token and its secret", Body: createTokenResponse{}},
+		},
+	},
+	{
+		Method: http.MethodGet, Path: "/api/v1/tokens", ID: "listTokens", Tag: "tokens", Scope: domain.ScopeAdmin,
+		Summary:   "List API tokens",
+		Responses: []apiResponse{{Status: http.StatusOK, Description: "All tokens", Body: listTokensResponse{}}},
+	},
+	{
+		Method: http.MethodDelete, Path: "/api/v1/tokens/:id", ID: "revokeToken", Tag: "tokens", Scope: domain.ScopeAdmin,
+		Summary:   "Revoke an API token",
+		Responses: []apiResponse{{Status: http.StatusNoContent, Description: "Token revoked"}},
+	},
+	{
+		Method: http.MethodGet, Path: "/api/v1/workers", ID: "listWorkers", Tag: "workers", Scope: domain.ScopeBuildsRead,
+		Summary:   "List registered workers with their status and current builds",
+		Responses: []apiResponse{{Status: http.StatusOK, Description: "All workers", Body: listWorkersResponse{}}},
+	},
+	{
+		Method: http.MethodPost, Path: "/api/v1/workers/:id/drain", ID: "drainWorker", Tag: "workers", Scope: domain.ScopeAdmin,
+		Summary:   "Stop a worker from claiming jobs and let it exit once idle",
+		Responses: []apiResponse{{Status: http.StatusOK, Description: "The drained worker", Body: domain.Worker{}}},
+	},
+	{
+		Method: http.MethodPost, Path: "/api/v1/workers/:id/pause", ID: "pauseWorker", Tag: "workers", Scope: domain.ScopeAdmin,
+		Summary:   "Stop a worker from claiming jobs until it is resumed",
+		Responses: []apiResponse{{Status: http.StatusOK, Description: "The paused worker", Body: domain.Worker{}}},
+	},
+	{
+		Method: http.MethodPost, Path: "/api/v1/workers/:id/resume", ID: "resumeWorker", Tag: "workers", Scope: domain.ScopeAdmin,
+		Summary:   "Let a paused or draining worker claim jobs again",
+		Responses: []apiResponse{{Status: http.StatusOK, Description: "The resumed worker", Body: domain.Worker{}}},
+	},
+	{
+		Method: http.MethodGet, Path: "/api/v1/repo-limits", ID: "listRepoLimits", Tag: "scheduling", Scope: domain.ScopeBuildsRead,
+		Summary:   "List the caps on running builds per repository",
+		Responses: []apiResponse{{Status: http.StatusOK, Description: "All repository limits", Body: listRepoLimitsResponse{}}},
+	},
+	{
+		Method: http.MethodPut, Path: "/api/v1/repo-limits", ID: "setRepoLimit", Tag: "scheduling", Scope: domain.ScopeAdmin,
+		Summary:   "Cap the builds of a repository that run at once; 0 removes the cap",
+		Request:   setRepoLimitRequest{},
+		Responses: []apiResponse{{Status: http.StatusOK, Description: "The repository limit", Body: domain.RepoLimit{}}},
+	},
+	{
+		Method: http.MethodPost, Path: "/api/v1/schedules", ID: "createSchedule", Tag: "scheduling", Scope: domain.ScopeBuildsWrite,
+		Summary: "Create a schedule that starts a build whenever its cron expression matches",
+		Request: createScheduleRequest{},
+		Responses: []apiResponse{
+			{Status: http.StatusCreated, Description: "The created schedule", Body: domain.Schedule{}},
+		},
+	},
+	{
+		Method: http.MethodGet, Path: "/api/v1/schedules", ID: "listSchedules", Tag: "scheduling", Scope: domain.ScopeBuildsRead,
+		Summary:   "List schedules by name",
+		Responses: []apiResponse{{Status: http.StatusOK, Description: "All schedules", Body: listSchedulesResponse{}}},
+	},
+	{
+		Method: http.MethodGet, Path: "/api/v1/schedules/:id", ID: "getSchedule", Tag: "scheduling", Scope: domain.ScopeBuildsRead,
+		Summary:   "Get a schedule with its next run",
+		Responses: []apiResponse{{Status: http.StatusOK, Description: "The schedule", Body: domain.Schedule{}}},
+	},
+	{
+		Method: http.MethodDelete, Path: "/api/v1/schedules/:id", ID: "deleteSchedule", Tag: "scheduling", Scope: domain.ScopeBuildsWrite,
+		Summary:   "Delete a schedule; builds it started are kept",
+		Responses: []apiResponse{{Status: http.StatusNoContent, Description: "Schedule deleted"}},
+	},
+	{
+		Method: http.MethodPost, Path: "/internal/v1/workers/register", ID: "registerWorker", Tag: "worker", Scope: domain.ScopeWorker,
+		Summary:   "Register the calling worker, its details and labels",
+		Request:   registerWorkerRequest{},
+		Responses: []apiResponse{{Status: http.StatusNoContent, Description: "Worker registered"}},
+	},
+	{
+		Method: http.MethodPost, Path: "/internal/v1/workers/heartbeat", ID: "heartbeatWorker", Tag: "worker", Scope: domain.ScopeWorker,
+		Summary:   "Mark the calling worker as seen and return its state",
+		Responses: []apiResponse{{Status: http.StatusOK, Description: "The worker", Body: domain.Worker{}}},
+	},
+	{
+		Method: http.MethodPost, Path: "/internal/v1/workers/deregister", ID: "deregisterWorker", Tag: "worker", Scope: domain.ScopeWorker,
+		Summary:   "Mark the calling worker as offline on shutdown",
+		Responses: []apiResponse{{Status: http.StatusNoContent, Description: "Worker deregistered"}},
+	},
+	{
+		Method: http.MethodPost, Path: "/internal/v1/jobs/claim", ID: "claimJob", Tag: "worker", Scope: domain.ScopeWorker,
+		Summary: "Claim the next runnable job",
+		Responses: []apiResponse{
+			{Status: http.StatusOK, Description: "The claimed job and its build", Body: claimedJob{}},
+			{Status: http.StatusNoContent, Description: "No job is runnable"},
+		},
+	},
+	{
+		Method: http.MethodPost, Path: "/internal/v1/jobs/:id/heartbeat", ID: "heartbeatJob", Tag: "worker", Scope: domain.ScopeWorker,
+		Summary:   "Extend the lock on a claimed job",
+		Responses: []apiResponse{{Status: http.StatusNoContent, Description: "Heartbeat recorded"}},
+	},
+	{
+		Method: http.MethodPost, Path: "/internal/v1/jobs/:id/logs", ID: "appendJobLogs", Tag: "worker", Scope: domain.ScopeWorker,
+		Summary:   "Append a batch of log lines",
+		Request:   appendLogsRequest{},
+		Responses: []apiResponse{{Status: http.StatusNoContent, Description: "Logs stored"}},
+	},
+	{
+		Method: http.MethodPost, Path: "/internal/v1/jobs/:id/complete", ID: "completeJob", Tag: "worker", Scope: domain.ScopeWorker,
+		Summary:   "Report the result of a job",
+		Request:   completeJobRequest{},
+		Responses: []apiResponse{{Status: http.StatusNoContent, Description: "Job completed"}},
+	},
+	{
+		Method: http.MethodGet, Path: "/internal/v1/jobs/:id/cancel", ID: "jobCancelRequested", Tag: "worker", Scope: domain.ScopeWorker,
+		Summary:   "Check whether the build of a job was canceled",
+		Responses: []apiResponse{{Status: http.StatusOK, Description: "Cancellation state", Body: cancelRequestedResponse{}}},
+	},
+	{
+		Method: http.MethodPut, Path: "/internal/v1/jobs/:id/artifacts/*path", ID: "uploadArtifact", Tag: "worker", Scope: domain.ScopeWorker,
+		Summary:     "Upload an artifact of a job",
+		RequestType: "application/octet-stream",
+		Responses:   []apiResponse{{Status: http.StatusCreated, Description: "The stored artifact", Body: domain.Artifact{}}},
+	},
+}
+
+type openAPIDocument struct {
+	OpenAPI    string                                  `json:"openapi"`
+	Info       openAPIInfo                             `json:"info"`
+	Security   []map[string][]string                   `json:"security"`
+	Paths      map[string]map[string]*openAPIOperation `json:"paths"`
+	Components openAPIComponents                       `json:"components"`
+}
+
+type openAPIInfo struct {
+	Title   string `json:"title"`
+	Version string `json:"version"`
+}
+
+type openAPIComponents struct {
+	Schemas         map[string]*jsonSchema       `json:"schemas"`
+	SecuritySchemes map[string]map[string]string `json:"securitySchemes"`
+}
+
+type openAPIOperation struct {
+	OperationID string                      `json:"operationId"`
+	Summary     string                      `json:"summary"`
+	Tags        []string                    `json:"tags"`
+	Description string                      `json:"description,omitempty"`
+	Security    *[]map[string][]string      `json:"security,omitempty"`
+	Parameters  []openAPIParameter          `json:"parameters,omitempty"`
+	RequestBody *openAPIRequestBody         `json:"requestBody,omitempty"`
+	Responses   map[string]*openAPIResponse `json:"responses"`
+}
+
+type openAPIParameter struct {
+	Name        string      `json:"name"`
+	In          string      `json:"in"`
+	Description string      `json:"description,omitempty"`
+	Required    bool        `json:"required,omitempty"`
+	Schema      *jsonSchema `json:"schema"`
+}
+
+type openAPIRequestBody struct {
+	Required bool                        `json:"required"`
+	Content  map[string]openAPIMediaType `json:"content"`
+}
+
+type openAPIResponse struct {
+	Description string                      `json:"description"`
+	Content     map[string]openAPIMediaType `json:"content,omitempty"`
+}
+
+type openAPIMediaType struct {
+	Schema *jsonSchema `json:"schema"`
+}
+
+type jsonSchema struct {
+	Ref                  string                 `json:"$ref,omitempty"`
+	Type                 string                 `json:"type,omitempty"`
+	Format               string                 `json:"format,omitempty"`
+	Description          string                 `json:"description,omitempty"`
+	Nullable             bool                   `json:"nullable,omitempty"`
+	Enum                 []string               `json:"enum,omitempty"`
+	Minimum              *int                   `json:"minimum,omitempty"`
+	Maximum              *int                   `json:"maximum,omitempty"`
+	Items                *jsonSchema            `json:"items,omitempty"`
+	Properties           map[string]*jsonSchema `json:"properties,omitempty"`
+	Required             []string               `json:"required,omitempty"`
+	AdditionalProperties *jsonSchema            `json:"additionalProperties,omitempty"`
+}
+
+func intPtr(i int) *int {
+	return &i
+}
+
+// enumValues lists the values of the domain's string enums.
+var enumValues = map[reflect.Type][]string{
+	reflect.TypeOf(domain.BuildStatus("")): {
+		string(domain.BuildStatusPending), string(domain.BuildStatusRunning), string(domain.BuildStatusSuccess),
+		string(domain.BuildStatusFailed), string(domain.BuildStatusCanceled),
+	},
+	reflect.TypeOf(domain.JobStatus("")): {
+		string(domain.JobStatusPending), string(domain.JobStatusRunning), string(domain.JobStatusSuccess),
+		string(domain.JobStatusFailed), string(domain.JobStatusCanceled), string(domain.JobStatusSkipped),
+	},
+	reflect.TypeOf(domain.LogStream("")): {string(domain.LogStdout), string(domain.LogStderr)},
+	reflect.TypeOf(domain.WorkerState("")): {
+		string(domain.WorkerStateActive), string(domain.WorkerStateDraining), string(domain.WorkerStatePaused),
+	},
+	reflect.TypeOf(domain.WorkerStatus("")): {
+		string(domain.WorkerStatusOnline), string(domain.WorkerStatusDraining), string(domain.WorkerStatusPaused),
+		string(domain.WorkerStatusOffline),
+	},
+	reflect.TypeOf(domain.ConcurrencyPolicy("")): {
+		string(domain.ConcurrencyQueue), string(domain.ConcurrencyCancelPending), string(domain.ConcurrencyCancelInProgress),
+	},
+	reflect.TypeOf(domain.MissedRunPolicy("")): {
+		string(domain.MissedRunSkip), string(domain.MissedRunOnce), string(domain.MissedRunAll),
+	},
+}
+
+// customSchemas covers types with a hand written JSON encoding.
+var customSchemas = map[reflect.Type]*jsonSchema{
+	reflect.TypeOf(domain.Matrix{}): {
+		Type:                 "object",
+		Description:          "Every key other than include, exclude and fail_fast is an axis with a list of values; include and exclude are lists of combinations, fail_fast is a boolean.",
+		AdditionalProperties: &jsonSchema{},
+	},
+}
+
+var (
+	timeType   = reflect.TypeOf(time.Time{})
+	domainPath = reflect.TypeOf(domain.Build{}).PkgPath()
+)
+
+// schemaRegistry turns Go types into schemas, registering named structs as
+// components so recursive types such as Build.Children terminate.
+type schemaRegistry struct {
+	schemas map[string]*jsonSchema
+}
+
+func (r *schemaRegistry) schemaOf(t reflect.Type) *jsonSchema {
+	if t.Kind() == reflect.Pointer {
+		s := r.schemaOf(t.Elem())
+		if s.Ref == "" {
+			s.Nullable = true
+		}
+		return s
+	}
+
+	if values, ok := enumValues[t]; ok {
+		return &jsonSchema{Type: "string", Enum: values}
+	}
+	if t == timeType {
+		return &jsonSchema{Type: "string", Format: "date-time"}
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		return &jsonSchema{Type: "string"}
+	case reflect.Bool:
+		return &jsonSchema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32:
+		return &jsonSchema{Type: "integer", Format: "int32"}
+	case reflect.Int64, reflect.Uint64:
+		return &jsonSchema{Type: "integer", Format: "int64"}
+	case reflect.Float32, reflect.Float64:
+		return &jsonSchema{Type: "number"}
+	case reflect.Slice, reflect.Array:
+		return &jsonSchema{Type: "array", Items: r.schemaOf(t.Elem())}
+	case reflect.Map:
+		return &jsonSchema{Type: "object", AdditionalProperties: r.schemaOf(t.Elem())}
+	case reflect.Interface:
+		return &jsonSchema{}
+	case reflect.Struct:
+		if t.Name() == "" {
+			return r.structSchema(t)
+		}
+		name := componentName(t)
+		if _, ok := r.schemas[name]; !ok {
+			// Reserve the name first so self references resolve to it.
+			r.schemas[name] = &jsonSchema{}
+			if custom, ok := customSchemas[t]; ok {
+				*r.schemas[name] = *custom
+			} else {
+				*r.schemas[name] = *r.structSchema(t)
+			}
+		}
+		return &jsonSchema{Ref: "#/components/schemas/" + name}
+	}
+
+	return &jsonSchema{}
+}
+
+func (r *schemaRegistry) structSchema(t reflect.Type) *jsonSchema {
+	s := &jsonSchema{Type: "object", Properties: map[string]*jsonSchema{}}
+	r.addFields(s, t)
+	return s
+}
+
+// addFields follows encoding/json: embedded structs without a tag are
+// flattened and fields tagged "-" are skipped.
+func (r *schemaRegistry) addFields(s *jsonSchema, t reflect.Type) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, _, _ := strings.Cut(tag, ",")
+
+		if field.Anonymous && name == "" {
+			embedded := field.Type
+			if embedded.Kind() == reflect.Pointer {
+				embedded = embedded.Elem()
+			}
+			if embedded.Kind() == reflect.Struct {
+				r.addFields(s, embedded)
+				continue
+			}
+		}
+		if !field.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+
+		s.Properties[name] = r.schemaOf(field.Type)
+		if strings.Contains(field.Tag.Get("binding"), "required") {
+			s.Required = append(s.Required, name)
+		}
+	}
+}
+
+// componentName exports the Go type name, prefixing types outside the domain
+// package that would otherwise clash with a domain type.
+func componentName(t reflect.Type) string {
+	name := t.Name()
+	if t.PkgPath() == domainPath {
+		return name
+	}
+	return strings.ToUpper(name[:1]) + name[1:]
+}
+
+var ginParam = regexp.MustCompile(`[:*]([A-Za-z_]+)`)
+
+// openAPIPathOf converts a gin route pattern such as /builds/:id/artifacts/*path
+// into the OpenAPI form /builds/{id}/artifacts/{path}.
+func openAPIPathOf(path string) string {
+	return ginParam.ReplaceAllString(path, "{$1}")
+}
+
+func newOpenAPIDocument() *openAPIDocument {
+	registry := &schemaRegistry{schemas: map[string]*jsonSchema{}}
+	errorSchema := registry.schemaOf(reflect.TypeOf(errorEnvelope{}))
+
+	doc := &openAPIDocument{
+		OpenAPI:  "3.0.3",
+		Info:     openAPIInfo{Title: "ci-orchestrator", Version: "v1"},
+		Security: []map[string][]string{{"bearerAuth": {}}},
+		Paths:    map[string]map[string]*openAPIOperation{},
+		Components: openAPIComponents{
+			Schemas: registry.schemas,
+			SecuritySchemes: map[string]map[string]string{
+				"bearerAuth": {"type": "http", "scheme": "bearer"},
+			},
+		},
+	}
+
+	for _, op := range apiOperations {
+		operation := &openAPIOperation{
+			OperationID: op.ID,
+			Summary:     op.Summary,
+			Tags:        []string{op.Tag},
+			Responses:   map[string]*openAPIResponse{},
+		}
+
+		if op.Public {
+			operation.Security = &[]map[string][]string{}
+		} else {
+			operation.Description = "Requires the `" + string(op.Scope) + "` scope."
+		}
+
+		for _, match := range ginParam.FindAllStringSubmatch(op.Path, -1) {
+			operation.Parameters = append(operation.Parameters, openAPIParameter{
+				Name: match[1], In: "path", Required: true, Schema: &jsonSchema{Type: "string"},
+			})
+		}
+		for _, param := range op.Query {
+			operation.Parameters = append(operation.Parameters, openAPIParameter{
+				Name: param.Name, In: "query", Description: param.Description, Schema: param.Schema,
+			})
+		}
+
+		switch {
+		case op.Request != nil:
+			operation.RequestBody = &openAPIRequestBody{
+				Required: true,
+				Content:  map[string]openAPIMediaType{"application/json": {Schema: registry.schemaOf(reflect.TypeOf(op.Request))}},
+			}
+		case op.RequestType != "":
+			operation.RequestBody = &openAPIRequestBody{
+				Required: true,
+				Content:  map[string]openAPIMediaType{op.RequestType: {Schema: &jsonSchema{Type: "string", Format: "binary"}}},
+			}
+		}
+
+		for _, resp := range op.Responses {
+			response := &openAPIResponse{Description: resp.Description}
+			switch {
+			case resp.Body != nil:
+				response.Content = map[string]openAPIMediaType{"application/json": {Schema: registry.schemaOf(reflect.TypeOf(resp.Body))}}
+			case resp.ContentType != "":
+				response.Content = map[string]openAPIMediaType{resp.ContentType: {Schema: &jsonSchema{Type: "string", Format: "binary"}}}
+			}
+			operation.Responses[strconv.Itoa(resp.Status)] = response
+		}
+		operation.Responses["default"] = &openAPIResponse{
+			Description: "Error envelope",
+			Content:     map[string]openAPIMediaType{"application/json": {Schema: errorSchema}},
+		}
+
+		path := openAPIPathOf(op.Path)
+		if doc.Paths[path] == nil {
+			doc.Paths[path] = map[string]*openAPIOperation{}
+		}
+		doc.Paths[path][strings.ToLower(op.Method)] = operation
+	}
+
+	return doc
+}
+
+var openAPIJSON = sync.OnceValues(func() ([]byte, error) {
+	return json.Marshal(newOpenAPIDocument())
+})
+
+func serveOpenAPI(c *gin.Context) {
+	body, err := openAPIJSON()
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.Data(http.StatusOK, "application/json", body)
+}
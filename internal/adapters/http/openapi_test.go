@@ -0,0 +1,109 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/H3nSte1n/ci-orchestrator/internal/core/domain"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func registeredRoutes(t *testing.T) gin.RoutesInfo {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	router := NewRouter(&BuildController{}, &ArtifactController{}, &LogController{}, &TokenController{}, &WorkerController{}, &FleetController{}, &RepoLimitController{}, &ScheduleController{}, nil)
+	router.RegisterRoutes()
+	return router.engine.Routes()
+}
+
+func TestOpenAPI_CoversRegisteredRoutes(t *testing.T) {
+	doc := newOpenAPIDocument()
+
+	for _, route := range registeredRoutes(t) {
+		operations, ok := doc.Paths[openAPIPathOf(route.Path)]
+		if !assert.True(t, ok, "no OpenAPI path for %s %s", route.Method, route.Path) {
+			continue
+		}
+		assert.Contains(t, operations, strings.ToLower(route.Method), "no OpenAPI operation for %s %s", route.Method, route.Path)
+	}
+}
+
+func TestOpenAPI_HasNoUnregisteredRoutes(t *testing.T) {
+	registered := map[string]bool{}
+	for _, route := range registeredRoutes(t) {
+		registered[route.Method+" "+route.Path] = true
+	}
+
+	for _, op := range apiOperations {
+		assert.True(t, registered[op.Method+" "+op.Path], "OpenAPI documents unregistered route %s %s", op.Method, op.Path)
+	}
+}
+
+func TestOpenAPI_OperationIDsAreUnique(t *testing.T) {
+	seen := map[string]bool{}
+	for _, op := range apiOperations {
+		assert.False(t, seen[op.ID], "duplicate operation id %s", op.ID)
+		seen[op.ID] = true
+	}
+}
+
+func TestOpenAPIPathOf(t *testing.T) {
+	assert.Equal(t, "/api/v1/builds/{id}/artifacts/{path}", openAPIPathOf("/api/v1/builds/:id/artifacts/*path"))
+	assert.Equal(t, "/api/v1/builds", openAPIPathOf("/api/v1/builds"))
+}
+
+func TestSchemaRegistry_DomainTypes(t *testing.T) {
+	registry := &schemaRegistry{schemas: map[string]*jsonSchema{}}
+
+	ref := registry.schemaOf(reflect.TypeOf(buildResponse{}))
+
+	assert.Equal(t, "#/components/schemas/BuildResponse", ref.Ref)
+	response := registry.schemas["BuildResponse"]
+	require.NotNil(t, response)
+	assert.Contains(t, response.Properties, "repo_url", "embedded build fields are flattened")
+	assert.Contains(t, response.Properties, "links")
+
+	build := registry.schemas["Build"]
+	require.NotNil(t, build)
+	assert.Equal(t, "#/components/schemas/Build", build.Properties["children"].Items.Ref)
+	assert.Equal(t, []string{"pending", "running", "success", "failed", "canceled"}, build.Properties["status"].Enum)
+	assert.Equal(t, "date-time", build.Properties["created_at"].Format)
+	assert.True(t, build.Properties["finished_at"].Nullable)
+	assert.Equal(t, "object", build.Properties["env"].Type)
+	assert.Equal(t, "#/components/schemas/Matrix", build.Properties["matrix"].Ref)
+
+	job := registry.schemas["Job"]
+	require.NotNil(t, job)
+	assert.NotContains(t, job.Properties, "Build", "fields tagged json:\"-\" are skipped")
+}
+
+func TestSchemaRegistry_SkipsSecrets(t *testing.T) {
+	registry := &schemaRegistry{schemas: map[string]*jsonSchema{}}
+
+	registry.schemaOf(reflect.TypeOf(domain.APIToken{}))
+
+	token := registry.schemas["APIToken"]
+	require.NotNil(t, token)
+	assert.NotContains(t, token.Properties, "token_hash")
+	assert.NotContains(t, token.Properties, "TokenHash")
+}
+
+func TestServeOpenAPI(t *testing.T) {
+	router := newTestRouter()
+	router.GET(openAPIPath, serveOpenAPI)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, openAPIPath, nil))
+
+	require.Equal(t, http.StatusOK, w.Code)
+	var doc map[string]interface{}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &doc))
+	assert.Equal(t, "3.0.3", doc["openapi"])
+	assert.Contains(t, doc["paths"], "/api/v1/builds/{id}")
+}
@@ -0,0 +1,55 @@
+package http
+
+import (
+	"github.com/H3nSte1n/ci-orchestrator/internal/core/domain"
+	"github.com/H3nSte1n/ci-orchestrator/internal/core/ports"
+	"github.com/gin-gonic/gin"
+	"net/http"
+)
+
+// RepoLimitController shows and sets how many builds of one repository may
+// run at once.
+type RepoLimitController struct {
+	limitService ports.RepoLimitService
+}
+
+func NewRepoLimitController(limitService ports.RepoLimitService) *RepoLimitController {
+	return &RepoLimitController{
+		limitService: limitService,
+	}
+}
+
+type setRepoLimitRequest struct {
+	RepoUrl          string `json:"repo_url"`
+	MaxRunningBuilds int    `json:"max_running_builds"`
+}
+
+type listRepoLimitsResponse struct {
+	Limits []domain.RepoLimit `json:"limits"`
+}
+
+func (rc *RepoLimitController) ListRepoLimits(c *gin.Context) {
+	limits, err := rc.limitService.ListRepoLimits(c.Request.Context())
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, listRepoLimitsResponse{Limits: limits})
+}
+
+func (rc *RepoLimitController) SetRepoLimit(c *gin.Context) {
+	var req setRepoLimitRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		_ = c.Error(withDetails(invalidArgument("invalid request body"), err.Error()))
+		return
+	}
+
+	limit := &domain.RepoLimit{RepoUrl: req.RepoUrl, MaxRunningBuilds: req.MaxRunningBuilds}
+	if err := rc.limitService.SetRepoLimit(c.Request.Context(), limit); err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, limit)
+}
@@ -0,0 +1,73 @@
+package http
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/H3nSte1n/ci-orchestrator/internal/core/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type mockRepoLimitService struct {
+	mock.Mock
+}
+
+func (m *mockRepoLimitService) SetRepoLimit(ctx context.Context, limit *domain.RepoLimit) error {
+	args := m.Called(ctx, limit)
+	return args.Error(0)
+}
+
+func (m *mockRepoLimitService) ListRepoLimits(ctx context.Context) ([]domain.RepoLimit, error) {
+	args := m.Called(ctx)
+	if limits := args.Get(0); limits != nil {
+		return limits.([]domain.RepoLimit), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func TestRepoLimitController_ListRepoLimits(t *testing.T) {
+	limitService := new(mockRepoLimitService)
+	limitService.On("ListRepoLimits", mock.Anything).Return([]domain.RepoLimit{{RepoUrl: "https://github.com/test/repo", MaxRunningBuilds: 2}}, nil)
+
+	router := newTestRouter()
+	router.GET("/repo-limits", NewRepoLimitController(limitService).ListRepoLimits)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/repo-limits", nil))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"limits":[{"repo_url":"https://github.com/test/repo","max_running_builds":2`)
+}
+
+func TestRepoLimitController_SetRepoLimit(t *testing.T) {
+	limitService := new(mockRepoLimitService)
+	limitService.On("SetRepoLimit", mock.Anything, &domain.RepoLimit{RepoUrl: "https://github.com/test/repo", MaxRunningBuilds: 3}).Return(nil)
+
+	router := newTestRouter()
+	router.PUT("/repo-limits", NewRepoLimitController(limitService).SetRepoLimit)
+
+	w := httptest.NewRecorder()
+	body := `{"repo_url":"https://github.com/test/repo","max_running_builds":3}`
+	router.ServeHTTP(w, httptest.NewRequest("PUT", "/repo-limits", strings.NewReader(body)))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"max_running_builds":3`)
+	limitService.AssertExpectations(t)
+}
+
+func TestRepoLimitController_SetRepoLimit_Invalid(t *testing.T) {
+	limitService := new(mockRepoLimitService)
+	limitService.On("SetRepoLimit", mock.Anything, mock.Anything).Return(domain.ErrInvalidRepoLimit)
+
+	router := newTestRouter()
+	router.PUT("/repo-limits", NewRepoLimitController(limitService).SetRepoLimit)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("PUT", "/repo-limits", strings.NewReader(`{"repo_url":"","max_running_builds":1}`)))
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
@@ -0,0 +1,97 @@
+package http
+
+import (
+	"github.com/H3nSte1n/ci-orchestrator/internal/core/domain"
+	"github.com/H3nSte1n/ci-orchestrator/internal/core/ports"
+	"github.com/gin-gonic/gin"
+	"net/http"
+)
+
+// ScheduleController manages the schedules that start builds from cron
+// expressions.
+type ScheduleController struct {
+	scheduleService ports.ScheduleService
+}
+
+func NewScheduleController(scheduleService ports.ScheduleService) *ScheduleController {
+	return &ScheduleController{
+		scheduleService: scheduleService,
+	}
+}
+
+type createScheduleRequest struct {
+	Name            string                 `json:"name"`
+	RepoUrl         string                 `json:"repo_url"`
+	Ref             string                 `json:"ref"`
+	Command         string                 `json:"command"`
+	Env             map[string]string      `json:"env"`
+	Cron            string                 `json:"cron"`
+	Timezone        string                 `json:"timezone"`
+	MissedRunPolicy domain.MissedRunPolicy `json:"missed_run_policy"`
+}
+
+type listSchedulesResponse struct {
+	Schedules []domain.Schedule `json:"schedules"`
+}
+
+func (r *createScheduleRequest) toDomain() *domain.Schedule {
+	return &domain.Schedule{
+		Name:            r.Name,
+		RepoUrl:         r.RepoUrl,
+		Ref:             r.Ref,
+		Command:         r.Command,
+		Env:             domain.StringMap(r.Env),
+		Cron:            r.Cron,
+		Timezone:        r.Timezone,
+		MissedRunPolicy: r.MissedRunPolicy,
+	}
+}
+
+func (sc *ScheduleController) CreateSchedule(c *gin.Context) {
+	var req createScheduleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		_ = c.Error(withDetails(invalidArgument("invalid request body"), err.Error()))
+		return
+	}
+
+	schedule := req.toDomain()
+	if fieldErrors := domain.ValidateNewSchedule(schedule); len(fieldErrors) > 0 {
+		_ = c.Error(validationFailed(fieldErrors))
+		return
+	}
+	if err := sc.scheduleService.CreateSchedule(c.Request.Context(), schedule); err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, schedule)
+}
+
+func (sc *ScheduleController) ListSchedules(c *gin.Context) {
+	schedules, err := sc.scheduleService.ListSchedules(c.Request.Context())
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, listSchedulesResponse{Schedules: schedules})
+}
+
+func (sc *ScheduleController) GetSchedule(c *gin.Context) {
+	schedule, err := sc.scheduleService.GetSchedule(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, schedule)
+}
+
+func (sc *ScheduleController) DeleteSchedule(c *gin.Context) {
+	if err := sc.scheduleService.DeleteSchedule(c.Request.Context(), c.Param("id")); err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
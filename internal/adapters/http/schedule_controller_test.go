@@ -0,0 +1,126 @@
+package http
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/H3nSte1n/ci-orchestrator/internal/core/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type mockScheduleService struct {
+	mock.Mock
+}
+
+func (m *mockScheduleService) CreateSchedule(ctx context.Context, schedule *domain.Schedule) error {
+	args := m.Called(ctx, schedule)
+	return args.Error(0)
+}
+
+func (m *mockScheduleService) GetSchedule(ctx context.Context, scheduleId string) (*domain.Schedule, error) {
+	args := m.Called(ctx, scheduleId)
+	if schedule := args.Get(0); schedule != nil {
+		return schedule.(*domain.Schedule), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *mockScheduleService) ListSchedules(ctx context.Context) ([]domain.Schedule, error) {
+	args := m.Called(ctx)
+	if schedules := args.Get(0); schedules != nil {
+		return schedules.([]domain.Schedule), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *mockScheduleService) DeleteSchedule(ctx context.Context, scheduleId string) error {
+	args := m.Called(ctx, scheduleId)
+	return args.Error(0)
+}
+
+func (m *mockScheduleService) RunDue(ctx context.Context, now time.Time, grace time.Duration) (int, error) {
+	args := m.Called(ctx, now, grace)
+	return args.Int(0), args.Error(1)
+}
+
+func TestScheduleController_CreateSchedule(t *testing.T) {
+	scheduleService := new(mockScheduleService)
+	scheduleService.On("CreateSchedule", mock.Anything, mock.MatchedBy(func(s *domain.Schedule) bool {
+		return s.Name == "nightly" && s.Cron == "0 3 * * *" && s.Timezone == "Europe/Berlin" && s.Env["SUITE"] == "full"
+	})).Run(func(args mock.Arguments) {
+		args.Get(1).(*domain.Schedule).ID = "schedule-id"
+	}).Return(nil)
+
+	router := newTestRouter()
+	router.POST("/schedules", NewScheduleController(scheduleService).CreateSchedule)
+
+	w := httptest.NewRecorder()
+	body := `{"name":"nightly","repo_url":"https://github.com/test/repo","ref":"main","command":"make test","env":{"SUITE":"full"},"cron":"0 3 * * *","timezone":"Europe/Berlin"}`
+	router.ServeHTTP(w, httptest.NewRequest("POST", "/schedules", strings.NewReader(body)))
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+	assert.Contains(t, w.Body.String(), `"id":"schedule-id"`)
+	scheduleService.AssertExpectations(t)
+}
+
+func TestScheduleController_CreateSchedule_ValidationFailed(t *testing.T) {
+	scheduleService := new(mockScheduleService)
+
+	router := newTestRouter()
+	router.POST("/schedules", NewScheduleController(scheduleService).CreateSchedule)
+
+	w := httptest.NewRecorder()
+	body := `{"name":"nightly","repo_url":"https://github.com/test/repo","ref":"main","command":"make test","cron":"0 25 * * *","timezone":"Mars/Olympus"}`
+	router.ServeHTTP(w, httptest.NewRequest("POST", "/schedules", strings.NewReader(body)))
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), `"field":"cron"`)
+	assert.Contains(t, w.Body.String(), `"field":"timezone"`)
+	scheduleService.AssertNotCalled(t, "CreateSchedule", mock.Anything, mock.Anything)
+}
+
+func TestScheduleController_ListSchedules(t *testing.T) {
+	scheduleService := new(mockScheduleService)
+	scheduleService.On("ListSchedules", mock.Anything).Return([]domain.Schedule{{ID: "schedule-id", Name: "nightly"}}, nil)
+
+	router := newTestRouter()
+	router.GET("/schedules", NewScheduleController(scheduleService).ListSchedules)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/schedules", nil))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"schedules":[{"id":"schedule-id","name":"nightly"`)
+}
+
+func TestScheduleController_GetSchedule_NotFound(t *testing.T) {
+	scheduleService := new(mockScheduleService)
+	scheduleService.On("GetSchedule", mock.Anything, "missing").Return(nil, domain.ErrScheduleNotFound)
+
+	router := newTestRouter()
+	router.GET("/schedules/:id", NewScheduleController(scheduleService).GetSchedule)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/schedules/missing", nil))
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestScheduleController_DeleteSchedule(t *testing.T) {
+	scheduleService := new(mockScheduleService)
+	scheduleService.On("DeleteSchedule", mock.Anything, "schedule-id").Return(nil)
+
+	router := newTestRouter()
+	router.DELETE("/schedules/:id", NewScheduleController(scheduleService).DeleteSchedule)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("DELETE", "/schedules/schedule-id", nil))
+
+	assert.Equal(t, http.StatusNoContent, w.Code)
+	scheduleService.AssertExpectations(t)
+}
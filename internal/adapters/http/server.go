@@ -1,35 +1,111 @@
 package http
 
 import (
+	"github.com/H3nSte1n/ci-orchestrator/internal/core/domain"
+	"github.com/H3nSte1n/ci-orchestrator/internal/core/ports"
 	"github.com/gin-gonic/gin"
 )
 
 type Router struct {
-	engine     *gin.Engine
-	controller *BuildController
+	engine             *gin.Engine
+	controller         *BuildController
+	artifactController *ArtifactController
+	logController      *LogController
+	tokenController    *TokenController
+	workerController   *WorkerController
+	fleetController    *FleetController
+	limitController    *RepoLimitController
+	scheduleController *ScheduleController
+	tokenService       ports.APITokenService
 }
 
-func NewRouter(controller *BuildController) *Router {
+func NewRouter(controller *BuildController, artifactController *ArtifactController, logController *LogController, tokenController *TokenController, workerController *WorkerController, fleetController *FleetController, limitController *RepoLimitController, scheduleController *ScheduleController, tokenService ports.APITokenService) *Router {
 	engine := gin.Default()
 
 	engine.Use(gin.Recovery())
 	engine.Use(gin.Logger())
+	engine.Use(RequestID())
+	engine.Use(ErrorHandler())
+	engine.NoRoute(routeNotFound)
 
 	return &Router{
-		engine:     engine,
-		controller: controller,
+		engine:             engine,
+		controller:         controller,
+		artifactController: artifactController,
+		logController:      logController,
+		tokenController:    tokenController,
+		workerController:   workerController,
+		fleetController:    fleetController,
+		limitController:    limitController,
+		scheduleController: scheduleController,
+		tokenService:       tokenService,
 	}
 }
 
 func (r *Router) RegisterRoutes() {
-	v1 := r.engine.Group("/api/v1")
+	r.engine.GET(openAPIPath, serveOpenAPI)
+
+	v1 := r.engine.Group("/api/v1", Authenticate(r.tokenService))
 	{
+		read := RequireScope(domain.ScopeBuildsRead)
+
 		builds := v1.Group("/builds")
 		{
-			builds.POST("", r.controller.CreateBuild)
-			builds.GET("/:id", r.controller.GetBuild)
-			builds.PATCH("/:id/status", r.controller.UpdateStatus)
-			builds.POST("/:id/cancel", r.controller.CancelBuild)
+			builds.POST("", RequireScope(domain.ScopeBuildsWrite), r.controller.CreateBuild)
+			builds.GET("", read, r.controller.ListBuilds)
+			builds.GET("/:id", read, r.controller.GetBuild)
+			builds.PATCH("/:id/status", RequireScope(domain.ScopeAdmin), r.controller.UpdateStatus)
+			builds.PUT("/:id/priority", RequireScope(domain.ScopeBuildsWrite), r.controller.UpdatePriority)
+			builds.POST("/:id/cancel", RequireScope(domain.ScopeBuildsCancel), r.controller.CancelBuild)
+			builds.POST("/:id/retry", RequireScope(domain.ScopeBuildsWrite), r.controller.RetryBuild)
+			builds.GET("/:id/logs", read, r.logController.ListLogs)
+			builds.GET("/:id/artifacts", read, r.artifactController.ListArtifacts)
+			builds.GET("/:id/artifacts/*path", read, r.artifactController.DownloadArtifact)
+		}
+
+		tokens := v1.Group("/tokens", RequireScope(domain.ScopeAdmin))
+		{
+			tokens.POST("", r.tokenController.CreateToken)
+			tokens.GET("", r.tokenController.ListTokens)
+			tokens.DELETE("/:id", r.tokenController.RevokeToken)
+		}
+
+		workers := v1.Group("/workers")
+		{
+			admin := RequireScope(domain.ScopeAdmin)
+			workers.GET("", read, r.fleetController.ListWorkers)
+			workers.POST("/:id/drain", admin, r.fleetController.DrainWorker)
+			workers.POST("/:id/pause", admin, r.fleetController.PauseWorker)
+			workers.POST("/:id/resume", admin, r.fleetController.ResumeWorker)
+		}
+
+		v1.GET("/repo-limits", read, r.limitController.ListRepoLimits)
+		v1.PUT("/repo-limits", RequireScope(domain.ScopeAdmin), r.limitController.SetRepoLimit)
+
+		schedules := v1.Group("/schedules")
+		{
+			write := RequireScope(domain.ScopeBuildsWrite)
+			schedules.POST("", write, r.scheduleController.CreateSchedule)
+			schedules.GET("", read, r.scheduleController.ListSchedules)
+			schedules.GET("/:id", read, r.scheduleController.GetSchedule)
+			schedules.DELETE("/:id", write, r.scheduleController.DeleteSchedule)
+		}
+	}
+
+	internal := r.engine.Group("/internal/v1", Authenticate(r.tokenService), RequireScope(domain.ScopeWorker))
+	{
+		internal.POST("/workers/register", r.workerController.RegisterWorker)
+		internal.POST("/workers/heartbeat", r.workerController.WorkerHeartbeat)
+		internal.POST("/workers/deregister", r.workerController.DeregisterWorker)
+
+		jobs := internal.Group("/jobs")
+		{
+			jobs.POST("/claim", r.workerController.ClaimJob)
+			jobs.POST("/:id/heartbeat", r.workerController.Heartbeat)
+			jobs.POST("/:id/logs", r.workerController.AppendLogs)
+			jobs.POST("/:id/complete", r.workerController.CompleteJob)
+			jobs.GET("/:id/cancel", r.workerController.CancelRequested)
+			jobs.PUT("/:id/artifacts/*path", r.workerController.UploadArtifact)
 		}
 	}
 }
@@ -0,0 +1,67 @@
+package http
+
+import (
+	"github.com/H3nSte1n/ci-orchestrator/internal/core/domain"
+	"github.com/H3nSte1n/ci-orchestrator/internal/core/ports"
+	"github.com/gin-gonic/gin"
+	"net/http"
+)
+
+type TokenController struct {
+	tokenService ports.APITokenService
+}
+
+func NewTokenController(tokenService ports.APITokenService) *TokenController {
+	return &TokenController{
+		tokenService: tokenService,
+	}
+}
+
+type createTokenRequest struct {
+	Name   string   `json:"name"`
+	Scopes []string `json:"scopes"`
+}
+
+type createTokenResponse struct {
+	*domain.APIToken
+	Token string `json:"token"`
+}
+
+type listTokensResponse struct {
+	Tokens []domain.APIToken `json:"tokens"`
+}
+
+func (tc *TokenController) CreateToken(c *gin.Context) {
+	var req createTokenRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		_ = c.Error(withDetails(invalidArgument("invalid request body"), err.Error()))
+		return
+	}
+
+	token, secret, err := tc.tokenService.Create(c.Request.Context(), req.Name, req.Scopes)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, createTokenResponse{APIToken: token, Token: secret})
+}
+
+func (tc *TokenController) ListTokens(c *gin.Context) {
+	tokens, err := tc.tokenService.List(c.Request.Context())
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, listTokensResponse{Tokens: tokens})
+}
+
+func (tc *TokenController) RevokeToken(c *gin.Context) {
+	if err := tc.tokenService.Revoke(c.Request.Context(), c.Param("id")); err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
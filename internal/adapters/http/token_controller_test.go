@@ -0,0 +1,76 @@
+package http
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/H3nSte1n/ci-orchestrator/internal/core/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestTokenController_CreateToken_Success(t *testing.T) {
+	tokens := new(mockAPITokenService)
+	tokens.On("Create", mock.Anything, "deploy-bot", []string{"builds:write"}).
+		Return(&domain.APIToken{ID: "token-id", Name: "deploy-bot", TokenHash: "hash"}, "cio_secret", nil)
+
+	router := newTestRouter()
+	router.POST("/tokens", NewTokenController(tokens).CreateToken)
+
+	body := []byte(`{"name":"deploy-bot","scopes":["builds:write"]}`)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("POST", "/tokens", bytes.NewReader(body)))
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+	assert.Contains(t, w.Body.String(), `"token":"cio_secret"`)
+	assert.Contains(t, w.Body.String(), `"id":"token-id"`)
+	assert.NotContains(t, w.Body.String(), "hash")
+}
+
+func TestTokenController_CreateToken_InvalidScope(t *testing.T) {
+	tokens := new(mockAPITokenService)
+	tokens.On("Create", mock.Anything, "bot", []string{"nope"}).Return(nil, "", domain.ErrInvalidScope)
+
+	router := newTestRouter()
+	router.POST("/tokens", NewTokenController(tokens).CreateToken)
+
+	body := []byte(`{"name":"bot","scopes":["nope"]}`)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("POST", "/tokens", bytes.NewReader(body)))
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), "invalid scope")
+}
+
+func TestTokenController_ListTokens(t *testing.T) {
+	tokens := new(mockAPITokenService)
+	tokens.On("List", mock.Anything).Return([]domain.APIToken{{ID: "token-id", Name: "bot"}}, nil)
+
+	router := newTestRouter()
+	router.GET("/tokens", NewTokenController(tokens).ListTokens)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/tokens", nil))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"tokens":[{"id":"token-id"`)
+}
+
+func TestTokenController_RevokeToken(t *testing.T) {
+	tokens := new(mockAPITokenService)
+	tokens.On("Revoke", mock.Anything, "token-id").Return(nil)
+	tokens.On("Revoke", mock.Anything, "missing").Return(domain.ErrTokenNotFound)
+
+	router := newTestRouter()
+	router.DELETE("/tokens/:id", NewTokenController(tokens).RevokeToken)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("DELETE", "/tokens/token-id", nil))
+	assert.Equal(t, http.StatusNoContent, w.Code)
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("DELETE", "/tokens/missing", nil))
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
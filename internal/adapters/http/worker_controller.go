@@ -0,0 +1,248 @@
+package http
+
+import (
+	"errors"
+	"github.com/H3nSte1n/ci-orchestrator/internal/core/domain"
+	"github.com/H3nSte1n/ci-orchestrator/internal/core/ports"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// maxLogBatch caps the number of log lines a worker sends per request.
+const maxLogBatch = 1000
+
+// WorkerController serves the internal API that workers use instead of
+// connecting to the database. The worker id is the name of the calling
+// token, and workers can only touch jobs they have claimed.
+type WorkerController struct {
+	buildService    ports.BuildService
+	buildLogService ports.BuildLogService
+	artifactService ports.ArtifactService
+	workerService   ports.WorkerService
+}
+
+func NewWorkerController(buildService ports.BuildService, buildLogService ports.BuildLogService, artifactService ports.ArtifactService, workerService ports.WorkerService) *WorkerController {
+	return &WorkerController{
+		buildService:    buildService,
+		buildLogService: buildLogService,
+		artifactService: artifactService,
+		workerService:   workerService,
+	}
+}
+
+// claimedJob is the wire format of a claimed job; the build travels next to
+// the job because domain.Job does not serialize it.
+type claimedJob struct {
+	Job   *domain.Job   `json:"job"`
+	Build *domain.Build `json:"build"`
+}
+
+type registerWorkerRequest struct {
+	Hostname string   `json:"hostname"`
+	Version  string   `json:"version"`
+	Labels   []string `json:"labels"`
+	Slots    int      `json:"slots"`
+}
+
+type appendLogsRequest struct {
+	Events []domain.LogEvent `json:"events"`
+}
+
+type completeJobRequest struct {
+	ExitCode   int        `json:"exit_code"`
+	FinishedAt *time.Time `json:"finished_at"`
+	Error      string     `json:"error"`
+	// Canceled reports that the worker stopped the job on a cancel request.
+	Canceled bool `json:"canceled"`
+}
+
+type cancelRequestedResponse struct {
+	CancelRequested bool `json:"cancel_requested"`
+}
+
+func (wc *WorkerController) RegisterWorker(c *gin.Context) {
+	var req registerWorkerRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		_ = c.Error(withDetails(invalidArgument("invalid request body"), err.Error()))
+		return
+	}
+
+	worker := &domain.Worker{
+		ID:       workerID(c),
+		Hostname: req.Hostname,
+		Version:  req.Version,
+		Labels:   domain.StringList(req.Labels),
+		Slots:    req.Slots,
+	}
+	if err := wc.workerService.Register(c.Request.Context(), worker); err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (wc *WorkerController) WorkerHeartbeat(c *gin.Context) {
+	worker, err := wc.workerService.Heartbeat(c.Request.Context(), workerID(c))
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, worker)
+}
+
+func (wc *WorkerController) DeregisterWorker(c *gin.Context) {
+	if err := wc.workerService.Deregister(c.Request.Context(), workerID(c)); err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (wc *WorkerController) ClaimJob(c *gin.Context) {
+	job, err := wc.buildService.ClaimNext(c.Request.Context(), workerID(c))
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	if job == nil {
+		c.Status(http.StatusNoContent)
+		return
+	}
+
+	c.JSON(http.StatusOK, claimedJob{Job: job, Build: job.Build})
+}
+
+func (wc *WorkerController) Heartbeat(c *gin.Context) {
+	if err := wc.buildService.Heartbeat(c.Request.Context(), c.Param("id"), workerID(c)); err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (wc *WorkerController) AppendLogs(c *gin.Context) {
+	job, ok := wc.ownedJob(c)
+	if !ok {
+		return
+	}
+
+	var req appendLogsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		_ = c.Error(withDetails(invalidArgument("invalid request body"), err.Error()))
+		return
+	}
+	if len(req.Events) > maxLogBatch {
+		_ = c.Error(invalidArgument("too many log events in one batch"))
+		return
+	}
+
+	if err := wc.buildLogService.AppendLogs(c.Request.Context(), job.BuildID, job.ID, req.Events); err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (wc *WorkerController) CompleteJob(c *gin.Context) {
+	job, ok := wc.ownedJob(c)
+	if !ok {
+		return
+	}
+
+	var req completeJobRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		_ = c.Error(withDetails(invalidArgument("invalid request body"), err.Error()))
+		return
+	}
+
+	var runErr error
+	switch {
+	case req.Canceled:
+		runErr = domain.ErrJobCanceled
+	case req.Error != "":
+		runErr = errors.New(req.Error)
+	}
+
+	if err := wc.buildService.CompleteJob(c.Request.Context(), job.ID, workerID(c), req.ExitCode, req.FinishedAt, runErr); err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (wc *WorkerController) CancelRequested(c *gin.Context) {
+	job, ok := wc.ownedJob(c)
+	if !ok {
+		return
+	}
+
+	requested, err := wc.buildService.CancelRequested(c.Request.Context(), job.ID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, cancelRequestedResponse{CancelRequested: requested})
+}
+
+func (wc *WorkerController) UploadArtifact(c *gin.Context) {
+	job, ok := wc.ownedJob(c)
+	if !ok {
+		return
+	}
+
+	name := strings.TrimPrefix(c.Param("path"), "/")
+	if name == "" {
+		_ = c.Error(invalidArgument("artifact name is required"))
+		return
+	}
+	if c.Request.ContentLength < 0 {
+		_ = c.Error(invalidArgument("Content-Length is required"))
+		return
+	}
+
+	artifact, err := wc.artifactService.Store(c.Request.Context(), job, name, c.Request.Body, c.Request.ContentLength)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, artifact)
+}
+
+// ownedJob loads the job named in the path and reports it as not found when
+// another worker holds it, and as a conflict when it is no longer running.
+func (wc *WorkerController) ownedJob(c *gin.Context) (*domain.Job, bool) {
+	job, err := wc.buildService.GetJob(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		_ = c.Error(err)
+		return nil, false
+	}
+
+	if job.LockedBy == nil || *job.LockedBy != workerID(c) {
+		_ = c.Error(domain.ErrJobNotFound)
+		return nil, false
+	}
+	if job.Status != domain.JobStatusRunning {
+		_ = c.Error(domain.ErrJobNotRunning)
+		return nil, false
+	}
+
+	return job, true
+}
+
+func workerID(c *gin.Context) string {
+	if token := currentToken(c); token != nil {
+		return token.Name
+	}
+	return ""
+}
@@ -0,0 +1,292 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/H3nSte1n/ci-orchestrator/internal/core/domain"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type mockBuildLogService struct {
+	mock.Mock
+}
+
+func (m *mockBuildLogService) AppendLog(ctx context.Context, buildId string, jobId string, logEvent domain.LogEvent) error {
+	args := m.Called(ctx, buildId, jobId, logEvent)
+	return args.Error(0)
+}
+
+func (m *mockBuildLogService) AppendLogs(ctx context.Context, buildId string, jobId string, logEvents []domain.LogEvent) error {
+	args := m.Called(ctx, buildId, jobId, logEvents)
+	return args.Error(0)
+}
+
+func (m *mockBuildLogService) ListLogs(ctx context.Context, buildId string, afterSeq int64, limit int) ([]domain.BuildLog, error) {
+	args := m.Called(ctx, buildId, afterSeq, limit)
+	logs, _ := args.Get(0).([]domain.BuildLog)
+	return logs, args.Error(1)
+}
+
+type mockWorkerService struct {
+	mock.Mock
+}
+
+func (m *mockWorkerService) Register(ctx context.Context, worker *domain.Worker) error {
+	args := m.Called(ctx, worker)
+	return args.Error(0)
+}
+
+func (m *mockWorkerService) Heartbeat(ctx context.Context, workerId string) (*domain.Worker, error) {
+	args := m.Called(ctx, workerId)
+	worker, _ := args.Get(0).(*domain.Worker)
+	return worker, args.Error(1)
+}
+
+func (m *mockWorkerService) Deregister(ctx context.Context, workerId string) error {
+	args := m.Called(ctx, workerId)
+	return args.Error(0)
+}
+
+func (m *mockWorkerService) ListWorkers(ctx context.Context) ([]domain.Worker, error) {
+	args := m.Called(ctx)
+	workers, _ := args.Get(0).([]domain.Worker)
+	return workers, args.Error(1)
+}
+
+func (m *mockWorkerService) SetState(ctx context.Context, workerId string, state domain.WorkerState) (*domain.Worker, error) {
+	args := m.Called(ctx, workerId, state)
+	worker, _ := args.Get(0).(*domain.Worker)
+	return worker, args.Error(1)
+}
+
+// newWorkerTestRouter serves the worker routes as the worker named "worker-1".
+func newWorkerTestRouter(wc *WorkerController) *gin.Engine {
+	router := newTestRouter()
+	asWorker := func(c *gin.Context) {
+		c.Set(apiTokenKey, &domain.APIToken{Name: "worker-1", Scopes: domain.StringList{"worker"}})
+	}
+	router.POST("/workers/register", asWorker, wc.RegisterWorker)
+	router.POST("/workers/heartbeat", asWorker, wc.WorkerHeartbeat)
+	router.POST("/workers/deregister", asWorker, wc.DeregisterWorker)
+	jobs := router.Group("/jobs", asWorker)
+	jobs.POST("/claim", wc.ClaimJob)
+	jobs.POST("/:id/heartbeat", wc.Heartbeat)
+	jobs.POST("/:id/logs", wc.AppendLogs)
+	jobs.POST("/:id/complete", wc.CompleteJob)
+	jobs.GET("/:id/cancel", wc.CancelRequested)
+	jobs.PUT("/:id/artifacts/*path", wc.UploadArtifact)
+	return router
+}
+
+func claimedJobTestData(lockedBy string) *domain.Job {
+	return &domain.Job{
+		ID:       "job-id",
+		BuildID:  "ci-id",
+		Name:     domain.DefaultJobName,
+		Status:   domain.JobStatusRunning,
+		LockedBy: &lockedBy,
+		Build:    &domain.Build{ID: "ci-id", RepoUrl: "https://github.com/test/repo", Ref: "main"},
+	}
+}
+
+func TestWorkerController_RegisterWorker(t *testing.T) {
+	workerService := new(mockWorkerService)
+	workerService.On("Register", mock.Anything, &domain.Worker{
+		ID:       "worker-1",
+		Hostname: "host-1",
+		Version:  "v1.2.0",
+		Labels:   domain.StringList{"linux", "arm64"},
+		Slots:    2,
+	}).Return(nil)
+
+	router := newWorkerTestRouter(NewWorkerController(nil, nil, nil, workerService))
+	body := []byte(`{"hostname": "host-1", "version": "v1.2.0", "labels": ["linux", "arm64"], "slots": 2}`)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("POST", "/workers/register", bytes.NewReader(body)))
+
+	assert.Equal(t, http.StatusNoContent, w.Code)
+	workerService.AssertExpectations(t)
+}
+
+func TestWorkerController_WorkerHeartbeat(t *testing.T) {
+	workerService := new(mockWorkerService)
+	workerService.On("Heartbeat", mock.Anything, "worker-1").Return(&domain.Worker{ID: "worker-1", State: domain.WorkerStateDraining}, nil)
+
+	router := newWorkerTestRouter(NewWorkerController(nil, nil, nil, workerService))
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("POST", "/workers/heartbeat", nil))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"state":"draining"`)
+}
+
+func TestWorkerController_DeregisterWorker(t *testing.T) {
+	workerService := new(mockWorkerService)
+	workerService.On("Deregister", mock.Anything, "worker-1").Return(nil)
+
+	router := newWorkerTestRouter(NewWorkerController(nil, nil, nil, workerService))
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("POST", "/workers/deregister", nil))
+
+	assert.Equal(t, http.StatusNoContent, w.Code)
+	workerService.AssertExpectations(t)
+}
+
+func TestWorkerController_ClaimJob(t *testing.T) {
+	buildService := new(mockBuildService)
+	buildService.On("ClaimNext", mock.Anything, "worker-1").Return(claimedJobTestData("worker-1"), nil)
+
+	router := newWorkerTestRouter(NewWorkerController(buildService, nil, nil, nil))
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("POST", "/jobs/claim", nil))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"job":{"id":"job-id"`)
+	assert.Contains(t, w.Body.String(), `"build":{"id":"ci-id","repo_url":"https://github.com/test/repo"`)
+}
+
+func TestWorkerController_ClaimJob_NothingQueued(t *testing.T) {
+	buildService := new(mockBuildService)
+	buildService.On("ClaimNext", mock.Anything, "worker-1").Return(nil, nil)
+
+	router := newWorkerTestRouter(NewWorkerController(buildService, nil, nil, nil))
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("POST", "/jobs/claim", nil))
+
+	assert.Equal(t, http.StatusNoContent, w.Code)
+}
+
+func TestWorkerController_Heartbeat(t *testing.T) {
+	buildService := new(mockBuildService)
+	buildService.On("Heartbeat", mock.Anything, "job-id", "worker-1").Return(nil)
+
+	router := newWorkerTestRouter(NewWorkerController(buildService, nil, nil, nil))
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("POST", "/jobs/job-id/heartbeat", nil))
+
+	assert.Equal(t, http.StatusNoContent, w.Code)
+	buildService.AssertExpectations(t)
+}
+
+func TestWorkerController_AppendLogs(t *testing.T) {
+	buildService := new(mockBuildService)
+	buildService.On("GetJob", mock.Anything, "job-id").Return(claimedJobTestData("worker-1"), nil)
+	logService := new(mockBuildLogService)
+	logService.On("AppendLogs", mock.Anything, "ci-id", "job-id", mock.MatchedBy(func(events []domain.LogEvent) bool {
+		return len(events) == 2 && events[1].Stream == domain.LogStderr && events[1].Line == "oops"
+	})).Return(nil)
+
+	router := newWorkerTestRouter(NewWorkerController(buildService, logService, nil, nil))
+	body := []byte(`{"events":[{"stream":"stdout","line":"hi"},{"stream":"stderr","line":"oops"}]}`)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("POST", "/jobs/job-id/logs", bytes.NewReader(body)))
+
+	assert.Equal(t, http.StatusNoContent, w.Code)
+	logService.AssertExpectations(t)
+}
+
+func TestWorkerController_RejectsJobsOfOtherWorkers(t *testing.T) {
+	buildService := new(mockBuildService)
+	buildService.On("GetJob", mock.Anything, "job-id").Return(claimedJobTestData("worker-2"), nil)
+	logService := new(mockBuildLogService)
+
+	router := newWorkerTestRouter(NewWorkerController(buildService, logService, nil, nil))
+
+	for _, req := range []*http.Request{
+		httptest.NewRequest("POST", "/jobs/job-id/logs", bytes.NewReader([]byte(`{"events":[]}`))),
+		httptest.NewRequest("POST", "/jobs/job-id/complete", bytes.NewReader([]byte(`{"exit_code":0}`))),
+		httptest.NewRequest("GET", "/jobs/job-id/cancel", nil),
+		httptest.NewRequest("PUT", "/jobs/job-id/artifacts/out.txt", bytes.NewReader([]byte("x"))),
+	} {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusNotFound, w.Code, req.URL.Path)
+	}
+	buildService.AssertNotCalled(t, "CompleteJob", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	logService.AssertNotCalled(t, "AppendLogs", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestWorkerController_CompleteJob(t *testing.T) {
+	buildService := new(mockBuildService)
+	buildService.On("GetJob", mock.Anything, "job-id").Return(claimedJobTestData("worker-1"), nil)
+	buildService.On("CompleteJob", mock.Anything, "job-id", "worker-1", 2, mock.Anything, mock.MatchedBy(func(err error) bool {
+		return err != nil && err.Error() == "exit status 2"
+	})).Return(nil)
+
+	router := newWorkerTestRouter(NewWorkerController(buildService, nil, nil, nil))
+	body := []byte(`{"exit_code":2,"finished_at":"2025-01-01T00:00:00Z","error":"exit status 2"}`)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("POST", "/jobs/job-id/complete", bytes.NewReader(body)))
+
+	assert.Equal(t, http.StatusNoContent, w.Code)
+	buildService.AssertExpectations(t)
+}
+
+func TestWorkerController_CompleteJob_Twice(t *testing.T) {
+	buildService := new(mockBuildService)
+	finished := claimedJobTestData("worker-1")
+	finished.Status = domain.JobStatusSuccess
+	buildService.On("GetJob", mock.Anything, "job-id").Return(claimedJobTestData("worker-1"), nil).Once()
+	buildService.On("GetJob", mock.Anything, "job-id").Return(finished, nil).Once()
+	buildService.On("CompleteJob", mock.Anything, "job-id", "worker-1", 0, mock.Anything, nil).Return(nil).Once()
+
+	router := newWorkerTestRouter(NewWorkerController(buildService, nil, nil, nil))
+	body := []byte(`{"exit_code":0,"finished_at":"2025-01-01T00:00:00Z"}`)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("POST", "/jobs/job-id/complete", bytes.NewReader(body)))
+	assert.Equal(t, http.StatusNoContent, w.Code)
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("POST", "/jobs/job-id/complete", bytes.NewReader(body)))
+	assert.Equal(t, http.StatusConflict, w.Code)
+	buildService.AssertExpectations(t)
+}
+
+func TestWorkerController_CompleteJob_Canceled(t *testing.T) {
+	buildService := new(mockBuildService)
+	buildService.On("GetJob", mock.Anything, "job-id").Return(claimedJobTestData("worker-1"), nil)
+	buildService.On("CompleteJob", mock.Anything, "job-id", "worker-1", -1, mock.Anything, domain.ErrJobCanceled).Return(nil)
+
+	router := newWorkerTestRouter(NewWorkerController(buildService, nil, nil, nil))
+	body := []byte(`{"exit_code":-1,"error":"job canceled","canceled":true}`)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("POST", "/jobs/job-id/complete", bytes.NewReader(body)))
+
+	assert.Equal(t, http.StatusNoContent, w.Code)
+	buildService.AssertExpectations(t)
+}
+
+func TestWorkerController_CancelRequested(t *testing.T) {
+	buildService := new(mockBuildService)
+	buildService.On("GetJob", mock.Anything, "job-id").Return(claimedJobTestData("worker-1"), nil)
+	buildService.On("CancelRequested", mock.Anything, "job-id").Return(true, nil)
+
+	router := newWorkerTestRouter(NewWorkerController(buildService, nil, nil, nil))
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/jobs/job-id/cancel", nil))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"cancel_requested":true}`, w.Body.String())
+}
+
+func TestWorkerController_UploadArtifact(t *testing.T) {
+	job := claimedJobTestData("worker-1")
+	buildService := new(mockBuildService)
+	buildService.On("GetJob", mock.Anything, "job-id").Return(job, nil)
+	artifactService := new(mockArtifactService)
+	artifactService.On("Store", mock.Anything, job, "dist/app", mock.Anything, int64(6)).Return(&domain.Artifact{Name: "dist/app", Size: 6}, nil)
+
+	router := newWorkerTestRouter(NewWorkerController(buildService, nil, artifactService, nil))
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("PUT", "/jobs/job-id/artifacts/dist/app", bytes.NewReader([]byte("binary"))))
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+	assert.Contains(t, w.Body.String(), `"name":"dist/app"`)
+	artifactService.AssertExpectations(t)
+}
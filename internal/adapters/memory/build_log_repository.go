@@ -0,0 +1,92 @@
+package memory
+
+import (
+	"cmp"
+	"context"
+	"github.com/H3nSte1n/ci-orchestrator/internal/core/domain"
+	"github.com/H3nSte1n/ci-orchestrator/internal/core/ports"
+	"slices"
+)
+
+type buildLogRepository struct {
+	store *Store
+}
+
+func NewBuildLogRepository(store *Store) ports.BuildLogRepository {
+	return &buildLogRepository{store: store}
+}
+
+func (r *buildLogRepository) Save(ctx context.Context, buildLog *domain.BuildLog) error {
+	lines := []domain.BuildLog{*buildLog}
+	if err := r.SaveAll(ctx, lines); err != nil {
+		return err
+	}
+	*buildLog = lines[0]
+	return nil
+}
+
+// SaveAll inserts a batch of log lines; either all of them are stored or
+// none. Lines without a seq get the next one, as from a Postgres sequence.
+func (r *buildLogRepository) SaveAll(ctx context.Context, buildLogs []domain.BuildLog) error {
+	if len(buildLogs) == 0 {
+		return nil
+	}
+	if err := r.store.lock(ctx); err != nil {
+		return err
+	}
+	defer r.store.mu.Unlock()
+
+	for _, line := range buildLogs {
+		if !domain.ValidID(line.BuildID) || line.JobID != nil && !domain.ValidID(*line.JobID) || line.ID != "" && !domain.ValidID(line.ID) {
+			return errMalformedID
+		}
+		if _, ok := r.store.builds[line.BuildID]; !ok {
+			return errMissingRef
+		}
+		if line.JobID != nil {
+			if _, ok := r.store.jobs[*line.JobID]; !ok {
+				return errMissingRef
+			}
+		}
+	}
+
+	at := now()
+	for i := range buildLogs {
+		line := &buildLogs[i]
+		if line.ID == "" {
+			line.ID = newID()
+		}
+		if line.Seq == 0 {
+			r.store.nextSeq++
+			line.Seq = r.store.nextSeq
+		}
+		if line.CreatedAt.IsZero() {
+			line.CreatedAt = at
+		}
+		r.store.logs = append(r.store.logs, *line)
+	}
+	return nil
+}
+
+func (r *buildLogRepository) FindByBuildID(ctx context.Context, buildId string, afterSeq int64, limit int) ([]domain.BuildLog, error) {
+	if err := r.store.lock(ctx); err != nil {
+		return nil, err
+	}
+	defer r.store.mu.Unlock()
+
+	if !domain.ValidID(buildId) {
+		return nil, errMalformedID
+	}
+
+	logs := []domain.BuildLog{}
+	for _, line := range r.store.logs {
+		if line.BuildID == buildId && line.Seq > afterSeq {
+			logs = append(logs, line)
+		}
+	}
+	slices.SortFunc(logs, func(a, b domain.BuildLog) int { return cmp.Compare(a.Seq, b.Seq) })
+	if limit > 0 && len(logs) > limit {
+		logs = logs[:limit]
+	}
+	return logs, nil
+}
@@ -0,0 +1,11 @@
+package memory
+
+import (
+	"testing"
+
+	"github.com/H3nSte1n/ci-orchestrator/internal/adapters/repotest"
+)
+
+func TestBuildLogRepository(t *testing.T) {
+	repotest.RunBuildLogRepository(t, newRepositories)
+}
@@ -0,0 +1,759 @@
+package memory
+
+import (
+	"context"
+	"github.com/H3nSte1n/ci-orchestrator/internal/core/domain"
+	"github.com/H3nSte1n/ci-orchestrator/internal/core/ports"
+	"reflect"
+	"slices"
+	"strings"
+	"time"
+)
+
+type buildRepository struct {
+	store *Store
+}
+
+func NewBuildRepository(store *Store) ports.BuildRepository {
+	return &buildRepository{store: store}
+}
+
+// Save inserts build together with its jobs and matrix children and fills in
+// the generated ids, defaults and timestamps.
+func (r *buildRepository) Save(ctx context.Context, build *domain.Build) error {
+	if err := r.store.lock(ctx); err != nil {
+		return err
+	}
+	defer r.store.mu.Unlock()
+
+	if err := r.store.checkNewBuild(build, true); err != nil {
+		return err
+	}
+	at := now()
+	build.RankJobs(at)
+	r.store.insertBuild(build, at)
+	return nil
+}
+
+func (s *Store) checkNewBuild(build *domain.Build, checkParent bool) error {
+	if build.ID != "" {
+		if !domain.ValidID(build.ID) {
+			return errMalformedID
+		}
+		if _, ok := s.builds[build.ID]; ok {
+			return errAlreadyExists
+		}
+	}
+	if checkParent && build.ParentID != nil {
+		if !domain.ValidID(*build.ParentID) {
+			return errMalformedID
+		}
+		if _, ok := s.builds[*build.ParentID]; !ok {
+			return errMissingRef
+		}
+	}
+
+	for _, job := range build.Jobs {
+		if job.ID == "" {
+			continue
+		}
+		if !domain.ValidID(job.ID) {
+			return errMalformedID
+		}
+		if _, ok := s.jobs[job.ID]; ok {
+			return errAlreadyExists
+		}
+	}
+
+	for i := range build.Children {
+		if err := s.checkNewBuild(&build.Children[i], false); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *Store) insertBuild(build *domain.Build, at time.Time) {
+	if build.ID == "" {
+		build.ID = newID()
+	}
+	if build.Status == "" {
+		build.Status = domain.BuildStatusPending
+	}
+	if build.CreatedAt.IsZero() {
+		build.CreatedAt = at
+	}
+	if build.UpdatedAt.IsZero() {
+		build.UpdatedAt = at
+	}
+
+	stored := copyBuild(build)
+	stored.CreatedAt = stored.CreatedAt.Round(time.Microsecond)
+	s.builds[build.ID] = &stored
+	s.insertOrder(build.ID)
+
+	names := map[string]bool{}
+	for i := range build.Jobs {
+		job := &build.Jobs[i]
+		job.BuildID = build.ID
+		if job.ID == "" {
+			job.ID = newID()
+		}
+		if job.Status == "" {
+			job.Status = domain.JobStatusPending
+		}
+		if job.CreatedAt.IsZero() {
+			job.CreatedAt = at
+		}
+		if job.UpdatedAt.IsZero() {
+			job.UpdatedAt = at
+		}
+
+		// Jobs are inserted with ON CONFLICT DO NOTHING, so a second job
+		// with the same name is dropped rather than rejected.
+		if names[job.Name] {
+			continue
+		}
+		names[job.Name] = true
+
+		storedJob := copyJob(job)
+		s.jobs[job.ID] = &storedJob
+		s.insertOrder(job.ID)
+	}
+
+	for i := range build.Children {
+		child := &build.Children[i]
+		child.ParentID = &build.ID
+		s.insertBuild(child, at)
+	}
+}
+
+// Update writes the non-zero fields of build, like a gorm struct update.
+func (r *buildRepository) Update(ctx context.Context, build *domain.Build) error {
+	if err := r.store.lock(ctx); err != nil {
+		return err
+	}
+	defer r.store.mu.Unlock()
+
+	if !domain.ValidID(build.ID) {
+		return errMalformedID
+	}
+	stored, ok := r.store.builds[build.ID]
+	if !ok {
+		return domain.ErrBuildNotFound
+	}
+
+	src := reflect.ValueOf(build).Elem()
+	dst := reflect.ValueOf(stored).Elem()
+	for i := 0; i < src.NumField(); i++ {
+		switch src.Type().Field(i).Name {
+		case "ID", "Jobs", "Children":
+			continue
+		}
+		if field := src.Field(i); !field.IsZero() {
+			dst.Field(i).Set(field)
+		}
+	}
+	*stored = copyBuild(stored)
+	stored.UpdatedAt = now()
+	return nil
+}
+
+func (r *buildRepository) FindByID(ctx context.Context, buildId string) (*domain.Build, error) {
+	if err := r.store.lock(ctx); err != nil {
+		return nil, err
+	}
+	defer r.store.mu.Unlock()
+
+	if !domain.ValidID(buildId) {
+		return nil, errMalformedID
+	}
+	stored, ok := r.store.builds[buildId]
+	if !ok {
+		return nil, domain.ErrBuildNotFound
+	}
+
+	build := copyBuild(stored)
+	build.Jobs = []domain.Job{}
+	for _, job := range r.store.jobsOf(buildId) {
+		build.Jobs = append(build.Jobs, copyJob(job))
+	}
+	build.Children = []domain.Build{}
+	for _, child := range r.store.childrenOf(buildId) {
+		build.Children = append(build.Children, copyBuild(child))
+	}
+	return &build, nil
+}
+
+// List returns the builds matching filter, newest first, without their jobs.
+func (r *buildRepository) List(ctx context.Context, filter domain.BuildFilter) ([]domain.Build, error) {
+	if err := r.store.lock(ctx); err != nil {
+		return nil, err
+	}
+	defer r.store.mu.Unlock()
+
+	var matches []*domain.Build
+	for _, build := range r.store.builds {
+		if matchesFilter(build, filter) {
+			matches = append(matches, build)
+		}
+	}
+	slices.SortFunc(matches, func(a, b *domain.Build) int {
+		if c := b.CreatedAt.Compare(a.CreatedAt); c != 0 {
+			return c
+		}
+		return strings.Compare(b.ID, a.ID)
+	})
+	if filter.Limit > 0 && len(matches) > filter.Limit {
+		matches = matches[:filter.Limit]
+	}
+
+	builds := []domain.Build{}
+	for _, build := range matches {
+		builds = append(builds, copyBuild(build))
+	}
+	return builds, nil
+}
+
+func matchesFilter(build *domain.Build, filter domain.BuildFilter) bool {
+	if len(filter.Statuses) > 0 && !slices.Contains(filter.Statuses, build.Status) {
+		return false
+	}
+	if filter.RepoUrl != "" && build.RepoUrl != filter.RepoUrl {
+		return false
+	}
+	if filter.Ref != "" && build.Ref != filter.Ref {
+		return false
+	}
+	if filter.LockedBy != "" && (build.LockedBy == nil || *build.LockedBy != filter.LockedBy) {
+		return false
+	}
+	if filter.CreatedAfter != nil && build.CreatedAt.Before(*filter.CreatedAfter) {
+		return false
+	}
+	if filter.CreatedBefore != nil && !build.CreatedAt.Before(*filter.CreatedBefore) {
+		return false
+	}
+	if filter.FinishedAfter != nil && (build.FinishedAt == nil || build.FinishedAt.Before(*filter.FinishedAfter)) {
+		return false
+	}
+	if filter.FinishedBefore != nil && (build.FinishedAt == nil || !build.FinishedAt.Before(*filter.FinishedBefore)) {
+		return false
+	}
+	if filter.Query != "" && !strings.Contains(strings.ToLower(build.Command), strings.ToLower(filter.Query)) {
+		return false
+	}
+	if filter.After != nil {
+		c := build.CreatedAt.Compare(filter.After.CreatedAt)
+		if c > 0 || c == 0 && build.ID >= filter.After.ID {
+			return false
+		}
+	}
+	return true
+}
+
+// ClaimNext locks the pending job whose needs have all succeeded, whose
+// runs_on the worker's labels satisfy and whose repository and concurrency
+// group have room, earliest domain.ShareRank first. The store mutex makes
+// the claim atomic, as SKIP LOCKED does in Postgres.
+func (r *buildRepository) ClaimNext(ctx context.Context, workerId string) (*domain.Job, error) {
+	if err := r.store.lock(ctx); err != nil {
+		return nil, err
+	}
+	defer r.store.mu.Unlock()
+
+	at := now()
+	var candidates []*domain.Job
+	for _, job := range r.store.jobs {
+		if r.store.claimable(job) && r.store.builds[job.BuildID].Due(at) && r.store.runsOn(job, workerId) && r.store.admits(job) == nil {
+			candidates = append(candidates, job)
+		}
+	}
+	if len(candidates) == 0 {
+		return nil, nil
+	}
+	sortByCreation(r.store, candidates, func(j *domain.Job) (time.Time, string) { return j.CreatedAt, j.ID })
+	running := r.store.runningByRepo()
+	rank := func(j *domain.Job) time.Time {
+		return domain.ShareRank(j.RankedAt, running[r.store.builds[j.BuildID].RepoUrl])
+	}
+	slices.SortStableFunc(candidates, func(a, b *domain.Job) int { return rank(a).Compare(rank(b)) })
+
+	return r.store.start(candidates[0], workerId), nil
+}
+
+// UpdatePriority changes the priority of a pending build and its matrix
+// children and reranks their jobs.
+func (r *buildRepository) UpdatePriority(ctx context.Context, buildId string, priority int) error {
+	if err := r.store.lock(ctx); err != nil {
+		return err
+	}
+	defer r.store.mu.Unlock()
+
+	if !domain.ValidID(buildId) {
+		return errMalformedID
+	}
+	build, ok := r.store.builds[buildId]
+	if !ok {
+		return domain.ErrBuildNotFound
+	}
+	if build.Status != domain.BuildStatusPending {
+		return domain.ErrBuildNotPending
+	}
+
+	at := now()
+	for _, b := range append([]*domain.Build{build}, r.store.childrenOf(buildId)...) {
+		b.Priority = priority
+		b.UpdatedAt = at
+		for _, job := range r.store.jobsOf(b.ID) {
+			if job.Status == domain.JobStatusPending {
+				job.RankedAt = domain.RankAt(job.CreatedAt, priority)
+				job.UpdatedAt = at
+			}
+		}
+	}
+	return nil
+}
+
+// StartJob starts a job handed out by a queue, see ports.BuildRepository.
+func (r *buildRepository) StartJob(ctx context.Context, jobId string, workerId string) (*domain.Job, error) {
+	if err := r.store.lock(ctx); err != nil {
+		return nil, err
+	}
+	defer r.store.mu.Unlock()
+
+	if !domain.ValidID(jobId) {
+		return nil, errMalformedID
+	}
+	job, ok := r.store.jobs[jobId]
+	if !ok {
+		return nil, domain.ErrJobNotFound
+	}
+
+	if job.Status == domain.JobStatusRunning && job.LockedBy != nil && *job.LockedBy == workerId {
+		started := copyJob(job)
+		build := copyBuild(r.store.builds[job.BuildID])
+		started.Build = &build
+		return &started, nil
+	}
+	if !r.store.claimable(job) {
+		return nil, domain.ErrJobNotClaimable
+	}
+	if !r.store.builds[job.BuildID].Due(now()) {
+		return nil, domain.ErrBuildNotDue
+	}
+	if !r.store.runsOn(job, workerId) {
+		return nil, domain.ErrJobNotForWorker
+	}
+	if err := r.store.admits(job); err != nil {
+		return nil, err
+	}
+	return r.store.start(job, workerId), nil
+}
+
+// ReleaseJob puts a running job back to pending.
+func (r *buildRepository) ReleaseJob(ctx context.Context, jobId string, workerId string) error {
+	if err := r.store.lock(ctx); err != nil {
+		return err
+	}
+	defer r.store.mu.Unlock()
+
+	if !domain.ValidID(jobId) {
+		return errMalformedID
+	}
+	job, ok := r.store.jobs[jobId]
+	if !ok || job.Status != domain.JobStatusRunning || job.LockedBy == nil || *job.LockedBy != workerId {
+		return domain.ErrJobNotFound
+	}
+
+	job.Status = domain.JobStatusPending
+	job.LockedBy = nil
+	job.LockedAt = nil
+	job.UpdatedAt = now()
+	return nil
+}
+
+// start marks job as running for workerId, together with its build and
+// matrix parent, and returns a copy with the build attached.
+func (s *Store) start(job *domain.Job, workerId string) *domain.Job {
+	at := now()
+	job.Status = domain.JobStatusRunning
+	job.LockedBy = &workerId
+	job.LockedAt = &at
+	job.UpdatedAt = at
+
+	build := s.builds[job.BuildID]
+	if build.ParentID != nil {
+		if parent, ok := s.builds[*build.ParentID]; ok && parent.Status == domain.BuildStatusPending {
+			parent.Status = domain.BuildStatusRunning
+			parent.UpdatedAt = at
+		}
+	}
+	if build.Status == domain.BuildStatusPending {
+		build.Status = domain.BuildStatusRunning
+		build.LockedBy = &workerId
+		build.LockedAt = &at
+		build.UpdatedAt = at
+	}
+
+	claimed := copyJob(job)
+	claimedBuild := copyBuild(build)
+	claimed.Build = &claimedBuild
+	return &claimed
+}
+
+func (s *Store) claimable(job *domain.Job) bool {
+	if job.Status != domain.JobStatusPending || job.LockedBy != nil {
+		return false
+	}
+
+	build := s.builds[job.BuildID]
+	if build.Status != domain.BuildStatusPending && build.Status != domain.BuildStatusRunning {
+		return false
+	}
+
+	for _, other := range s.jobs {
+		if other.BuildID == job.BuildID && slices.Contains(job.Needs, other.Name) && other.Status != domain.JobStatusSuccess {
+			return false
+		}
+	}
+	return true
+}
+
+// runsOn reports whether the registered labels of workerId satisfy the
+// runs_on of the job's build. Unregistered workers have no labels.
+func (s *Store) runsOn(job *domain.Job, workerId string) bool {
+	var labels []string
+	if worker, ok := s.workers[workerId]; ok {
+		labels = worker.Labels
+	}
+	return domain.Satisfies(labels, s.builds[job.BuildID].RunsOn)
+}
+
+// admits returns nil when the job's build may start a job: its top-level
+// build already runs, or its repository and its concurrency group both run
+// fewer top-level builds than their limits. Otherwise it returns
+// domain.ErrRepoLimit or domain.ErrConcurrencyLimit.
+func (s *Store) admits(job *domain.Job) error {
+	build := s.builds[job.BuildID]
+	root := build
+	if build.ParentID != nil {
+		root = s.builds[*build.ParentID]
+	}
+	if root.Status == domain.BuildStatusRunning {
+		return nil
+	}
+
+	running := s.runningByRepo()
+	if limit, ok := s.limits[build.RepoUrl]; ok && limit.MaxRunningBuilds > 0 && running[build.RepoUrl] >= limit.MaxRunningBuilds {
+		return domain.ErrRepoLimit
+	}
+	if build.ConcurrencyGroup == nil {
+		return nil
+	}
+	inGroup := 0
+	for _, other := range s.builds {
+		if other.ParentID == nil && sameGroup(other, build) && s.holdsGroupSlot(other) {
+			inGroup++
+		}
+	}
+	if inGroup >= max(build.MaxConcurrency, 1) {
+		return domain.ErrConcurrencyLimit
+	}
+	return nil
+}
+
+// holdsGroupSlot reports whether the top-level build occupies a slot of its
+// concurrency group: it runs, or it was canceled and a job still runs.
+func (s *Store) holdsGroupSlot(build *domain.Build) bool {
+	switch build.Status {
+	case domain.BuildStatusRunning:
+		return true
+	case domain.BuildStatusCanceled:
+		for _, b := range append([]*domain.Build{build}, s.childrenOf(build.ID)...) {
+			for _, job := range s.jobsOf(b.ID) {
+				if job.Status == domain.JobStatusRunning {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// runningByRepo counts the running top-level builds of each repository.
+func (s *Store) runningByRepo() map[string]int {
+	running := map[string]int{}
+	for _, build := range s.builds {
+		if build.ParentID == nil && build.Status == domain.BuildStatusRunning {
+			running[build.RepoUrl]++
+		}
+	}
+	return running
+}
+
+func sameGroup(a, b *domain.Build) bool {
+	return a.ConcurrencyGroup != nil && b.ConcurrencyGroup != nil && *a.ConcurrencyGroup == *b.ConcurrencyGroup
+}
+
+// CancelSuperseded cancels the older builds of build's concurrency group and
+// their unfinished matrix children.
+func (r *buildRepository) CancelSuperseded(ctx context.Context, build *domain.Build, statuses []domain.BuildStatus) error {
+	if build.ConcurrencyGroup == nil || len(statuses) == 0 {
+		return nil
+	}
+	if err := r.store.lock(ctx); err != nil {
+		return err
+	}
+	defer r.store.mu.Unlock()
+
+	at := now()
+	for _, other := range r.store.builds {
+		if other.ID == build.ID || other.ParentID != nil || !sameGroup(other, build) || !slices.Contains(statuses, other.Status) {
+			continue
+		}
+		if c := other.CreatedAt.Compare(build.CreatedAt); c > 0 || c == 0 && r.store.order[other.ID] > r.store.order[build.ID] {
+			continue
+		}
+		r.store.cancel(other, at)
+		for _, child := range r.store.childrenOf(other.ID) {
+			r.store.cancel(child, at)
+		}
+	}
+	return nil
+}
+
+// RequeueExpired puts running jobs with an expired lock back to pending.
+func (r *buildRepository) RequeueExpired(ctx context.Context, before time.Time) ([]string, error) {
+	if err := r.store.lock(ctx); err != nil {
+		return nil, err
+	}
+	defer r.store.mu.Unlock()
+
+	var expired []*domain.Job
+	for _, job := range r.store.jobs {
+		if job.Status == domain.JobStatusRunning && job.LockedAt != nil && job.LockedAt.Before(before) {
+			expired = append(expired, job)
+		}
+	}
+	sortByCreation(r.store, expired, func(j *domain.Job) (time.Time, string) { return *j.LockedAt, j.ID })
+
+	at := now()
+	var requeued []string
+	for _, job := range expired {
+		job.UpdatedAt = at
+		if r.store.builds[job.BuildID].Status.IsTerminal() {
+			job.Status = domain.JobStatusCanceled
+			job.FinishedAt = &at
+			continue
+		}
+		job.Status = domain.JobStatusPending
+		job.LockedBy = nil
+		job.LockedAt = nil
+		requeued = append(requeued, job.ID)
+	}
+	return requeued, nil
+}
+
+// FindJobByID loads a job together with its build.
+func (r *buildRepository) FindJobByID(ctx context.Context, jobId string) (*domain.Job, error) {
+	if err := r.store.lock(ctx); err != nil {
+		return nil, err
+	}
+	defer r.store.mu.Unlock()
+
+	if !domain.ValidID(jobId) {
+		return nil, errMalformedID
+	}
+	stored, ok := r.store.jobs[jobId]
+	if !ok {
+		return nil, domain.ErrJobNotFound
+	}
+
+	job := copyJob(stored)
+	build := copyBuild(r.store.builds[stored.BuildID])
+	job.Build = &build
+	return &job, nil
+}
+
+// Heartbeat refreshes the lock of a running job held by workerId.
+func (r *buildRepository) Heartbeat(ctx context.Context, jobId string, workerId string, at time.Time) error {
+	if err := r.store.lock(ctx); err != nil {
+		return err
+	}
+	defer r.store.mu.Unlock()
+
+	if !domain.ValidID(jobId) {
+		return errMalformedID
+	}
+	job, ok := r.store.jobs[jobId]
+	if !ok || job.LockedBy == nil || *job.LockedBy != workerId || job.Status != domain.JobStatusRunning {
+		return domain.ErrJobNotFound
+	}
+
+	job.LockedAt = &at
+	job.UpdatedAt = now()
+	return nil
+}
+
+// CompleteJob records the outcome of a job held by workerId, skips everything
+// downstream of a failed job and rolls the job states up into the build
+// status (and, for matrix children, into the parent).
+func (r *buildRepository) CompleteJob(ctx context.Context, job *domain.Job, workerId string) error {
+	if err := r.store.lock(ctx); err != nil {
+		return err
+	}
+	defer r.store.mu.Unlock()
+
+	if !domain.ValidID(job.ID) {
+		return errMalformedID
+	}
+	stored, ok := r.store.jobs[job.ID]
+	if !ok {
+		return domain.ErrJobNotFound
+	}
+	if stored.Status != domain.JobStatusRunning || stored.LockedBy == nil || *stored.LockedBy != workerId {
+		return domain.ErrJobLockLost
+	}
+
+	build := r.store.builds[stored.BuildID]
+	var parentStatus domain.BuildStatus
+	if build.ParentID != nil {
+		parentStatus = r.store.builds[*build.ParentID].Status
+	}
+
+	at := now()
+	stored.Status = job.Status
+	stored.ExitCode = job.ExitCode
+	stored.Error = job.Error
+	stored.FinishedAt = job.FinishedAt
+	stored.UpdatedAt = at
+
+	jobs := r.store.jobsOf(stored.BuildID)
+
+	if job.Status != domain.JobStatusSuccess {
+		skipped := domain.Downstream(derefJobs(jobs), stored.Name)
+		for _, j := range jobs {
+			if slices.Contains(skipped, j.Name) && j.Status == domain.JobStatusPending {
+				j.Status = domain.JobStatusSkipped
+				j.FinishedAt = job.FinishedAt
+				j.UpdatedAt = at
+			}
+		}
+	}
+
+	if build.Status == domain.BuildStatusCanceled {
+		return nil
+	}
+
+	status, finished := domain.AggregateStatus(derefJobs(jobs))
+	build.Status = status
+	build.UpdatedAt = at
+	if finished {
+		build.FinishedAt = job.FinishedAt
+		for _, j := range jobs {
+			if j.Status == domain.JobStatusFailed {
+				build.ExitCode = j.ExitCode
+				build.Error = j.Error
+				break
+			}
+		}
+	}
+
+	if build.ParentID != nil && finished && !parentStatus.IsTerminal() {
+		r.store.rollUpParent(r.store.builds[*build.ParentID], status, job.FinishedAt)
+	}
+
+	return nil
+}
+
+// Cancel cancels a build together with its matrix children and jobs.
+func (r *buildRepository) Cancel(ctx context.Context, buildId string) error {
+	if err := r.store.lock(ctx); err != nil {
+		return err
+	}
+	defer r.store.mu.Unlock()
+
+	if !domain.ValidID(buildId) {
+		return errMalformedID
+	}
+	build, ok := r.store.builds[buildId]
+	if !ok {
+		return domain.ErrBuildNotFound
+	}
+	if build.Status.IsTerminal() {
+		return nil
+	}
+
+	at := now()
+	r.store.cancel(build, at)
+	for _, child := range r.store.childrenOf(buildId) {
+		r.store.cancel(child, at)
+	}
+	if build.ParentID != nil {
+		if parent := r.store.builds[*build.ParentID]; !parent.Status.IsTerminal() {
+			r.store.rollUpParent(parent, domain.BuildStatusCanceled, &at)
+		}
+	}
+	return nil
+}
+
+// cancel cancels build when it is unfinished: its pending jobs are canceled
+// and its running jobs are asked to stop.
+func (s *Store) cancel(build *domain.Build, at time.Time) {
+	if build.Status != domain.BuildStatusPending && build.Status != domain.BuildStatusRunning {
+		return
+	}
+	build.Status = domain.BuildStatusCanceled
+	build.CancelRequestedAt = &at
+	build.FinishedAt = &at
+	build.UpdatedAt = at
+
+	for _, job := range s.jobsOf(build.ID) {
+		switch job.Status {
+		case domain.JobStatusPending:
+			job.Status = domain.JobStatusCanceled
+			job.FinishedAt = &at
+			job.UpdatedAt = at
+		case domain.JobStatusRunning:
+			job.CancelRequestedAt = &at
+			job.UpdatedAt = at
+		}
+	}
+}
+
+// rollUpParent updates a matrix parent after one of its children finished,
+// canceling the remaining children first when the matrix is fail-fast.
+func (s *Store) rollUpParent(parent *domain.Build, childStatus domain.BuildStatus, finishedAt *time.Time) {
+	at := now()
+	children := s.childrenOf(parent.ID)
+
+	if childStatus == domain.BuildStatusFailed && parent.Matrix != nil && parent.Matrix.FailFast {
+		for _, child := range children {
+			s.cancel(child, at)
+		}
+	}
+
+	status, finished := domain.RollUpChildren(derefBuilds(children))
+	parent.Status = status
+	parent.UpdatedAt = at
+	if finished {
+		parent.FinishedAt = finishedAt
+	}
+}
+
+func derefJobs(jobs []*domain.Job) []domain.Job {
+	out := make([]domain.Job, len(jobs))
+	for i, job := range jobs {
+		out[i] = *job
+	}
+	return out
+}
+
+func derefBuilds(builds []*domain.Build) []domain.Build {
+	out := make([]domain.Build, len(builds))
+	for i, build := range builds {
+		out[i] = *build
+	}
+	return out
+}
@@ -0,0 +1,22 @@
+package memory
+
+import (
+	"testing"
+
+	"github.com/H3nSte1n/ci-orchestrator/internal/adapters/repotest"
+)
+
+func newRepositories(t *testing.T) repotest.Repositories {
+	store := NewStore()
+	return repotest.Repositories{
+		Builds:    NewBuildRepository(store),
+		Logs:      NewBuildLogRepository(store),
+		Workers:   NewWorkerRepository(store),
+		Limits:    NewRepoLimitRepository(store),
+		Schedules: NewScheduleRepository(store),
+	}
+}
+
+func TestBuildRepository(t *testing.T) {
+	repotest.RunBuildRepository(t, newRepositories)
+}
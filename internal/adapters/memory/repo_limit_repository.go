@@ -0,0 +1,53 @@
+package memory
+
+import (
+	"context"
+	"github.com/H3nSte1n/ci-orchestrator/internal/core/domain"
+	"github.com/H3nSte1n/ci-orchestrator/internal/core/ports"
+	"slices"
+	"strings"
+)
+
+type repoLimitRepository struct {
+	store *Store
+}
+
+func NewRepoLimitRepository(store *Store) ports.RepoLimitRepository {
+	return &repoLimitRepository{store: store}
+}
+
+func (r *repoLimitRepository) Save(ctx context.Context, limit *domain.RepoLimit) error {
+	if err := r.store.lock(ctx); err != nil {
+		return err
+	}
+	defer r.store.mu.Unlock()
+
+	at := now()
+	if limit.CreatedAt.IsZero() {
+		limit.CreatedAt = at
+	}
+	limit.UpdatedAt = at
+
+	if stored, ok := r.store.limits[limit.RepoUrl]; ok {
+		stored.MaxRunningBuilds = limit.MaxRunningBuilds
+		stored.UpdatedAt = limit.UpdatedAt
+		return nil
+	}
+	stored := *limit
+	r.store.limits[limit.RepoUrl] = &stored
+	return nil
+}
+
+func (r *repoLimitRepository) List(ctx context.Context) ([]domain.RepoLimit, error) {
+	if err := r.store.lock(ctx); err != nil {
+		return nil, err
+	}
+	defer r.store.mu.Unlock()
+
+	limits := []domain.RepoLimit{}
+	for _, limit := range r.store.limits {
+		limits = append(limits, *limit)
+	}
+	slices.SortFunc(limits, func(a, b domain.RepoLimit) int { return strings.Compare(a.RepoUrl, b.RepoUrl) })
+	return limits, nil
+}
@@ -0,0 +1,11 @@
+package memory
+
+import (
+	"testing"
+
+	"github.com/H3nSte1n/ci-orchestrator/internal/adapters/repotest"
+)
+
+func TestRepoLimitRepository(t *testing.T) {
+	repotest.RunRepoLimitRepository(t, newRepositories)
+}
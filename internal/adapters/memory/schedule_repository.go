@@ -0,0 +1,148 @@
+package memory
+
+import (
+	"context"
+	"github.com/H3nSte1n/ci-orchestrator/internal/core/domain"
+	"github.com/H3nSte1n/ci-orchestrator/internal/core/ports"
+	"slices"
+	"strings"
+	"time"
+)
+
+type scheduleRepository struct {
+	store *Store
+}
+
+func NewScheduleRepository(store *Store) ports.ScheduleRepository {
+	return &scheduleRepository{store: store}
+}
+
+func (r *scheduleRepository) Save(ctx context.Context, schedule *domain.Schedule) error {
+	if err := r.store.lock(ctx); err != nil {
+		return err
+	}
+	defer r.store.mu.Unlock()
+
+	if schedule.ID == "" {
+		schedule.ID = newID()
+	} else if !domain.ValidID(schedule.ID) {
+		return errMalformedID
+	}
+	if _, ok := r.store.schedules[schedule.ID]; ok {
+		return errAlreadyExists
+	}
+
+	at := now()
+	schedule.CreatedAt = at
+	schedule.UpdatedAt = at
+	if schedule.Env == nil {
+		schedule.Env = domain.StringMap{}
+	}
+	stored := copySchedule(schedule)
+	r.store.schedules[schedule.ID] = &stored
+	r.store.insertOrder(schedule.ID)
+	return nil
+}
+
+func (r *scheduleRepository) FindByID(ctx context.Context, scheduleId string) (*domain.Schedule, error) {
+	if err := r.store.lock(ctx); err != nil {
+		return nil, err
+	}
+	defer r.store.mu.Unlock()
+
+	if !domain.ValidID(scheduleId) {
+		return nil, errMalformedID
+	}
+	stored, ok := r.store.schedules[scheduleId]
+	if !ok {
+		return nil, domain.ErrScheduleNotFound
+	}
+	schedule := copySchedule(stored)
+	return &schedule, nil
+}
+
+func (r *scheduleRepository) List(ctx context.Context) ([]domain.Schedule, error) {
+	if err := r.store.lock(ctx); err != nil {
+		return nil, err
+	}
+	defer r.store.mu.Unlock()
+
+	return r.store.listSchedules(func(*domain.Schedule) bool { return true }, func(a, b *domain.Schedule) int {
+		return strings.Compare(a.Name, b.Name)
+	}), nil
+}
+
+func (r *scheduleRepository) Delete(ctx context.Context, scheduleId string) error {
+	if err := r.store.lock(ctx); err != nil {
+		return err
+	}
+	defer r.store.mu.Unlock()
+
+	if !domain.ValidID(scheduleId) {
+		return errMalformedID
+	}
+	if _, ok := r.store.schedules[scheduleId]; !ok {
+		return domain.ErrScheduleNotFound
+	}
+	delete(r.store.schedules, scheduleId)
+	return nil
+}
+
+func (r *scheduleRepository) ListDue(ctx context.Context, at time.Time) ([]domain.Schedule, error) {
+	if err := r.store.lock(ctx); err != nil {
+		return nil, err
+	}
+	defer r.store.mu.Unlock()
+
+	return r.store.listSchedules(func(s *domain.Schedule) bool { return !s.NextRunAt.After(at) }, func(a, b *domain.Schedule) int {
+		return a.NextRunAt.Compare(b.NextRunAt)
+	}), nil
+}
+
+// Advance is a compare-and-set on NextRunAt, see ports.ScheduleRepository.
+func (r *scheduleRepository) Advance(ctx context.Context, scheduleId string, from time.Time, next time.Time, lastRunAt *time.Time) (bool, error) {
+	if err := r.store.lock(ctx); err != nil {
+		return false, err
+	}
+	defer r.store.mu.Unlock()
+
+	if !domain.ValidID(scheduleId) {
+		return false, errMalformedID
+	}
+	stored, ok := r.store.schedules[scheduleId]
+	if !ok || !stored.NextRunAt.Equal(from) {
+		return false, nil
+	}
+
+	stored.NextRunAt = next.UTC()
+	stored.LastRunAt = nil
+	if lastRunAt != nil {
+		at := lastRunAt.UTC()
+		stored.LastRunAt = &at
+	}
+	stored.UpdatedAt = now()
+	return true, nil
+}
+
+// listSchedules returns copies of the schedules matching keep, sorted by cmp
+// and then by insertion order.
+func (s *Store) listSchedules(keep func(*domain.Schedule) bool, cmp func(a, b *domain.Schedule) int) []domain.Schedule {
+	var matching []*domain.Schedule
+	for _, schedule := range s.schedules {
+		if keep(schedule) {
+			matching = append(matching, schedule)
+		}
+	}
+	slices.SortFunc(matching, func(a, b *domain.Schedule) int {
+		if c := cmp(a, b); c != 0 {
+			return c
+		}
+		return int(s.order[a.ID] - s.order[b.ID])
+	})
+
+	schedules := []domain.Schedule{}
+	for _, schedule := range matching {
+		schedules = append(schedules, copySchedule(schedule))
+	}
+	return schedules
+}
@@ -0,0 +1,163 @@
+// Package memory keeps builds, jobs, logs, workers, repository limits and
+// schedules in process memory. Its repositories behave like the Postgres
+// ones and are meant for tests and single process setups; nothing survives
+// a restart.
+package memory
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+	"github.com/H3nSte1n/ci-orchestrator/internal/core/domain"
+	"maps"
+	"slices"
+	"sync"
+	"time"
+)
+
+// Store holds the rows shared by the repositories. A single mutex plays the
+// part of the database's transactions and row locks.
+type Store struct {
+	mu        sync.Mutex
+	builds    map[string]*domain.Build
+	jobs      map[string]*domain.Job
+	logs      []domain.BuildLog
+	workers   map[string]*domain.Worker
+	limits    map[string]*domain.RepoLimit
+	schedules map[string]*domain.Schedule
+
+	// order records insertion order, which breaks ties between equal
+	// created_at values the way a sequential scan would.
+	order   map[string]int64
+	nextRow int64
+	nextSeq int64
+}
+
+func NewStore() *Store {
+	return &Store{
+		builds:    map[string]*domain.Build{},
+		jobs:      map[string]*domain.Job{},
+		workers:   map[string]*domain.Worker{},
+		limits:    map[string]*domain.RepoLimit{},
+		schedules: map[string]*domain.Schedule{},
+		order:     map[string]int64{},
+	}
+}
+
+func (s *Store) lock(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	s.mu.Lock()
+	return nil
+}
+
+func (s *Store) insertOrder(id string) {
+	s.nextRow++
+	s.order[id] = s.nextRow
+}
+
+// jobsOf returns the jobs of a build ordered by creation.
+func (s *Store) jobsOf(buildId string) []*domain.Job {
+	var jobs []*domain.Job
+	for _, job := range s.jobs {
+		if job.BuildID == buildId {
+			jobs = append(jobs, job)
+		}
+	}
+	sortByCreation(s, jobs, func(j *domain.Job) (time.Time, string) { return j.CreatedAt, j.ID })
+	return jobs
+}
+
+// childrenOf returns the children of a matrix build ordered by creation.
+func (s *Store) childrenOf(parentId string) []*domain.Build {
+	var children []*domain.Build
+	for _, build := range s.builds {
+		if build.ParentID != nil && *build.ParentID == parentId {
+			children = append(children, build)
+		}
+	}
+	sortByCreation(s, children, func(b *domain.Build) (time.Time, string) { return b.CreatedAt, b.ID })
+	return children
+}
+
+func sortByCreation[T any](s *Store, rows []T, key func(T) (time.Time, string)) {
+	slices.SortFunc(rows, func(a, b T) int {
+		ta, ida := key(a)
+		tb, idb := key(b)
+		if c := ta.Compare(tb); c != 0 {
+			return c
+		}
+		return int(s.order[ida] - s.order[idb])
+	})
+}
+
+// now returns the current time at the precision Postgres stores.
+func now() time.Time {
+	return time.Now().Round(time.Microsecond)
+}
+
+// newID returns a random version 4 UUID.
+func newID() string {
+	var b [16]byte
+	_, _ = rand.Read(b[:])
+	b[6] = b[6]&0x0f | 0x40
+	b[8] = b[8]&0x3f | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16])
+}
+
+// The errors the Postgres repositories report for the same conditions.
+var (
+	errMalformedID   = domain.NewError(domain.ErrInvalidArgument, "malformed value")
+	errMissingRef    = domain.NewError(domain.ErrInvalidArgument, "referenced record does not exist")
+	errAlreadyExists = domain.NewError(domain.ErrConflict, "record already exists")
+)
+
+// copyBuild returns a copy of b without its jobs and children that shares
+// no maps or slices with b. Like a row read back from Postgres, nil JSON
+// columns come back empty.
+func copyBuild(b *domain.Build) domain.Build {
+	c := *b
+	c.Env = copyMap(b.Env)
+	c.MatrixValues = copyMap(b.MatrixValues)
+	c.Artifacts = append(domain.StringList{}, b.Artifacts...)
+	c.Caches = append(domain.CacheList{}, b.Caches...)
+	c.RunsOn = append(domain.StringList{}, b.RunsOn...)
+	c.Jobs = nil
+	c.Children = nil
+	return c
+}
+
+func copyJob(j *domain.Job) domain.Job {
+	c := *j
+	c.Needs = append(domain.StringList{}, j.Needs...)
+	c.Artifacts = append(domain.StringList{}, j.Artifacts...)
+	c.Build = nil
+	return c
+}
+
+func copyWorker(w *domain.Worker) domain.Worker {
+	c := *w
+	c.Labels = append(domain.StringList{}, w.Labels...)
+	if w.DeregisteredAt != nil {
+		at := *w.DeregisteredAt
+		c.DeregisteredAt = &at
+	}
+	return c
+}
+
+func copySchedule(s *domain.Schedule) domain.Schedule {
+	c := *s
+	c.Env = copyMap(s.Env)
+	if s.LastRunAt != nil {
+		at := *s.LastRunAt
+		c.LastRunAt = &at
+	}
+	return c
+}
+
+func copyMap(m domain.StringMap) domain.StringMap {
+	c := make(domain.StringMap, len(m))
+	maps.Copy(c, m)
+	return c
+}
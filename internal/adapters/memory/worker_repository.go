@@ -0,0 +1,132 @@
+package memory
+
+import (
+	"context"
+	"github.com/H3nSte1n/ci-orchestrator/internal/core/domain"
+	"github.com/H3nSte1n/ci-orchestrator/internal/core/ports"
+	"slices"
+	"strings"
+	"time"
+)
+
+type workerRepository struct {
+	store *Store
+}
+
+func NewWorkerRepository(store *Store) ports.WorkerRepository {
+	return &workerRepository{store: store}
+}
+
+func (r *workerRepository) Register(ctx context.Context, worker *domain.Worker) error {
+	if err := r.store.lock(ctx); err != nil {
+		return err
+	}
+	defer r.store.mu.Unlock()
+
+	at := now()
+	if worker.CreatedAt.IsZero() {
+		worker.CreatedAt = at
+	}
+	worker.UpdatedAt = at
+
+	if stored, ok := r.store.workers[worker.ID]; ok {
+		state := domain.WorkerStateActive
+		if stored.State == domain.WorkerStatePaused {
+			state = stored.State
+		}
+		createdAt := stored.CreatedAt
+		*stored = copyWorker(worker)
+		stored.State = state
+		stored.CreatedAt = createdAt
+		return nil
+	}
+	stored := copyWorker(worker)
+	if stored.State == "" {
+		stored.State = domain.WorkerStateActive
+	}
+	r.store.workers[worker.ID] = &stored
+	return nil
+}
+
+func (r *workerRepository) FindByID(ctx context.Context, workerId string) (*domain.Worker, error) {
+	if err := r.store.lock(ctx); err != nil {
+		return nil, err
+	}
+	defer r.store.mu.Unlock()
+
+	stored, ok := r.store.workers[workerId]
+	if !ok {
+		return nil, domain.ErrWorkerNotFound
+	}
+	worker := copyWorker(stored)
+	return &worker, nil
+}
+
+func (r *workerRepository) List(ctx context.Context) ([]domain.Worker, error) {
+	if err := r.store.lock(ctx); err != nil {
+		return nil, err
+	}
+	defer r.store.mu.Unlock()
+
+	workers := []domain.Worker{}
+	for _, worker := range r.store.workers {
+		workers = append(workers, copyWorker(worker))
+	}
+	slices.SortFunc(workers, func(a, b domain.Worker) int { return strings.Compare(a.ID, b.ID) })
+	return workers, nil
+}
+
+func (r *workerRepository) Heartbeat(ctx context.Context, workerId string, at time.Time) error {
+	return r.update(ctx, workerId, func(w *domain.Worker) { w.LastSeenAt = at })
+}
+
+func (r *workerRepository) Deregister(ctx context.Context, workerId string, at time.Time) error {
+	return r.update(ctx, workerId, func(w *domain.Worker) { w.DeregisteredAt = &at })
+}
+
+func (r *workerRepository) UpdateState(ctx context.Context, workerId string, state domain.WorkerState) error {
+	return r.update(ctx, workerId, func(w *domain.Worker) { w.State = state })
+}
+
+func (r *workerRepository) RunningJobs(ctx context.Context) ([]domain.Job, error) {
+	if err := r.store.lock(ctx); err != nil {
+		return nil, err
+	}
+	defer r.store.mu.Unlock()
+
+	jobs := []domain.Job{}
+	for _, job := range r.store.jobs {
+		if job.Status == domain.JobStatusRunning {
+			jobs = append(jobs, copyJob(job))
+		}
+	}
+	slices.SortFunc(jobs, func(a, b domain.Job) int { return compareLockedAt(a.LockedAt, b.LockedAt) })
+	return jobs, nil
+}
+
+func (r *workerRepository) update(ctx context.Context, workerId string, apply func(*domain.Worker)) error {
+	if err := r.store.lock(ctx); err != nil {
+		return err
+	}
+	defer r.store.mu.Unlock()
+
+	stored, ok := r.store.workers[workerId]
+	if !ok {
+		return domain.ErrWorkerNotFound
+	}
+	apply(stored)
+	stored.UpdatedAt = now()
+	return nil
+}
+
+func compareLockedAt(a, b *time.Time) int {
+	switch {
+	case a == nil && b == nil:
+		return 0
+	case a == nil:
+		return 1
+	case b == nil:
+		return -1
+	}
+	return a.Compare(*b)
+}
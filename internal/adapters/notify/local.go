@@ -0,0 +1,48 @@
+package notify
+
+import (
+	"context"
+	"github.com/H3nSte1n/ci-orchestrator/internal/core/ports"
+	"sync"
+)
+
+// localNotifier delivers notifications to subscribers in the same process.
+type localNotifier struct {
+	mu          sync.Mutex
+	subscribers map[chan struct{}]struct{}
+}
+
+func NewLocalNotifier() ports.JobNotifier {
+	return &localNotifier{
+		subscribers: map[chan struct{}]struct{}{},
+	}
+}
+
+func (n *localNotifier) Notify(ctx context.Context) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	for ch := range n.subscribers {
+		select {
+		case ch <- struct{}{}:
+		default:
+		}
+	}
+}
+
+func (n *localNotifier) Subscribe(ctx context.Context) <-chan struct{} {
+	ch := make(chan struct{}, 1)
+
+	n.mu.Lock()
+	n.subscribers[ch] = struct{}{}
+	n.mu.Unlock()
+
+	go func() {
+		<-ctx.Done()
+		n.mu.Lock()
+		delete(n.subscribers, ch)
+		n.mu.Unlock()
+	}()
+
+	return ch
+}
@@ -0,0 +1,59 @@
+package notify
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func received(ch <-chan struct{}) bool {
+	select {
+	case <-ch:
+		return true
+	case <-time.After(100 * time.Millisecond):
+		return false
+	}
+}
+
+func TestLocalNotifier_WakesEverySubscriber(t *testing.T) {
+	n := NewLocalNotifier()
+	a := n.Subscribe(context.Background())
+	b := n.Subscribe(context.Background())
+
+	n.Notify(context.Background())
+
+	assert.True(t, received(a))
+	assert.True(t, received(b))
+}
+
+func TestLocalNotifier_CollapsesPendingNotifications(t *testing.T) {
+	n := NewLocalNotifier()
+	ch := n.Subscribe(context.Background())
+
+	n.Notify(context.Background())
+	n.Notify(context.Background())
+	n.Notify(context.Background())
+
+	assert.True(t, received(ch))
+	assert.False(t, received(ch))
+}
+
+func TestLocalNotifier_StopsAfterCancel(t *testing.T) {
+	n := NewLocalNotifier().(*localNotifier)
+	ctx, cancel := context.WithCancel(context.Background())
+	n.Subscribe(ctx)
+
+	cancel()
+
+	assert.Eventually(t, func() bool {
+		n.mu.Lock()
+		defer n.mu.Unlock()
+		return len(n.subscribers) == 0
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestLocalNotifier_NotifyWithoutSubscribers(t *testing.T) {
+	assert.NotPanics(t, func() { NewLocalNotifier().Notify(context.Background()) })
+}
@@ -0,0 +1,107 @@
+package notify
+
+import (
+	"context"
+	"database/sql/driver"
+	"fmt"
+	"github.com/H3nSte1n/ci-orchestrator/internal/core/ports"
+	"github.com/jackc/pgx/v5/stdlib"
+	"gorm.io/gorm"
+	"sync/atomic"
+	"time"
+)
+
+// jobsChannel is the Postgres notification channel for claimable jobs.
+const jobsChannel = "ci_jobs"
+
+// postgresNotifier wakes workers in every process connected to the same
+// database: Notify sends a NOTIFY and Listen turns the notifications into
+// wake-ups for this process's subscribers.
+type postgresNotifier struct {
+	db        *gorm.DB
+	local     ports.JobNotifier
+	retry     time.Duration
+	connected atomic.Bool
+}
+
+func NewPostgresNotifier(db *gorm.DB) *postgresNotifier {
+	return &postgresNotifier{
+		db:    db,
+		local: NewLocalNotifier(),
+		retry: 5 * time.Second,
+	}
+}
+
+// Notify is best effort: if it fails, workers pick the job up on their next
+// poll.
+func (n *postgresNotifier) Notify(ctx context.Context) {
+	if err := n.db.WithContext(ctx).Exec("SELECT pg_notify(?, '')", jobsChannel).Error; err != nil {
+		fmt.Println("Error sending job notification:", err)
+	}
+}
+
+func (n *postgresNotifier) Subscribe(ctx context.Context) <-chan struct{} {
+	return n.local.Subscribe(ctx)
+}
+
+// Connected reports whether Listen currently holds a connection in LISTEN.
+func (n *postgresNotifier) Connected() bool {
+	return n.connected.Load()
+}
+
+// Listen holds one connection in LISTEN until ctx is done, reconnecting
+// after a pause when it drops. Subscribers are woken after every (re)connect
+// to catch up on notifications sent while nobody listened, and when the
+// connection drops so they go back to polling at their short interval.
+func (n *postgresNotifier) Listen(ctx context.Context) {
+	for {
+		err := n.listen(ctx)
+		if ctx.Err() != nil {
+			return
+		}
+		fmt.Println("Job notifications interrupted, falling back to polling:", err)
+		n.local.Notify(ctx)
+
+		select {
+		case <-time.After(n.retry):
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func (n *postgresNotifier) listen(ctx context.Context) error {
+	sqlDB, err := n.db.DB()
+	if err != nil {
+		return err
+	}
+
+	conn, err := sqlDB.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	return conn.Raw(func(driverConn any) error {
+		stdConn, ok := driverConn.(*stdlib.Conn)
+		if !ok {
+			return fmt.Errorf("unexpected driver connection %T", driverConn)
+		}
+		pgConn := stdConn.Conn()
+
+		if _, err := pgConn.Exec(ctx, "LISTEN "+jobsChannel); err != nil {
+			return err
+		}
+		n.connected.Store(true)
+		defer n.connected.Store(false)
+		n.local.Notify(ctx)
+
+		for {
+			if _, err := pgConn.WaitForNotification(ctx); err != nil {
+				// The connection is still subscribed; keep it out of the pool.
+				return fmt.Errorf("%w: %v", driver.ErrBadConn, err)
+			}
+			n.local.Notify(ctx)
+		}
+	})
+}
@@ -0,0 +1,68 @@
+package notify
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/H3nSte1n/ci-orchestrator/internal/platform/config"
+	"github.com/H3nSte1n/ci-orchestrator/internal/platform/db"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func openTestDB(t *testing.T) *gorm.DB {
+	if os.Getenv("BASE_DIR") == "" {
+		t.Setenv("BASE_DIR", "../../../")
+	}
+
+	cfg, err := config.LoadConfig("test")
+	if err != nil {
+		t.Skipf("no test database: %v", err)
+	}
+	conn, err := db.NewPostgresConnection(cfg)
+	if err != nil {
+		t.Skipf("no test database: %v", err)
+	}
+	if err := conn.Exec("SELECT 1").Error; err != nil {
+		t.Skipf("no test database: %v", err)
+	}
+	return conn
+}
+
+func TestPostgresNotifier_WakesListenersInOtherProcesses(t *testing.T) {
+	conn := openTestDB(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	listener := NewPostgresNotifier(conn)
+	wake := listener.Subscribe(ctx)
+	go listener.Listen(ctx)
+
+	// Listen wakes subscribers once it is connected.
+	assert.True(t, received(wake))
+	assert.True(t, listener.Connected())
+
+	NewPostgresNotifier(conn).Notify(ctx)
+
+	assert.True(t, received(wake))
+}
+
+func TestPostgresNotifier_ReconnectsAfterDrop(t *testing.T) {
+	conn := openTestDB(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	listener := NewPostgresNotifier(conn)
+	listener.retry = 10 * time.Millisecond
+	wake := listener.Subscribe(ctx)
+	go listener.Listen(ctx)
+	assert.True(t, received(wake))
+
+	assert.NoError(t, conn.Exec("SELECT pg_terminate_backend(pid) FROM pg_stat_activity WHERE query = ?", "LISTEN "+jobsChannel).Error)
+
+	assert.Eventually(t, func() bool { return received(wake) }, 5*time.Second, 10*time.Millisecond)
+	NewPostgresNotifier(conn).Notify(ctx)
+	assert.True(t, received(wake))
+}
@@ -0,0 +1,34 @@
+package queue
+
+import (
+	"context"
+	"fmt"
+	"github.com/H3nSte1n/ci-orchestrator/internal/core/ports"
+	"github.com/H3nSte1n/ci-orchestrator/internal/platform/config"
+	"github.com/nats-io/nats.go"
+	"time"
+)
+
+// DefaultLease is how long a claimed job stays with its worker without a
+// heartbeat when the queue config sets no lease.
+const DefaultLease = time.Minute
+
+func NewQueue(ctx context.Context, cfg config.QueueConfig, buildRepository ports.BuildRepository) (ports.Queue, error) {
+	switch cfg.Driver {
+	case "", "database":
+		return NewRepositoryQueue(buildRepository), nil
+	case "nats":
+		lease := cfg.Lease
+		if lease <= 0 {
+			lease = DefaultLease
+		}
+
+		nc, err := nats.Connect(cfg.NATS.URL)
+		if err != nil {
+			return nil, fmt.Errorf("connect to nats: %w", err)
+		}
+		return NewJetStreamQueue(ctx, nc, lease)
+	default:
+		return nil, fmt.Errorf("unknown queue driver %q", cfg.Driver)
+	}
+}
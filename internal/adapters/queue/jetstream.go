@@ -0,0 +1,158 @@
+package queue
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/H3nSte1n/ci-orchestrator/internal/core/domain"
+	"github.com/H3nSte1n/ci-orchestrator/internal/core/ports"
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+	"sync"
+	"time"
+)
+
+const (
+	jetStreamName     = "CI_JOBS"
+	jetStreamSubject  = "ci.jobs"
+	jetStreamConsumer = "workers"
+)
+
+// jetStreamQueue keeps job ids in a NATS JetStream work queue. A claimed
+// message stays unacknowledged while the job runs; its ack wait is the
+// lease, renewed by Extend. Messages are acked by the process that claimed
+// them, so workers must reach the broker through one process per job, which
+// holds for workers with database access and for the API serving remote
+// workers.
+type jetStreamQueue struct {
+	js       jetstream.JetStream
+	consumer jetstream.Consumer
+	lease    time.Duration
+
+	mu       sync.Mutex
+	inFlight map[string]jetstream.Msg
+}
+
+// NewJetStreamQueue creates the stream and the shared consumer if they do
+// not exist yet.
+func NewJetStreamQueue(ctx context.Context, nc *nats.Conn, lease time.Duration) (ports.Queue, error) {
+	js, err := jetstream.New(nc)
+	if err != nil {
+		return nil, err
+	}
+
+	stream, err := js.CreateOrUpdateStream(ctx, jetstream.StreamConfig{
+		Name:      jetStreamName,
+		Subjects:  []string{jetStreamSubject},
+		Retention: jetstream.WorkQueuePolicy,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("create stream: %w", err)
+	}
+
+	consumer, err := stream.CreateOrUpdateConsumer(ctx, jetstream.ConsumerConfig{
+		Durable:   jetStreamConsumer,
+		AckPolicy: jetstream.AckExplicitPolicy,
+		AckWait:   lease,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("create consumer: %w", err)
+	}
+
+	return &jetStreamQueue{
+		js:       js,
+		consumer: consumer,
+		lease:    lease,
+		inFlight: map[string]jetstream.Msg{},
+	}, nil
+}
+
+// Enqueue publishes one message per job. The job id doubles as message id,
+// so JetStream drops a job enqueued twice within its duplicate window.
+func (q *jetStreamQueue) Enqueue(ctx context.Context, jobIds ...string) error {
+	for _, jobId := range jobIds {
+		if _, err := q.js.Publish(ctx, jetStreamSubject, []byte(jobId), jetstream.WithMsgID(jobId)); err != nil {
+			return fmt.Errorf("enqueue job %s: %w", jobId, err)
+		}
+	}
+	return nil
+}
+
+func (q *jetStreamQueue) Claim(ctx context.Context, workerId string) (*domain.Lease, error) {
+	batch, err := q.consumer.FetchNoWait(1)
+	if err != nil {
+		return nil, err
+	}
+
+	msg, ok := <-batch.Messages()
+	if !ok {
+		if err := batch.Error(); err != nil && !errors.Is(err, nats.ErrTimeout) {
+			return nil, err
+		}
+		return nil, nil
+	}
+
+	jobId := string(msg.Data())
+	q.mu.Lock()
+	q.inFlight[jobId] = msg
+	q.mu.Unlock()
+
+	lease := &domain.Lease{JobID: jobId, WorkerID: workerId, ExpiresAt: time.Now().Add(q.lease)}
+	if meta, err := msg.Metadata(); err == nil {
+		lease.Deliveries = int(meta.NumDelivered)
+	}
+	return lease, nil
+}
+
+// Ack, Nack, Defer and Extend ignore jobs this process does not hold: the
+// message is redelivered once its lease expires and starting it again fails.
+func (q *jetStreamQueue) Ack(ctx context.Context, jobId string) error {
+	msg := q.take(jobId)
+	if msg == nil {
+		return nil
+	}
+	return msg.Ack()
+}
+
+func (q *jetStreamQueue) Nack(ctx context.Context, jobId string) error {
+	msg := q.take(jobId)
+	if msg == nil {
+		return nil
+	}
+	return msg.Nak()
+}
+
+// Defer redelivers the message once until has passed.
+func (q *jetStreamQueue) Defer(ctx context.Context, jobId string, until time.Time) error {
+	msg := q.take(jobId)
+	if msg == nil {
+		return nil
+	}
+	return msg.NakWithDelay(time.Until(until))
+}
+
+func (q *jetStreamQueue) Extend(ctx context.Context, jobId string) error {
+	q.mu.Lock()
+	msg := q.inFlight[jobId]
+	q.mu.Unlock()
+
+	if msg == nil {
+		return nil
+	}
+	return msg.InProgress()
+}
+
+// Ranked is false: the stream hands jobs out in the order they were
+// enqueued, so the build service rejects priorities with this queue.
+func (q *jetStreamQueue) Ranked() bool {
+	return false
+}
+
+func (q *jetStreamQueue) take(jobId string) jetstream.Msg {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	msg := q.inFlight[jobId]
+	delete(q.inFlight, jobId)
+	return msg
+}
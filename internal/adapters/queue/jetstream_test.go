@@ -0,0 +1,205 @@
+package queue
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/H3nSte1n/ci-orchestrator/internal/adapters/memory"
+	"github.com/H3nSte1n/ci-orchestrator/internal/core/domain"
+	"github.com/H3nSte1n/ci-orchestrator/internal/core/ports"
+	"github.com/H3nSte1n/ci-orchestrator/internal/core/service"
+	"github.com/nats-io/nats-server/v2/server"
+	"github.com/nats-io/nats.go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newJetStreamQueue starts an embedded NATS server with JetStream for one
+// test.
+func newJetStreamQueue(t *testing.T, lease time.Duration) ports.Queue {
+	srv, err := server.NewServer(&server.Options{
+		Host:      "127.0.0.1",
+		Port:      server.RANDOM_PORT,
+		JetStream: true,
+		StoreDir:  t.TempDir(),
+		NoLog:     true,
+		NoSigs:    true,
+	})
+	require.NoError(t, err)
+	srv.Start()
+	t.Cleanup(srv.Shutdown)
+	require.True(t, srv.ReadyForConnections(5*time.Second))
+
+	nc, err := nats.Connect(srv.ClientURL())
+	require.NoError(t, err)
+	t.Cleanup(nc.Close)
+
+	q, err := NewJetStreamQueue(context.Background(), nc, lease)
+	require.NoError(t, err)
+	return q
+}
+
+func claimID(t *testing.T, q ports.Queue) string {
+	t.Helper()
+	lease, err := q.Claim(context.Background(), "worker-1")
+	require.NoError(t, err)
+	if lease == nil {
+		return ""
+	}
+	assert.Nil(t, lease.Job, "jobs are started by the build service")
+	return lease.JobID
+}
+
+func TestJetStreamQueue_ClaimsInOrder(t *testing.T) {
+	q := newJetStreamQueue(t, time.Minute)
+	ctx := context.Background()
+
+	assert.Empty(t, claimID(t, q))
+
+	require.NoError(t, q.Enqueue(ctx, "job-1", "job-2"))
+	assert.Equal(t, "job-1", claimID(t, q))
+	assert.Equal(t, "job-2", claimID(t, q))
+	assert.Empty(t, claimID(t, q))
+
+	require.NoError(t, q.Ack(ctx, "job-1"))
+	require.NoError(t, q.Ack(ctx, "job-2"))
+	assert.NoError(t, q.Ack(ctx, "unknown"))
+}
+
+func TestJetStreamQueue_DropsDuplicates(t *testing.T) {
+	q := newJetStreamQueue(t, time.Minute)
+	ctx := context.Background()
+
+	require.NoError(t, q.Enqueue(ctx, "job-1"))
+	require.NoError(t, q.Enqueue(ctx, "job-1"))
+
+	assert.Equal(t, "job-1", claimID(t, q))
+	assert.Empty(t, claimID(t, q))
+}
+
+func TestJetStreamQueue_NackRedelivers(t *testing.T) {
+	q := newJetStreamQueue(t, time.Minute)
+	ctx := context.Background()
+
+	require.NoError(t, q.Enqueue(ctx, "job-1"))
+	require.Equal(t, "job-1", claimID(t, q))
+	require.NoError(t, q.Nack(ctx, "job-1"))
+
+	assert.Eventually(t, func() bool { return claimID(t, q) == "job-1" }, 2*time.Second, 20*time.Millisecond)
+}
+
+func TestJetStreamQueue_DeferRedeliversLater(t *testing.T) {
+	q := newJetStreamQueue(t, time.Minute)
+	ctx := context.Background()
+
+	require.NoError(t, q.Enqueue(ctx, "job-1"))
+	require.Equal(t, "job-1", claimID(t, q))
+	until := time.Now().Add(300 * time.Millisecond)
+	require.NoError(t, q.Defer(ctx, "job-1", until))
+
+	assert.Empty(t, claimID(t, q))
+	assert.Eventually(t, func() bool { return claimID(t, q) == "job-1" }, 2*time.Second, 20*time.Millisecond)
+	assert.False(t, time.Now().Before(until))
+}
+
+func TestJetStreamQueue_LeaseExpiresUnlessExtended(t *testing.T) {
+	const lease = 300 * time.Millisecond
+	q := newJetStreamQueue(t, lease)
+	ctx := context.Background()
+
+	require.NoError(t, q.Enqueue(ctx, "extended", "expired"))
+	require.Equal(t, "extended", claimID(t, q))
+	require.Equal(t, "expired", claimID(t, q))
+
+	deadline := time.Now().Add(2 * lease)
+	var redelivered []string
+	for time.Now().Before(deadline) {
+		require.NoError(t, q.Extend(ctx, "extended"))
+		if id := claimID(t, q); id != "" {
+			redelivered = append(redelivered, id)
+		}
+		time.Sleep(lease / 5)
+	}
+
+	assert.Equal(t, []string{"expired"}, redelivered)
+}
+
+// limitedRepo may run one build at a time.
+const limitedRepo = "https://github.com/test/limited"
+
+// TestJetStreamQueue_ClaimSkipsHeldBackJobs puts a job the claiming worker
+// cannot start yet ahead of a runnable one in the stream.
+func TestJetStreamQueue_ClaimSkipsHeldBackJobs(t *testing.T) {
+	tests := []struct {
+		name string
+		// hold creates the build whose job cannot start yet.
+		hold func(t *testing.T, svc ports.BuildService) *domain.Build
+	}{
+		{"runs_on", func(t *testing.T, svc ports.BuildService) *domain.Build {
+			build := newServiceBuild("https://github.com/test/arm")
+			build.RunsOn = domain.StringList{"arm64"}
+			require.NoError(t, svc.CreateBuild(context.Background(), build))
+			return build
+		}},
+		{"concurrency group", func(t *testing.T, svc ports.BuildService) *domain.Build {
+			return holdBehindRunning(t, svc, func(build *domain.Build) {
+				group := "deploy-prod"
+				build.ConcurrencyGroup = &group
+			})
+		}},
+		{"repo limit", func(t *testing.T, svc ports.BuildService) *domain.Build {
+			return holdBehindRunning(t, svc, func(build *domain.Build) {
+				build.RepoUrl = limitedRepo
+			})
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			store := memory.NewStore()
+			require.NoError(t, memory.NewRepoLimitRepository(store).Save(ctx, &domain.RepoLimit{RepoUrl: limitedRepo, MaxRunningBuilds: 1}))
+			svc := service.NewBuildService(memory.NewBuildRepository(store), memory.NewWorkerRepository(store), newJetStreamQueue(t, time.Minute))
+
+			held := tt.hold(t, svc)
+			runnable := newServiceBuild("https://github.com/test/quiet")
+			require.NoError(t, svc.CreateBuild(ctx, runnable))
+
+			job, err := svc.ClaimNext(ctx, "worker-1")
+			require.NoError(t, err)
+			require.NotNil(t, job)
+			assert.Equal(t, runnable.Jobs[0].ID, job.ID, "the held back job does not block the next one")
+
+			job, err = svc.ClaimNext(ctx, "worker-1")
+			require.NoError(t, err)
+			assert.Nil(t, job, "the held back job stays out of the queue for a while")
+
+			found, err := svc.GetBuild(ctx, held.ID)
+			require.NoError(t, err)
+			assert.Equal(t, domain.BuildStatusPending, found.Status)
+		})
+	}
+}
+
+// holdBehindRunning starts a build set up by configure and creates another
+// one set up the same way, which has to wait for the first.
+func holdBehindRunning(t *testing.T, svc ports.BuildService, configure func(build *domain.Build)) *domain.Build {
+	ctx := context.Background()
+	running := newServiceBuild("https://github.com/test/busy")
+	configure(running)
+	require.NoError(t, svc.CreateBuild(ctx, running))
+	job, err := svc.ClaimNext(ctx, "worker-0")
+	require.NoError(t, err)
+	require.NotNil(t, job)
+	require.Equal(t, running.Jobs[0].ID, job.ID)
+
+	held := newServiceBuild("https://github.com/test/busy")
+	configure(held)
+	require.NoError(t, svc.CreateBuild(ctx, held))
+	return held
+}
+
+func newServiceBuild(repoUrl string) *domain.Build {
+	return &domain.Build{RepoUrl: repoUrl, Ref: "main", Command: "make"}
+}
@@ -0,0 +1,62 @@
+package queue
+
+import (
+	"context"
+	"github.com/H3nSte1n/ci-orchestrator/internal/core/domain"
+	"github.com/H3nSte1n/ci-orchestrator/internal/core/ports"
+	"time"
+)
+
+// repositoryQueue uses the job rows as the queue: claiming locks and starts
+// the oldest ready job in one transaction, and the heartbeat stored on the
+// row is the lease. Enqueue, Ack and Extend have nothing left to do.
+type repositoryQueue struct {
+	buildRepo ports.BuildRepository
+}
+
+func NewRepositoryQueue(buildRepository ports.BuildRepository) ports.Queue {
+	return &repositoryQueue{
+		buildRepo: buildRepository,
+	}
+}
+
+func (q *repositoryQueue) Enqueue(ctx context.Context, jobIds ...string) error {
+	return nil
+}
+
+func (q *repositoryQueue) Claim(ctx context.Context, workerId string) (*domain.Lease, error) {
+	job, err := q.buildRepo.ClaimNext(ctx, workerId)
+	if err != nil || job == nil {
+		return nil, err
+	}
+	return &domain.Lease{JobID: job.ID, WorkerID: workerId, Job: job}, nil
+}
+
+func (q *repositoryQueue) Ack(ctx context.Context, jobId string) error {
+	return nil
+}
+
+func (q *repositoryQueue) Nack(ctx context.Context, jobId string) error {
+	job, err := q.buildRepo.FindJobByID(ctx, jobId)
+	if err != nil {
+		return err
+	}
+	if job.LockedBy == nil {
+		return nil
+	}
+	return q.buildRepo.ReleaseJob(ctx, jobId, *job.LockedBy)
+}
+
+// Defer releases the job like Nack; claims skip it until its build is due.
+func (q *repositoryQueue) Defer(ctx context.Context, jobId string, until time.Time) error {
+	return q.Nack(ctx, jobId)
+}
+
+func (q *repositoryQueue) Extend(ctx context.Context, jobId string) error {
+	return nil
+}
+
+// Ranked is true: claims order the job rows by ranked_at.
+func (q *repositoryQueue) Ranked() bool {
+	return true
+}
@@ -0,0 +1,41 @@
+package queue
+
+import (
+	"context"
+	"testing"
+
+	"github.com/H3nSte1n/ci-orchestrator/internal/adapters/memory"
+	"github.com/H3nSte1n/ci-orchestrator/internal/core/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepositoryQueue(t *testing.T) {
+	ctx := context.Background()
+	repo := memory.NewBuildRepository(memory.NewStore())
+	q := NewRepositoryQueue(repo)
+
+	lease, err := q.Claim(ctx, "worker-1")
+	require.NoError(t, err)
+	assert.Nil(t, lease)
+
+	build := &domain.Build{RepoUrl: "https://github.com/test/repo", Ref: "main", Command: "make", Jobs: []domain.Job{{Name: domain.DefaultJobName, Command: "make"}}}
+	require.NoError(t, repo.Save(ctx, build))
+	require.NoError(t, q.Enqueue(ctx, build.Jobs[0].ID))
+
+	lease, err = q.Claim(ctx, "worker-1")
+	require.NoError(t, err)
+	require.NotNil(t, lease)
+	assert.Equal(t, build.Jobs[0].ID, lease.JobID)
+	require.NotNil(t, lease.Job, "the job is started while claiming")
+	assert.Equal(t, domain.JobStatusRunning, lease.Job.Status)
+	assert.NoError(t, q.Extend(ctx, lease.JobID))
+
+	require.NoError(t, q.Nack(ctx, lease.JobID))
+	lease, err = q.Claim(ctx, "worker-2")
+	require.NoError(t, err)
+	require.NotNil(t, lease)
+	assert.Equal(t, build.Jobs[0].ID, lease.JobID)
+	assert.Equal(t, "worker-2", *lease.Job.LockedBy)
+	assert.NoError(t, q.Ack(ctx, lease.JobID))
+}
@@ -0,0 +1,66 @@
+package repositories
+
+import (
+	"context"
+	"github.com/H3nSte1n/ci-orchestrator/internal/core/domain"
+	"github.com/H3nSte1n/ci-orchestrator/internal/core/ports"
+	"gorm.io/gorm"
+	"time"
+)
+
+type apiTokenRepository struct {
+	db ports.DB
+}
+
+func NewAPITokenRepository(db *gorm.DB) ports.APITokenRepository {
+	return &apiTokenRepository{
+		db: NewGormAdapter(db),
+	}
+}
+
+func (r *apiTokenRepository) Save(ctx context.Context, token *domain.APIToken) error {
+	return translateError(r.db.WithContext(ctx).Create(token).GetError(), domain.ErrTokenNotFound)
+}
+
+func (r *apiTokenRepository) FindByHash(ctx context.Context, tokenHash string) (*domain.APIToken, error) {
+	var token domain.APIToken
+	err := r.db.WithContext(ctx).Where("token_hash = ?", tokenHash).First(&token).GetError()
+	if err != nil {
+		return nil, translateError(err, domain.ErrTokenNotFound)
+	}
+	return &token, nil
+}
+
+func (r *apiTokenRepository) List(ctx context.Context) ([]domain.APIToken, error) {
+	tokens := []domain.APIToken{}
+	err := r.db.WithContext(ctx).Order("created_at DESC").Find(&tokens).GetError()
+	if err != nil {
+		return nil, translateError(err, domain.ErrTokenNotFound)
+	}
+	return tokens, nil
+}
+
+// Revoke marks an active token as revoked; revoking an unknown or already
+// revoked token reports ErrTokenNotFound.
+func (r *apiTokenRepository) Revoke(ctx context.Context, tokenId string, at time.Time) error {
+	result := r.db.WithContext(ctx).
+		Model(&domain.APIToken{}).
+		Where("id = ? AND revoked_at IS NULL", tokenId).
+		Updates(map[string]interface{}{"revoked_at": at})
+	if err := result.GetError(); err != nil {
+		return translateError(err, domain.ErrTokenNotFound)
+	}
+	if result.GetRowsAffected() == 0 {
+		return domain.ErrTokenNotFound
+	}
+	return nil
+}
+
+func (r *apiTokenRepository) TouchLastUsed(ctx context.Context, tokenId string, at time.Time) error {
+	err := r.db.WithContext(ctx).
+		Model(&domain.APIToken{}).
+		Where("id = ?", tokenId).
+		Updates(map[string]interface{}{"last_used_at": at}).
+		GetError()
+	return translateError(err, domain.ErrTokenNotFound)
+}
@@ -0,0 +1,128 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/H3nSte1n/ci-orchestrator/internal/core/domain"
+	"github.com/H3nSte1n/ci-orchestrator/internal/core/ports"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+func TestNewAPITokenRepository(t *testing.T) {
+	repo := NewAPITokenRepository(&gorm.DB{})
+
+	assert.NotNil(t, repo)
+	assert.Implements(t, (*ports.APITokenRepository)(nil), repo)
+}
+
+func TestAPITokenRepository_Save_Success(t *testing.T) {
+	mockDB := new(mockDB)
+
+	mockDB.On("WithContext", mock.Anything).Return(mockDB)
+	mockDB.On("Create", mock.Anything).Return(mockDB)
+
+	repo := &apiTokenRepository{db: mockDB}
+	err := repo.Save(context.Background(), &domain.APIToken{Name: "bot", TokenHash: "hash"})
+
+	assert.NoError(t, err)
+	mockDB.AssertExpectations(t)
+}
+
+func TestAPITokenRepository_FindByHash_Success(t *testing.T) {
+	mockDB := new(mockDB)
+
+	mockDB.On("WithContext", mock.Anything).Return(mockDB)
+	mockDB.On("Where", "token_hash = ?", []interface{}{"hash"}).Return(mockDB)
+	mockDB.On("First", mock.Anything).Return(mockDB)
+
+	repo := &apiTokenRepository{db: mockDB}
+	token, err := repo.FindByHash(context.Background(), "hash")
+
+	assert.NoError(t, err)
+	assert.NotNil(t, token)
+	mockDB.AssertExpectations(t)
+}
+
+func TestAPITokenRepository_FindByHash_NotFound(t *testing.T) {
+	mockDB := new(mockDB)
+	mockDB.Error = gorm.ErrRecordNotFound
+
+	mockDB.On("WithContext", mock.Anything).Return(mockDB)
+	mockDB.On("Where", mock.Anything, mock.Anything).Return(mockDB)
+	mockDB.On("First", mock.Anything).Return(mockDB)
+
+	repo := &apiTokenRepository{db: mockDB}
+	token, err := repo.FindByHash(context.Background(), "hash")
+
+	assert.Nil(t, token)
+	assert.ErrorIs(t, err, domain.ErrTokenNotFound)
+}
+
+func TestAPITokenRepository_List_Error(t *testing.T) {
+	mockDB := new(mockDB)
+	expectedErr := errors.New("find failed")
+	mockDB.Error = expectedErr
+
+	mockDB.On("WithContext", mock.Anything).Return(mockDB)
+	mockDB.On("Order", "created_at DESC").Return(mockDB)
+	mockDB.On("Find", mock.Anything).Return(mockDB)
+
+	repo := &apiTokenRepository{db: mockDB}
+	tokens, err := repo.List(context.Background())
+
+	assert.Nil(t, tokens)
+	assert.Equal(t, expectedErr, err)
+}
+
+func TestAPITokenRepository_Revoke_Success(t *testing.T) {
+	mockDB := new(mockDB)
+	mockDB.RowsAffected = 1
+	at := time.Now()
+
+	mockDB.On("WithContext", mock.Anything).Return(mockDB)
+	mockDB.On("Model", mock.Anything).Return(mockDB)
+	mockDB.On("Where", "id = ? AND revoked_at IS NULL", []interface{}{"token-id"}).Return(mockDB)
+	mockDB.On("Updates", map[string]interface{}{"revoked_at": at}).Return(mockDB)
+
+	repo := &apiTokenRepository{db: mockDB}
+	err := repo.Revoke(context.Background(), "token-id", at)
+
+	assert.NoError(t, err)
+	mockDB.AssertExpectations(t)
+}
+
+func TestAPITokenRepository_Revoke_NotFound(t *testing.T) {
+	mockDB := new(mockDB)
+	mockDB.RowsAffected = 0
+
+	mockDB.On("WithContext", mock.Anything).Return(mockDB)
+	mockDB.On("Model", mock.Anything).Return(mockDB)
+	mockDB.On("Where", mock.Anything, mock.Anything).Return(mockDB)
+	mockDB.On("Updates", mock.Anything).Return(mockDB)
+
+	repo := &apiTokenRepository{db: mockDB}
+	err := repo.Revoke(context.Background(), "token-id", time.Now())
+
+	assert.ErrorIs(t, err, domain.ErrTokenNotFound)
+}
+
+func TestAPITokenRepository_TouchLastUsed(t *testing.T) {
+	mockDB := new(mockDB)
+	at := time.Now()
+
+	mockDB.On("WithContext", mock.Anything).Return(mockDB)
+	mockDB.On("Model", mock.Anything).Return(mockDB)
+	mockDB.On("Where", "id = ?", []interface{}{"token-id"}).Return(mockDB)
+	mockDB.On("Updates", map[string]interface{}{"last_used_at": at}).Return(mockDB)
+
+	repo := &apiTokenRepository{db: mockDB}
+	err := repo.TouchLastUsed(context.Background(), "token-id", at)
+
+	assert.NoError(t, err)
+	mockDB.AssertExpectations(t)
+}
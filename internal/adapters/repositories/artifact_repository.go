@@ -0,0 +1,40 @@
+package repositories
+
+import (
+	"context"
+	"github.com/H3nSte1n/ci-orchestrator/internal/core/domain"
+	"github.com/H3nSte1n/ci-orchestrator/internal/core/ports"
+	"gorm.io/gorm"
+)
+
+type artifactRepository struct {
+	db ports.DB
+}
+
+func NewArtifactRepository(db *gorm.DB) ports.ArtifactRepository {
+	return &artifactRepository{
+		db: NewGormAdapter(db),
+	}
+}
+
+func (r *artifactRepository) Save(ctx context.Context, artifact *domain.Artifact) error {
+	return translateError(r.db.WithContext(ctx).Create(artifact).GetError(), domain.ErrArtifactNotFound)
+}
+
+func (r *artifactRepository) FindByBuildID(ctx context.Context, buildId string) ([]domain.Artifact, error) {
+	artifacts := []domain.Artifact{}
+	err := r.db.WithContext(ctx).Where("build_id = ?", buildId).Order("name ASC").Find(&artifacts).GetError()
+	if err != nil {
+		return nil, translateError(err, domain.ErrArtifactNotFound)
+	}
+	return artifacts, nil
+}
+
+func (r *artifactRepository) FindByName(ctx context.Context, buildId string, name string) (*domain.Artifact, error) {
+	var artifact domain.Artifact
+	err := r.db.WithContext(ctx).Where("build_id = ? AND name = ?", buildId, name).First(&artifact).GetError()
+	if err != nil {
+		return nil, translateError(err, domain.ErrArtifactNotFound)
+	}
+	return &artifact, nil
+}
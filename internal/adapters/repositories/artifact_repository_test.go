@@ -0,0 +1,133 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/H3nSte1n/ci-orchestrator/internal/core/domain"
+	"github.com/H3nSte1n/ci-orchestrator/internal/core/ports"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+func artifactTestData() *domain.Artifact {
+	return &domain.Artifact{
+		BuildID:     "ci-id",
+		JobID:       "job-id",
+		Name:        "dist/app",
+		Size:        42,
+		Checksum:    "sha256:abc",
+		ContentType: "application/octet-stream",
+		StorageKey:  "builds/ci-id/dist/app",
+	}
+}
+
+func TestNewArtifactRepository(t *testing.T) {
+	repo := NewArtifactRepository(&gorm.DB{})
+
+	assert.NotNil(t, repo)
+	assert.Implements(t, (*ports.ArtifactRepository)(nil), repo)
+}
+
+func TestArtifactRepository_Save_Success(t *testing.T) {
+	mockDB := new(mockDB)
+	mockDB.Error = nil
+
+	mockDB.On("WithContext", mock.Anything).Return(mockDB)
+	mockDB.On("Create", mock.Anything).Return(mockDB)
+
+	repo := &artifactRepository{db: mockDB}
+	err := repo.Save(context.Background(), artifactTestData())
+
+	assert.NoError(t, err)
+	mockDB.AssertExpectations(t)
+}
+
+func TestArtifactRepository_Save_Error(t *testing.T) {
+	mockDB := new(mockDB)
+	expectedErr := errors.New("create failed")
+	mockDB.Error = expectedErr
+
+	mockDB.On("WithContext", mock.Anything).Return(mockDB)
+	mockDB.On("Create", mock.Anything).Return(mockDB)
+
+	repo := &artifactRepository{db: mockDB}
+	err := repo.Save(context.Background(), artifactTestData())
+
+	assert.Equal(t, expectedErr, err)
+	mockDB.AssertExpectations(t)
+}
+
+func TestArtifactRepository_FindByBuildID_Success(t *testing.T) {
+	mockDB := new(mockDB)
+	mockDB.Error = nil
+
+	mockDB.On("WithContext", mock.Anything).Return(mockDB)
+	mockDB.On("Where", "build_id = ?", []interface{}{"ci-id"}).Return(mockDB)
+	mockDB.On("Order", "name ASC").Return(mockDB)
+	mockDB.On("Find", mock.AnythingOfType("*[]domain.Artifact")).Run(func(args mock.Arguments) {
+		dest := args.Get(0).(*[]domain.Artifact)
+		*dest = []domain.Artifact{*artifactTestData()}
+	}).Return(mockDB)
+
+	repo := &artifactRepository{db: mockDB}
+	artifacts, err := repo.FindByBuildID(context.Background(), "ci-id")
+
+	assert.NoError(t, err)
+	assert.Len(t, artifacts, 1)
+	assert.Equal(t, "dist/app", artifacts[0].Name)
+	mockDB.AssertExpectations(t)
+}
+
+func TestArtifactRepository_FindByBuildID_Error(t *testing.T) {
+	mockDB := new(mockDB)
+	expectedErr := errors.New("query failed")
+	mockDB.Error = expectedErr
+
+	mockDB.On("WithContext", mock.Anything).Return(mockDB)
+	mockDB.On("Where", mock.Anything, mock.Anything).Return(mockDB)
+	mockDB.On("Order", mock.Anything).Return(mockDB)
+	mockDB.On("Find", mock.Anything).Return(mockDB)
+
+	repo := &artifactRepository{db: mockDB}
+	artifacts, err := repo.FindByBuildID(context.Background(), "ci-id")
+
+	assert.Nil(t, artifacts)
+	assert.Equal(t, expectedErr, err)
+}
+
+func TestArtifactRepository_FindByName_Success(t *testing.T) {
+	mockDB := new(mockDB)
+	mockDB.Error = nil
+
+	mockDB.On("WithContext", mock.Anything).Return(mockDB)
+	mockDB.On("Where", "build_id = ? AND name = ?", []interface{}{"ci-id", "dist/app"}).Return(mockDB)
+	mockDB.On("First", mock.AnythingOfType("*domain.Artifact")).Run(func(args mock.Arguments) {
+		dest := args.Get(0).(*domain.Artifact)
+		*dest = *artifactTestData()
+	}).Return(mockDB)
+
+	repo := &artifactRepository{db: mockDB}
+	artifact, err := repo.FindByName(context.Background(), "ci-id", "dist/app")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "builds/ci-id/dist/app", artifact.StorageKey)
+	mockDB.AssertExpectations(t)
+}
+
+func TestArtifactRepository_FindByName_NotFound(t *testing.T) {
+	mockDB := new(mockDB)
+	mockDB.Error = gorm.ErrRecordNotFound
+
+	mockDB.On("WithContext", mock.Anything).Return(mockDB)
+	mockDB.On("Where", mock.Anything, mock.Anything).Return(mockDB)
+	mockDB.On("First", mock.Anything).Return(mockDB)
+
+	repo := &artifactRepository{db: mockDB}
+	artifact, err := repo.FindByName(context.Background(), "ci-id", "missing")
+
+	assert.Nil(t, artifact)
+	assert.ErrorIs(t, err, domain.ErrArtifactNotFound)
+}
@@ -5,18 +5,74 @@ import (
 	"github.com/H3nSte1n/ci-orchestrator/internal/core/domain"
 	"github.com/H3nSte1n/ci-orchestrator/internal/core/ports"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"slices"
 )
 
 type buildLogRepository struct {
-	db ports.DB
+	db      ports.DB
+	dialect dialect
 }
 
 func NewBuildLogRepository(db *gorm.DB) ports.BuildLogRepository {
 	return &buildLogRepository{
-		db: NewGormAdapter(db),
+		db:      NewGormAdapter(db),
+		dialect: dialectOf(db),
 	}
 }
 
 func (blR *buildLogRepository) Save(ctx context.Context, buildLog *domain.BuildLog) error {
-	return blR.db.WithContext(ctx).Create(buildLog).GetError()
+	return blR.insert(ctx, []string{buildLog.BuildID}, buildLog)
+}
+
+// SaveAll inserts a batch of log lines in one statement.
+func (blR *buildLogRepository) SaveAll(ctx context.Context, buildLogs []domain.BuildLog) error {
+	if len(buildLogs) == 0 {
+		return nil
+	}
+	buildIds := make([]string, 0, 1)
+	for _, buildLog := range buildLogs {
+		if err := blR.dialect.checkID(buildLog.BuildID); err != nil {
+			return err
+		}
+		buildIds = append(buildIds, buildLog.BuildID)
+	}
+	slices.Sort(buildIds)
+	return blR.insert(ctx, slices.Compact(buildIds), &buildLogs)
+}
+
+// insert creates lines while holding the rows of their builds. Jobs of one
+// build append in parallel, and without the lock a line could take a lower
+// seq yet commit after a follower has paged past it; with it, the seqs of a
+// build commit in order.
+func (blR *buildLogRepository) insert(ctx context.Context, buildIds []string, lines interface{}) error {
+	err := blR.db.WithContext(ctx).Transaction(func(tx ports.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("id IN ?", buildIds).
+			Order("id").
+			Find(&[]domain.Build{}).GetError(); err != nil {
+			return err
+		}
+		return tx.Create(lines).GetError()
+	})
+	return translateError(err, domain.ErrBuildNotFound)
+}
+
+func (blR *buildLogRepository) FindByBuildID(ctx context.Context, buildId string, afterSeq int64, limit int) ([]domain.BuildLog, error) {
+	if err := blR.dialect.checkID(buildId); err != nil {
+		return nil, err
+	}
+	query := blR.db.WithContext(ctx).Where("build_id = ?", buildId)
+	if afterSeq > 0 {
+		query = query.Where("seq > ?", afterSeq)
+	}
+	if limit > 0 {
+		query = query.Limit(limit)
+	}
+
+	logs := []domain.BuildLog{}
+	if err := query.Order("seq ASC").Find(&logs).GetError(); err != nil {
+		return nil, translateError(err, domain.ErrBuildNotFound)
+	}
+	return logs, nil
 }
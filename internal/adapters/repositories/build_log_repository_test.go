@@ -34,6 +34,10 @@ func TestBuildLogRepository_Save_Success(t *testing.T) {
 	mockDB.Error = nil
 
 	mockDB.On("WithContext", mock.Anything).Return(mockDB)
+	mockDB.On("Clauses", mock.Anything).Return(mockDB)
+	mockDB.On("Where", "id IN ?", []interface{}{[]string{"1"}}).Return(mockDB)
+	mockDB.On("Order", "id").Return(mockDB)
+	mockDB.On("Find", mock.Anything).Return(mockDB)
 	mockDB.On("Create", mock.Anything).Return(mockDB)
 
 	ctx := context.Background()
@@ -51,7 +55,10 @@ func TestBuildLogRepository_Save_Error(t *testing.T) {
 	mockDB.Error = expectedErr
 
 	mockDB.On("WithContext", mock.Anything).Return(mockDB)
-	mockDB.On("Create", mock.Anything).Return(mockDB)
+	mockDB.On("Clauses", mock.Anything).Return(mockDB)
+	mockDB.On("Where", "id IN ?", []interface{}{[]string{"1"}}).Return(mockDB)
+	mockDB.On("Order", "id").Return(mockDB)
+	mockDB.On("Find", mock.Anything).Return(mockDB)
 
 	ctx := context.Background()
 	buildLog := buildLogTestData()
@@ -61,4 +68,52 @@ func TestBuildLogRepository_Save_Error(t *testing.T) {
 	assert.Error(t, err)
 	assert.Equal(t, expectedErr, err)
 	mockDB.AssertExpectations(t)
+	mockDB.AssertNotCalled(t, "Create", mock.Anything)
+}
+
+func TestBuildLogRepository_SaveAll(t *testing.T) {
+	mockDB := new(mockDB)
+
+	mockDB.On("WithContext", mock.Anything).Return(mockDB)
+	mockDB.On("Clauses", mock.Anything).Return(mockDB)
+	mockDB.On("Where", "id IN ?", []interface{}{[]string{"1"}}).Return(mockDB)
+	mockDB.On("Order", "id").Return(mockDB)
+	mockDB.On("Find", mock.Anything).Return(mockDB)
+	mockDB.On("Create", mock.MatchedBy(func(logs *[]domain.BuildLog) bool {
+		return len(*logs) == 2
+	})).Return(mockDB)
+
+	repo := buildLogRepository{db: mockDB}
+	err := repo.SaveAll(context.Background(), []domain.BuildLog{buildLogTestData(), buildLogTestData()})
+
+	assert.NoError(t, err)
+	mockDB.AssertExpectations(t)
+}
+
+func TestBuildLogRepository_SaveAll_Empty(t *testing.T) {
+	mockDB := new(mockDB)
+
+	repo := buildLogRepository{db: mockDB}
+	err := repo.SaveAll(context.Background(), nil)
+
+	assert.NoError(t, err)
+	mockDB.AssertNotCalled(t, "Create", mock.Anything)
+}
+
+func TestBuildLogRepository_FindByBuildID(t *testing.T) {
+	mockDB := new(mockDB)
+
+	mockDB.On("WithContext", mock.Anything).Return(mockDB)
+	mockDB.On("Where", "build_id = ?", []interface{}{"1"}).Return(mockDB)
+	mockDB.On("Where", "seq > ?", []interface{}{int64(10)}).Return(mockDB)
+	mockDB.On("Limit", 100).Return(mockDB)
+	mockDB.On("Order", "seq ASC").Return(mockDB)
+	mockDB.On("Find", mock.Anything).Return(mockDB)
+
+	repo := buildLogRepository{db: mockDB}
+	logs, err := repo.FindByBuildID(context.Background(), "1", 10, 100)
+
+	assert.NoError(t, err)
+	assert.NotNil(t, logs)
+	mockDB.AssertExpectations(t)
 }
@@ -7,66 +7,679 @@ import (
 	"github.com/H3nSte1n/ci-orchestrator/internal/core/ports"
 	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
+	"strings"
 	"time"
 )
 
 type buildRepository struct {
-	db ports.DB
+	db      ports.DB
+	dialect dialect
 }
 
 func NewBuildRepository(gormDB *gorm.DB) ports.BuildRepository {
 	return &buildRepository{
-		db: NewGormAdapter(gormDB),
+		db:      NewGormAdapter(gormDB),
+		dialect: dialectOf(gormDB),
 	}
 }
 
 func (r *buildRepository) Save(ctx context.Context, build *domain.Build) error {
-	return r.db.WithContext(ctx).Create(build).GetError()
+	build.RankJobs(time.Now())
+	err := r.db.WithContext(ctx).Transaction(func(tx ports.DB) error {
+		if err := saveConcurrencyGroup(tx, build); err != nil {
+			return err
+		}
+		return tx.Create(build).GetError()
+	})
+	return translateError(err, domain.ErrBuildNotFound)
 }
 
 func (r *buildRepository) Update(ctx context.Context, build *domain.Build) error {
-	return r.db.WithContext(ctx).Where("id = ?", build.ID).Updates(build).GetError()
+	if err := r.dialect.checkID(build.ID); err != nil {
+		return err
+	}
+	result := r.db.WithContext(ctx).Where("id = ?", build.ID).Updates(build)
+	if err := result.GetError(); err != nil {
+		return translateError(err, domain.ErrBuildNotFound)
+	}
+	if result.GetRowsAffected() == 0 {
+		return domain.ErrBuildNotFound
+	}
+	return nil
 }
 
 func (r *buildRepository) FindByID(ctx context.Context, buildId string) (*domain.Build, error) {
+	if err := r.dialect.checkID(buildId); err != nil {
+		return nil, err
+	}
 	var build domain.Build
-	err := r.db.WithContext(ctx).Where("id = ?", buildId).First(&build).GetError()
+	err := r.db.WithContext(ctx).
+		Preload("Jobs").
+		Preload("Children").
+		Where("id = ?", buildId).
+		First(&build).GetError()
 	if err != nil {
-		return nil, err
+		return nil, translateError(err, domain.ErrBuildNotFound)
 	}
 	return &build, nil
 }
 
-func (r *buildRepository) ClaimNext(ctx context.Context, workerId string) (*domain.Build, error) {
-	var build domain.Build
+// List returns the builds matching filter, newest first, without their jobs.
+func (r *buildRepository) List(ctx context.Context, filter domain.BuildFilter) ([]domain.Build, error) {
+	query := r.db.WithContext(ctx)
+
+	if len(filter.Statuses) > 0 {
+		query = query.Where("status IN ?", filter.Statuses)
+	}
+	if filter.RepoUrl != "" {
+		query = query.Where("repo_url = ?", filter.RepoUrl)
+	}
+	if filter.Ref != "" {
+		query = query.Where("ref = ?", filter.Ref)
+	}
+	if filter.LockedBy != "" {
+		query = query.Where("locked_by = ?", filter.LockedBy)
+	}
+	if filter.CreatedAfter != nil {
+		query = query.Where("created_at >= ?", *filter.CreatedAfter)
+	}
+	if filter.CreatedBefore != nil {
+		query = query.Where("created_at < ?", *filter.CreatedBefore)
+	}
+	if filter.FinishedAfter != nil {
+		query = query.Where("finished_at >= ?", *filter.FinishedAfter)
+	}
+	if filter.FinishedBefore != nil {
+		query = query.Where("finished_at < ?", *filter.FinishedBefore)
+	}
+	if filter.Query != "" {
+		query = query.Where(`LOWER(command) LIKE ? ESCAPE '\'`, "%"+escapeLike(strings.ToLower(filter.Query))+"%")
+	}
+	if filter.After != nil {
+		query = query.Where("(created_at, id) < (?, ?)", filter.After.CreatedAt, filter.After.ID)
+	}
+	if filter.Limit > 0 {
+		query = query.Limit(filter.Limit)
+	}
+
+	builds := []domain.Build{}
+	if err := query.Order("created_at DESC, id DESC").Find(&builds).GetError(); err != nil {
+		return nil, translateError(err, domain.ErrBuildNotFound)
+	}
+	return builds, nil
+}
+
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(s)
+}
+
+// UpdatePriority changes the priority of a pending build and its matrix
+// children and reranks their pending jobs.
+func (r *buildRepository) UpdatePriority(ctx context.Context, buildId string, priority int) error {
+	if err := r.dialect.checkID(buildId); err != nil {
+		return err
+	}
 
 	err := r.db.WithContext(ctx).Transaction(func(tx ports.DB) error {
-		if err := tx.Where("status = ?", domain.BuildStatusPending).
-			Where("locked_by IS NULL").
-			Where("locked_at IS NULL").
-			Order("created_at ASC").
-			Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+		var build domain.Build
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("id = ?", buildId).
 			First(&build).GetError(); err != nil {
 			return err
 		}
+		if build.Status != domain.BuildStatusPending {
+			return domain.ErrBuildNotPending
+		}
 
-		if err := tx.Model(&build).Updates(map[string]interface{}{
-			"status":    domain.BuildStatusRunning,
-			"locked_by": workerId,
-			"locked_at": time.Now(),
-		}).GetError(); err != nil {
+		if err := tx.Model(&domain.Build{}).
+			Where("id = ? OR parent_id = ?", buildId, buildId).
+			Updates(map[string]interface{}{"priority": priority}).GetError(); err != nil {
 			return err
 		}
 
+		var jobs []domain.Job
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("build_id IN (SELECT id FROM builds WHERE id = ? OR parent_id = ?)", buildId, buildId).
+			Where("status = ?", domain.JobStatusPending).
+			Find(&jobs).GetError(); err != nil {
+			return err
+		}
+		for i := range jobs {
+			if err := tx.Model(&jobs[i]).Updates(map[string]interface{}{
+				"ranked_at": domain.RankAt(jobs[i].CreatedAt, priority),
+			}).GetError(); err != nil {
+				return err
+			}
+		}
 		return nil
 	})
 
+	if err != nil {
+		if errors.Is(err, domain.ErrBuildNotPending) {
+			return domain.ErrBuildNotPending
+		}
+		return translateError(err, domain.ErrBuildNotFound)
+	}
+	return nil
+}
+
+func (r *buildRepository) ClaimNext(ctx context.Context, workerId string) (*domain.Job, error) {
+	var job domain.Job
+
+	err := r.db.WithContext(ctx).Transaction(func(tx ports.DB) error {
+		// Jobs whose repository or group filled up between the candidate
+		// query and the locks; the next candidate query sees the new running
+		// build.
+		var full []string
+		for {
+			query := r.claimable(tx).
+				Joins(repoShareJoin, domain.BuildStatusRunning).
+				Where("jobs.build_id IN (SELECT id FROM builds WHERE "+dueClause+" AND "+r.dialect.runsOnClause()+" AND "+concurrencyClause+" AND "+repoLimitClause+")",
+					time.Now().UTC(), workerId, domain.BuildStatusRunning, domain.BuildStatusRunning, domain.BuildStatusRunning, domain.BuildStatusRunning)
+			if len(full) > 0 {
+				query = query.Where("jobs.id NOT IN ?", full)
+			}
+			job = domain.Job{}
+			if err := query.
+				Order(r.dialect.shareRankOrder() + " ASC, jobs.created_at ASC").
+				// SQLite ignores the lock; its transactions take the write lock
+				// when they begin, so claims run one at a time.
+				Clauses(clause.Locking{Strength: "UPDATE", Table: clause.Table{Name: "jobs"}, Options: "SKIP LOCKED"}).
+				First(&job).GetError(); err != nil {
+				return err
+			}
+
+			err := holdSlot(tx, job.BuildID)
+			if errors.Is(err, domain.ErrRepoLimit) || errors.Is(err, domain.ErrConcurrencyLimit) {
+				full = append(full, job.ID)
+				continue
+			}
+			if err != nil {
+				return err
+			}
+			return start(tx, &job, workerId)
+		}
+	})
+
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, nil
 		}
+		return nil, translateError(err, domain.ErrJobNotFound)
+	}
+
+	return &job, nil
+}
+
+// StartJob starts a job handed out by a queue, see ports.BuildRepository.
+func (r *buildRepository) StartJob(ctx context.Context, jobId string, workerId string) (*domain.Job, error) {
+	if err := r.dialect.checkID(jobId); err != nil {
 		return nil, err
 	}
 
-	return &build, nil
+	var job domain.Job
+
+	err := r.db.WithContext(ctx).Transaction(func(tx ports.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("id = ?", jobId).
+			First(&job).GetError(); err != nil {
+			return err
+		}
+
+		if job.Status == domain.JobStatusRunning && job.LockedBy != nil && *job.LockedBy == workerId {
+			var build domain.Build
+			if err := tx.Where("id = ?", job.BuildID).First(&build).GetError(); err != nil {
+				return err
+			}
+			job.Build = &build
+			return nil
+		}
+
+		if err := r.claimable(tx).Where("id = ?", jobId).First(&domain.Job{}).GetError(); err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return domain.ErrJobNotClaimable
+			}
+			return err
+		}
+
+		if err := tx.Where("id = ?", job.BuildID).
+			Where(dueClause, time.Now().UTC()).
+			First(&domain.Build{}).GetError(); err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return domain.ErrBuildNotDue
+			}
+			return err
+		}
+
+		if err := tx.Where("id = ?", job.BuildID).
+			Where(r.dialect.runsOnClause(), workerId).
+			First(&domain.Build{}).GetError(); err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return domain.ErrJobNotForWorker
+			}
+			return err
+		}
+
+		if err := holdSlot(tx, job.BuildID); err != nil {
+			return err
+		}
+
+		return start(tx, &job, workerId)
+	})
+
+	if err != nil {
+		if errors.Is(err, domain.ErrJobNotClaimable) || errors.Is(err, domain.ErrBuildNotDue) || errors.Is(err, domain.ErrJobNotForWorker) ||
+			errors.Is(err, domain.ErrConcurrencyLimit) || errors.Is(err, domain.ErrRepoLimit) {
+			return nil, err
+		}
+		return nil, translateError(err, domain.ErrJobNotFound)
+	}
+
+	return &job, nil
+}
+
+// ReleaseJob puts a running job back to pending.
+func (r *buildRepository) ReleaseJob(ctx context.Context, jobId string, workerId string) error {
+	if err := r.dialect.checkID(jobId); err != nil {
+		return err
+	}
+
+	result := r.db.WithContext(ctx).
+		Model(&domain.Job{}).
+		Where("id = ? AND locked_by = ? AND status = ?", jobId, workerId, domain.JobStatusRunning).
+		Updates(map[string]interface{}{
+			"status":    domain.JobStatusPending,
+			"locked_by": nil,
+			"locked_at": nil,
+		})
+	if err := result.GetError(); err != nil {
+		return translateError(err, domain.ErrJobNotFound)
+	}
+	if result.GetRowsAffected() == 0 {
+		return domain.ErrJobNotFound
+	}
+	return nil
+}
+
+// RequeueExpired puts running jobs with an expired lock back to pending, see
+// ports.BuildRepository.
+func (r *buildRepository) RequeueExpired(ctx context.Context, before time.Time) ([]string, error) {
+	var requeued []string
+	err := r.db.WithContext(ctx).Transaction(func(tx ports.DB) error {
+		var jobs []domain.Job
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("status = ? AND locked_at < ?", domain.JobStatusRunning, before).
+			Order("locked_at ASC").
+			Find(&jobs).GetError(); err != nil {
+			return err
+		}
+
+		now := time.Now()
+		for i := range jobs {
+			var build domain.Build
+			if err := tx.Where("id = ?", jobs[i].BuildID).First(&build).GetError(); err != nil {
+				return err
+			}
+
+			updates := map[string]interface{}{
+				"status":    domain.JobStatusPending,
+				"locked_by": nil,
+				"locked_at": nil,
+			}
+			if build.Status.IsTerminal() {
+				updates = map[string]interface{}{
+					"status":      domain.JobStatusCanceled,
+					"finished_at": now,
+				}
+			}
+			if err := tx.Model(&jobs[i]).Updates(updates).GetError(); err != nil {
+				return err
+			}
+			if !build.Status.IsTerminal() {
+				requeued = append(requeued, jobs[i].ID)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, translateError(err, domain.ErrJobNotFound)
+	}
+	return requeued, nil
+}
+
+// claimable narrows a job query to pending jobs of active builds whose
+// needs have all succeeded.
+func (r *buildRepository) claimable(tx ports.DB) ports.DB {
+	return tx.Where("jobs.status = ?", domain.JobStatusPending).
+		Where("jobs.locked_by IS NULL").
+		Where("jobs.build_id IN (SELECT id FROM builds WHERE status IN ?)", []domain.BuildStatus{domain.BuildStatusPending, domain.BuildStatusRunning}).
+		Where("NOT "+r.dialect.pendingNeedsClause(), domain.JobStatusSuccess)
+}
+
+// start marks a locked job as running for workerId, together with its build
+// and matrix parent, and attaches the build to job.
+func start(tx ports.DB, job *domain.Job, workerId string) error {
+	now := time.Now()
+	if err := tx.Model(job).Updates(map[string]interface{}{
+		"status":    domain.JobStatusRunning,
+		"locked_by": workerId,
+		"locked_at": now,
+	}).GetError(); err != nil {
+		return err
+	}
+	job.Status = domain.JobStatusRunning
+	job.LockedBy = &workerId
+	job.LockedAt = &now
+
+	// Parent before child: CompleteJob locks in the same order.
+	if err := tx.Model(&domain.Build{}).
+		Where("id = (SELECT parent_id FROM builds WHERE id = ?)", job.BuildID).
+		Where("status = ?", domain.BuildStatusPending).
+		Updates(map[string]interface{}{
+			"status": domain.BuildStatusRunning,
+		}).GetError(); err != nil {
+		return err
+	}
+
+	if err := tx.Model(&domain.Build{}).
+		Where("id = ?", job.BuildID).
+		Where("status = ?", domain.BuildStatusPending).
+		Updates(map[string]interface{}{
+			"status":    domain.BuildStatusRunning,
+			"locked_by": workerId,
+			"locked_at": now,
+		}).GetError(); err != nil {
+		return err
+	}
+
+	var build domain.Build
+	if err := tx.Where("id = ?", job.BuildID).First(&build).GetError(); err != nil {
+		return err
+	}
+	job.Build = &build
+
+	return nil
+}
+
+// FindJobByID loads a job together with its build.
+func (r *buildRepository) FindJobByID(ctx context.Context, jobId string) (*domain.Job, error) {
+	if err := r.dialect.checkID(jobId); err != nil {
+		return nil, err
+	}
+	var job domain.Job
+	if err := r.db.WithContext(ctx).Where("id = ?", jobId).First(&job).GetError(); err != nil {
+		return nil, translateError(err, domain.ErrJobNotFound)
+	}
+
+	var build domain.Build
+	if err := r.db.WithContext(ctx).Where("id = ?", job.BuildID).First(&build).GetError(); err != nil {
+		return nil, translateError(err, domain.ErrBuildNotFound)
+	}
+	job.Build = &build
+
+	return &job, nil
+}
+
+// Heartbeat refreshes the lock of a running job held by workerId.
+func (r *buildRepository) Heartbeat(ctx context.Context, jobId string, workerId string, at time.Time) error {
+	if err := r.dialect.checkID(jobId); err != nil {
+		return err
+	}
+	result := r.db.WithContext(ctx).
+		Model(&domain.Job{}).
+		Where("id = ? AND locked_by = ? AND status = ?", jobId, workerId, domain.JobStatusRunning).
+		Updates(map[string]interface{}{"locked_at": at})
+	if err := result.GetError(); err != nil {
+		return translateError(err, domain.ErrJobNotFound)
+	}
+	if result.GetRowsAffected() == 0 {
+		return domain.ErrJobNotFound
+	}
+	return nil
+}
+
+// CompleteJob records the outcome of a job held by workerId, skips everything
+// downstream of a failed job and rolls the job states up into the build
+// status (and, for matrix children, into the parent). Build rows are locked
+// parent first so that sibling jobs finishing concurrently aggregate in
+// order.
+func (r *buildRepository) CompleteJob(ctx context.Context, job *domain.Job, workerId string) error {
+	if err := r.dialect.checkID(job.ID); err != nil {
+		return err
+	}
+	err := r.db.WithContext(ctx).Transaction(func(tx ports.DB) error {
+		var stored domain.Job
+		if err := tx.Where("id = ?", job.ID).First(&stored).GetError(); err != nil {
+			return err
+		}
+
+		var build domain.Build
+		if err := tx.Where("id = ?", stored.BuildID).First(&build).GetError(); err != nil {
+			return err
+		}
+
+		var parent *domain.Build
+		if build.ParentID != nil {
+			parent = &domain.Build{}
+			if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+				Where("id = ?", *build.ParentID).
+				First(parent).GetError(); err != nil {
+				return err
+			}
+		}
+
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("id = ?", stored.BuildID).
+			First(&build).GetError(); err != nil {
+			return err
+		}
+
+		result := tx.Model(&domain.Job{}).
+			Where("id = ? AND locked_by = ? AND status = ?", job.ID, workerId, domain.JobStatusRunning).
+			Updates(map[string]interface{}{
+				"status":      job.Status,
+				"exit_code":   job.ExitCode,
+				"error":       job.Error,
+				"finished_at": job.FinishedAt,
+			})
+		if err := result.GetError(); err != nil {
+			return err
+		}
+		if result.GetRowsAffected() == 0 {
+			return domain.ErrJobLockLost
+		}
+
+		var jobs []domain.Job
+		if err := tx.Where("build_id = ?", stored.BuildID).Order("created_at ASC").Find(&jobs).GetError(); err != nil {
+			return err
+		}
+
+		for i := range jobs {
+			if jobs[i].ID == job.ID {
+				jobs[i].Status = job.Status
+				jobs[i].ExitCode = job.ExitCode
+				jobs[i].Error = job.Error
+			}
+		}
+
+		if job.Status != domain.JobStatusSuccess {
+			skipped := domain.Downstream(jobs, stored.Name)
+			if len(skipped) > 0 {
+				if err := tx.Model(&domain.Job{}).
+					Where("build_id = ?", stored.BuildID).
+					Where("name IN ?", skipped).
+					Where("status = ?", domain.JobStatusPending).
+					Updates(map[string]interface{}{
+						"status":      domain.JobStatusSkipped,
+						"finished_at": job.FinishedAt,
+					}).GetError(); err != nil {
+					return err
+				}
+
+				for i := range jobs {
+					for _, name := range skipped {
+						if jobs[i].Name == name && jobs[i].Status == domain.JobStatusPending {
+							jobs[i].Status = domain.JobStatusSkipped
+						}
+					}
+				}
+			}
+		}
+
+		if build.Status == domain.BuildStatusCanceled {
+			return nil
+		}
+
+		status, finished := domain.AggregateStatus(jobs)
+		updates := map[string]interface{}{
+			"status": status,
+		}
+		if finished {
+			updates["finished_at"] = job.FinishedAt
+			for _, j := range jobs {
+				if j.Status == domain.JobStatusFailed {
+					updates["exit_code"] = j.ExitCode
+					updates["error"] = j.Error
+					break
+				}
+			}
+		}
+
+		if err := tx.Model(&build).Updates(updates).GetError(); err != nil {
+			return err
+		}
+
+		if parent != nil && finished {
+			return rollUpParent(tx, r.dialect, parent, status, job.FinishedAt)
+		}
+
+		return nil
+	})
+
+	return translateError(err, domain.ErrJobNotFound)
+}
+
+// Cancel cancels a build together with its matrix children and jobs, see
+// ports.BuildRepository.
+func (r *buildRepository) Cancel(ctx context.Context, buildId string) error {
+	if err := r.dialect.checkID(buildId); err != nil {
+		return err
+	}
+	err := r.db.WithContext(ctx).Transaction(func(tx ports.DB) error {
+		var build domain.Build
+		if err := tx.Where("id = ?", buildId).First(&build).GetError(); err != nil {
+			return err
+		}
+
+		var parent *domain.Build
+		if build.ParentID != nil {
+			parent = &domain.Build{}
+			if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+				Where("id = ?", *build.ParentID).
+				First(parent).GetError(); err != nil {
+				return err
+			}
+		}
+
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("id = ?", buildId).
+			First(&build).GetError(); err != nil {
+			return err
+		}
+		if build.Status.IsTerminal() {
+			return nil
+		}
+
+		if err := cancelBuilds(tx, r.dialect, "id = ? OR parent_id = ?", buildId, buildId); err != nil {
+			return err
+		}
+
+		if parent != nil {
+			now := time.Now()
+			return rollUpParent(tx, r.dialect, parent, domain.BuildStatusCanceled, &now)
+		}
+		return nil
+	})
+
+	return translateError(err, domain.ErrBuildNotFound)
+}
+
+// cancelBuilds cancels the unfinished builds matching query. Their pending
+// jobs are canceled and their running jobs get cancel_requested_at, which
+// tells the worker running them to stop.
+func cancelBuilds(tx ports.DB, d dialect, query string, args ...interface{}) error {
+	var builds []domain.Build
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where(query, args...).
+		Where("status IN ?", []domain.BuildStatus{domain.BuildStatusPending, domain.BuildStatusRunning}).
+		Find(&builds).GetError(); err != nil {
+		return err
+	}
+	if len(builds) == 0 {
+		return nil
+	}
+	ids := make([]string, len(builds))
+	for i := range builds {
+		ids[i] = builds[i].ID
+	}
+
+	now := time.Now()
+	if err := tx.Model(&domain.Build{}).
+		Where("id IN ?", ids).
+		Updates(map[string]interface{}{
+			"status":              domain.BuildStatusCanceled,
+			"cancel_requested_at": now,
+			"finished_at":         now,
+		}).GetError(); err != nil {
+		return err
+	}
+
+	// Jobs a worker is claiming right now are skipped rather than waited
+	// for; they finish against a canceled build and are ignored. SQLite
+	// has no row locks to skip: claims and this update never overlap.
+	pending := "id IN (SELECT id FROM jobs WHERE status = ? AND build_id IN ? FOR UPDATE SKIP LOCKED)"
+	if d == dialectSQLite {
+		pending = "status = ? AND build_id IN ?"
+	}
+	if err := tx.Model(&domain.Job{}).
+		Where(pending, domain.JobStatusPending, ids).
+		Updates(map[string]interface{}{
+			"status":      domain.JobStatusCanceled,
+			"finished_at": now,
+		}).GetError(); err != nil {
+		return err
+	}
+
+	return tx.Model(&domain.Job{}).
+		Where("status = ? AND build_id IN ?", domain.JobStatusRunning, ids).
+		Updates(map[string]interface{}{"cancel_requested_at": now}).GetError()
+}
+
+// rollUpParent updates a matrix parent after one of its children finished,
+// canceling the remaining children first when the matrix is fail-fast.
+func rollUpParent(tx ports.DB, d dialect, parent *domain.Build, childStatus domain.BuildStatus, finishedAt *time.Time) error {
+	if parent.Status.IsTerminal() {
+		return nil
+	}
+
+	if childStatus == domain.BuildStatusFailed && parent.Matrix != nil && parent.Matrix.FailFast {
+		if err := cancelBuilds(tx, d, "parent_id = ?", parent.ID); err != nil {
+			return err
+		}
+	}
+
+	var children []domain.Build
+	if err := tx.Where("parent_id = ?", parent.ID).Find(&children).GetError(); err != nil {
+		return err
+	}
+
+	status, finished := domain.RollUpChildren(children)
+	updates := map[string]interface{}{
+		"status": status,
+	}
+	if finished {
+		updates["finished_at"] = finishedAt
+	}
+
+	return tx.Model(parent).Updates(updates).GetError()
 }
@@ -6,10 +6,12 @@ import (
 	"github.com/H3nSte1n/ci-orchestrator/internal/core/ports"
 	"gorm.io/gorm"
 	"testing"
+	"time"
 
 	"github.com/H3nSte1n/ci-orchestrator/internal/core/domain"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 )
 
 func buildTestData() *domain.Build {
@@ -22,6 +24,16 @@ func buildTestData() *domain.Build {
 	}
 }
 
+func jobTestData() *domain.Job {
+	return &domain.Job{
+		ID:      "job-id",
+		BuildID: "ci-id",
+		Name:    domain.DefaultJobName,
+		Command: "npm test",
+		Status:  domain.JobStatusPending,
+	}
+}
+
 func TestNewBuildRepository(t *testing.T) {
 	mockDB := &gorm.DB{}
 
@@ -68,6 +80,7 @@ func TestBuildRepository_Save_Error(t *testing.T) {
 func TestBuildRepository_Update_Success(t *testing.T) {
 	mockDB := new(mockDB)
 	mockDB.Error = nil
+	mockDB.RowsAffected = 1
 
 	mockDB.On("WithContext", mock.Anything).Return(mockDB)
 	mockDB.On("Where", mock.Anything, mock.Anything).Return(mockDB)
@@ -101,11 +114,46 @@ func TestBuildRepository_Update_Error(t *testing.T) {
 	mockDB.AssertExpectations(t)
 }
 
+func TestBuildRepository_Update_NotFound(t *testing.T) {
+	mockDB := new(mockDB)
+	mockDB.Error = nil
+	mockDB.RowsAffected = 0
+
+	mockDB.On("WithContext", mock.Anything).Return(mockDB)
+	mockDB.On("Where", mock.Anything, mock.Anything).Return(mockDB)
+	mockDB.On("Updates", mock.Anything).Return(mockDB)
+
+	repo := &buildRepository{db: mockDB}
+	err := repo.Update(context.Background(), buildTestData())
+
+	assert.ErrorIs(t, err, domain.ErrBuildNotFound)
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+}
+
+func TestBuildRepository_FindByID_NotFound(t *testing.T) {
+	mockDB := new(mockDB)
+	mockDB.Error = gorm.ErrRecordNotFound
+
+	mockDB.On("WithContext", mock.Anything).Return(mockDB)
+	mockDB.On("Preload", mock.Anything, mock.Anything).Return(mockDB)
+	mockDB.On("Where", mock.Anything, mock.Anything).Return(mockDB)
+	mockDB.On("First", mock.Anything).Return(mockDB)
+
+	repo := &buildRepository{db: mockDB}
+	build, err := repo.FindByID(context.Background(), "3f1b2c1e-7d4a-4a57-9a61-8a4f0e6c9d10")
+
+	assert.Nil(t, build)
+	assert.ErrorIs(t, err, domain.ErrBuildNotFound)
+	assert.NotContains(t, err.Error(), "record not found")
+}
+
 func TestBuildRepository_FindByID_Success(t *testing.T) {
 	mockDB := new(mockDB)
 	mockDB.Error = nil
 
 	mockDB.On("WithContext", mock.Anything).Return(mockDB)
+	mockDB.On("Preload", "Jobs", mock.Anything).Return(mockDB)
+	mockDB.On("Preload", "Children", mock.Anything).Return(mockDB)
 	mockDB.On("Where", mock.Anything, mock.Anything).Return(mockDB)
 	mockDB.On("First", mock.Anything).Return(mockDB).Run(func(args mock.Arguments) {
 		build := args.Get(0).(*domain.Build)
@@ -127,6 +175,8 @@ func TestBuildRepository_FindByID_Error(t *testing.T) {
 	mockDB.Error = expectedErr
 
 	mockDB.On("WithContext", mock.Anything).Return(mockDB)
+	mockDB.On("Preload", "Jobs", mock.Anything).Return(mockDB)
+	mockDB.On("Preload", "Children", mock.Anything).Return(mockDB)
 	mockDB.On("Where", mock.Anything, mock.Anything).Return(mockDB)
 	mockDB.On("First", mock.Anything).Return(mockDB)
 
@@ -139,27 +189,90 @@ func TestBuildRepository_FindByID_Error(t *testing.T) {
 	mockDB.AssertExpectations(t)
 }
 
+func TestBuildRepository_List_AppliesFilters(t *testing.T) {
+	mockDB := new(mockDB)
+	mockDB.Error = nil
+	createdAfter := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	cursor := &domain.BuildCursor{CreatedAt: createdAfter.Add(time.Hour), ID: "cursor-id"}
+
+	mockDB.On("WithContext", mock.Anything).Return(mockDB)
+	mockDB.On("Where", "status IN ?", []interface{}{[]domain.BuildStatus{domain.BuildStatusFailed}}).Return(mockDB)
+	mockDB.On("Where", "repo_url = ?", []interface{}{"https://github.com/test/repo"}).Return(mockDB)
+	mockDB.On("Where", "locked_by = ?", []interface{}{"worker-1"}).Return(mockDB)
+	mockDB.On("Where", "created_at >= ?", []interface{}{createdAfter}).Return(mockDB)
+	mockDB.On("Where", `LOWER(command) LIKE ? ESCAPE '\'`, []interface{}{`%npm\_test 100\%%`}).Return(mockDB)
+	mockDB.On("Where", "(created_at, id) < (?, ?)", []interface{}{cursor.CreatedAt, "cursor-id"}).Return(mockDB)
+	mockDB.On("Limit", 21).Return(mockDB)
+	mockDB.On("Order", "created_at DESC, id DESC").Return(mockDB)
+	mockDB.On("Find", mock.AnythingOfType("*[]domain.Build")).Return(mockDB).Run(func(args mock.Arguments) {
+		builds := args.Get(0).(*[]domain.Build)
+		*builds = []domain.Build{*buildTestData()}
+	})
+
+	repo := &buildRepository{db: mockDB}
+	builds, err := repo.List(context.Background(), domain.BuildFilter{
+		Statuses:     []domain.BuildStatus{domain.BuildStatusFailed},
+		RepoUrl:      "https://github.com/test/repo",
+		LockedBy:     "worker-1",
+		CreatedAfter: &createdAfter,
+		Query:        "NPM_test 100%",
+		After:        cursor,
+		Limit:        21,
+	})
+
+	assert.NoError(t, err)
+	assert.Len(t, builds, 1)
+	mockDB.AssertExpectations(t)
+}
+
+func TestBuildRepository_List_Error(t *testing.T) {
+	mockDB := new(mockDB)
+	expectedErr := errors.New("database error")
+	mockDB.Error = expectedErr
+
+	mockDB.On("WithContext", mock.Anything).Return(mockDB)
+	mockDB.On("Order", mock.Anything).Return(mockDB)
+	mockDB.On("Find", mock.Anything).Return(mockDB)
+
+	repo := &buildRepository{db: mockDB}
+	builds, err := repo.List(context.Background(), domain.BuildFilter{})
+
+	assert.Nil(t, builds)
+	assert.Equal(t, expectedErr, err)
+	mockDB.AssertNotCalled(t, "Where", mock.Anything, mock.Anything)
+	mockDB.AssertNotCalled(t, "Limit", mock.Anything)
+}
+
 func TestBuildRepository_ClaimNext_Success(t *testing.T) {
 	mockDB := new(mockDB)
 	mockDB.Error = nil
 
 	mockDB.On("WithContext", mock.Anything).Return(mockDB)
 	mockDB.On("Where", mock.Anything, mock.Anything).Return(mockDB)
+	mockDB.On("Joins", mock.Anything, mock.Anything).Return(mockDB)
 	mockDB.On("Order", mock.Anything).Return(mockDB)
 	mockDB.On("Clauses", mock.Anything).Return(mockDB)
 	mockDB.On("First", mock.Anything).Return(mockDB).Run(func(args mock.Arguments) {
-		build := args.Get(0).(*domain.Build)
-		*build = *buildTestData()
+		switch dest := args.Get(0).(type) {
+		case *domain.Job:
+			*dest = *jobTestData()
+		case *domain.Build:
+			*dest = *buildTestData()
+		}
 	})
+	mockDB.On("Find", mock.Anything).Return(mockDB)
 	mockDB.On("Model", mock.Anything).Return(mockDB)
 	mockDB.On("Updates", mock.Anything).Return(mockDB)
 
 	repo := &buildRepository{db: mockDB}
 	ctx := context.Background()
-	build, err := repo.ClaimNext(ctx, "worker-id")
+	job, err := repo.ClaimNext(ctx, "worker-id")
 
 	assert.NoError(t, err)
-	assert.Equal(t, "ci-id", build.ID)
+	assert.Equal(t, "job-id", job.ID)
+	assert.Equal(t, domain.JobStatusRunning, job.Status)
+	assert.Equal(t, "worker-id", *job.LockedBy)
+	assert.Equal(t, "ci-id", job.Build.ID)
 	mockDB.AssertExpectations(t)
 }
 
@@ -170,16 +283,291 @@ func TestBuildRepository_ClaimNext_Error(t *testing.T) {
 
 	mockDB.On("WithContext", mock.Anything).Return(mockDB)
 	mockDB.On("Where", mock.Anything, mock.Anything).Return(mockDB)
+	mockDB.On("Joins", mock.Anything, mock.Anything).Return(mockDB)
 	mockDB.On("Order", mock.Anything).Return(mockDB)
 	mockDB.On("Clauses", mock.Anything).Return(mockDB)
 	mockDB.On("First", mock.Anything).Return(mockDB)
 
 	repo := &buildRepository{db: mockDB}
 	ctx := context.Background()
-	build, err := repo.ClaimNext(ctx, "worker-id")
+	job, err := repo.ClaimNext(ctx, "worker-id")
 
 	assert.Error(t, err)
-	assert.Nil(t, build)
+	assert.Nil(t, job)
 	assert.Equal(t, expectedErr, err)
 	mockDB.AssertExpectations(t)
 }
+
+func TestBuildRepository_ClaimNext_NoPendingJobs(t *testing.T) {
+	mockDB := new(mockDB)
+	mockDB.Error = gorm.ErrRecordNotFound
+
+	mockDB.On("WithContext", mock.Anything).Return(mockDB)
+	mockDB.On("Where", mock.Anything, mock.Anything).Return(mockDB)
+	mockDB.On("Joins", mock.Anything, mock.Anything).Return(mockDB)
+	mockDB.On("Order", mock.Anything).Return(mockDB)
+	mockDB.On("Clauses", mock.Anything).Return(mockDB)
+	mockDB.On("First", mock.Anything).Return(mockDB)
+
+	repo := &buildRepository{db: mockDB}
+	job, err := repo.ClaimNext(context.Background(), "worker-id")
+
+	assert.NoError(t, err)
+	assert.Nil(t, job)
+}
+
+func TestBuildRepository_CompleteJob_FailureSkipsDownstreamAndFailsBuild(t *testing.T) {
+	mockDB := new(mockDB)
+	mockDB.Error = nil
+	mockDB.RowsAffected = 1
+
+	var buildUpdates map[string]interface{}
+	var skippedUpdate bool
+
+	mockDB.On("WithContext", mock.Anything).Return(mockDB)
+	mockDB.On("Where", mock.Anything, mock.Anything).Return(mockDB)
+	mockDB.On("Order", mock.Anything).Return(mockDB)
+	mockDB.On("Clauses", mock.Anything).Return(mockDB)
+	mockDB.On("First", mock.Anything).Return(mockDB).Run(func(args mock.Arguments) {
+		switch dest := args.Get(0).(type) {
+		case *domain.Job:
+			*dest = domain.Job{ID: "job-build", BuildID: "ci-id", Name: "build", Status: domain.JobStatusRunning}
+		case *domain.Build:
+			*dest = domain.Build{ID: "ci-id", Status: domain.BuildStatusRunning}
+		}
+	})
+	mockDB.On("Find", mock.Anything).Return(mockDB).Run(func(args mock.Arguments) {
+		jobs := args.Get(0).(*[]domain.Job)
+		*jobs = []domain.Job{
+			{ID: "job-build", BuildID: "ci-id", Name: "build", Status: domain.JobStatusRunning},
+			{ID: "job-test", BuildID: "ci-id", Name: "test", Status: domain.JobStatusPending, Needs: domain.StringList{"build"}},
+			{ID: "job-lint", BuildID: "ci-id", Name: "lint", Status: domain.JobStatusSuccess},
+		}
+	})
+	mockDB.On("Model", mock.Anything).Return(mockDB)
+	mockDB.On("Updates", mock.Anything).Return(mockDB).Run(func(args mock.Arguments) {
+		updates := args.Get(0).(map[string]interface{})
+		if updates["status"] == domain.JobStatusSkipped {
+			skippedUpdate = true
+		}
+		if _, ok := updates["status"].(domain.BuildStatus); ok {
+			buildUpdates = updates
+		}
+	})
+
+	repo := &buildRepository{db: mockDB}
+	err := repo.CompleteJob(context.Background(), &domain.Job{
+		ID:       "job-build",
+		Status:   domain.JobStatusFailed,
+		ExitCode: 2,
+		Error:    "exit status 2",
+	}, "worker-1")
+
+	assert.NoError(t, err)
+	assert.True(t, skippedUpdate)
+	assert.Equal(t, domain.BuildStatusFailed, buildUpdates["status"])
+	assert.Equal(t, 2, buildUpdates["exit_code"])
+	mockDB.AssertExpectations(t)
+}
+
+func TestBuildRepository_CompleteJob_KeepsBuildRunningWhileJobsRemain(t *testing.T) {
+	mockDB := new(mockDB)
+	mockDB.Error = nil
+	mockDB.RowsAffected = 1
+
+	var buildUpdates map[string]interface{}
+
+	mockDB.On("WithContext", mock.Anything).Return(mockDB)
+	mockDB.On("Where", mock.Anything, mock.Anything).Return(mockDB)
+	mockDB.On("Order", mock.Anything).Return(mockDB)
+	mockDB.On("Clauses", mock.Anything).Return(mockDB)
+	mockDB.On("First", mock.Anything).Return(mockDB).Run(func(args mock.Arguments) {
+		switch dest := args.Get(0).(type) {
+		case *domain.Job:
+			*dest = domain.Job{ID: "job-build", BuildID: "ci-id", Name: "build", Status: domain.JobStatusRunning}
+		case *domain.Build:
+			*dest = domain.Build{ID: "ci-id", Status: domain.BuildStatusRunning}
+		}
+	})
+	mockDB.On("Find", mock.Anything).Return(mockDB).Run(func(args mock.Arguments) {
+		jobs := args.Get(0).(*[]domain.Job)
+		*jobs = []domain.Job{
+			{ID: "job-build", BuildID: "ci-id", Name: "build", Status: domain.JobStatusRunning},
+			{ID: "job-test", BuildID: "ci-id", Name: "test", Status: domain.JobStatusPending, Needs: domain.StringList{"build"}},
+		}
+	})
+	mockDB.On("Model", mock.Anything).Return(mockDB)
+	mockDB.On("Updates", mock.Anything).Return(mockDB).Run(func(args mock.Arguments) {
+		updates := args.Get(0).(map[string]interface{})
+		if _, ok := updates["status"].(domain.BuildStatus); ok {
+			buildUpdates = updates
+		}
+	})
+
+	repo := &buildRepository{db: mockDB}
+	err := repo.CompleteJob(context.Background(), &domain.Job{
+		ID:     "job-build",
+		Status: domain.JobStatusSuccess,
+	}, "worker-1")
+
+	assert.NoError(t, err)
+	assert.Equal(t, domain.BuildStatusRunning, buildUpdates["status"])
+	assert.NotContains(t, buildUpdates, "finished_at")
+}
+
+func TestBuildRepository_CompleteJob_Error(t *testing.T) {
+	mockDB := new(mockDB)
+	expectedErr := errors.New("database error")
+	mockDB.Error = expectedErr
+
+	mockDB.On("WithContext", mock.Anything).Return(mockDB)
+	mockDB.On("Where", mock.Anything, mock.Anything).Return(mockDB)
+	mockDB.On("First", mock.Anything).Return(mockDB)
+
+	repo := &buildRepository{db: mockDB}
+	err := repo.CompleteJob(context.Background(), &domain.Job{ID: "job-id", Status: domain.JobStatusSuccess}, "worker-1")
+
+	assert.Equal(t, expectedErr, err)
+}
+
+func TestBuildRepository_RollUpParent_FailFastCancelsSiblings(t *testing.T) {
+	mockDB := new(mockDB)
+	mockDB.Error = nil
+
+	var updates []map[string]interface{}
+
+	mockDB.On("Clauses", mock.Anything).Return(mockDB)
+	mockDB.On("Where", mock.Anything, mock.Anything).Return(mockDB)
+	mockDB.On("Find", mock.Anything).Return(mockDB).Run(func(args mock.Arguments) {
+		children := args.Get(0).(*[]domain.Build)
+		*children = []domain.Build{
+			{ID: "child-1", Status: domain.BuildStatusFailed},
+			{ID: "child-2", Status: domain.BuildStatusCanceled},
+		}
+	})
+	mockDB.On("Model", mock.Anything).Return(mockDB)
+	mockDB.On("Updates", mock.Anything).Return(mockDB).Run(func(args mock.Arguments) {
+		updates = append(updates, args.Get(0).(map[string]interface{}))
+	})
+
+	parent := &domain.Build{
+		ID:     "parent-id",
+		Status: domain.BuildStatusRunning,
+		Matrix: &domain.Matrix{FailFast: true},
+	}
+	finishedAt := time.Now()
+
+	err := rollUpParent(mockDB, dialectPostgres, parent, domain.BuildStatusFailed, &finishedAt)
+
+	assert.NoError(t, err)
+	require.Len(t, updates, 4)
+	assert.Equal(t, domain.BuildStatusCanceled, updates[0]["status"])
+	assert.Equal(t, domain.JobStatusCanceled, updates[1]["status"])
+	assert.Contains(t, updates[2], "cancel_requested_at", "running jobs are asked to stop")
+	assert.Equal(t, domain.BuildStatusFailed, updates[3]["status"])
+	assert.Equal(t, &finishedAt, updates[3]["finished_at"])
+}
+
+func TestBuildRepository_RollUpParent_WithoutFailFast(t *testing.T) {
+	mockDB := new(mockDB)
+	mockDB.Error = nil
+
+	var updates []map[string]interface{}
+
+	mockDB.On("Where", mock.Anything, mock.Anything).Return(mockDB)
+	mockDB.On("Find", mock.Anything).Return(mockDB).Run(func(args mock.Arguments) {
+		children := args.Get(0).(*[]domain.Build)
+		*children = []domain.Build{
+			{ID: "child-1", Status: domain.BuildStatusFailed},
+			{ID: "child-2", Status: domain.BuildStatusRunning},
+		}
+	})
+	mockDB.On("Model", mock.Anything).Return(mockDB)
+	mockDB.On("Updates", mock.Anything).Return(mockDB).Run(func(args mock.Arguments) {
+		updates = append(updates, args.Get(0).(map[string]interface{}))
+	})
+
+	parent := &domain.Build{
+		ID:     "parent-id",
+		Status: domain.BuildStatusRunning,
+		Matrix: &domain.Matrix{},
+	}
+
+	err := rollUpParent(mockDB, dialectPostgres, parent, domain.BuildStatusFailed, nil)
+
+	assert.NoError(t, err)
+	require.Len(t, updates, 1)
+	assert.Equal(t, domain.BuildStatusRunning, updates[0]["status"])
+	assert.NotContains(t, updates[0], "finished_at")
+}
+
+func TestBuildRepository_FindJobByID_Success(t *testing.T) {
+	mockDB := new(mockDB)
+
+	mockDB.On("WithContext", mock.Anything).Return(mockDB)
+	mockDB.On("Where", "id = ?", []interface{}{"job-id"}).Return(mockDB)
+	mockDB.On("Where", "id = ?", []interface{}{"ci-id"}).Return(mockDB)
+	mockDB.On("First", mock.Anything).Return(mockDB).Run(func(args mock.Arguments) {
+		switch dest := args.Get(0).(type) {
+		case *domain.Job:
+			*dest = *jobTestData()
+		case *domain.Build:
+			*dest = *buildTestData()
+		}
+	})
+
+	repo := &buildRepository{db: mockDB}
+	job, err := repo.FindJobByID(context.Background(), "job-id")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "job-id", job.ID)
+	assert.Equal(t, "ci-id", job.Build.ID)
+	mockDB.AssertExpectations(t)
+}
+
+func TestBuildRepository_FindJobByID_NotFound(t *testing.T) {
+	mockDB := new(mockDB)
+	mockDB.Error = gorm.ErrRecordNotFound
+
+	mockDB.On("WithContext", mock.Anything).Return(mockDB)
+	mockDB.On("Where", mock.Anything, mock.Anything).Return(mockDB)
+	mockDB.On("First", mock.Anything).Return(mockDB)
+
+	repo := &buildRepository{db: mockDB}
+	job, err := repo.FindJobByID(context.Background(), "job-id")
+
+	assert.Nil(t, job)
+	assert.ErrorIs(t, err, domain.ErrJobNotFound)
+}
+
+func TestBuildRepository_Heartbeat(t *testing.T) {
+	mockDB := new(mockDB)
+	mockDB.RowsAffected = 1
+	at := time.Now()
+
+	mockDB.On("WithContext", mock.Anything).Return(mockDB)
+	mockDB.On("Model", mock.Anything).Return(mockDB)
+	mockDB.On("Where", "id = ? AND locked_by = ? AND status = ?", []interface{}{"job-id", "worker-1", domain.JobStatusRunning}).Return(mockDB)
+	mockDB.On("Updates", map[string]interface{}{"locked_at": at}).Return(mockDB)
+
+	repo := &buildRepository{db: mockDB}
+	err := repo.Heartbeat(context.Background(), "job-id", "worker-1", at)
+
+	assert.NoError(t, err)
+	mockDB.AssertExpectations(t)
+}
+
+func TestBuildRepository_Heartbeat_NotOwned(t *testing.T) {
+	mockDB := new(mockDB)
+	mockDB.RowsAffected = 0
+
+	mockDB.On("WithContext", mock.Anything).Return(mockDB)
+	mockDB.On("Model", mock.Anything).Return(mockDB)
+	mockDB.On("Where", mock.Anything, mock.Anything).Return(mockDB)
+	mockDB.On("Updates", mock.Anything).Return(mockDB)
+
+	repo := &buildRepository{db: mockDB}
+	err := repo.Heartbeat(context.Background(), "job-id", "worker-2", time.Now())
+
+	assert.ErrorIs(t, err, domain.ErrJobNotFound)
+}
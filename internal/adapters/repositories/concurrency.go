@@ -0,0 +1,125 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"github.com/H3nSte1n/ci-orchestrator/internal/core/domain"
+	"github.com/H3nSte1n/ci-orchestrator/internal/core/ports"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// concurrencyGroup has one row per group ever used. Claims lock it while
+// they count the group's running builds.
+type concurrencyGroup struct {
+	Name string `gorm:"primaryKey;type:text"`
+}
+
+func (concurrencyGroup) TableName() string {
+	return "concurrency_groups"
+}
+
+// holdsGroupSlot matches top-level builds g that occupy a slot of their
+// concurrency group: running builds, and canceled builds until their last
+// running job has stopped. It takes the running status.
+const holdsGroupSlot = "(g.status = ? OR (g.status = '" + string(domain.BuildStatusCanceled) + "'" +
+	" AND EXISTS (SELECT 1 FROM jobs j JOIN builds jb ON jb.id = j.build_id" +
+	" WHERE (jb.id = g.id OR jb.parent_id = g.id) AND j.status = '" + string(domain.JobStatusRunning) + "')))"
+
+// concurrencyClause matches builds that may start a job: builds outside a
+// concurrency group, builds whose top-level build already runs and holds a
+// slot, and builds whose group holds fewer slots than their max_concurrency.
+// It takes the running status twice.
+const concurrencyClause = "(builds.concurrency_group IS NULL" +
+	" OR EXISTS (SELECT 1 FROM builds r WHERE r.id = COALESCE(builds.parent_id, builds.id) AND r.status = ?)" +
+	" OR (SELECT COUNT(*) FROM builds g WHERE g.parent_id IS NULL AND g.concurrency_group = builds.concurrency_group AND " + holdsGroupSlot + ") < builds.max_concurrency)"
+
+// saveConcurrencyGroup makes sure the lock row of build's group exists.
+func saveConcurrencyGroup(tx ports.DB, build *domain.Build) error {
+	if build.ConcurrencyGroup == nil {
+		return nil
+	}
+	return tx.Clauses(clause.OnConflict{DoNothing: true}).
+		Create(&concurrencyGroup{Name: *build.ConcurrencyGroup}).GetError()
+}
+
+// repoLimitClause matches builds whose top-level build already runs and
+// builds whose repository runs fewer top-level builds than its limit in
+// repo_limits. It takes the running status twice.
+const repoLimitClause = "(EXISTS (SELECT 1 FROM builds r WHERE r.id = COALESCE(builds.parent_id, builds.id) AND r.status = ?)" +
+	" OR NOT EXISTS (SELECT 1 FROM repo_limits l WHERE l.repo_url = builds.repo_url AND l.max_running_builds > 0" +
+	" AND (SELECT COUNT(*) FROM builds g WHERE g.parent_id IS NULL AND g.repo_url = builds.repo_url AND g.status = ?) >= l.max_running_builds))"
+
+// dueClause matches builds without a not_before or whose not_before has
+// passed. It takes the current time.
+const dueClause = "(builds.not_before IS NULL OR builds.not_before <= ?)"
+
+// repoShareJoin joins each job to the number of top-level builds its
+// repository runs. The counts are grouped once per claim rather than per
+// candidate. It takes the running status.
+const repoShareJoin = "JOIN builds jb ON jb.id = jobs.build_id" +
+	" LEFT JOIN (SELECT repo_url, COUNT(*) AS running FROM builds WHERE parent_id IS NULL AND status = ? GROUP BY repo_url) repo_share" +
+	" ON repo_share.repo_url = jb.repo_url"
+
+// holdSlot locks the limit of the build's repository and its concurrency
+// group, always in that order, and checks again that both have room. A claim
+// that started a build of the same repository or group meanwhile has
+// committed once the locks are granted, so its build counts. It returns
+// domain.ErrRepoLimit or domain.ErrConcurrencyLimit when one is full.
+// SQLite runs claims one at a time and needs no lock.
+func holdSlot(tx ports.DB, buildId string) error {
+	var build domain.Build
+	if err := tx.Where("id = ?", buildId).First(&build).GetError(); err != nil {
+		return err
+	}
+
+	var limits []domain.RepoLimit
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("repo_url = ? AND max_running_builds > 0", build.RepoUrl).
+		Find(&limits).GetError(); err != nil {
+		return err
+	}
+	if len(limits) > 0 {
+		if err := admit(tx, buildId, repoLimitClause, domain.ErrRepoLimit); err != nil {
+			return err
+		}
+	}
+
+	if build.ConcurrencyGroup == nil {
+		return nil
+	}
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("name = ?", *build.ConcurrencyGroup).
+		First(&concurrencyGroup{}).GetError(); err != nil {
+		return err
+	}
+	return admit(tx, buildId, concurrencyClause, domain.ErrConcurrencyLimit)
+}
+
+// admit returns full unless the build matches clause, which takes the
+// running status twice.
+func admit(tx ports.DB, buildId string, clause string, full error) error {
+	err := tx.Where("id = ?", buildId).
+		Where(clause, domain.BuildStatusRunning, domain.BuildStatusRunning).
+		First(&domain.Build{}).GetError()
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return full
+	}
+	return err
+}
+
+// CancelSuperseded cancels the older builds of build's concurrency group, see
+// ports.BuildRepository.
+func (r *buildRepository) CancelSuperseded(ctx context.Context, build *domain.Build, statuses []domain.BuildStatus) error {
+	if build.ConcurrencyGroup == nil || len(statuses) == 0 {
+		return nil
+	}
+
+	older := "SELECT id FROM builds WHERE parent_id IS NULL AND concurrency_group = ? AND status IN ? AND id <> ? AND created_at < ?"
+	args := []interface{}{*build.ConcurrencyGroup, statuses, build.ID, build.CreatedAt}
+
+	err := r.db.WithContext(ctx).Transaction(func(tx ports.DB) error {
+		return cancelBuilds(tx, r.dialect, "id IN ("+older+") OR parent_id IN ("+older+")", append(args, args...)...)
+	})
+	return translateError(err, domain.ErrBuildNotFound)
+}
@@ -0,0 +1,125 @@
+package repositories
+
+import (
+	"github.com/H3nSte1n/ci-orchestrator/internal/adapters/repotest"
+	"github.com/H3nSte1n/ci-orchestrator/internal/platform/config"
+	"github.com/H3nSte1n/ci-orchestrator/internal/platform/db"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+)
+
+var (
+	testDBOnce sync.Once
+	testDB     *gorm.DB
+	testDBErr  error
+)
+
+// openTestDB connects to the database of the test config and migrates it.
+// The conformance tests are skipped when no database is reachable.
+func openTestDB(t *testing.T) *gorm.DB {
+	testDBOnce.Do(func() {
+		if os.Getenv("BASE_DIR") == "" {
+			_ = os.Setenv("BASE_DIR", "../../../")
+		}
+
+		cfg, err := config.LoadConfig("test")
+		if err != nil {
+			testDBErr = err
+			return
+		}
+		if testDB, err = db.NewPostgresConnection(cfg); err != nil {
+			testDBErr = err
+			return
+		}
+		testDBErr = db.Migrate(cfg)
+	})
+
+	if testDBErr != nil {
+		t.Skipf("no test database: %v", testDBErr)
+	}
+	return testDB
+}
+
+func newRepositories(conn *gorm.DB) repotest.Repositories {
+	return repotest.Repositories{
+		Builds:    NewBuildRepository(conn),
+		Logs:      NewBuildLogRepository(conn),
+		Workers:   NewWorkerRepository(conn),
+		Limits:    NewRepoLimitRepository(conn),
+		Schedules: NewScheduleRepository(conn),
+	}
+}
+
+func newPostgresRepositories(t *testing.T) repotest.Repositories {
+	conn := openTestDB(t)
+	require.NoError(t, conn.Exec("TRUNCATE builds, jobs, build_logs, artifacts, workers, concurrency_groups, repo_limits, schedules CASCADE").Error)
+	return newRepositories(conn)
+}
+
+func TestPostgresBuildRepository_Conformance(t *testing.T) {
+	openTestDB(t)
+	repotest.RunBuildRepository(t, newPostgresRepositories)
+}
+
+func TestPostgresBuildLogRepository_Conformance(t *testing.T) {
+	openTestDB(t)
+	repotest.RunBuildLogRepository(t, newPostgresRepositories)
+}
+
+// newSQLiteRepositories migrates a fresh database file for every test.
+func newSQLiteRepositories(t *testing.T) repotest.Repositories {
+	if os.Getenv("BASE_DIR") == "" {
+		t.Setenv("BASE_DIR", "../../../")
+	}
+
+	cfg := &config.Config{DB: config.DBConfig{Driver: "sqlite", Path: filepath.Join(t.TempDir(), "ci.db"), Quiet: true}}
+	require.NoError(t, db.Migrate(cfg))
+
+	conn, err := db.NewConnection(cfg)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		if sqlDB, err := conn.DB(); err == nil {
+			_ = sqlDB.Close()
+		}
+	})
+	return newRepositories(conn)
+}
+
+func TestPostgresWorkerRepository_Conformance(t *testing.T) {
+	openTestDB(t)
+	repotest.RunWorkerRepository(t, newPostgresRepositories)
+}
+
+func TestPostgresRepoLimitRepository_Conformance(t *testing.T) {
+	openTestDB(t)
+	repotest.RunRepoLimitRepository(t, newPostgresRepositories)
+}
+
+func TestPostgresScheduleRepository_Conformance(t *testing.T) {
+	openTestDB(t)
+	repotest.RunScheduleRepository(t, newPostgresRepositories)
+}
+
+func TestSQLiteBuildRepository_Conformance(t *testing.T) {
+	repotest.RunBuildRepository(t, newSQLiteRepositories)
+}
+
+func TestSQLiteBuildLogRepository_Conformance(t *testing.T) {
+	repotest.RunBuildLogRepository(t, newSQLiteRepositories)
+}
+
+func TestSQLiteWorkerRepository_Conformance(t *testing.T) {
+	repotest.RunWorkerRepository(t, newSQLiteRepositories)
+}
+
+func TestSQLiteRepoLimitRepository_Conformance(t *testing.T) {
+	repotest.RunRepoLimitRepository(t, newSQLiteRepositories)
+}
+
+func TestSQLiteScheduleRepository_Conformance(t *testing.T) {
+	repotest.RunScheduleRepository(t, newSQLiteRepositories)
+}
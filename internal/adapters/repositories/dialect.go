@@ -0,0 +1,59 @@
+package repositories
+
+import (
+	"fmt"
+	"github.com/H3nSte1n/ci-orchestrator/internal/core/domain"
+	"gorm.io/gorm"
+)
+
+// dialect selects the few queries that differ between the supported
+// databases. The zero value is Postgres.
+type dialect int
+
+const (
+	dialectPostgres dialect = iota
+	dialectSQLite
+)
+
+func dialectOf(db *gorm.DB) dialect {
+	if db.Config != nil && db.Dialector != nil && db.Dialector.Name() == "sqlite" {
+		return dialectSQLite
+	}
+	return dialectPostgres
+}
+
+// checkID rejects malformed ids up front on SQLite, which stores them as
+// text; Postgres rejects them itself when casting to uuid.
+func (d dialect) checkID(id string) error {
+	if d == dialectSQLite && !domain.ValidID(id) {
+		return domain.NewError(domain.ErrInvalidArgument, "malformed value")
+	}
+	return nil
+}
+
+// pendingNeedsClause matches jobs that still wait for one of their needs.
+func (d dialect) pendingNeedsClause() string {
+	if d == dialectSQLite {
+		return "EXISTS (SELECT 1 FROM jobs d, json_each(jobs.needs) need WHERE d.build_id = jobs.build_id AND d.name = need.value AND d.status <> ?)"
+	}
+	return "EXISTS (SELECT 1 FROM jobs d WHERE d.build_id = jobs.build_id AND jsonb_exists(jobs.needs, d.name) AND d.status <> ?)"
+}
+
+// runsOnClause matches builds whose runs_on labels are all among the labels
+// of the worker given as the argument.
+func (d dialect) runsOnClause() string {
+	if d == dialectSQLite {
+		return "NOT EXISTS (SELECT 1 FROM json_each(builds.runs_on) r WHERE r.value NOT IN (SELECT l.value FROM workers w, json_each(w.labels) l WHERE w.id = ?))"
+	}
+	return "builds.runs_on <@ COALESCE((SELECT w.labels FROM workers w WHERE w.id = ?), '[]'::jsonb)"
+}
+
+// shareRankOrder orders jobs joined with repoShareJoin by domain.ShareRank.
+// ORDER BY takes no arguments in gorm, so the weight is inlined.
+func (d dialect) shareRankOrder() string {
+	seconds := int(domain.RepoShareAging.Seconds())
+	if d == dialectSQLite {
+		return fmt.Sprintf("julianday(jobs.ranked_at) + COALESCE(repo_share.running, 0) * %d / 86400.0", seconds)
+	}
+	return fmt.Sprintf("jobs.ranked_at + COALESCE(repo_share.running, 0) * INTERVAL '%d seconds'", seconds)
+}
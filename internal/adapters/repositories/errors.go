@@ -0,0 +1,55 @@
+package repositories
+
+import (
+	"errors"
+	"github.com/H3nSte1n/ci-orchestrator/internal/core/domain"
+	"github.com/jackc/pgx/v5/pgconn"
+	"gorm.io/gorm"
+)
+
+// Postgres error codes, see https://www.postgresql.org/docs/current/errcodes-appendix.html.
+const (
+	pgInvalidTextRepresentation = "22P02"
+	pgInvalidDatetimeFormat     = "22007"
+	pgDatetimeFieldOverflow     = "22008"
+	pgForeignKeyViolation       = "23503"
+	pgUniqueViolation           = "23505"
+	pgCheckViolation            = "23514"
+)
+
+// translateError maps gorm, Postgres and SQLite errors onto domain error kinds so
+// that callers never see driver specific errors. notFound is returned for
+// missing records. Errors it does not recognise are returned unchanged.
+func translateError(err error, notFound *domain.Error) error {
+	if err == nil {
+		return nil
+	}
+
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return notFound.WithCause(err)
+	}
+
+	// Translated by dialectors that set gorm.Config.TranslateError (SQLite).
+	if errors.Is(err, gorm.ErrDuplicatedKey) {
+		return domain.NewError(domain.ErrConflict, "record already exists").WithCause(err)
+	}
+	if errors.Is(err, gorm.ErrForeignKeyViolated) {
+		return domain.NewError(domain.ErrInvalidArgument, "referenced record does not exist").WithCause(err)
+	}
+
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		switch pgErr.Code {
+		case pgInvalidTextRepresentation, pgInvalidDatetimeFormat, pgDatetimeFieldOverflow:
+			return domain.NewError(domain.ErrInvalidArgument, "malformed value").WithCause(err)
+		case pgForeignKeyViolation:
+			return domain.NewError(domain.ErrInvalidArgument, "referenced record does not exist").WithCause(err)
+		case pgCheckViolation:
+			return domain.NewError(domain.ErrInvalidArgument, "value violates a constraint").WithCause(err)
+		case pgUniqueViolation:
+			return domain.NewError(domain.ErrConflict, "record already exists").WithCause(err)
+		}
+	}
+
+	return err
+}
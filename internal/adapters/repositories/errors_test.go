@@ -0,0 +1,47 @@
+package repositories
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/H3nSte1n/ci-orchestrator/internal/core/domain"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func TestTranslateError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		kind error
+	}{
+		{"record not found", gorm.ErrRecordNotFound, domain.ErrNotFound},
+		{"invalid uuid", &pgconn.PgError{Code: "22P02", Message: `invalid input syntax for type uuid: "nope"`}, domain.ErrInvalidArgument},
+		{"wrapped invalid uuid", fmt.Errorf("query: %w", &pgconn.PgError{Code: "22P02"}), domain.ErrInvalidArgument},
+		{"foreign key", &pgconn.PgError{Code: "23503"}, domain.ErrInvalidArgument},
+		{"unique", &pgconn.PgError{Code: "23505", Message: `duplicate key value violates unique constraint "artifacts_build_id_name_key"`}, domain.ErrConflict},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := translateError(tt.err, domain.ErrBuildNotFound)
+
+			assert.ErrorIs(t, err, tt.kind)
+			assert.ErrorIs(t, err, tt.err, "the cause is kept for logging")
+			assert.NotContains(t, err.Error(), "uuid")
+			assert.NotContains(t, err.Error(), "constraint")
+		})
+	}
+}
+
+func TestTranslateError_PassesThroughUnknownErrors(t *testing.T) {
+	connErr := errors.New("connection refused")
+
+	assert.Nil(t, translateError(nil, domain.ErrBuildNotFound))
+	assert.Equal(t, connErr, translateError(connErr, domain.ErrBuildNotFound))
+
+	shutdown := &pgconn.PgError{Code: "57P01"}
+	assert.Equal(t, shutdown, translateError(shutdown, domain.ErrBuildNotFound))
+}
@@ -27,18 +27,34 @@ func (g *gormAdapter) Where(query interface{}, args ...interface{}) ports.DB {
 	return &gormAdapter{g.DB.Where(query, args...)}
 }
 
+func (g *gormAdapter) Joins(query string, args ...interface{}) ports.DB {
+	return &gormAdapter{g.DB.Joins(query, args...)}
+}
+
 func (g *gormAdapter) Updates(value interface{}) ports.DB {
 	return &gormAdapter{g.DB.Updates(value)}
 }
 
+func (g *gormAdapter) Delete(value interface{}) ports.DB {
+	return &gormAdapter{g.DB.Delete(value)}
+}
+
 func (g *gormAdapter) First(value interface{}) ports.DB {
 	return &gormAdapter{g.DB.First(value)}
 }
 
+func (g *gormAdapter) Find(dest interface{}) ports.DB {
+	return &gormAdapter{g.DB.Find(dest)}
+}
+
 func (g *gormAdapter) Order(value string) ports.DB {
 	return &gormAdapter{g.DB.Order(value)}
 }
 
+func (g *gormAdapter) Limit(limit int) ports.DB {
+	return &gormAdapter{g.DB.Limit(limit)}
+}
+
 func (g *gormAdapter) Clauses(conds ...interface{}) ports.DB {
 	clauseExprs := make([]clause.Expression, len(conds))
 	for i, cond := range conds {
@@ -51,6 +67,10 @@ func (g *gormAdapter) Model(value interface{}) ports.DB {
 	return &gormAdapter{g.DB.Model(value)}
 }
 
+func (g *gormAdapter) Preload(query string, args ...interface{}) ports.DB {
+	return &gormAdapter{g.DB.Preload(query, args...)}
+}
+
 func (g *gormAdapter) Transaction(fn func(tx ports.DB) error) error {
 	return g.DB.Transaction(func(tx *gorm.DB) error {
 		return fn(&gormAdapter{tx})
@@ -60,3 +80,7 @@ func (g *gormAdapter) Transaction(fn func(tx ports.DB) error) error {
 func (g *gormAdapter) GetError() error {
 	return g.DB.Error
 }
+
+func (g *gormAdapter) GetRowsAffected() int64 {
+	return g.DB.RowsAffected
+}
@@ -8,7 +8,8 @@ import (
 
 type mockDB struct {
 	mock.Mock
-	Error error
+	Error        error
+	RowsAffected int64
 }
 
 func (m *mockDB) WithContext(ctx context.Context) ports.DB {
@@ -26,11 +27,21 @@ func (m *mockDB) Where(query interface{}, args ...interface{}) ports.DB {
 	return m
 }
 
+func (m *mockDB) Joins(query string, args ...interface{}) ports.DB {
+	m.Called(query, args)
+	return m
+}
+
 func (m *mockDB) Updates(value interface{}) ports.DB {
 	m.Called(value)
 	return m
 }
 
+func (m *mockDB) Delete(value interface{}) ports.DB {
+	m.Called(value)
+	return m
+}
+
 func (m *mockDB) Transaction(fn func(tx ports.DB) error) error {
 	err := fn(m)
 	if err != nil {
@@ -44,11 +55,21 @@ func (m *mockDB) First(value interface{}) ports.DB {
 	return m
 }
 
+func (m *mockDB) Find(dest interface{}) ports.DB {
+	m.Called(dest)
+	return m
+}
+
 func (m *mockDB) Order(value string) ports.DB {
 	m.Called(value)
 	return m
 }
 
+func (m *mockDB) Limit(limit int) ports.DB {
+	m.Called(limit)
+	return m
+}
+
 func (m *mockDB) Clauses(conds ...interface{}) ports.DB {
 	m.Called(conds)
 	return m
@@ -59,6 +80,15 @@ func (m *mockDB) Model(value interface{}) ports.DB {
 	return m
 }
 
+func (m *mockDB) Preload(query string, args ...interface{}) ports.DB {
+	m.Called(query, args)
+	return m
+}
+
 func (m *mockDB) GetError() error {
 	return m.Error
 }
+
+func (m *mockDB) GetRowsAffected() int64 {
+	return m.RowsAffected
+}
@@ -0,0 +1,37 @@
+package repositories
+
+import (
+	"context"
+	"github.com/H3nSte1n/ci-orchestrator/internal/core/domain"
+	"github.com/H3nSte1n/ci-orchestrator/internal/core/ports"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type repoLimitRepository struct {
+	db ports.DB
+}
+
+func NewRepoLimitRepository(db *gorm.DB) ports.RepoLimitRepository {
+	return &repoLimitRepository{
+		db: NewGormAdapter(db),
+	}
+}
+
+func (r *repoLimitRepository) Save(ctx context.Context, limit *domain.RepoLimit) error {
+	err := r.db.WithContext(ctx).
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "repo_url"}},
+			DoUpdates: clause.AssignmentColumns([]string{"max_running_builds", "updated_at"}),
+		}).
+		Create(limit).GetError()
+	return translateError(err, domain.ErrRepoLimitNotFound)
+}
+
+func (r *repoLimitRepository) List(ctx context.Context) ([]domain.RepoLimit, error) {
+	limits := []domain.RepoLimit{}
+	if err := r.db.WithContext(ctx).Order("repo_url ASC").Find(&limits).GetError(); err != nil {
+		return nil, translateError(err, domain.ErrRepoLimitNotFound)
+	}
+	return limits, nil
+}
@@ -0,0 +1,95 @@
+package repositories
+
+import (
+	"context"
+	"github.com/H3nSte1n/ci-orchestrator/internal/core/domain"
+	"github.com/H3nSte1n/ci-orchestrator/internal/core/ports"
+	"gorm.io/gorm"
+	"time"
+)
+
+type scheduleRepository struct {
+	db      ports.DB
+	dialect dialect
+}
+
+func NewScheduleRepository(gormDB *gorm.DB) ports.ScheduleRepository {
+	return &scheduleRepository{
+		db:      NewGormAdapter(gormDB),
+		dialect: dialectOf(gormDB),
+	}
+}
+
+func (r *scheduleRepository) Save(ctx context.Context, schedule *domain.Schedule) error {
+	return translateError(r.db.WithContext(ctx).Create(schedule).GetError(), domain.ErrScheduleNotFound)
+}
+
+func (r *scheduleRepository) FindByID(ctx context.Context, scheduleId string) (*domain.Schedule, error) {
+	if err := r.dialect.checkID(scheduleId); err != nil {
+		return nil, err
+	}
+	var schedule domain.Schedule
+	if err := r.db.WithContext(ctx).Where("id = ?", scheduleId).First(&schedule).GetError(); err != nil {
+		return nil, translateError(err, domain.ErrScheduleNotFound)
+	}
+	return &schedule, nil
+}
+
+func (r *scheduleRepository) List(ctx context.Context) ([]domain.Schedule, error) {
+	schedules := []domain.Schedule{}
+	if err := r.db.WithContext(ctx).Order("name ASC, created_at ASC").Find(&schedules).GetError(); err != nil {
+		return nil, translateError(err, domain.ErrScheduleNotFound)
+	}
+	return schedules, nil
+}
+
+func (r *scheduleRepository) Delete(ctx context.Context, scheduleId string) error {
+	if err := r.dialect.checkID(scheduleId); err != nil {
+		return err
+	}
+	result := r.db.WithContext(ctx).Where("id = ?", scheduleId).Delete(&domain.Schedule{})
+	if err := result.GetError(); err != nil {
+		return translateError(err, domain.ErrScheduleNotFound)
+	}
+	if result.GetRowsAffected() == 0 {
+		return domain.ErrScheduleNotFound
+	}
+	return nil
+}
+
+func (r *scheduleRepository) ListDue(ctx context.Context, now time.Time) ([]domain.Schedule, error) {
+	schedules := []domain.Schedule{}
+	err := r.db.WithContext(ctx).
+		Where("next_run_at <= ?", now.UTC()).
+		Order("next_run_at ASC").
+		Find(&schedules).GetError()
+	if err != nil {
+		return nil, translateError(err, domain.ErrScheduleNotFound)
+	}
+	return schedules, nil
+}
+
+// Advance is a compare-and-set on next_run_at: of several schedulers that
+// found the same run due, only the first one's update matches a row.
+func (r *scheduleRepository) Advance(ctx context.Context, scheduleId string, from time.Time, next time.Time, lastRunAt *time.Time) (bool, error) {
+	if err := r.dialect.checkID(scheduleId); err != nil {
+		return false, err
+	}
+	updates := map[string]interface{}{
+		"next_run_at": next.UTC(),
+		"last_run_at": nil,
+		"updated_at":  time.Now(),
+	}
+	if lastRunAt != nil {
+		updates["last_run_at"] = lastRunAt.UTC()
+	}
+
+	result := r.db.WithContext(ctx).
+		Model(&domain.Schedule{}).
+		Where("id = ? AND next_run_at = ?", scheduleId, from.UTC()).
+		Updates(updates)
+	if err := result.GetError(); err != nil {
+		return false, translateError(err, domain.ErrScheduleNotFound)
+	}
+	return result.GetRowsAffected() == 1, nil
+}
@@ -0,0 +1,91 @@
+package repositories
+
+import (
+	"context"
+	"github.com/H3nSte1n/ci-orchestrator/internal/core/domain"
+	"github.com/H3nSte1n/ci-orchestrator/internal/core/ports"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"time"
+)
+
+type workerRepository struct {
+	db ports.DB
+}
+
+func NewWorkerRepository(db *gorm.DB) ports.WorkerRepository {
+	return &workerRepository{
+		db: NewGormAdapter(db),
+	}
+}
+
+func (r *workerRepository) Register(ctx context.Context, worker *domain.Worker) error {
+	updates := clause.AssignmentColumns([]string{"hostname", "version", "labels", "slots", "last_seen_at", "deregistered_at", "updated_at"})
+	updates = append(updates, clause.Assignment{
+		Column: clause.Column{Name: "state"},
+		Value:  gorm.Expr("CASE WHEN workers.state = ? THEN workers.state ELSE ? END", domain.WorkerStatePaused, domain.WorkerStateActive),
+	})
+
+	err := r.db.WithContext(ctx).
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "id"}},
+			DoUpdates: updates,
+		}).
+		Create(worker).GetError()
+	return translateError(err, domain.ErrWorkerNotFound)
+}
+
+func (r *workerRepository) FindByID(ctx context.Context, workerId string) (*domain.Worker, error) {
+	var worker domain.Worker
+	if err := r.db.WithContext(ctx).Where("id = ?", workerId).First(&worker).GetError(); err != nil {
+		return nil, translateError(err, domain.ErrWorkerNotFound)
+	}
+	return &worker, nil
+}
+
+func (r *workerRepository) List(ctx context.Context) ([]domain.Worker, error) {
+	workers := []domain.Worker{}
+	if err := r.db.WithContext(ctx).Order("id ASC").Find(&workers).GetError(); err != nil {
+		return nil, translateError(err, domain.ErrWorkerNotFound)
+	}
+	return workers, nil
+}
+
+func (r *workerRepository) Heartbeat(ctx context.Context, workerId string, at time.Time) error {
+	return r.update(ctx, workerId, map[string]interface{}{"last_seen_at": at})
+}
+
+func (r *workerRepository) Deregister(ctx context.Context, workerId string, at time.Time) error {
+	return r.update(ctx, workerId, map[string]interface{}{"deregistered_at": at})
+}
+
+func (r *workerRepository) UpdateState(ctx context.Context, workerId string, state domain.WorkerState) error {
+	return r.update(ctx, workerId, map[string]interface{}{"state": state})
+}
+
+func (r *workerRepository) RunningJobs(ctx context.Context) ([]domain.Job, error) {
+	jobs := []domain.Job{}
+	err := r.db.WithContext(ctx).
+		Where("status = ?", domain.JobStatusRunning).
+		Order("locked_at ASC").
+		Find(&jobs).GetError()
+	if err != nil {
+		return nil, translateError(err, domain.ErrJobNotFound)
+	}
+	return jobs, nil
+}
+
+func (r *workerRepository) update(ctx context.Context, workerId string, values map[string]interface{}) error {
+	values["updated_at"] = time.Now()
+	result := r.db.WithContext(ctx).
+		Model(&domain.Worker{}).
+		Where("id = ?", workerId).
+		Updates(values)
+	if err := result.GetError(); err != nil {
+		return translateError(err, domain.ErrWorkerNotFound)
+	}
+	if result.GetRowsAffected() == 0 {
+		return domain.ErrWorkerNotFound
+	}
+	return nil
+}
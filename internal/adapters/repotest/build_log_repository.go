@@ -0,0 +1,188 @@
+package repotest
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/H3nSte1n/ci-orchestrator/internal/core/domain"
+	"github.com/H3nSte1n/ci-orchestrator/internal/core/ports"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// RunBuildLogRepository runs the log repository conformance tests.
+func RunBuildLogRepository(t *testing.T, newRepos Factory) {
+	tests := []struct {
+		name string
+		run  func(t *testing.T, builds ports.BuildRepository, logs ports.BuildLogRepository)
+	}{
+		{"SaveAllAssignsSeq", testSaveAllAssignsSeq},
+		{"Save", testSaveLog},
+		{"SaveAllEmpty", testSaveAllEmpty},
+		{"SaveAllUnknownBuild", testSaveAllUnknownBuild},
+		{"FindByBuildIDPages", testFindByBuildIDPages},
+		{"FindByBuildIDUnknownBuild", testFindByBuildIDUnknownBuild},
+		{"FollowConcurrentJobs", testFollowConcurrentJobs},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repos := newRepos(t)
+			tt.run(t, repos.Builds, repos.Logs)
+		})
+	}
+}
+
+func logLines(build *domain.Build, contents ...string) []domain.BuildLog {
+	lines := make([]domain.BuildLog, len(contents))
+	for i, content := range contents {
+		lines[i] = domain.BuildLog{BuildID: build.ID, JobID: &build.Jobs[0].ID, Stream: domain.LogStdout, Content: content}
+	}
+	return lines
+}
+
+func contents(lines []domain.BuildLog) []string {
+	out := make([]string, len(lines))
+	for i, line := range lines {
+		out[i] = line.Content
+	}
+	return out
+}
+
+func testSaveAllAssignsSeq(t *testing.T, builds ports.BuildRepository, logs ports.BuildLogRepository) {
+	build := save(t, builds, newBuild("make", newJob(domain.DefaultJobName)))
+	lines := logLines(build, "one", "two", "three")
+
+	require.NoError(t, logs.SaveAll(context.Background(), lines))
+
+	for i, line := range lines {
+		assert.NotEmpty(t, line.ID)
+		assert.Positive(t, line.Seq)
+		if i > 0 {
+			assert.Greater(t, line.Seq, lines[i-1].Seq)
+		}
+	}
+
+	found, err := logs.FindByBuildID(context.Background(), build.ID, 0, 0)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"one", "two", "three"}, contents(found))
+	assert.Equal(t, lines[0].Seq, found[0].Seq)
+	require.NotNil(t, found[0].JobID)
+	assert.Equal(t, build.Jobs[0].ID, *found[0].JobID)
+	assert.Equal(t, domain.LogStdout, found[0].Stream)
+}
+
+func testSaveLog(t *testing.T, builds ports.BuildRepository, logs ports.BuildLogRepository) {
+	build := save(t, builds, newBuild("make", newJob(domain.DefaultJobName)))
+	first := logLines(build, "first")[0]
+	second := logLines(build, "second")[0]
+	second.Stream = domain.LogStderr
+
+	require.NoError(t, logs.Save(context.Background(), &first))
+	require.NoError(t, logs.Save(context.Background(), &second))
+
+	assert.Greater(t, second.Seq, first.Seq)
+	found, err := logs.FindByBuildID(context.Background(), build.ID, 0, 0)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"first", "second"}, contents(found))
+	assert.Equal(t, domain.LogStderr, found[1].Stream)
+}
+
+func testSaveAllEmpty(t *testing.T, builds ports.BuildRepository, logs ports.BuildLogRepository) {
+	assert.NoError(t, logs.SaveAll(context.Background(), nil))
+}
+
+func testSaveAllUnknownBuild(t *testing.T, builds ports.BuildRepository, logs ports.BuildLogRepository) {
+	err := logs.SaveAll(context.Background(), []domain.BuildLog{{BuildID: unknownID, Stream: domain.LogStdout, Content: "lost"}})
+
+	assert.ErrorIs(t, err, domain.ErrInvalidArgument)
+}
+
+func testFindByBuildIDPages(t *testing.T, builds ports.BuildRepository, logs ports.BuildLogRepository) {
+	build := save(t, builds, newBuild("make", newJob(domain.DefaultJobName)))
+	other := save(t, builds, newBuild("make", newJob(domain.DefaultJobName)))
+	require.NoError(t, logs.SaveAll(context.Background(), logLines(build, "1", "2")))
+	require.NoError(t, logs.SaveAll(context.Background(), logLines(other, "other")))
+	require.NoError(t, logs.SaveAll(context.Background(), logLines(build, "3", "4", "5")))
+
+	page, err := logs.FindByBuildID(context.Background(), build.ID, 0, 2)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"1", "2"}, contents(page))
+
+	page, err = logs.FindByBuildID(context.Background(), build.ID, page[1].Seq, 2)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"3", "4"}, contents(page))
+
+	page, err = logs.FindByBuildID(context.Background(), build.ID, page[1].Seq, 2)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"5"}, contents(page))
+
+	page, err = logs.FindByBuildID(context.Background(), build.ID, page[0].Seq, 2)
+	require.NoError(t, err)
+	assert.Empty(t, page)
+}
+
+func testFindByBuildIDUnknownBuild(t *testing.T, builds ports.BuildRepository, logs ports.BuildLogRepository) {
+	found, err := logs.FindByBuildID(context.Background(), unknownID, 0, 0)
+
+	require.NoError(t, err)
+	assert.Empty(t, found)
+}
+
+// testFollowConcurrentJobs pages through a build while two of its jobs
+// append at the same time; the follower must see every line exactly once.
+func testFollowConcurrentJobs(t *testing.T, builds ports.BuildRepository, logs ports.BuildLogRepository) {
+	const batches = 20
+	build := save(t, builds, newBuild("make", newJob("build"), newJob("test")))
+
+	var wg sync.WaitGroup
+	errs := make(chan error, len(build.Jobs))
+	for _, job := range build.Jobs {
+		wg.Add(1)
+		go func(jobId string) {
+			defer wg.Done()
+			for i := range batches {
+				line := domain.BuildLog{BuildID: build.ID, JobID: &jobId, Stream: domain.LogStdout, Content: fmt.Sprintf("%s %d", jobId, i)}
+				if err := logs.SaveAll(context.Background(), []domain.BuildLog{line}); err != nil {
+					errs <- err
+					return
+				}
+			}
+		}(job.ID)
+	}
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+
+	seen := map[string]int{}
+	var afterSeq int64
+	follow := func() {
+		page, err := logs.FindByBuildID(context.Background(), build.ID, afterSeq, 0)
+		require.NoError(t, err)
+		for _, line := range page {
+			seen[line.Content]++
+			afterSeq = line.Seq
+		}
+	}
+	for following := true; following; {
+		select {
+		case <-done:
+			following = false
+		default:
+		}
+		follow()
+	}
+	close(errs)
+	for err := range errs {
+		require.NoError(t, err)
+	}
+
+	assert.Len(t, seen, len(build.Jobs)*batches)
+	for content, n := range seen {
+		assert.Equal(t, 1, n, content)
+	}
+}
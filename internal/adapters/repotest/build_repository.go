@@ -0,0 +1,903 @@
+package repotest
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/H3nSte1n/ci-orchestrator/internal/core/domain"
+	"github.com/H3nSte1n/ci-orchestrator/internal/core/ports"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// RunBuildRepository runs the build repository conformance tests.
+func RunBuildRepository(t *testing.T, newRepos Factory) {
+	tests := []struct {
+		name string
+		run  func(t *testing.T, repo ports.BuildRepository)
+	}{
+		{"SaveAndFindByID", testSaveAndFindByID},
+		{"SaveMatrix", testSaveMatrix},
+		{"SaveDuplicateID", testSaveDuplicateID},
+		{"FindByIDNotFound", testFindByIDNotFound},
+		{"Update", testUpdate},
+		{"UpdateNotFound", testUpdateNotFound},
+		{"List", testList},
+		{"ListCursor", testListCursor},
+		{"ClaimNextEmpty", testClaimNextEmpty},
+		{"ClaimNextOldestFirst", testClaimNextOldestFirst},
+		{"ClaimNextByPriority", testClaimNextByPriority},
+		{"ClaimNextAgesLowPriority", testClaimNextAgesLowPriority},
+		{"ClaimNextRespectsNeeds", testClaimNextRespectsNeeds},
+		{"ClaimNextSkipsCanceledBuilds", testClaimNextSkipsCanceledBuilds},
+		{"ClaimNextSkipsBuildsNotDue", testClaimNextSkipsBuildsNotDue},
+		{"ClaimNextConcurrent", testClaimNextConcurrent},
+		{"ClaimNextConcurrencyGroup", testClaimNextConcurrencyGroup},
+		{"ClaimNextMaxConcurrency", testClaimNextMaxConcurrency},
+		{"ClaimNextWithinGroupSlot", testClaimNextWithinGroupSlot},
+		{"UpdatePriority", testUpdatePriority},
+		{"UpdatePriorityNotPending", testUpdatePriorityNotPending},
+		{"StartJob", testStartJob},
+		{"StartJobNotClaimable", testStartJobNotClaimable},
+		{"StartJobNotDue", testStartJobNotDue},
+		{"StartJobConcurrencyLimit", testStartJobConcurrencyLimit},
+		{"CancelMatrix", testCancelMatrix},
+		{"CancelNotFound", testCancelNotFound},
+		{"CancelSuperseded", testCancelSuperseded},
+		{"ReleaseJob", testReleaseJob},
+		{"RequeueExpiredAfterWorkerDies", testRequeueExpiredAfterWorkerDies},
+		{"RequeueExpiredCancelsFinishedBuilds", testRequeueExpiredCancelsFinishedBuilds},
+		{"FindJobByID", testFindJobByID},
+		{"Heartbeat", testHeartbeat},
+		{"CompleteJobSuccess", testCompleteJobSuccess},
+		{"CompleteJobFailureSkipsDownstream", testCompleteJobFailureSkipsDownstream},
+		{"CompleteJobKeepsCanceledBuild", testCompleteJobKeepsCanceledBuild},
+		{"CompleteJobNotFound", testCompleteJobNotFound},
+		{"CompleteJobAfterLeaseLost", testCompleteJobAfterLeaseLost},
+		{"CompleteJobRollsUpMatrix", testCompleteJobRollsUpMatrix},
+		{"CompleteJobFailFast", testCompleteJobFailFast},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.run(t, newRepos(t).Builds)
+		})
+	}
+}
+
+func testSaveAndFindByID(t *testing.T, repo ports.BuildRepository) {
+	triggeredBy := "ci-bot"
+	build := newBuild("make test", newJob("lint"), newJob("test", "lint"))
+	build.Env = domain.StringMap{"GOFLAGS": "-v"}
+	build.TriggeredBy = &triggeredBy
+	save(t, repo, build)
+
+	assert.NotEmpty(t, build.ID)
+	assert.Equal(t, domain.BuildStatusPending, build.Status)
+	assert.False(t, build.CreatedAt.IsZero())
+	for _, job := range build.Jobs {
+		assert.NotEmpty(t, job.ID)
+		assert.Equal(t, build.ID, job.BuildID)
+		assert.Equal(t, domain.JobStatusPending, job.Status)
+	}
+
+	found := find(t, repo, build.ID)
+	assert.Equal(t, build.ID, found.ID)
+	assert.Equal(t, "https://github.com/test/repo", found.RepoUrl)
+	assert.Equal(t, "main", found.Ref)
+	assert.Equal(t, "make test", found.Command)
+	assert.Equal(t, domain.BuildStatusPending, found.Status)
+	assert.Equal(t, domain.StringMap{"GOFLAGS": "-v"}, found.Env)
+	require.NotNil(t, found.TriggeredBy)
+	assert.Equal(t, "ci-bot", *found.TriggeredBy)
+	assert.WithinDuration(t, build.CreatedAt, found.CreatedAt, time.Millisecond)
+	assert.Nil(t, found.FinishedAt)
+	assert.Nil(t, found.LockedBy)
+	assert.Empty(t, found.Children)
+
+	require.Len(t, found.Jobs, 2)
+	test := findJob(t, found, "test")
+	assert.Equal(t, "run test", test.Command)
+	assert.Equal(t, domain.StringList{"lint"}, test.Needs)
+	assert.Equal(t, domain.JobStatusPending, test.Status)
+}
+
+func testSaveMatrix(t *testing.T, repo ports.BuildRepository) {
+	parent := newBuild("go test ./...")
+	parent.Matrix = &domain.Matrix{Axes: map[string][]string{"go": {"1.24", "1.25"}}}
+	for _, version := range []string{"1.24", "1.25"} {
+		child := *newBuild("go test ./...", newJob(domain.DefaultJobName))
+		child.MatrixValues = domain.StringMap{"go": version}
+		parent.Children = append(parent.Children, child)
+	}
+	save(t, repo, parent)
+
+	found := find(t, repo, parent.ID)
+	assert.Empty(t, found.Jobs)
+	require.NotNil(t, found.Matrix)
+	assert.Equal(t, []string{"1.24", "1.25"}, found.Matrix.Axes["go"])
+	require.Len(t, found.Children, 2)
+
+	for _, child := range found.Children {
+		require.NotNil(t, child.ParentID)
+		assert.Equal(t, parent.ID, *child.ParentID)
+		assert.Equal(t, domain.BuildStatusPending, child.Status)
+
+		loaded := find(t, repo, child.ID)
+		require.Len(t, loaded.Jobs, 1)
+		assert.Equal(t, domain.DefaultJobName, loaded.Jobs[0].Name)
+	}
+}
+
+func testSaveDuplicateID(t *testing.T, repo ports.BuildRepository) {
+	build := save(t, repo, newBuild("make"))
+
+	err := repo.Save(context.Background(), &domain.Build{ID: build.ID, RepoUrl: "r", Ref: "main", Command: "make"})
+
+	assert.ErrorIs(t, err, domain.ErrConflict)
+}
+
+func testFindByIDNotFound(t *testing.T, repo ports.BuildRepository) {
+	_, err := repo.FindByID(context.Background(), unknownID)
+	assert.ErrorIs(t, err, domain.ErrBuildNotFound)
+
+	_, err = repo.FindByID(context.Background(), "not-a-uuid")
+	assert.ErrorIs(t, err, domain.ErrInvalidArgument)
+}
+
+func testUpdate(t *testing.T, repo ports.BuildRepository) {
+	build := save(t, repo, newBuild("make"))
+
+	require.NoError(t, repo.Update(context.Background(), &domain.Build{ID: build.ID, Status: domain.BuildStatusCanceled}))
+
+	found := find(t, repo, build.ID)
+	assert.Equal(t, domain.BuildStatusCanceled, found.Status)
+	assert.Equal(t, "make", found.Command, "zero fields are left alone")
+	assert.Equal(t, "main", found.Ref)
+}
+
+func testUpdateNotFound(t *testing.T, repo ports.BuildRepository) {
+	err := repo.Update(context.Background(), &domain.Build{ID: unknownID, Status: domain.BuildStatusCanceled})
+
+	assert.ErrorIs(t, err, domain.ErrBuildNotFound)
+}
+
+func testList(t *testing.T, repo ports.BuildRepository) {
+	base := time.Now().Add(-time.Hour).Truncate(time.Second)
+	builds := []*domain.Build{newBuild("make test"), newBuild("make LINT"), newBuild("go build")}
+	for i, build := range builds {
+		build.CreatedAt = base.Add(time.Duration(i) * time.Minute)
+		save(t, repo, build)
+	}
+	require.NoError(t, repo.Update(context.Background(), &domain.Build{ID: builds[2].ID, Ref: "release", Status: domain.BuildStatusFailed}))
+
+	ids := func(filter domain.BuildFilter) []string {
+		t.Helper()
+		listed, err := repo.List(context.Background(), filter)
+		require.NoError(t, err)
+		out := []string{}
+		for _, build := range listed {
+			assert.Empty(t, build.Jobs, "List does not load jobs")
+			out = append(out, build.ID)
+		}
+		return out
+	}
+
+	assert.Equal(t, []string{builds[2].ID, builds[1].ID, builds[0].ID}, ids(domain.BuildFilter{}))
+	assert.Equal(t, []string{builds[2].ID, builds[1].ID}, ids(domain.BuildFilter{Limit: 2}))
+	assert.Equal(t, []string{builds[2].ID}, ids(domain.BuildFilter{Statuses: []domain.BuildStatus{domain.BuildStatusFailed}}))
+	assert.Equal(t, []string{builds[1].ID, builds[0].ID}, ids(domain.BuildFilter{Ref: "main"}))
+	assert.Equal(t, []string{builds[1].ID}, ids(domain.BuildFilter{Query: "lint"}))
+	assert.Equal(t, []string{}, ids(domain.BuildFilter{RepoUrl: "https://github.com/test/other"}))
+	assert.Equal(t, []string{}, ids(domain.BuildFilter{Query: "%"}))
+
+	after := base.Add(time.Minute)
+	before := base.Add(2 * time.Minute)
+	assert.Equal(t, []string{builds[1].ID}, ids(domain.BuildFilter{CreatedAfter: &after, CreatedBefore: &before}))
+}
+
+func testListCursor(t *testing.T, repo ports.BuildRepository) {
+	createdAt := time.Now().Add(-time.Hour).Truncate(time.Second)
+	for i := 0; i < 3; i++ {
+		build := newBuild("make")
+		build.CreatedAt = createdAt
+		save(t, repo, build)
+	}
+
+	listed, err := repo.List(context.Background(), domain.BuildFilter{})
+	require.NoError(t, err)
+	require.Len(t, listed, 3)
+	assert.Greater(t, listed[0].ID, listed[1].ID, "equal timestamps are ordered by id")
+	assert.Greater(t, listed[1].ID, listed[2].ID)
+
+	rest, err := repo.List(context.Background(), domain.BuildFilter{After: &domain.BuildCursor{CreatedAt: listed[0].CreatedAt, ID: listed[0].ID}})
+	require.NoError(t, err)
+	require.Len(t, rest, 2)
+	assert.Equal(t, listed[1].ID, rest[0].ID)
+	assert.Equal(t, listed[2].ID, rest[1].ID)
+}
+
+func testClaimNextEmpty(t *testing.T, repo ports.BuildRepository) {
+	assert.Nil(t, claim(t, repo, "worker-1"))
+}
+
+func testClaimNextOldestFirst(t *testing.T, repo ports.BuildRepository) {
+	base := time.Now().Add(-time.Minute)
+	newer := newBuild("newer", newJob(domain.DefaultJobName))
+	newer.Jobs[0].CreatedAt = base.Add(time.Second)
+	older := newBuild("older", newJob(domain.DefaultJobName))
+	older.Jobs[0].CreatedAt = base
+	save(t, repo, newer)
+	save(t, repo, older)
+
+	job := claim(t, repo, "worker-1")
+
+	require.NotNil(t, job)
+	assert.Equal(t, older.Jobs[0].ID, job.ID)
+	assert.Equal(t, domain.JobStatusRunning, job.Status)
+	require.NotNil(t, job.LockedBy)
+	assert.Equal(t, "worker-1", *job.LockedBy)
+	assert.NotNil(t, job.LockedAt)
+	require.NotNil(t, job.Build)
+	assert.Equal(t, older.ID, job.Build.ID)
+	assert.Equal(t, "older", job.Build.Command)
+	assert.Equal(t, domain.BuildStatusRunning, job.Build.Status)
+
+	found := find(t, repo, older.ID)
+	assert.Equal(t, domain.BuildStatusRunning, found.Status)
+	require.NotNil(t, found.LockedBy)
+	assert.Equal(t, "worker-1", *found.LockedBy)
+	assert.Equal(t, domain.JobStatusRunning, found.Jobs[0].Status)
+
+	next := claim(t, repo, "worker-2")
+	require.NotNil(t, next)
+	assert.Equal(t, newer.Jobs[0].ID, next.ID)
+	assert.Nil(t, claim(t, repo, "worker-3"))
+}
+
+func testClaimNextByPriority(t *testing.T, repo ports.BuildRepository) {
+	base := time.Now().Add(-time.Minute)
+	older := newBuild("older", newJob(domain.DefaultJobName))
+	older.Jobs[0].CreatedAt = base
+	urgent := newBuild("urgent", newJob(domain.DefaultJobName))
+	urgent.Priority = 5
+	urgent.Jobs[0].CreatedAt = base.Add(time.Second)
+	save(t, repo, older)
+	save(t, repo, urgent)
+
+	first := claim(t, repo, "worker-1")
+	require.NotNil(t, first)
+	assert.Equal(t, urgent.Jobs[0].ID, first.ID)
+	assert.Equal(t, 5, first.Build.Priority)
+
+	second := claim(t, repo, "worker-2")
+	require.NotNil(t, second)
+	assert.Equal(t, older.Jobs[0].ID, second.ID)
+}
+
+func testClaimNextAgesLowPriority(t *testing.T, repo ports.BuildRepository) {
+	waiting := newBuild("nightly", newJob(domain.DefaultJobName))
+	waiting.Priority = -10
+	waiting.Jobs[0].CreatedAt = time.Now().Add(-time.Hour)
+	urgent := newBuild("urgent", newJob(domain.DefaultJobName))
+	urgent.Priority = 10
+	save(t, repo, urgent)
+	save(t, repo, waiting)
+
+	job := claim(t, repo, "worker-1")
+
+	require.NotNil(t, job)
+	assert.Equal(t, waiting.Jobs[0].ID, job.ID, "an hour of waiting outweighs 20 priority points")
+}
+
+func testClaimNextRespectsNeeds(t *testing.T, repo ports.BuildRepository) {
+	build := save(t, repo, newBuild("make", newJob("build"), newJob("test", "build")))
+
+	first := claim(t, repo, "worker-1")
+	require.NotNil(t, first)
+	assert.Equal(t, "build", first.Name)
+	assert.Nil(t, claim(t, repo, "worker-2"), "test waits for build")
+
+	complete(t, repo, first.ID, domain.JobStatusSuccess, 0, time.Now())
+
+	second := claim(t, repo, "worker-2")
+	require.NotNil(t, second)
+	assert.Equal(t, "test", second.Name)
+	assert.Equal(t, build.ID, second.BuildID)
+}
+
+func testClaimNextSkipsCanceledBuilds(t *testing.T, repo ports.BuildRepository) {
+	build := save(t, repo, newBuild("make", newJob(domain.DefaultJobName)))
+	require.NoError(t, repo.Update(context.Background(), &domain.Build{ID: build.ID, Status: domain.BuildStatusCanceled}))
+
+	assert.Nil(t, claim(t, repo, "worker-1"))
+}
+
+// delayedBuild returns a single job build that may not start before
+// notBefore.
+func delayedBuild(notBefore time.Time) *domain.Build {
+	build := newBuild("make", newJob(domain.DefaultJobName))
+	notBefore = notBefore.UTC().Truncate(time.Second)
+	build.NotBefore = &notBefore
+	return build
+}
+
+func testClaimNextSkipsBuildsNotDue(t *testing.T, repo ports.BuildRepository) {
+	later := save(t, repo, delayedBuild(time.Now().Add(time.Hour)))
+	due := save(t, repo, delayedBuild(time.Now().Add(-time.Minute)))
+
+	job := claim(t, repo, "worker-1")
+	require.NotNil(t, job)
+	assert.Equal(t, due.ID, job.BuildID)
+	assert.Nil(t, claim(t, repo, "worker-1"), "the other build waits for its not_before")
+
+	found := find(t, repo, later.ID)
+	require.NotNil(t, found.NotBefore)
+	assert.True(t, later.NotBefore.Equal(*found.NotBefore))
+	assert.Equal(t, domain.BuildStatusPending, found.Status)
+}
+
+func testClaimNextConcurrent(t *testing.T, repo ports.BuildRepository) {
+	const builds, workers = 20, 8
+	for i := 0; i < builds; i++ {
+		save(t, repo, newBuild("make", newJob(domain.DefaultJobName)))
+	}
+
+	var (
+		mu      sync.Mutex
+		claimed = map[string]int{}
+		wg      sync.WaitGroup
+		errs    = make(chan error, workers)
+	)
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				job, err := repo.ClaimNext(context.Background(), "worker")
+				if err != nil {
+					errs <- err
+					return
+				}
+				if job == nil {
+					return
+				}
+				mu.Lock()
+				claimed[job.ID]++
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		require.NoError(t, err)
+	}
+	assert.Len(t, claimed, builds)
+	for id, n := range claimed {
+		assert.Equal(t, 1, n, "job %s claimed %d times", id, n)
+	}
+}
+
+// newGroupedBuilds returns builds of the concurrency group, oldest first.
+func newGroupedBuilds(group string, maxConcurrency int, n int) []*domain.Build {
+	base := time.Now().Add(-time.Minute)
+	builds := make([]*domain.Build, n)
+	for i := range builds {
+		build := newBuild("deploy", newJob(domain.DefaultJobName))
+		build.ConcurrencyGroup = &group
+		build.MaxConcurrency = maxConcurrency
+		build.CreatedAt = base.Add(time.Duration(i) * time.Second)
+		build.Jobs[0].CreatedAt = build.CreatedAt
+		builds[i] = build
+	}
+	return builds
+}
+
+func testClaimNextConcurrencyGroup(t *testing.T, repo ports.BuildRepository) {
+	grouped := newGroupedBuilds("deploy-prod", 1, 2)
+	for _, build := range grouped {
+		save(t, repo, build)
+	}
+	other := newBuild("make", newJob(domain.DefaultJobName))
+	other.Jobs[0].CreatedAt = time.Now()
+	save(t, repo, other)
+
+	first := claim(t, repo, "worker-1")
+	require.NotNil(t, first)
+	assert.Equal(t, grouped[0].Jobs[0].ID, first.ID)
+
+	next := claim(t, repo, "worker-2")
+	require.NotNil(t, next)
+	assert.Equal(t, other.Jobs[0].ID, next.ID, "the group's second build waits")
+	assert.Nil(t, claim(t, repo, "worker-3"))
+
+	complete(t, repo, first.ID, domain.JobStatusSuccess, 0, time.Now())
+
+	next = claim(t, repo, "worker-3")
+	require.NotNil(t, next)
+	assert.Equal(t, grouped[1].Jobs[0].ID, next.ID)
+}
+
+func testClaimNextMaxConcurrency(t *testing.T, repo ports.BuildRepository) {
+	grouped := newGroupedBuilds("deploy", 2, 3)
+	for _, build := range grouped {
+		save(t, repo, build)
+	}
+
+	for i, workerId := range []string{"worker-1", "worker-2"} {
+		job := claim(t, repo, workerId)
+		require.NotNil(t, job)
+		assert.Equal(t, grouped[i].Jobs[0].ID, job.ID)
+	}
+	assert.Nil(t, claim(t, repo, "worker-3"))
+}
+
+func testClaimNextWithinGroupSlot(t *testing.T, repo ports.BuildRepository) {
+	grouped := newGroupedBuilds("deploy", 1, 2)
+	grouped[0].Jobs = []domain.Job{newJob("build"), newJob("test", "build")}
+	for i := range grouped[0].Jobs {
+		grouped[0].Jobs[i].CreatedAt = grouped[0].CreatedAt
+	}
+	save(t, repo, grouped[0])
+	save(t, repo, grouped[1])
+
+	first := claim(t, repo, "worker-1")
+	require.NotNil(t, first)
+	complete(t, repo, first.ID, domain.JobStatusSuccess, 0, time.Now())
+
+	next := claim(t, repo, "worker-1")
+	require.NotNil(t, next)
+	assert.Equal(t, "test", next.Name, "the running build keeps its slot")
+	assert.Equal(t, grouped[0].ID, next.BuildID)
+
+	group := "matrix"
+	parent := newBuild("go test ./...")
+	parent.ConcurrencyGroup = &group
+	parent.Matrix = &domain.Matrix{Axes: map[string][]string{"go": {"1.24", "1.25"}}}
+	for _, version := range []string{"1.24", "1.25"} {
+		child := *newBuild("go test ./...", newJob(domain.DefaultJobName))
+		child.ConcurrencyGroup = &group
+		child.MatrixValues = domain.StringMap{"go": version}
+		parent.Children = append(parent.Children, child)
+	}
+	save(t, repo, parent)
+
+	for _, workerId := range []string{"worker-2", "worker-3"} {
+		job := claim(t, repo, workerId)
+		require.NotNil(t, job, "matrix children share their parent's slot")
+		assert.Equal(t, parent.ID, *job.Build.ParentID)
+	}
+}
+
+func testStartJobConcurrencyLimit(t *testing.T, repo ports.BuildRepository) {
+	grouped := newGroupedBuilds("deploy", 1, 2)
+	for _, build := range grouped {
+		save(t, repo, build)
+	}
+
+	_, err := repo.StartJob(context.Background(), grouped[0].Jobs[0].ID, "worker-1")
+	require.NoError(t, err)
+
+	_, err = repo.StartJob(context.Background(), grouped[1].Jobs[0].ID, "worker-2")
+	assert.ErrorIs(t, err, domain.ErrConcurrencyLimit)
+	assert.Equal(t, domain.BuildStatusPending, find(t, repo, grouped[1].ID).Status)
+}
+
+func testCancelSuperseded(t *testing.T, repo ports.BuildRepository) {
+	grouped := newGroupedBuilds("deploy", 1, 4)
+	for _, build := range grouped {
+		save(t, repo, build)
+	}
+	other := newGroupedBuilds("other", 1, 1)[0]
+	save(t, repo, other)
+	running := claim(t, repo, "worker-1")
+	require.NotNil(t, running)
+	require.Equal(t, grouped[0].ID, running.BuildID)
+
+	pendingOnly := []domain.BuildStatus{domain.BuildStatusPending}
+	require.NoError(t, repo.CancelSuperseded(context.Background(), grouped[2], pendingOnly))
+
+	assert.Equal(t, domain.BuildStatusRunning, find(t, repo, grouped[0].ID).Status)
+	assert.Equal(t, domain.BuildStatusCanceled, find(t, repo, grouped[1].ID).Status)
+	assert.Equal(t, domain.BuildStatusPending, find(t, repo, grouped[2].ID).Status)
+	assert.Equal(t, domain.BuildStatusPending, find(t, repo, grouped[3].ID).Status, "newer builds are kept")
+	assert.Equal(t, domain.BuildStatusPending, find(t, repo, other.ID).Status)
+
+	unfinished := []domain.BuildStatus{domain.BuildStatusPending, domain.BuildStatusRunning}
+	require.NoError(t, repo.CancelSuperseded(context.Background(), grouped[3], unfinished))
+	assert.Equal(t, domain.BuildStatusCanceled, find(t, repo, grouped[0].ID).Status)
+	assert.Equal(t, domain.BuildStatusCanceled, find(t, repo, grouped[2].ID).Status)
+	assert.Equal(t, domain.BuildStatusPending, find(t, repo, grouped[3].ID).Status)
+
+	canceled := find(t, repo, grouped[1].ID)
+	assert.NotNil(t, canceled.FinishedAt)
+	assert.Equal(t, domain.JobStatusCanceled, canceled.Jobs[0].Status)
+	stopping := find(t, repo, grouped[0].ID)
+	assert.NotNil(t, stopping.CancelRequestedAt)
+	assert.NotNil(t, stopping.Jobs[0].CancelRequestedAt, "the running job is asked to stop")
+
+	assert.Equal(t, other.ID, claim(t, repo, "worker-2").BuildID)
+	assert.Nil(t, claim(t, repo, "worker-2"), "the canceled build holds its slot until its job stops")
+	complete(t, repo, running.ID, domain.JobStatusCanceled, -1, time.Now())
+	next := claim(t, repo, "worker-2")
+	require.NotNil(t, next)
+	assert.Equal(t, grouped[3].ID, next.BuildID)
+}
+
+func testFindJobByID(t *testing.T, repo ports.BuildRepository) {
+	build := save(t, repo, newBuild("make", newJob(domain.DefaultJobName)))
+
+	job, err := repo.FindJobByID(context.Background(), build.Jobs[0].ID)
+	require.NoError(t, err)
+	assert.Equal(t, domain.DefaultJobName, job.Name)
+	require.NotNil(t, job.Build)
+	assert.Equal(t, build.ID, job.Build.ID)
+
+	_, err = repo.FindJobByID(context.Background(), unknownID)
+	assert.ErrorIs(t, err, domain.ErrJobNotFound)
+}
+
+func testUpdatePriority(t *testing.T, repo ports.BuildRepository) {
+	base := time.Now().Add(-time.Minute)
+	older := newBuild("older", newJob(domain.DefaultJobName))
+	older.Jobs[0].CreatedAt = base
+	matrix := &domain.Build{
+		RepoUrl: "https://github.com/test/repo",
+		Ref:     "main",
+		Command: "make",
+		Children: []domain.Build{
+			{RepoUrl: "https://github.com/test/repo", Ref: "main", Command: "make", Jobs: []domain.Job{newJob(domain.DefaultJobName)}},
+		},
+	}
+	matrix.Children[0].Jobs[0].CreatedAt = base.Add(time.Second)
+	save(t, repo, older)
+	save(t, repo, matrix)
+
+	require.NoError(t, repo.UpdatePriority(context.Background(), matrix.ID, 3))
+
+	assert.Equal(t, 3, find(t, repo, matrix.ID).Priority)
+	assert.Equal(t, 3, find(t, repo, matrix.Children[0].ID).Priority)
+	first := claim(t, repo, "worker-1")
+	require.NotNil(t, first)
+	assert.Equal(t, matrix.Children[0].Jobs[0].ID, first.ID)
+}
+
+func testUpdatePriorityNotPending(t *testing.T, repo ports.BuildRepository) {
+	build := save(t, repo, newBuild("make", newJob(domain.DefaultJobName)))
+	require.NotNil(t, claim(t, repo, "worker-1"))
+
+	err := repo.UpdatePriority(context.Background(), build.ID, 3)
+	assert.ErrorIs(t, err, domain.ErrBuildNotPending)
+	assert.Equal(t, 0, find(t, repo, build.ID).Priority)
+
+	err = repo.UpdatePriority(context.Background(), unknownID, 3)
+	assert.ErrorIs(t, err, domain.ErrBuildNotFound)
+}
+
+func testStartJob(t *testing.T, repo ports.BuildRepository) {
+	build := save(t, repo, newBuild("make", newJob(domain.DefaultJobName)))
+	jobId := build.Jobs[0].ID
+
+	job, err := repo.StartJob(context.Background(), jobId, "worker-1")
+	require.NoError(t, err)
+	assert.Equal(t, domain.JobStatusRunning, job.Status)
+	require.NotNil(t, job.LockedBy)
+	assert.Equal(t, "worker-1", *job.LockedBy)
+	require.NotNil(t, job.Build)
+	assert.Equal(t, domain.BuildStatusRunning, job.Build.Status)
+
+	again, err := repo.StartJob(context.Background(), jobId, "worker-1")
+	require.NoError(t, err, "a redelivered job is started again by its worker")
+	assert.Equal(t, jobId, again.ID)
+
+	_, err = repo.StartJob(context.Background(), jobId, "worker-2")
+	assert.ErrorIs(t, err, domain.ErrJobNotClaimable)
+	assert.Nil(t, claim(t, repo, "worker-2"))
+}
+
+func testStartJobNotClaimable(t *testing.T, repo ports.BuildRepository) {
+	build := save(t, repo, newBuild("make", newJob("build"), newJob("test", "build")))
+	canceled := save(t, repo, newBuild("make", newJob(domain.DefaultJobName)))
+	require.NoError(t, repo.Update(context.Background(), &domain.Build{ID: canceled.ID, Status: domain.BuildStatusCanceled}))
+
+	_, err := repo.StartJob(context.Background(), findJob(t, build, "test").ID, "worker-1")
+	assert.ErrorIs(t, err, domain.ErrJobNotClaimable, "needs have not succeeded")
+
+	_, err = repo.StartJob(context.Background(), canceled.Jobs[0].ID, "worker-1")
+	assert.ErrorIs(t, err, domain.ErrJobNotClaimable)
+
+	_, err = repo.StartJob(context.Background(), unknownID, "worker-1")
+	assert.ErrorIs(t, err, domain.ErrJobNotFound)
+}
+
+func testStartJobNotDue(t *testing.T, repo ports.BuildRepository) {
+	later := save(t, repo, delayedBuild(time.Now().Add(time.Hour)))
+	due := save(t, repo, delayedBuild(time.Now().Add(-time.Minute)))
+
+	_, err := repo.StartJob(context.Background(), later.Jobs[0].ID, "worker-1")
+	assert.ErrorIs(t, err, domain.ErrBuildNotDue)
+	assert.Equal(t, domain.BuildStatusPending, find(t, repo, later.ID).Status)
+
+	job, err := repo.StartJob(context.Background(), due.Jobs[0].ID, "worker-1")
+	require.NoError(t, err)
+	assert.Equal(t, domain.JobStatusRunning, job.Status)
+}
+
+func testReleaseJob(t *testing.T, repo ports.BuildRepository) {
+	save(t, repo, newBuild("make", newJob(domain.DefaultJobName)))
+	job := claim(t, repo, "worker-1")
+	require.NotNil(t, job)
+
+	assert.ErrorIs(t, repo.ReleaseJob(context.Background(), job.ID, "worker-2"), domain.ErrJobNotFound)
+	require.NoError(t, repo.ReleaseJob(context.Background(), job.ID, "worker-1"))
+
+	stored, err := repo.FindJobByID(context.Background(), job.ID)
+	require.NoError(t, err)
+	assert.Equal(t, domain.JobStatusPending, stored.Status)
+	assert.Nil(t, stored.LockedBy)
+
+	reclaimed := claim(t, repo, "worker-2")
+	require.NotNil(t, reclaimed)
+	assert.Equal(t, job.ID, reclaimed.ID)
+}
+
+func testRequeueExpiredAfterWorkerDies(t *testing.T, repo ports.BuildRepository) {
+	ctx := context.Background()
+	save(t, repo, newBuild("make", newJob(domain.DefaultJobName)))
+	save(t, repo, newBuild("make", newJob(domain.DefaultJobName)))
+	alive := claim(t, repo, "worker-1")
+	dead := claim(t, repo, "worker-2")
+	require.NotNil(t, alive)
+	require.NotNil(t, dead)
+
+	// worker-2 is killed mid-job and stops sending heartbeats.
+	now := time.Now()
+	require.NoError(t, repo.Heartbeat(ctx, alive.ID, "worker-1", now))
+	require.NoError(t, repo.Heartbeat(ctx, dead.ID, "worker-2", now.Add(-2*time.Minute)))
+
+	requeued, err := repo.RequeueExpired(ctx, now.Add(-time.Minute))
+	require.NoError(t, err)
+	assert.Equal(t, []string{dead.ID}, requeued)
+
+	stored, err := repo.FindJobByID(ctx, dead.ID)
+	require.NoError(t, err)
+	assert.Equal(t, domain.JobStatusPending, stored.Status)
+	assert.Nil(t, stored.LockedBy)
+
+	taken := claim(t, repo, "worker-3")
+	require.NotNil(t, taken)
+	assert.Equal(t, dead.ID, taken.ID)
+
+	requeued, err = repo.RequeueExpired(ctx, now.Add(-time.Minute))
+	require.NoError(t, err)
+	assert.Empty(t, requeued)
+}
+
+func testRequeueExpiredCancelsFinishedBuilds(t *testing.T, repo ports.BuildRepository) {
+	ctx := context.Background()
+	build := save(t, repo, newBuild("make", newJob(domain.DefaultJobName)))
+	job := claim(t, repo, "worker-1")
+	require.NotNil(t, job)
+	require.NoError(t, repo.Cancel(ctx, build.ID))
+
+	requeued, err := repo.RequeueExpired(ctx, time.Now().Add(time.Minute))
+	require.NoError(t, err)
+	assert.Empty(t, requeued)
+
+	found := find(t, repo, build.ID)
+	assert.Equal(t, domain.JobStatusCanceled, found.Jobs[0].Status)
+	assert.NotNil(t, found.Jobs[0].FinishedAt)
+}
+
+func testHeartbeat(t *testing.T, repo ports.BuildRepository) {
+	build := save(t, repo, newBuild("make", newJob("a"), newJob("b", "a")))
+	job := claim(t, repo, "worker-1")
+	require.NotNil(t, job)
+
+	at := time.Now().Add(time.Minute)
+	require.NoError(t, repo.Heartbeat(context.Background(), job.ID, "worker-1", at))
+
+	stored, err := repo.FindJobByID(context.Background(), job.ID)
+	require.NoError(t, err)
+	require.NotNil(t, stored.LockedAt)
+	assert.WithinDuration(t, at, *stored.LockedAt, time.Millisecond)
+
+	assert.ErrorIs(t, repo.Heartbeat(context.Background(), job.ID, "worker-2", at), domain.ErrJobNotFound)
+	assert.ErrorIs(t, repo.Heartbeat(context.Background(), findJob(t, build, "b").ID, "worker-1", at), domain.ErrJobNotFound)
+
+	complete(t, repo, job.ID, domain.JobStatusSuccess, 0, time.Now())
+	assert.ErrorIs(t, repo.Heartbeat(context.Background(), job.ID, "worker-1", at), domain.ErrJobNotFound)
+}
+
+func testCompleteJobSuccess(t *testing.T, repo ports.BuildRepository) {
+	build := save(t, repo, newBuild("make", newJob(domain.DefaultJobName)))
+	job := claim(t, repo, "worker-1")
+	require.NotNil(t, job)
+
+	finishedAt := time.Now()
+	complete(t, repo, job.ID, domain.JobStatusSuccess, 0, finishedAt)
+
+	found := find(t, repo, build.ID)
+	assert.Equal(t, domain.BuildStatusSuccess, found.Status)
+	require.NotNil(t, found.FinishedAt)
+	assert.WithinDuration(t, finishedAt, *found.FinishedAt, time.Millisecond)
+	assert.Equal(t, domain.JobStatusSuccess, found.Jobs[0].Status)
+	require.NotNil(t, found.Jobs[0].FinishedAt)
+}
+
+func testCompleteJobFailureSkipsDownstream(t *testing.T, repo ports.BuildRepository) {
+	build := save(t, repo, newBuild("make", newJob("build"), newJob("test", "build"), newJob("deploy", "test"), newJob("lint")))
+
+	var buildJob *domain.Job
+	for buildJob == nil || buildJob.Name != "build" {
+		buildJob = claim(t, repo, "worker-1")
+		require.NotNil(t, buildJob)
+	}
+	complete(t, repo, buildJob.ID, domain.JobStatusFailed, 2, time.Now())
+
+	found := find(t, repo, build.ID)
+	assert.Equal(t, domain.JobStatusFailed, findJob(t, found, "build").Status)
+	assert.Equal(t, domain.JobStatusSkipped, findJob(t, found, "test").Status)
+	assert.Equal(t, domain.JobStatusSkipped, findJob(t, found, "deploy").Status)
+	assert.NotEqual(t, domain.JobStatusSkipped, findJob(t, found, "lint").Status)
+	assert.False(t, found.Status.IsTerminal(), "lint has not finished")
+
+	lint, err := repo.FindJobByID(context.Background(), findJob(t, found, "lint").ID)
+	require.NoError(t, err)
+	if lint.Status == domain.JobStatusPending {
+		lint = claim(t, repo, "worker-1")
+		require.NotNil(t, lint)
+	}
+	complete(t, repo, lint.ID, domain.JobStatusSuccess, 0, time.Now())
+
+	found = find(t, repo, build.ID)
+	assert.Equal(t, domain.BuildStatusFailed, found.Status)
+	assert.Equal(t, 2, found.ExitCode)
+	assert.Equal(t, "exit status 2", found.Error)
+	assert.NotNil(t, found.FinishedAt)
+}
+
+func testCompleteJobKeepsCanceledBuild(t *testing.T, repo ports.BuildRepository) {
+	build := save(t, repo, newBuild("make", newJob(domain.DefaultJobName)))
+	job := claim(t, repo, "worker-1")
+	require.NotNil(t, job)
+	require.NoError(t, repo.Update(context.Background(), &domain.Build{ID: build.ID, Status: domain.BuildStatusCanceled}))
+
+	complete(t, repo, job.ID, domain.JobStatusFailed, 137, time.Now())
+
+	found := find(t, repo, build.ID)
+	assert.Equal(t, domain.BuildStatusCanceled, found.Status)
+	assert.Equal(t, domain.JobStatusFailed, found.Jobs[0].Status)
+	assert.Equal(t, 137, found.Jobs[0].ExitCode)
+}
+
+func testCompleteJobNotFound(t *testing.T, repo ports.BuildRepository) {
+	now := time.Now()
+	err := repo.CompleteJob(context.Background(), &domain.Job{ID: unknownID, Status: domain.JobStatusSuccess, FinishedAt: &now}, "worker-1")
+
+	assert.ErrorIs(t, err, domain.ErrJobNotFound)
+}
+
+// testCompleteJobAfterLeaseLost lets a worker whose lease expired report its
+// result after another worker took the job over.
+func testCompleteJobAfterLeaseLost(t *testing.T, repo ports.BuildRepository) {
+	ctx := context.Background()
+	build := save(t, repo, newBuild("make", newJob(domain.DefaultJobName)))
+	stale := claim(t, repo, "worker-1")
+	require.NotNil(t, stale)
+	_, err := repo.RequeueExpired(ctx, time.Now().Add(time.Minute))
+	require.NoError(t, err)
+	live := claim(t, repo, "worker-2")
+	require.NotNil(t, live)
+	require.Equal(t, stale.ID, live.ID)
+
+	now := time.Now()
+	err = repo.CompleteJob(ctx, &domain.Job{ID: stale.ID, Status: domain.JobStatusFailed, ExitCode: 1, FinishedAt: &now}, "worker-1")
+	assert.ErrorIs(t, err, domain.ErrJobLockLost)
+
+	found := find(t, repo, build.ID)
+	assert.Equal(t, domain.JobStatusRunning, found.Jobs[0].Status, "the stale result is dropped")
+	assert.Equal(t, domain.BuildStatusRunning, found.Status)
+
+	require.NoError(t, repo.CompleteJob(ctx, &domain.Job{ID: live.ID, Status: domain.JobStatusSuccess, FinishedAt: &now}, "worker-2"))
+	assert.Equal(t, domain.BuildStatusSuccess, find(t, repo, build.ID).Status)
+
+	err = repo.CompleteJob(ctx, &domain.Job{ID: live.ID, Status: domain.JobStatusFailed, ExitCode: 1, FinishedAt: &now}, "worker-2")
+	assert.ErrorIs(t, err, domain.ErrJobLockLost, "a finished job is not completed twice")
+}
+
+func saveMatrix(t *testing.T, repo ports.BuildRepository, failFast bool) *domain.Build {
+	t.Helper()
+	parent := newBuild("go test ./...")
+	parent.Matrix = &domain.Matrix{Axes: map[string][]string{"go": {"1.24", "1.25"}}, FailFast: failFast}
+	base := time.Now().Add(-time.Minute)
+	for i, version := range []string{"1.24", "1.25"} {
+		child := *newBuild("go test ./...", newJob(domain.DefaultJobName))
+		child.MatrixValues = domain.StringMap{"go": version}
+		child.Jobs[0].CreatedAt = base.Add(time.Duration(i) * time.Second)
+		parent.Children = append(parent.Children, child)
+	}
+	return save(t, repo, parent)
+}
+
+func testCompleteJobRollsUpMatrix(t *testing.T, repo ports.BuildRepository) {
+	parent := saveMatrix(t, repo, false)
+
+	first := claim(t, repo, "worker-1")
+	require.NotNil(t, first)
+	assert.Equal(t, domain.BuildStatusRunning, find(t, repo, parent.ID).Status)
+
+	complete(t, repo, first.ID, domain.JobStatusFailed, 1, time.Now())
+	assert.Equal(t, domain.BuildStatusRunning, find(t, repo, parent.ID).Status, "the other child is still pending")
+
+	second := claim(t, repo, "worker-1")
+	require.NotNil(t, second)
+	complete(t, repo, second.ID, domain.JobStatusSuccess, 0, time.Now())
+
+	found := find(t, repo, parent.ID)
+	assert.Equal(t, domain.BuildStatusFailed, found.Status)
+	assert.NotNil(t, found.FinishedAt)
+}
+
+func testCancelMatrix(t *testing.T, repo ports.BuildRepository) {
+	ctx := context.Background()
+	parent := saveMatrix(t, repo, false)
+	running := claim(t, repo, "worker-1")
+	require.NotNil(t, running)
+
+	require.NoError(t, repo.Cancel(ctx, parent.ID))
+
+	found := find(t, repo, parent.ID)
+	assert.Equal(t, domain.BuildStatusCanceled, found.Status)
+	assert.NotNil(t, found.FinishedAt)
+	for _, child := range found.Children {
+		assert.Equal(t, domain.BuildStatusCanceled, child.Status)
+		assert.NotNil(t, child.CancelRequestedAt)
+		assert.NotNil(t, child.FinishedAt)
+
+		job := find(t, repo, child.ID).Jobs[0]
+		if child.ID == running.BuildID {
+			assert.Equal(t, domain.JobStatusRunning, job.Status, "the worker stops the running job")
+			assert.NotNil(t, job.CancelRequestedAt)
+			continue
+		}
+		assert.Equal(t, domain.JobStatusCanceled, job.Status)
+	}
+	assert.Nil(t, claim(t, repo, "worker-1"), "canceled children are not claimed")
+
+	complete(t, repo, running.ID, domain.JobStatusCanceled, -1, time.Now())
+	assert.Equal(t, domain.BuildStatusCanceled, find(t, repo, running.BuildID).Status)
+
+	require.NoError(t, repo.Cancel(ctx, parent.ID), "canceling a finished build does nothing")
+}
+
+func testCancelNotFound(t *testing.T, repo ports.BuildRepository) {
+	assert.ErrorIs(t, repo.Cancel(context.Background(), unknownID), domain.ErrBuildNotFound)
+}
+
+func testCompleteJobFailFast(t *testing.T, repo ports.BuildRepository) {
+	parent := saveMatrix(t, repo, true)
+
+	first := claim(t, repo, "worker-1")
+	require.NotNil(t, first)
+	complete(t, repo, first.ID, domain.JobStatusFailed, 1, time.Now())
+
+	found := find(t, repo, parent.ID)
+	assert.Equal(t, domain.BuildStatusFailed, found.Status)
+	assert.NotNil(t, found.FinishedAt)
+
+	for _, child := range found.Children {
+		if child.ID == first.BuildID {
+			assert.Equal(t, domain.BuildStatusFailed, child.Status)
+			continue
+		}
+		assert.Equal(t, domain.BuildStatusCanceled, child.Status)
+		assert.NotNil(t, child.CancelRequestedAt)
+		assert.Equal(t, domain.JobStatusCanceled, find(t, repo, child.ID).Jobs[0].Status)
+	}
+	assert.Nil(t, claim(t, repo, "worker-1"), "canceled children are not claimed")
+}
@@ -0,0 +1,197 @@
+package repotest
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/H3nSte1n/ci-orchestrator/internal/core/domain"
+	"github.com/H3nSte1n/ci-orchestrator/internal/core/ports"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	busyRepo  = "https://github.com/test/busy"
+	quietRepo = "https://github.com/test/quiet"
+)
+
+// RunRepoLimitRepository runs the repository limit conformance tests,
+// including fair sharing and limits in the build repository's claim path.
+func RunRepoLimitRepository(t *testing.T, newRepos Factory) {
+	tests := []struct {
+		name string
+		run  func(t *testing.T, builds ports.BuildRepository, limits ports.RepoLimitRepository)
+	}{
+		{"SaveAndList", testSaveAndListRepoLimits},
+		{"ClaimNextTakesTurns", testClaimNextTakesTurns},
+		{"ClaimNextPriorityOutweighsShare", testClaimNextPriorityOutweighsShare},
+		{"ClaimNextRepoLimit", testClaimNextRepoLimit},
+		{"ClaimNextRepoLimitConcurrent", testClaimNextRepoLimitConcurrent},
+		{"StartJobRepoLimit", testStartJobRepoLimit},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repos := newRepos(t)
+			tt.run(t, repos.Builds, repos.Limits)
+		})
+	}
+}
+
+func setLimit(t *testing.T, limits ports.RepoLimitRepository, repoUrl string, maxRunning int) {
+	t.Helper()
+	require.NoError(t, limits.Save(context.Background(), &domain.RepoLimit{RepoUrl: repoUrl, MaxRunningBuilds: maxRunning}))
+}
+
+// saveRepoBuilds saves n single job builds of repoUrl whose jobs were
+// created one second apart from base.
+func saveRepoBuilds(t *testing.T, builds ports.BuildRepository, repoUrl string, base time.Time, n int) []*domain.Build {
+	t.Helper()
+	saved := make([]*domain.Build, n)
+	for i := range saved {
+		build := newBuild("make", newJob(domain.DefaultJobName))
+		build.RepoUrl = repoUrl
+		build.Jobs[0].CreatedAt = base.Add(time.Duration(i) * time.Second)
+		saved[i] = save(t, builds, build)
+	}
+	return saved
+}
+
+func testSaveAndListRepoLimits(t *testing.T, _ ports.BuildRepository, limits ports.RepoLimitRepository) {
+	setLimit(t, limits, quietRepo, 3)
+	setLimit(t, limits, busyRepo, 2)
+	setLimit(t, limits, quietRepo, 0)
+
+	listed, err := limits.List(context.Background())
+	require.NoError(t, err)
+	require.Len(t, listed, 2)
+	assert.Equal(t, busyRepo, listed[0].RepoUrl)
+	assert.Equal(t, 2, listed[0].MaxRunningBuilds)
+	assert.Equal(t, quietRepo, listed[1].RepoUrl)
+	assert.Equal(t, 0, listed[1].MaxRunningBuilds, "saving again replaces the maximum")
+}
+
+func testClaimNextTakesTurns(t *testing.T, builds ports.BuildRepository, _ ports.RepoLimitRepository) {
+	base := time.Now().Add(-time.Hour)
+	busy := saveRepoBuilds(t, builds, busyRepo, base, 4)
+	quiet := saveRepoBuilds(t, builds, quietRepo, base.Add(time.Minute), 2)
+
+	var order []string
+	for {
+		job := claim(t, builds, "worker")
+		if job == nil {
+			break
+		}
+		order = append(order, job.BuildID)
+	}
+
+	assert.Equal(t, []string{busy[0].ID, quiet[0].ID, busy[1].ID, quiet[1].ID, busy[2].ID, busy[3].ID}, order,
+		"the quiet repository does not wait for the busy one's backlog")
+}
+
+func testClaimNextPriorityOutweighsShare(t *testing.T, builds ports.BuildRepository, _ ports.RepoLimitRepository) {
+	base := time.Now().Add(-time.Hour)
+	running := saveRepoBuilds(t, builds, busyRepo, base, 1)[0]
+	require.Equal(t, running.ID, claim(t, builds, "worker-1").BuildID)
+
+	urgent := newBuild("make", newJob(domain.DefaultJobName))
+	urgent.RepoUrl = busyRepo
+	urgent.Priority = 20
+	urgent.Jobs[0].CreatedAt = base.Add(time.Second)
+	save(t, builds, urgent)
+	same := saveRepoBuilds(t, builds, busyRepo, base.Add(time.Second), 1)[0]
+	quiet := saveRepoBuilds(t, builds, quietRepo, base.Add(2*time.Second), 1)[0]
+
+	var order []string
+	for _, worker := range []string{"worker-2", "worker-3", "worker-4"} {
+		job := claim(t, builds, worker)
+		require.NotNil(t, job)
+		order = append(order, job.BuildID)
+	}
+
+	assert.Equal(t, []string{urgent.ID, quiet.ID, same.ID}, order,
+		"priority outweighs the busy repository's running build, which still puts it behind at equal priority")
+}
+
+func testClaimNextRepoLimit(t *testing.T, builds ports.BuildRepository, limits ports.RepoLimitRepository) {
+	setLimit(t, limits, busyRepo, 1)
+	base := time.Now().Add(-time.Hour)
+	pipeline := newBuild("make", newJob("build"), newJob("test", "build"))
+	pipeline.RepoUrl = busyRepo
+	for i := range pipeline.Jobs {
+		pipeline.Jobs[i].CreatedAt = base
+	}
+	save(t, builds, pipeline)
+	waiting := saveRepoBuilds(t, builds, busyRepo, base.Add(time.Second), 1)[0]
+	quiet := saveRepoBuilds(t, builds, quietRepo, base.Add(time.Minute), 1)[0]
+
+	first := claim(t, builds, "worker-1")
+	require.NotNil(t, first)
+	assert.Equal(t, pipeline.ID, first.BuildID)
+
+	next := claim(t, builds, "worker-2")
+	require.NotNil(t, next)
+	assert.Equal(t, quiet.ID, next.BuildID)
+	assert.Nil(t, claim(t, builds, "worker-3"), "the busy repository runs its maximum")
+
+	complete(t, builds, first.ID, domain.JobStatusSuccess, 0, time.Now())
+	next = claim(t, builds, "worker-1")
+	require.NotNil(t, next)
+	assert.Equal(t, "test", next.Name, "a running build keeps its slot")
+	assert.Nil(t, claim(t, builds, "worker-3"))
+
+	complete(t, builds, next.ID, domain.JobStatusSuccess, 0, time.Now())
+	next = claim(t, builds, "worker-1")
+	require.NotNil(t, next)
+	assert.Equal(t, waiting.ID, next.BuildID)
+}
+
+func testClaimNextRepoLimitConcurrent(t *testing.T, builds ports.BuildRepository, limits ports.RepoLimitRepository) {
+	const maxRunning, workers = 3, 8
+	setLimit(t, limits, busyRepo, maxRunning)
+	saveRepoBuilds(t, builds, busyRepo, time.Now().Add(-time.Hour), 20)
+
+	var (
+		mu      sync.Mutex
+		claimed []string
+		wg      sync.WaitGroup
+		errs    = make(chan error, workers)
+	)
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			job, err := builds.ClaimNext(context.Background(), "worker")
+			if err != nil {
+				errs <- err
+				return
+			}
+			if job != nil {
+				mu.Lock()
+				claimed = append(claimed, job.ID)
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		require.NoError(t, err)
+	}
+	assert.Len(t, claimed, maxRunning)
+}
+
+func testStartJobRepoLimit(t *testing.T, builds ports.BuildRepository, limits ports.RepoLimitRepository) {
+	setLimit(t, limits, busyRepo, 1)
+	busy := saveRepoBuilds(t, builds, busyRepo, time.Now().Add(-time.Hour), 2)
+
+	_, err := builds.StartJob(context.Background(), busy[0].Jobs[0].ID, "worker-1")
+	require.NoError(t, err)
+
+	_, err = builds.StartJob(context.Background(), busy[1].Jobs[0].ID, "worker-2")
+	assert.ErrorIs(t, err, domain.ErrRepoLimit)
+	assert.Equal(t, domain.BuildStatusPending, find(t, builds, busy[1].ID).Status)
+}
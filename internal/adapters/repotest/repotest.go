@@ -0,0 +1,91 @@
+// Package repotest is a conformance suite for build, log, worker, repository
+// limit and schedule repositories. Every implementation of
+// ports.BuildRepository, ports.BuildLogRepository, ports.WorkerRepository,
+// ports.RepoLimitRepository and ports.ScheduleRepository runs it from its own
+// tests, so the adapters cannot drift apart.
+package repotest
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/H3nSte1n/ci-orchestrator/internal/core/domain"
+	"github.com/H3nSte1n/ci-orchestrator/internal/core/ports"
+	"github.com/stretchr/testify/require"
+)
+
+// Repositories share one store, so each sees the rows of the others.
+type Repositories struct {
+	Builds    ports.BuildRepository
+	Logs      ports.BuildLogRepository
+	Workers   ports.WorkerRepository
+	Limits    ports.RepoLimitRepository
+	Schedules ports.ScheduleRepository
+}
+
+// Factory returns repositories backed by a fresh, empty store. It is called
+// once per test.
+type Factory func(t *testing.T) Repositories
+
+// unknownID is a well formed id that no store generates.
+const unknownID = "00000000-0000-4000-8000-000000000000"
+
+func newBuild(command string, jobs ...domain.Job) *domain.Build {
+	return &domain.Build{
+		RepoUrl: "https://github.com/test/repo",
+		Ref:     "main",
+		Command: command,
+		Jobs:    jobs,
+	}
+}
+
+func newJob(name string, needs ...string) domain.Job {
+	return domain.Job{Name: name, Command: "run " + name, Needs: needs}
+}
+
+func save(t *testing.T, repo ports.BuildRepository, build *domain.Build) *domain.Build {
+	t.Helper()
+	require.NoError(t, repo.Save(context.Background(), build))
+	return build
+}
+
+func find(t *testing.T, repo ports.BuildRepository, buildId string) *domain.Build {
+	t.Helper()
+	build, err := repo.FindByID(context.Background(), buildId)
+	require.NoError(t, err)
+	return build
+}
+
+func findJob(t *testing.T, build *domain.Build, name string) domain.Job {
+	t.Helper()
+	for _, job := range build.Jobs {
+		if job.Name == name {
+			return job
+		}
+	}
+	t.Fatalf("build %s has no job %q", build.ID, name)
+	return domain.Job{}
+}
+
+func claim(t *testing.T, repo ports.BuildRepository, workerId string) *domain.Job {
+	t.Helper()
+	job, err := repo.ClaimNext(context.Background(), workerId)
+	require.NoError(t, err)
+	return job
+}
+
+// complete records the outcome of a job on behalf of the worker holding it.
+func complete(t *testing.T, repo ports.BuildRepository, jobId string, status domain.JobStatus, exitCode int, finishedAt time.Time) {
+	t.Helper()
+	stored, err := repo.FindJobByID(context.Background(), jobId)
+	require.NoError(t, err)
+	require.NotNil(t, stored.LockedBy, "job %s is not claimed", jobId)
+
+	job := &domain.Job{ID: jobId, Status: status, ExitCode: exitCode, FinishedAt: &finishedAt}
+	if status == domain.JobStatusFailed {
+		job.Error = fmt.Sprintf("exit status %d", exitCode)
+	}
+	require.NoError(t, repo.CompleteJob(context.Background(), job, *stored.LockedBy))
+}
@@ -0,0 +1,189 @@
+package repotest
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/H3nSte1n/ci-orchestrator/internal/core/domain"
+	"github.com/H3nSte1n/ci-orchestrator/internal/core/ports"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// RunScheduleRepository runs the schedule repository conformance tests.
+func RunScheduleRepository(t *testing.T, newRepos Factory) {
+	tests := []struct {
+		name string
+		run  func(t *testing.T, repo ports.ScheduleRepository)
+	}{
+		{"SaveAndFindByID", testSaveAndFindSchedule},
+		{"FindByIDNotFound", testFindScheduleNotFound},
+		{"List", testListSchedules},
+		{"Delete", testDeleteSchedule},
+		{"ListDue", testListDueSchedules},
+		{"Advance", testAdvanceSchedule},
+		{"AdvanceConcurrent", testAdvanceScheduleConcurrent},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.run(t, newRepos(t).Schedules)
+		})
+	}
+}
+
+// runAt is a whole minute in UTC, like the runs a cron expression yields.
+var runAt = time.Date(2026, 10, 19, 3, 0, 0, 0, time.UTC)
+
+func newSchedule(name string, nextRunAt time.Time) *domain.Schedule {
+	return &domain.Schedule{
+		Name:            name,
+		RepoUrl:         "https://github.com/test/repo",
+		Ref:             "main",
+		Command:         "make test",
+		Cron:            "0 3 * * *",
+		Timezone:        "UTC",
+		MissedRunPolicy: domain.MissedRunOnce,
+		NextRunAt:       nextRunAt,
+	}
+}
+
+func saveSchedule(t *testing.T, repo ports.ScheduleRepository, schedule *domain.Schedule) *domain.Schedule {
+	t.Helper()
+	require.NoError(t, repo.Save(context.Background(), schedule))
+	return schedule
+}
+
+func testSaveAndFindSchedule(t *testing.T, repo ports.ScheduleRepository) {
+	schedule := newSchedule("nightly", runAt)
+	schedule.Env = domain.StringMap{"SUITE": "full"}
+	saveSchedule(t, repo, schedule)
+	assert.NotEmpty(t, schedule.ID)
+	assert.False(t, schedule.CreatedAt.IsZero())
+
+	found, err := repo.FindByID(context.Background(), schedule.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "nightly", found.Name)
+	assert.Equal(t, schedule.Cron, found.Cron)
+	assert.Equal(t, domain.MissedRunOnce, found.MissedRunPolicy)
+	assert.Equal(t, domain.StringMap{"SUITE": "full"}, found.Env)
+	assert.True(t, runAt.Equal(found.NextRunAt))
+	assert.Nil(t, found.LastRunAt)
+}
+
+func testFindScheduleNotFound(t *testing.T, repo ports.ScheduleRepository) {
+	_, err := repo.FindByID(context.Background(), unknownID)
+	assert.ErrorIs(t, err, domain.ErrScheduleNotFound)
+
+	_, err = repo.FindByID(context.Background(), "not-a-uuid")
+	assert.ErrorIs(t, err, domain.ErrInvalidArgument)
+}
+
+func testListSchedules(t *testing.T, repo ports.ScheduleRepository) {
+	listed, err := repo.List(context.Background())
+	require.NoError(t, err)
+	assert.NotNil(t, listed)
+	assert.Empty(t, listed)
+
+	saveSchedule(t, repo, newSchedule("weekly", runAt))
+	saveSchedule(t, repo, newSchedule("hourly", runAt))
+
+	listed, err = repo.List(context.Background())
+	require.NoError(t, err)
+	require.Len(t, listed, 2)
+	assert.Equal(t, "hourly", listed[0].Name)
+	assert.Equal(t, "weekly", listed[1].Name)
+}
+
+func testDeleteSchedule(t *testing.T, repo ports.ScheduleRepository) {
+	schedule := saveSchedule(t, repo, newSchedule("nightly", runAt))
+
+	require.NoError(t, repo.Delete(context.Background(), schedule.ID))
+	_, err := repo.FindByID(context.Background(), schedule.ID)
+	assert.ErrorIs(t, err, domain.ErrScheduleNotFound)
+
+	assert.ErrorIs(t, repo.Delete(context.Background(), schedule.ID), domain.ErrScheduleNotFound)
+}
+
+func testListDueSchedules(t *testing.T, repo ports.ScheduleRepository) {
+	saveSchedule(t, repo, newSchedule("later", runAt.Add(time.Hour)))
+	saveSchedule(t, repo, newSchedule("now", runAt))
+	saveSchedule(t, repo, newSchedule("earlier", runAt.Add(-time.Hour)))
+
+	due, err := repo.ListDue(context.Background(), runAt)
+	require.NoError(t, err)
+	require.Len(t, due, 2)
+	assert.Equal(t, "earlier", due[0].Name)
+	assert.Equal(t, "now", due[1].Name)
+}
+
+func testAdvanceSchedule(t *testing.T, repo ports.ScheduleRepository) {
+	schedule := saveSchedule(t, repo, newSchedule("nightly", runAt))
+	next := runAt.AddDate(0, 0, 1)
+
+	advanced, err := repo.Advance(context.Background(), schedule.ID, runAt, next, &runAt)
+	require.NoError(t, err)
+	assert.True(t, advanced)
+
+	found, err := repo.FindByID(context.Background(), schedule.ID)
+	require.NoError(t, err)
+	assert.True(t, next.Equal(found.NextRunAt))
+	require.NotNil(t, found.LastRunAt)
+	assert.True(t, runAt.Equal(*found.LastRunAt))
+
+	advanced, err = repo.Advance(context.Background(), schedule.ID, runAt, next, &runAt)
+	require.NoError(t, err)
+	assert.False(t, advanced, "the run was taken already")
+
+	advanced, err = repo.Advance(context.Background(), schedule.ID, next, runAt, nil)
+	require.NoError(t, err)
+	assert.True(t, advanced)
+	found, err = repo.FindByID(context.Background(), schedule.ID)
+	require.NoError(t, err)
+	assert.True(t, runAt.Equal(found.NextRunAt))
+	assert.Nil(t, found.LastRunAt, "handing a run back restores the last run")
+
+	advanced, err = repo.Advance(context.Background(), unknownID, runAt, next, nil)
+	require.NoError(t, err)
+	assert.False(t, advanced)
+}
+
+// testAdvanceScheduleConcurrent lets several schedulers race for the same
+// run; exactly one of them may take it.
+func testAdvanceScheduleConcurrent(t *testing.T, repo ports.ScheduleRepository) {
+	const schedulers = 8
+	schedule := saveSchedule(t, repo, newSchedule("nightly", runAt))
+	next := runAt.AddDate(0, 0, 1)
+
+	var (
+		wg    sync.WaitGroup
+		mu    sync.Mutex
+		taken int
+		errs  = make(chan error, schedulers)
+	)
+	for i := 0; i < schedulers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			advanced, err := repo.Advance(context.Background(), schedule.ID, runAt, next, &runAt)
+			if err != nil {
+				errs <- err
+				return
+			}
+			if advanced {
+				mu.Lock()
+				taken++
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		require.NoError(t, err)
+	}
+	assert.Equal(t, 1, taken)
+}
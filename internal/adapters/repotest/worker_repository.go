@@ -0,0 +1,197 @@
+package repotest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/H3nSte1n/ci-orchestrator/internal/core/domain"
+	"github.com/H3nSte1n/ci-orchestrator/internal/core/ports"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// RunWorkerRepository runs the worker repository conformance tests,
+// including label matching in the build repository's claim path.
+func RunWorkerRepository(t *testing.T, newRepos Factory) {
+	tests := []struct {
+		name string
+		run  func(t *testing.T, builds ports.BuildRepository, workers ports.WorkerRepository)
+	}{
+		{"RegisterAndList", testRegisterAndList},
+		{"RegisterUpdatesLabels", testRegisterUpdatesLabels},
+		{"RegisterKeepsPause", testRegisterKeepsPause},
+		{"RegisterEndsDrainAndDeregistration", testRegisterEndsDrainAndDeregistration},
+		{"FindByIDNotFound", testFindWorkerNotFound},
+		{"HeartbeatAndDeregister", testWorkerHeartbeatAndDeregister},
+		{"UpdateStateNotFound", testUpdateWorkerStateNotFound},
+		{"RunningJobs", testRunningJobs},
+		{"ClaimNextMatchesLabels", testClaimNextMatchesLabels},
+		{"ClaimNextUnregisteredWorker", testClaimNextUnregisteredWorker},
+		{"StartJobNotForWorker", testStartJobNotForWorker},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repos := newRepos(t)
+			tt.run(t, repos.Builds, repos.Workers)
+		})
+	}
+}
+
+func register(t *testing.T, workers ports.WorkerRepository, workerId string, labels ...string) {
+	t.Helper()
+	require.NoError(t, workers.Register(context.Background(), &domain.Worker{
+		ID:         workerId,
+		Labels:     labels,
+		LastSeenAt: time.Now(),
+	}))
+}
+
+func testRegisterAndList(t *testing.T, _ ports.BuildRepository, workers ports.WorkerRepository) {
+	empty, err := workers.List(context.Background())
+	require.NoError(t, err)
+	assert.Empty(t, empty)
+
+	register(t, workers, "worker-b", "linux")
+	register(t, workers, "worker-a", "linux", "arm64")
+
+	list, err := workers.List(context.Background())
+	require.NoError(t, err)
+	require.Len(t, list, 2)
+	assert.Equal(t, "worker-a", list[0].ID)
+	assert.Equal(t, domain.StringList{"linux", "arm64"}, list[0].Labels)
+	assert.False(t, list[0].LastSeenAt.IsZero())
+	assert.Equal(t, "worker-b", list[1].ID)
+}
+
+func findWorker(t *testing.T, workers ports.WorkerRepository, workerId string) *domain.Worker {
+	t.Helper()
+	worker, err := workers.FindByID(context.Background(), workerId)
+	require.NoError(t, err)
+	return worker
+}
+
+func testRegisterUpdatesLabels(t *testing.T, _ ports.BuildRepository, workers ports.WorkerRepository) {
+	register(t, workers, "worker-1", "linux")
+	register(t, workers, "worker-1", "linux", "docker")
+
+	list, err := workers.List(context.Background())
+	require.NoError(t, err)
+	require.Len(t, list, 1)
+	assert.Equal(t, domain.StringList{"linux", "docker"}, list[0].Labels)
+}
+
+func testRegisterKeepsPause(t *testing.T, _ ports.BuildRepository, workers ports.WorkerRepository) {
+	ctx := context.Background()
+	require.NoError(t, workers.Register(ctx, &domain.Worker{
+		ID:         "worker-1",
+		Hostname:   "host-1",
+		Version:    "v1.2.0",
+		Slots:      4,
+		Labels:     domain.StringList{"linux"},
+		LastSeenAt: time.Now(),
+	}))
+
+	worker := findWorker(t, workers, "worker-1")
+	assert.Equal(t, "host-1", worker.Hostname)
+	assert.Equal(t, "v1.2.0", worker.Version)
+	assert.Equal(t, 4, worker.Slots)
+	assert.Equal(t, domain.WorkerStateActive, worker.State)
+
+	require.NoError(t, workers.UpdateState(ctx, "worker-1", domain.WorkerStatePaused))
+	register(t, workers, "worker-1", "linux")
+
+	assert.Equal(t, domain.WorkerStatePaused, findWorker(t, workers, "worker-1").State)
+}
+
+func testRegisterEndsDrainAndDeregistration(t *testing.T, _ ports.BuildRepository, workers ports.WorkerRepository) {
+	ctx := context.Background()
+	register(t, workers, "worker-1", "linux")
+	require.NoError(t, workers.UpdateState(ctx, "worker-1", domain.WorkerStateDraining))
+	require.NoError(t, workers.Deregister(ctx, "worker-1", time.Now()))
+
+	register(t, workers, "worker-1", "linux")
+
+	worker := findWorker(t, workers, "worker-1")
+	assert.Equal(t, domain.WorkerStateActive, worker.State)
+	assert.Nil(t, worker.DeregisteredAt)
+}
+
+func testFindWorkerNotFound(t *testing.T, _ ports.BuildRepository, workers ports.WorkerRepository) {
+	_, err := workers.FindByID(context.Background(), "missing")
+	assert.ErrorIs(t, err, domain.ErrWorkerNotFound)
+}
+
+func testWorkerHeartbeatAndDeregister(t *testing.T, _ ports.BuildRepository, workers ports.WorkerRepository) {
+	ctx := context.Background()
+	register(t, workers, "worker-1", "linux")
+	seenAt := time.Now().Add(time.Minute).UTC().Truncate(time.Second)
+
+	require.NoError(t, workers.Heartbeat(ctx, "worker-1", seenAt))
+	require.NoError(t, workers.Deregister(ctx, "worker-1", seenAt))
+
+	worker := findWorker(t, workers, "worker-1")
+	assert.True(t, seenAt.Equal(worker.LastSeenAt))
+	require.NotNil(t, worker.DeregisteredAt)
+	assert.True(t, seenAt.Equal(*worker.DeregisteredAt))
+
+	assert.ErrorIs(t, workers.Heartbeat(ctx, "missing", seenAt), domain.ErrWorkerNotFound)
+	assert.ErrorIs(t, workers.Deregister(ctx, "missing", seenAt), domain.ErrWorkerNotFound)
+}
+
+func testUpdateWorkerStateNotFound(t *testing.T, _ ports.BuildRepository, workers ports.WorkerRepository) {
+	err := workers.UpdateState(context.Background(), "missing", domain.WorkerStatePaused)
+	assert.ErrorIs(t, err, domain.ErrWorkerNotFound)
+}
+
+func testRunningJobs(t *testing.T, builds ports.BuildRepository, workers ports.WorkerRepository) {
+	running := save(t, builds, newBuild("running", newJob(domain.DefaultJobName)))
+	save(t, builds, newBuild("pending", newJob(domain.DefaultJobName)))
+	require.NotNil(t, claim(t, builds, "worker-1"))
+
+	jobs, err := workers.RunningJobs(context.Background())
+	require.NoError(t, err)
+	require.Len(t, jobs, 1)
+	assert.Equal(t, running.ID, jobs[0].BuildID)
+	require.NotNil(t, jobs[0].LockedBy)
+	assert.Equal(t, "worker-1", *jobs[0].LockedBy)
+}
+
+func testClaimNextMatchesLabels(t *testing.T, builds ports.BuildRepository, workers ports.WorkerRepository) {
+	register(t, workers, "amd", "linux", "amd64")
+	register(t, workers, "arm", "linux", "arm64", "docker")
+	build := newBuild("make", newJob(domain.DefaultJobName))
+	build.RunsOn = domain.StringList{"arm64", "linux"}
+	save(t, builds, build)
+
+	assert.Nil(t, claim(t, builds, "amd"))
+
+	job := claim(t, builds, "arm")
+	require.NotNil(t, job)
+	assert.Equal(t, build.Jobs[0].ID, job.ID)
+	assert.Equal(t, domain.StringList{"arm64", "linux"}, job.Build.RunsOn)
+}
+
+func testClaimNextUnregisteredWorker(t *testing.T, builds ports.BuildRepository, _ ports.WorkerRepository) {
+	labeled := newBuild("labeled", newJob(domain.DefaultJobName))
+	labeled.RunsOn = domain.StringList{"gpu"}
+	save(t, builds, labeled)
+	plain := save(t, builds, newBuild("plain", newJob(domain.DefaultJobName)))
+
+	job := claim(t, builds, "unknown")
+	require.NotNil(t, job)
+	assert.Equal(t, plain.Jobs[0].ID, job.ID)
+	assert.Nil(t, claim(t, builds, "unknown"))
+}
+
+func testStartJobNotForWorker(t *testing.T, builds ports.BuildRepository, workers ports.WorkerRepository) {
+	register(t, workers, "amd", "linux", "amd64")
+	build := newBuild("make", newJob(domain.DefaultJobName))
+	build.RunsOn = domain.StringList{"arm64"}
+	save(t, builds, build)
+
+	_, err := builds.StartJob(context.Background(), build.Jobs[0].ID, "amd")
+	assert.ErrorIs(t, err, domain.ErrJobNotForWorker)
+	assert.Equal(t, domain.JobStatusPending, find(t, builds, build.ID).Jobs[0].Status)
+}
@@ -0,0 +1,54 @@
+// Package scheduler turns due schedule runs into builds in the background of
+// the API.
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"github.com/H3nSte1n/ci-orchestrator/internal/core/ports"
+	"time"
+)
+
+// minGrace is the shortest time after a run in which it still counts as on
+// time rather than missed.
+const minGrace = time.Minute
+
+type scheduler struct {
+	scheduleService ports.ScheduleService
+	interval        time.Duration
+}
+
+func NewScheduler(scheduleService ports.ScheduleService, interval time.Duration) *scheduler {
+	return &scheduler{
+		scheduleService: scheduleService,
+		interval:        interval,
+	}
+}
+
+// Run checks for due schedule runs every interval until ctx is canceled. A
+// run is missed when no check reaches it within two intervals, or a minute
+// for short intervals, so a slow tick does not trip the missed-run policy.
+func (s *scheduler) Run(ctx context.Context) error {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		s.tick(ctx, time.Now())
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+func (s *scheduler) tick(ctx context.Context, now time.Time) {
+	if _, err := s.scheduleService.RunDue(ctx, now, s.grace()); err != nil && ctx.Err() == nil {
+		fmt.Println("Error running schedules:", err)
+	}
+}
+
+func (s *scheduler) grace() time.Duration {
+	return max(minGrace, 2*s.interval)
+}
@@ -0,0 +1,68 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/H3nSte1n/ci-orchestrator/internal/core/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// stubScheduleService records the grace of every RunDue call.
+type stubScheduleService struct {
+	mu     sync.Mutex
+	graces []time.Duration
+	err    error
+}
+
+func (s *stubScheduleService) CreateSchedule(ctx context.Context, schedule *domain.Schedule) error {
+	return nil
+}
+
+func (s *stubScheduleService) GetSchedule(ctx context.Context, scheduleId string) (*domain.Schedule, error) {
+	return nil, domain.ErrScheduleNotFound
+}
+
+func (s *stubScheduleService) ListSchedules(ctx context.Context) ([]domain.Schedule, error) {
+	return nil, nil
+}
+
+func (s *stubScheduleService) DeleteSchedule(ctx context.Context, scheduleId string) error {
+	return nil
+}
+
+func (s *stubScheduleService) RunDue(ctx context.Context, now time.Time, grace time.Duration) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.graces = append(s.graces, grace)
+	return 0, s.err
+}
+
+func (s *stubScheduleService) calls() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.graces)
+}
+
+func TestScheduler_RunChecksUntilCanceled(t *testing.T) {
+	svc := &stubScheduleService{err: errors.New("db down")}
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+
+	go func() {
+		done <- NewScheduler(svc, 10*time.Millisecond).Run(ctx)
+	}()
+
+	require.Eventually(t, func() bool { return svc.calls() >= 3 }, time.Second, 5*time.Millisecond, "errors do not stop the loop")
+	cancel()
+	assert.ErrorIs(t, <-done, context.Canceled)
+}
+
+func TestScheduler_Grace(t *testing.T) {
+	assert.Equal(t, time.Minute, NewScheduler(nil, 15*time.Second).grace())
+	assert.Equal(t, 10*time.Minute, NewScheduler(nil, 5*time.Minute).grace())
+}
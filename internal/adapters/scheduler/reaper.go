@@ -0,0 +1,43 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"github.com/H3nSte1n/ci-orchestrator/internal/core/ports"
+	"time"
+)
+
+type reaper struct {
+	buildService ports.BuildService
+	lease        time.Duration
+}
+
+// NewReaper returns a loop that requeues the jobs of workers that sent no
+// heartbeat for longer than lease, e.g. because they crashed mid-job.
+func NewReaper(buildService ports.BuildService, lease time.Duration) *reaper {
+	return &reaper{
+		buildService: buildService,
+		lease:        lease,
+	}
+}
+
+// Run looks for expired jobs every half lease until ctx is canceled, so a
+// lost job waits at most one and a half leases for another worker.
+func (r *reaper) Run(ctx context.Context) error {
+	ticker := time.NewTicker(r.lease / 2)
+	defer ticker.Stop()
+
+	for {
+		if n, err := r.buildService.RequeueExpired(ctx, r.lease); err != nil && ctx.Err() == nil {
+			fmt.Println("Error requeuing expired jobs:", err)
+		} else if n > 0 {
+			fmt.Printf("Requeued %d jobs of lost workers\n", n)
+		}
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
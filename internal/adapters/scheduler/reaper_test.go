@@ -0,0 +1,40 @@
+package scheduler
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/H3nSte1n/ci-orchestrator/internal/core/ports"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// stubBuildService counts RequeueExpired calls and checks their lease.
+type stubBuildService struct {
+	ports.BuildService
+	lease time.Duration
+	calls atomic.Int32
+}
+
+func (s *stubBuildService) RequeueExpired(ctx context.Context, lease time.Duration) (int, error) {
+	if lease == s.lease {
+		s.calls.Add(1)
+	}
+	return 1, nil
+}
+
+func TestReaper_RunRequeuesUntilCanceled(t *testing.T) {
+	svc := &stubBuildService{lease: 20 * time.Millisecond}
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+
+	go func() {
+		done <- NewReaper(svc, svc.lease).Run(ctx)
+	}()
+
+	require.Eventually(t, func() bool { return svc.calls.Load() >= 3 }, time.Second, 5*time.Millisecond)
+	cancel()
+	assert.ErrorIs(t, <-done, context.Canceled)
+}
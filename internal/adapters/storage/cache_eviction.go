@@ -0,0 +1,41 @@
+package storage
+
+import (
+	"context"
+	"github.com/H3nSte1n/ci-orchestrator/internal/core/ports"
+	"sort"
+)
+
+// evictCache deletes the least recently used entries until the store fits
+// into maxSize bytes. A non-positive maxSize disables eviction.
+func evictCache(ctx context.Context, store ports.CacheStore, maxSize int64) error {
+	if maxSize <= 0 {
+		return nil
+	}
+
+	entries, err := store.List(ctx, "")
+	if err != nil {
+		return err
+	}
+
+	var total int64
+	for _, entry := range entries {
+		total += entry.Size
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].LastUsed.Before(entries[j].LastUsed)
+	})
+
+	for _, entry := range entries {
+		if total <= maxSize {
+			break
+		}
+		if err := store.Delete(ctx, entry.Key); err != nil {
+			return err
+		}
+		total -= entry.Size
+	}
+
+	return nil
+}
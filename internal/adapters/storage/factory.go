@@ -0,0 +1,31 @@
+package storage
+
+import (
+	"fmt"
+	"github.com/H3nSte1n/ci-orchestrator/internal/core/ports"
+	"github.com/H3nSte1n/ci-orchestrator/internal/platform/config"
+)
+
+func NewArtifactStore(cfg config.StorageConfig) (ports.ArtifactStore, error) {
+	switch cfg.Driver {
+	case "", "local":
+		return NewLocalArtifactStore(cfg.Local.Path)
+	case "s3":
+		return NewS3ArtifactStore(cfg.S3.Endpoint, cfg.S3.Region, cfg.S3.Bucket, cfg.S3.AccessKey, cfg.S3.SecretKey, cfg.S3.UsePathStyle)
+	default:
+		return nil, fmt.Errorf("unknown artifact storage driver %q", cfg.Driver)
+	}
+}
+
+func NewCacheStore(cfg config.CacheConfig) (ports.CacheStore, error) {
+	maxSize := cfg.MaxSizeMB << 20
+
+	switch cfg.Driver {
+	case "", "local":
+		return NewLocalCacheStore(cfg.Local.Path, maxSize)
+	case "s3":
+		return NewS3CacheStore(cfg.S3.Endpoint, cfg.S3.Region, cfg.S3.Bucket, cfg.S3.AccessKey, cfg.S3.SecretKey, cfg.S3.UsePathStyle, maxSize)
+	default:
+		return nil, fmt.Errorf("unknown cache storage driver %q", cfg.Driver)
+	}
+}
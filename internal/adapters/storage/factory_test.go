@@ -0,0 +1,43 @@
+package storage
+
+import (
+	"testing"
+
+	"github.com/H3nSte1n/ci-orchestrator/internal/platform/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewArtifactStore(t *testing.T) {
+	local, err := NewArtifactStore(config.StorageConfig{Driver: "local", Local: config.LocalStorageConfig{Path: t.TempDir()}})
+	require.NoError(t, err)
+	assert.IsType(t, &LocalArtifactStore{}, local)
+
+	s3, err := NewArtifactStore(config.StorageConfig{Driver: "s3", S3: config.S3StorageConfig{
+		Endpoint: "http://minio:9000", Region: "us-east-1", Bucket: "ci", UsePathStyle: true,
+	}})
+	require.NoError(t, err)
+	assert.IsType(t, &S3ArtifactStore{}, s3)
+
+	_, err = NewArtifactStore(config.StorageConfig{Driver: "ftp"})
+	assert.Error(t, err)
+}
+
+func TestNewCacheStore(t *testing.T) {
+	local, err := NewCacheStore(config.CacheConfig{
+		StorageConfig: config.StorageConfig{Driver: "local", Local: config.LocalStorageConfig{Path: t.TempDir()}},
+		MaxSizeMB:     2,
+	})
+	require.NoError(t, err)
+	require.IsType(t, &LocalCacheStore{}, local)
+	assert.Equal(t, int64(2<<20), local.(*LocalCacheStore).maxSize)
+
+	s3, err := NewCacheStore(config.CacheConfig{StorageConfig: config.StorageConfig{Driver: "s3", S3: config.S3StorageConfig{
+		Endpoint: "http://minio:9000", Region: "us-east-1", Bucket: "ci", UsePathStyle: true,
+	}}})
+	require.NoError(t, err)
+	assert.IsType(t, &S3CacheStore{}, s3)
+
+	_, err = NewCacheStore(config.CacheConfig{StorageConfig: config.StorageConfig{Driver: "ftp"}})
+	assert.Error(t, err)
+}
@@ -0,0 +1,123 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"github.com/H3nSte1n/ci-orchestrator/internal/core/ports"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+type LocalArtifactStore struct {
+	root string
+}
+
+func NewLocalArtifactStore(root string) (ports.ArtifactStore, error) {
+	if root == "" {
+		return nil, fmt.Errorf("local storage: path is required")
+	}
+	if err := os.MkdirAll(root, 0o755); err != nil {
+		return nil, fmt.Errorf("local storage: %w", err)
+	}
+
+	return &LocalArtifactStore{root: root}, nil
+}
+
+func (s *LocalArtifactStore) Put(_ context.Context, key string, r io.Reader, _ int64, _ string) error {
+	return writeFileAtomic(s.root, key, r)
+}
+
+func (s *LocalArtifactStore) Open(_ context.Context, key string) (io.ReadCloser, error) {
+	p, err := resolveKey(s.root, key)
+	if err != nil {
+		return nil, err
+	}
+	return os.Open(p)
+}
+
+func (s *LocalArtifactStore) OpenRange(_ context.Context, key string, offset, length int64) (io.ReadCloser, error) {
+	p, err := resolveKey(s.root, key)
+	if err != nil {
+		return nil, err
+	}
+	return openFileRange(p, offset, length)
+}
+
+func (s *LocalArtifactStore) Delete(_ context.Context, key string) error {
+	p, err := resolveKey(s.root, key)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(p); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+type limitedFile struct {
+	r io.Reader
+	f *os.File
+}
+
+func (l *limitedFile) Read(p []byte) (int, error) {
+	return l.r.Read(p)
+}
+
+func (l *limitedFile) Close() error {
+	return l.f.Close()
+}
+
+func openFileRange(p string, offset, length int64) (io.ReadCloser, error) {
+	f, err := os.Open(p)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := f.Seek(offset, io.SeekStart); err != nil {
+		f.Close()
+		return nil, err
+	}
+	if length < 0 {
+		return f, nil
+	}
+	return &limitedFile{r: io.LimitReader(f, length), f: f}, nil
+}
+
+// resolveKey maps a storage key onto a path below root and rejects keys that
+// would escape it.
+func resolveKey(root, key string) (string, error) {
+	clean := filepath.Clean(filepath.FromSlash(key))
+	if clean == "." || filepath.IsAbs(clean) || clean == ".." || strings.HasPrefix(clean, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("local storage: invalid key %q", key)
+	}
+	return filepath.Join(root, clean), nil
+}
+
+// writeFileAtomic writes to a temp file next to the target and renames it
+// into place so readers never observe partial files.
+func writeFileAtomic(root, key string, r io.Reader) error {
+	p, err := resolveKey(root, key)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(p), 0o755); err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(p), ".upload-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := io.Copy(tmp, r); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), p)
+}
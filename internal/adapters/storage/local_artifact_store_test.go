@@ -0,0 +1,80 @@
+package storage
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/H3nSte1n/ci-orchestrator/internal/core/ports"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLocalArtifactStore_PutOpenDelete(t *testing.T) {
+	root := t.TempDir()
+	store, err := NewLocalArtifactStore(root)
+	require.NoError(t, err)
+	assert.Implements(t, (*ports.ArtifactStore)(nil), store)
+
+	ctx := context.Background()
+	require.NoError(t, store.Put(ctx, "builds/1/dist/app.tar", strings.NewReader("payload"), 7, "application/x-tar"))
+
+	onDisk, err := os.ReadFile(filepath.Join(root, "builds", "1", "dist", "app.tar"))
+	require.NoError(t, err)
+	assert.Equal(t, "payload", string(onDisk))
+
+	rc, err := store.Open(ctx, "builds/1/dist/app.tar")
+	require.NoError(t, err)
+	data, err := io.ReadAll(rc)
+	rc.Close()
+	require.NoError(t, err)
+	assert.Equal(t, "payload", string(data))
+
+	require.NoError(t, store.Delete(ctx, "builds/1/dist/app.tar"))
+	_, err = store.Open(ctx, "builds/1/dist/app.tar")
+	assert.True(t, os.IsNotExist(err))
+	assert.NoError(t, store.Delete(ctx, "builds/1/dist/app.tar"))
+}
+
+func TestLocalArtifactStore_RejectsEscapingKeys(t *testing.T) {
+	store, err := NewLocalArtifactStore(t.TempDir())
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	for _, key := range []string{"../outside", "a/../../outside", "/etc/passwd", ""} {
+		err := store.Put(ctx, key, strings.NewReader("x"), 1, "")
+		assert.Error(t, err, key)
+	}
+}
+
+func TestNewLocalArtifactStore_RequiresPath(t *testing.T) {
+	_, err := NewLocalArtifactStore("")
+	assert.Error(t, err)
+}
+
+func TestLocalArtifactStore_OpenRange(t *testing.T) {
+	store, err := NewLocalArtifactStore(t.TempDir())
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	require.NoError(t, store.Put(ctx, "out.txt", strings.NewReader("hello world"), 11, "text/plain"))
+
+	for _, tc := range []struct {
+		offset, length int64
+		want           string
+	}{
+		{0, 5, "hello"},
+		{6, -1, "world"},
+		{6, 100, "world"},
+	} {
+		rc, err := store.OpenRange(ctx, "out.txt", tc.offset, tc.length)
+		require.NoError(t, err)
+		data, err := io.ReadAll(rc)
+		rc.Close()
+		require.NoError(t, err)
+		assert.Equal(t, tc.want, string(data))
+	}
+}
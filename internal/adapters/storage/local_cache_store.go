@@ -0,0 +1,100 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"github.com/H3nSte1n/ci-orchestrator/internal/core/domain"
+	"github.com/H3nSte1n/ci-orchestrator/internal/core/ports"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// LocalCacheStore keeps one file per cache key. The modification time doubles
+// as the last-used time and is bumped on every hit.
+type LocalCacheStore struct {
+	root    string
+	maxSize int64
+}
+
+func NewLocalCacheStore(root string, maxSize int64) (ports.CacheStore, error) {
+	if root == "" {
+		return nil, fmt.Errorf("local cache: path is required")
+	}
+	if err := os.MkdirAll(root, 0o755); err != nil {
+		return nil, fmt.Errorf("local cache: %w", err)
+	}
+
+	return &LocalCacheStore{root: root, maxSize: maxSize}, nil
+}
+
+func (s *LocalCacheStore) Open(_ context.Context, key string) (io.ReadCloser, error) {
+	p := filepath.Join(s.root, cacheFileName(key))
+
+	f, err := os.Open(p)
+	if os.IsNotExist(err) {
+		return nil, domain.ErrCacheMiss
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	_ = os.Chtimes(p, now, now)
+
+	return f, nil
+}
+
+func (s *LocalCacheStore) Put(ctx context.Context, key string, r io.Reader, _ int64) error {
+	if err := writeFileAtomic(s.root, cacheFileName(key), r); err != nil {
+		return err
+	}
+	return evictCache(ctx, s, s.maxSize)
+}
+
+func (s *LocalCacheStore) List(_ context.Context, prefix string) ([]domain.CacheEntry, error) {
+	dirEntries, err := os.ReadDir(s.root)
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []domain.CacheEntry
+	for _, dirEntry := range dirEntries {
+		if !dirEntry.Type().IsRegular() || strings.HasPrefix(dirEntry.Name(), ".upload-") {
+			continue
+		}
+
+		key, err := url.PathUnescape(dirEntry.Name())
+		if err != nil || !strings.HasPrefix(key, prefix) {
+			continue
+		}
+
+		info, err := dirEntry.Info()
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		entries = append(entries, domain.CacheEntry{Key: key, Size: info.Size(), LastUsed: info.ModTime()})
+	}
+
+	return entries, nil
+}
+
+func (s *LocalCacheStore) Delete(_ context.Context, key string) error {
+	err := os.Remove(filepath.Join(s.root, cacheFileName(key)))
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+// cacheFileName flattens a key into a single file name.
+func cacheFileName(key string) string {
+	return url.PathEscape(key)
+}
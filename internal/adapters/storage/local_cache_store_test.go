@@ -0,0 +1,89 @@
+package storage
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/H3nSte1n/ci-orchestrator/internal/core/domain"
+	"github.com/H3nSte1n/ci-orchestrator/internal/core/ports"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLocalCacheStore_PutOpenListDelete(t *testing.T) {
+	store, err := NewLocalCacheStore(t.TempDir(), 0)
+	require.NoError(t, err)
+	assert.Implements(t, (*ports.CacheStore)(nil), store)
+
+	ctx := context.Background()
+	require.NoError(t, store.Put(ctx, "repo/go-abc", strings.NewReader("one"), 3))
+	require.NoError(t, store.Put(ctx, "repo/go-def", strings.NewReader("two!"), 4))
+	require.NoError(t, store.Put(ctx, "repo/node-abc", strings.NewReader("three"), 5))
+
+	rc, err := store.Open(ctx, "repo/go-abc")
+	require.NoError(t, err)
+	data, err := io.ReadAll(rc)
+	rc.Close()
+	require.NoError(t, err)
+	assert.Equal(t, "one", string(data))
+
+	entries, err := store.List(ctx, "repo/go-")
+	require.NoError(t, err)
+	keys := []string{}
+	for _, entry := range entries {
+		keys = append(keys, entry.Key)
+	}
+	assert.ElementsMatch(t, []string{"repo/go-abc", "repo/go-def"}, keys)
+
+	require.NoError(t, store.Delete(ctx, "repo/go-abc"))
+	_, err = store.Open(ctx, "repo/go-abc")
+	assert.ErrorIs(t, err, domain.ErrCacheMiss)
+	assert.NoError(t, store.Delete(ctx, "repo/go-abc"))
+}
+
+func TestLocalCacheStore_Open_Miss(t *testing.T) {
+	store, err := NewLocalCacheStore(t.TempDir(), 0)
+	require.NoError(t, err)
+
+	_, err = store.Open(context.Background(), "missing")
+	assert.ErrorIs(t, err, domain.ErrCacheMiss)
+}
+
+func TestLocalCacheStore_EvictsLeastRecentlyUsed(t *testing.T) {
+	root := t.TempDir()
+	store, err := NewLocalCacheStore(root, 10)
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	require.NoError(t, store.Put(ctx, "old", strings.NewReader("aaaa"), 4))
+	require.NoError(t, store.Put(ctx, "used", strings.NewReader("bbbb"), 4))
+
+	past := time.Now().Add(-time.Hour)
+	require.NoError(t, os.Chtimes(filepath.Join(root, "old"), past, past))
+	require.NoError(t, os.Chtimes(filepath.Join(root, "used"), past.Add(-time.Minute), past.Add(-time.Minute)))
+
+	// A hit refreshes the last-used time, so "old" becomes the eviction candidate.
+	rc, err := store.Open(ctx, "used")
+	require.NoError(t, err)
+	rc.Close()
+
+	require.NoError(t, store.Put(ctx, "new", strings.NewReader("cccc"), 4))
+
+	_, err = store.Open(ctx, "old")
+	assert.ErrorIs(t, err, domain.ErrCacheMiss)
+	for _, key := range []string{"used", "new"} {
+		rc, err := store.Open(ctx, key)
+		require.NoError(t, err, key)
+		rc.Close()
+	}
+}
+
+func TestNewLocalCacheStore_RequiresPath(t *testing.T) {
+	_, err := NewLocalCacheStore("", 0)
+	assert.Error(t, err)
+}
@@ -0,0 +1,46 @@
+package storage
+
+import (
+	"context"
+	"github.com/H3nSte1n/ci-orchestrator/internal/core/ports"
+	"io"
+	"path"
+)
+
+type S3ArtifactStore struct {
+	client *s3Client
+	prefix string
+}
+
+func NewS3ArtifactStore(endpoint, region, bucket, accessKey, secretKey string, pathStyle bool) (ports.ArtifactStore, error) {
+	client, err := newS3Client(endpoint, region, bucket, accessKey, secretKey, pathStyle)
+	if err != nil {
+		return nil, err
+	}
+
+	return &S3ArtifactStore{client: client, prefix: "artifacts"}, nil
+}
+
+func (s *S3ArtifactStore) Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) error {
+	return s.client.putObject(ctx, path.Join(s.prefix, key), r, size, contentType)
+}
+
+func (s *S3ArtifactStore) Open(ctx context.Context, key string) (io.ReadCloser, error) {
+	resp, err := s.client.getObject(ctx, path.Join(s.prefix, key), "")
+	if err != nil {
+		return nil, err
+	}
+	return resp.Body, nil
+}
+
+func (s *S3ArtifactStore) OpenRange(ctx context.Context, key string, offset, length int64) (io.ReadCloser, error) {
+	resp, err := s.client.getObject(ctx, path.Join(s.prefix, key), byteRange(offset, length))
+	if err != nil {
+		return nil, err
+	}
+	return resp.Body, nil
+}
+
+func (s *S3ArtifactStore) Delete(ctx context.Context, key string) error {
+	return s.client.deleteObject(ctx, path.Join(s.prefix, key))
+}
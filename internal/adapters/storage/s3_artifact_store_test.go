@@ -0,0 +1,73 @@
+package storage
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/H3nSte1n/ci-orchestrator/internal/core/ports"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestS3ArtifactStore_PutOpenDelete(t *testing.T) {
+	fake, srv := newFakeS3(t, "ci")
+
+	store, err := NewS3ArtifactStore(srv.URL, "us-east-1", "ci", "minio", "minio123", true)
+	require.NoError(t, err)
+	assert.Implements(t, (*ports.ArtifactStore)(nil), store)
+
+	ctx := context.Background()
+	require.NoError(t, store.Put(ctx, "builds/1/out.txt", strings.NewReader("hello"), 5, "text/plain"))
+	assert.Equal(t, "hello", string(fake.objects["artifacts/builds/1/out.txt"]))
+	assert.Equal(t, "text/plain", fake.types["artifacts/builds/1/out.txt"])
+
+	rc, err := store.Open(ctx, "builds/1/out.txt")
+	require.NoError(t, err)
+	data, err := io.ReadAll(rc)
+	rc.Close()
+	require.NoError(t, err)
+	assert.Equal(t, "hello", string(data))
+
+	require.NoError(t, store.Delete(ctx, "builds/1/out.txt"))
+	_, err = store.Open(ctx, "builds/1/out.txt")
+	assert.Error(t, err)
+}
+
+func TestS3ArtifactStore_Open_NotFound(t *testing.T) {
+	_, srv := newFakeS3(t, "ci")
+
+	store, err := NewS3ArtifactStore(srv.URL, "us-east-1", "ci", "minio", "minio123", true)
+	require.NoError(t, err)
+
+	_, err = store.Open(context.Background(), "missing")
+
+	var s3Err *s3Error
+	require.ErrorAs(t, err, &s3Err)
+	assert.Equal(t, 404, s3Err.StatusCode)
+}
+
+func TestS3ArtifactStore_OpenRange(t *testing.T) {
+	_, srv := newFakeS3(t, "ci")
+
+	store, err := NewS3ArtifactStore(srv.URL, "us-east-1", "ci", "minio", "minio123", true)
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	require.NoError(t, store.Put(ctx, "out.txt", strings.NewReader("hello world"), 11, "text/plain"))
+
+	rc, err := store.OpenRange(ctx, "out.txt", 6, 3)
+	require.NoError(t, err)
+	data, err := io.ReadAll(rc)
+	rc.Close()
+	require.NoError(t, err)
+	assert.Equal(t, "wor", string(data))
+
+	rc, err = store.OpenRange(ctx, "out.txt", 6, -1)
+	require.NoError(t, err)
+	data, err = io.ReadAll(rc)
+	rc.Close()
+	require.NoError(t, err)
+	assert.Equal(t, "world", string(data))
+}
@@ -0,0 +1,68 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"github.com/H3nSte1n/ci-orchestrator/internal/core/domain"
+	"github.com/H3nSte1n/ci-orchestrator/internal/core/ports"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// S3CacheStore keeps cache archives below the "cache/" prefix. S3 does not
+// track reads, so eviction falls back to the time an entry was last saved.
+type S3CacheStore struct {
+	client  *s3Client
+	prefix  string
+	maxSize int64
+}
+
+func NewS3CacheStore(endpoint, region, bucket, accessKey, secretKey string, pathStyle bool, maxSize int64) (ports.CacheStore, error) {
+	client, err := newS3Client(endpoint, region, bucket, accessKey, secretKey, pathStyle)
+	if err != nil {
+		return nil, err
+	}
+
+	return &S3CacheStore{client: client, prefix: "cache/", maxSize: maxSize}, nil
+}
+
+func (s *S3CacheStore) Open(ctx context.Context, key string) (io.ReadCloser, error) {
+	resp, err := s.client.getObject(ctx, s.prefix+key, "")
+	if err != nil {
+		var s3Err *s3Error
+		if errors.As(err, &s3Err) && s3Err.StatusCode == http.StatusNotFound {
+			return nil, domain.ErrCacheMiss
+		}
+		return nil, err
+	}
+	return resp.Body, nil
+}
+
+func (s *S3CacheStore) Put(ctx context.Context, key string, r io.Reader, size int64) error {
+	if err := s.client.putObject(ctx, s.prefix+key, r, size, "application/gzip"); err != nil {
+		return err
+	}
+	return evictCache(ctx, s, s.maxSize)
+}
+
+func (s *S3CacheStore) List(ctx context.Context, prefix string) ([]domain.CacheEntry, error) {
+	objects, err := s.client.listObjects(ctx, s.prefix+prefix)
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]domain.CacheEntry, 0, len(objects))
+	for _, object := range objects {
+		entries = append(entries, domain.CacheEntry{
+			Key:      strings.TrimPrefix(object.Key, s.prefix),
+			Size:     object.Size,
+			LastUsed: object.LastModified,
+		})
+	}
+	return entries, nil
+}
+
+func (s *S3CacheStore) Delete(ctx context.Context, key string) error {
+	return s.client.deleteObject(ctx, s.prefix+key)
+}
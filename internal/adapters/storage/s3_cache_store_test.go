@@ -0,0 +1,66 @@
+package storage
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/H3nSte1n/ci-orchestrator/internal/core/domain"
+	"github.com/H3nSte1n/ci-orchestrator/internal/core/ports"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestS3CacheStore_PutOpenListDelete(t *testing.T) {
+	fake, srv := newFakeS3(t, "ci")
+
+	store, err := NewS3CacheStore(srv.URL, "us-east-1", "ci", "minio", "minio123", true, 0)
+	require.NoError(t, err)
+	assert.Implements(t, (*ports.CacheStore)(nil), store)
+
+	ctx := context.Background()
+	require.NoError(t, store.Put(ctx, "repo/go-abc", strings.NewReader("one"), 3))
+	require.NoError(t, store.Put(ctx, "repo/node-abc", strings.NewReader("two"), 3))
+	assert.Equal(t, "application/gzip", fake.types["cache/repo/go-abc"])
+
+	rc, err := store.Open(ctx, "repo/go-abc")
+	require.NoError(t, err)
+	data, err := io.ReadAll(rc)
+	rc.Close()
+	require.NoError(t, err)
+	assert.Equal(t, "one", string(data))
+
+	entries, err := store.List(ctx, "repo/go-")
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.Equal(t, "repo/go-abc", entries[0].Key)
+	assert.Equal(t, int64(3), entries[0].Size)
+
+	require.NoError(t, store.Delete(ctx, "repo/go-abc"))
+	_, err = store.Open(ctx, "repo/go-abc")
+	assert.ErrorIs(t, err, domain.ErrCacheMiss)
+}
+
+func TestS3CacheStore_EvictsOldestEntries(t *testing.T) {
+	fake, srv := newFakeS3(t, "ci")
+	clock := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	fake.now = func() time.Time {
+		clock = clock.Add(time.Minute)
+		return clock
+	}
+
+	store, err := NewS3CacheStore(srv.URL, "us-east-1", "ci", "minio", "minio123", true, 8)
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	require.NoError(t, store.Put(ctx, "first", strings.NewReader("aaaa"), 4))
+	require.NoError(t, store.Put(ctx, "second", strings.NewReader("bbbb"), 4))
+	require.NoError(t, store.Put(ctx, "third", strings.NewReader("cccc"), 4))
+
+	_, err = store.Open(ctx, "first")
+	assert.ErrorIs(t, err, domain.ErrCacheMiss)
+	assert.Contains(t, fake.objects, "cache/second")
+	assert.Contains(t, fake.objects, "cache/third")
+}
@@ -0,0 +1,146 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"github.com/H3nSte1n/ci-orchestrator/internal/core/domain"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// uploadArtifacts stores every regular file in the workspace matching one of
+// the job's artifact patterns. Symlinks are skipped so a build cannot point
+// an artifact at files outside its workspace.
+func (w *worker) uploadArtifacts(ctx context.Context, job *domain.Job, workdir string) error {
+	if len(job.Artifacts) == 0 || w.artifactService == nil {
+		return nil
+	}
+
+	files, err := collectArtifacts(workdir, job.Artifacts)
+	if err != nil {
+		return err
+	}
+
+	for _, name := range files {
+		if err := w.uploadArtifact(ctx, job, workdir, name); err != nil {
+			return fmt.Errorf("%s: %w", name, err)
+		}
+	}
+
+	return nil
+}
+
+func (w *worker) uploadArtifact(ctx context.Context, job *domain.Job, workdir, name string) error {
+	f, err := os.Open(filepath.Join(workdir, filepath.FromSlash(name)))
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return err
+	}
+
+	_, err = w.artifactService.Store(ctx, job, name, f, info.Size())
+	return err
+}
+
+func collectArtifacts(workdir string, patterns []string) ([]string, error) {
+	for _, pattern := range patterns {
+		if err := validatePattern(pattern); err != nil {
+			return nil, err
+		}
+	}
+
+	var files []string
+	err := filepath.WalkDir(workdir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		rel, err := filepath.Rel(workdir, p)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+
+		if d.IsDir() {
+			if rel == ".git" {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+
+		for _, pattern := range patterns {
+			if matchGlob(pattern, rel) {
+				files = append(files, rel)
+				break
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return files, nil
+}
+
+func validatePattern(pattern string) error {
+	if pattern == "" || path.IsAbs(pattern) {
+		return fmt.Errorf("invalid artifact pattern %q", pattern)
+	}
+	for _, segment := range strings.Split(pattern, "/") {
+		if segment == ".." {
+			return fmt.Errorf("artifact pattern %q escapes the workspace", pattern)
+		}
+		if _, err := path.Match(segment, ""); err != nil {
+			return fmt.Errorf("invalid artifact pattern %q: %w", pattern, err)
+		}
+	}
+	return nil
+}
+
+// matchGlob matches a slash separated path against a pattern where each
+// segment follows path.Match and "**" matches any number of segments.
+// A pattern naming a directory matches everything below it.
+func matchGlob(pattern, name string) bool {
+	patternParts := strings.Split(strings.TrimSuffix(path.Clean(pattern), "/"), "/")
+	nameParts := strings.Split(name, "/")
+
+	if matchSegments(patternParts, nameParts) {
+		return true
+	}
+	return matchSegments(append(patternParts, "**"), nameParts)
+}
+
+func matchSegments(pattern, name []string) bool {
+	for len(pattern) > 0 {
+		if pattern[0] == "**" {
+			for i := 0; i <= len(name); i++ {
+				if matchSegments(pattern[1:], name[i:]) {
+					return true
+				}
+			}
+			return false
+		}
+
+		if len(name) == 0 {
+			return false
+		}
+		if ok, _ := path.Match(pattern[0], name[0]); !ok {
+			return false
+		}
+		pattern = pattern[1:]
+		name = name[1:]
+	}
+
+	return len(name) == 0
+}
@@ -0,0 +1,180 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/H3nSte1n/ci-orchestrator/internal/core/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type mockArtifactService struct {
+	mock.Mock
+}
+
+func (m *mockArtifactService) Store(ctx context.Context, job *domain.Job, name string, r io.Reader, size int64) (*domain.Artifact, error) {
+	data, _ := io.ReadAll(r)
+	args := m.Called(ctx, job, name, string(data), size)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Artifact), args.Error(1)
+}
+
+func (m *mockArtifactService) List(ctx context.Context, buildId string) ([]domain.Artifact, error) {
+	args := m.Called(ctx, buildId)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.Artifact), args.Error(1)
+}
+
+func (m *mockArtifactService) Get(ctx context.Context, buildId string, name string) (*domain.Artifact, error) {
+	args := m.Called(ctx, buildId, name)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Artifact), args.Error(1)
+}
+
+func (m *mockArtifactService) Open(ctx context.Context, artifact *domain.Artifact) (io.ReadSeekCloser, error) {
+	args := m.Called(ctx, artifact)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(io.ReadSeekCloser), args.Error(1)
+}
+
+func (m *mockArtifactService) WriteArchive(ctx context.Context, buildId string, format domain.ArchiveFormat, w io.Writer) error {
+	args := m.Called(ctx, buildId, format, w)
+	return args.Error(0)
+}
+
+// fileWritingRunner simulates a build that produces files in its workspace.
+type fileWritingRunner struct {
+	files map[string]string
+}
+
+func (r *fileWritingRunner) Start(_ context.Context, workdir, _ string, _ []string) (<-chan domain.LogEvent, func() (int, error), error) {
+	for name, content := range r.files {
+		p := filepath.Join(workdir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(p), 0o755); err != nil {
+			return nil, nil, err
+		}
+		if err := os.WriteFile(p, []byte(content), 0o644); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	ch := make(chan domain.LogEvent)
+	close(ch)
+	return ch, func() (int, error) { return 0, nil }, nil
+}
+
+func writeFiles(t *testing.T, root string, files ...string) {
+	t.Helper()
+	for _, name := range files {
+		p := filepath.Join(root, filepath.FromSlash(name))
+		require.NoError(t, os.MkdirAll(filepath.Dir(p), 0o755))
+		require.NoError(t, os.WriteFile(p, []byte(name), 0o644))
+	}
+}
+
+func TestMatchGlob(t *testing.T) {
+	tests := []struct {
+		pattern string
+		name    string
+		want    bool
+	}{
+		{"coverage.out", "coverage.out", true},
+		{"coverage.out", "sub/coverage.out", false},
+		{"*.log", "build.log", true},
+		{"*.log", "logs/build.log", false},
+		{"**/*.log", "build.log", true},
+		{"**/*.log", "logs/deep/build.log", true},
+		{"dist", "dist/app", true},
+		{"dist/", "dist/nested/app", true},
+		{"dist/*.tar.gz", "dist/app.tar.gz", true},
+		{"dist/*.tar.gz", "dist/app.zip", false},
+		{"reports/**/junit.xml", "reports/unit/junit.xml", true},
+		{"reports/**/junit.xml", "reports/junit.xml", true},
+		{"reports/**/junit.xml", "other/junit.xml", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.pattern+" "+tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, matchGlob(tt.pattern, tt.name))
+		})
+	}
+}
+
+func TestCollectArtifacts(t *testing.T) {
+	root := t.TempDir()
+	writeFiles(t, root, "dist/app", "dist/lib/util.so", "coverage.out", "main.go", ".git/config")
+	require.NoError(t, os.Symlink("/etc/passwd", filepath.Join(root, "dist", "passwd")))
+
+	files, err := collectArtifacts(root, []string{"dist", "coverage.out", "**/config"})
+
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"dist/app", "dist/lib/util.so", "coverage.out"}, files)
+}
+
+func TestCollectArtifacts_RejectsEscapingPatterns(t *testing.T) {
+	root := t.TempDir()
+
+	for _, pattern := range []string{"../secret", "/etc/passwd", "", "[invalid"} {
+		_, err := collectArtifacts(root, []string{pattern})
+		assert.Error(t, err, pattern)
+	}
+}
+
+func TestWorker_ClaimAndProcess_UploadsArtifacts(t *testing.T) {
+	job := jobTestData()
+	job.Artifacts = domain.StringList{"dist/*.txt"}
+
+	mockBuildService := new(mockBuildService)
+	mockBuildService.On("ClaimNext", mock.Anything, "worker-1").Return(job, nil)
+	mockBuildService.On("CompleteJob", mock.Anything, "job-id", "worker-1", 0, mock.Anything, nil).Return(nil)
+
+	mockArtifactService := new(mockArtifactService)
+	mockArtifactService.On("Store", mock.Anything, job, "dist/out.txt", "result", int64(6)).Return(&domain.Artifact{}, nil)
+
+	runner := &fileWritingRunner{files: map[string]string{"dist/out.txt": "result", "dist/skip.bin": "x"}}
+
+	worker := NewWorker("worker-1", mockBuildService, new(mockBuildLogService), 100*time.Millisecond, 0, runner, &stubVCS{}, mockArtifactService, nil)
+	err := worker.claimAndProcess(context.Background())
+
+	assert.NoError(t, err)
+	mockArtifactService.AssertExpectations(t)
+	mockBuildService.AssertExpectations(t)
+}
+
+func TestWorker_ClaimAndProcess_ArtifactUploadFailureFailsJob(t *testing.T) {
+	job := jobTestData()
+	job.Artifacts = domain.StringList{"out.txt"}
+
+	mockBuildService := new(mockBuildService)
+	mockBuildService.On("ClaimNext", mock.Anything, "worker-1").Return(job, nil)
+	mockBuildService.On("CompleteJob", mock.Anything, "job-id", "worker-1", 0, mock.Anything, mock.MatchedBy(func(err error) bool {
+		return err != nil && strings.Contains(err.Error(), "upload artifacts")
+	})).Return(nil)
+
+	mockArtifactService := new(mockArtifactService)
+	mockArtifactService.On("Store", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil, errors.New("bucket unavailable"))
+
+	runner := &fileWritingRunner{files: map[string]string{"out.txt": "result"}}
+
+	worker := NewWorker("worker-1", mockBuildService, new(mockBuildLogService), 100*time.Millisecond, 0, runner, &stubVCS{}, mockArtifactService, nil)
+	err := worker.claimAndProcess(context.Background())
+
+	assert.NoError(t, err)
+	mockBuildService.AssertExpectations(t)
+}
@@ -0,0 +1,301 @@
+package worker
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"github.com/H3nSte1n/ci-orchestrator/internal/core/domain"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"text/template"
+	"time"
+)
+
+type cacheState struct {
+	cache domain.Cache
+	key   string
+	hit   bool
+}
+
+// restoreCaches restores every cache declared on the job's build. Cache
+// failures never fail a job; they are reported in the job log instead.
+func (w *worker) restoreCaches(ctx context.Context, job *domain.Job, workdir string) []cacheState {
+	if w.cacheService == nil || len(job.Build.Caches) == 0 {
+		return nil
+	}
+
+	var states []cacheState
+	for _, cache := range job.Build.Caches {
+		key, err := renderCacheKey(cache.Key, workdir, job.Build.Env)
+		if err != nil {
+			w.logCache(ctx, job, "cache %q: %v", cache.Key, err)
+			continue
+		}
+
+		restoreKeys := make([]string, 0, len(cache.RestoreKeys))
+		for _, restoreKey := range cache.RestoreKeys {
+			rendered, err := renderCacheKey(restoreKey, workdir, job.Build.Env)
+			if err != nil {
+				w.logCache(ctx, job, "cache %q: restore key %q: %v", key, restoreKey, err)
+				continue
+			}
+			restoreKeys = append(restoreKeys, rendered)
+		}
+
+		state := cacheState{cache: cache, key: key}
+		states = append(states, state)
+
+		rc, matched, err := w.cacheService.Restore(ctx, job.Build.RepoUrl, key, restoreKeys)
+		if errors.Is(err, domain.ErrCacheMiss) {
+			w.logCache(ctx, job, "cache %q: miss", key)
+			continue
+		}
+		if err != nil {
+			w.logCache(ctx, job, "cache %q: restore failed: %v", key, err)
+			continue
+		}
+
+		err = extractCache(rc, workdir)
+		rc.Close()
+		if err != nil {
+			w.logCache(ctx, job, "cache %q: extract failed: %v", key, err)
+			continue
+		}
+
+		states[len(states)-1].hit = matched == key
+		w.logCache(ctx, job, "cache %q: restored from %q", key, matched)
+	}
+
+	return states
+}
+
+// saveCaches uploads the caches that were not an exact hit on restore.
+func (w *worker) saveCaches(ctx context.Context, job *domain.Job, workdir string, states []cacheState) {
+	for _, state := range states {
+		if state.hit {
+			continue
+		}
+		if err := w.saveCache(ctx, job, workdir, state); err != nil {
+			w.logCache(ctx, job, "cache %q: save failed: %v", state.key, err)
+			continue
+		}
+		w.logCache(ctx, job, "cache %q: saved", state.key)
+	}
+}
+
+func (w *worker) saveCache(ctx context.Context, job *domain.Job, workdir string, state cacheState) error {
+	tmp, err := os.CreateTemp("", "ci-cache-*.tar.gz")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	defer tmp.Close()
+
+	if err := writeCacheArchive(tmp, workdir, state.cache.Paths); err != nil {
+		return err
+	}
+
+	size, err := tmp.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+
+	return w.cacheService.Save(ctx, job.Build.RepoUrl, state.key, tmp, size)
+}
+
+func (w *worker) logCache(ctx context.Context, job *domain.Job, format string, args ...interface{}) {
+	_ = w.buildLogService.AppendLog(ctx, job.BuildID, job.ID, domain.LogEvent{
+		Stream: domain.LogStdout,
+		Line:   fmt.Sprintf(format, args...),
+		Time:   time.Now(),
+	})
+}
+
+// renderCacheKey expands a key template. Available functions:
+// hashFiles "pattern"... (sha256 over all matching workspace files),
+// env "NAME", os and arch.
+func renderCacheKey(key, workdir string, env domain.StringMap) (string, error) {
+	tmpl, err := template.New("key").Option("missingkey=error").Funcs(template.FuncMap{
+		"hashFiles": func(patterns ...string) (string, error) {
+			return hashFiles(workdir, patterns)
+		},
+		"env": func(name string) string {
+			return env[name]
+		},
+		"os": func() string {
+			return runtime.GOOS
+		},
+		"arch": func() string {
+			return runtime.GOARCH
+		},
+	}).Parse(key)
+	if err != nil {
+		return "", err
+	}
+
+	var b strings.Builder
+	if err := tmpl.Execute(&b, nil); err != nil {
+		return "", err
+	}
+
+	rendered := strings.TrimSpace(b.String())
+	if rendered == "" {
+		return "", fmt.Errorf("key renders empty")
+	}
+	return rendered, nil
+}
+
+// hashFiles hashes the names and contents of all files matching the patterns
+// in a stable order. No match hashes to an empty string, like an unset env.
+func hashFiles(workdir string, patterns []string) (string, error) {
+	files, err := collectArtifacts(workdir, patterns)
+	if err != nil {
+		return "", err
+	}
+	if len(files) == 0 {
+		return "", nil
+	}
+
+	hasher := sha256.New()
+	for _, name := range files {
+		f, err := os.Open(filepath.Join(workdir, filepath.FromSlash(name)))
+		if err != nil {
+			return "", err
+		}
+		io.WriteString(hasher, name+"\x00")
+		_, err = io.Copy(hasher, f)
+		f.Close()
+		if err != nil {
+			return "", err
+		}
+	}
+
+	return hex.EncodeToString(hasher.Sum(nil)), nil
+}
+
+// writeCacheArchive writes the given workspace paths as a tar.gz stream.
+// Symlinks are skipped for the same reason as with artifacts.
+func writeCacheArchive(w io.Writer, workdir string, paths []string) error {
+	gw := gzip.NewWriter(w)
+	tw := tar.NewWriter(gw)
+
+	for _, p := range paths {
+		root := filepath.Join(workdir, filepath.FromSlash(p))
+		err := filepath.WalkDir(root, func(current string, d fs.DirEntry, err error) error {
+			if errors.Is(err, fs.ErrNotExist) && current == root {
+				return nil
+			}
+			if err != nil {
+				return err
+			}
+			if !d.IsDir() && !d.Type().IsRegular() {
+				return nil
+			}
+
+			rel, err := filepath.Rel(workdir, current)
+			if err != nil {
+				return err
+			}
+
+			info, err := d.Info()
+			if err != nil {
+				return err
+			}
+
+			header, err := tar.FileInfoHeader(info, "")
+			if err != nil {
+				return err
+			}
+			header.Name = filepath.ToSlash(rel)
+			if d.IsDir() {
+				header.Name += "/"
+			}
+			if err := tw.WriteHeader(header); err != nil {
+				return err
+			}
+			if d.IsDir() {
+				return nil
+			}
+
+			f, err := os.Open(current)
+			if err != nil {
+				return err
+			}
+			defer f.Close()
+			_, err = io.Copy(tw, f)
+			return err
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	if err := tw.Close(); err != nil {
+		return err
+	}
+	return gw.Close()
+}
+
+// extractCache unpacks a cache archive into the workspace, refusing entries
+// that would land outside of it.
+func extractCache(r io.Reader, workdir string) error {
+	gr, err := gzip.NewReader(r)
+	if err != nil {
+		return err
+	}
+	defer gr.Close()
+
+	tr := tar.NewReader(gr)
+	for {
+		header, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		name := filepath.Clean(filepath.FromSlash(header.Name))
+		if filepath.IsAbs(name) || name == ".." || strings.HasPrefix(name, ".."+string(filepath.Separator)) {
+			return fmt.Errorf("archive entry %q escapes the workspace", header.Name)
+		}
+		target := filepath.Join(workdir, name)
+
+		switch header.Typeflag {
+		case tar.TypeDir:
+			if err := os.MkdirAll(target, 0o755); err != nil {
+				return err
+			}
+		case tar.TypeReg:
+			if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+				return err
+			}
+			if err := writeCacheFile(target, tr, header.FileInfo().Mode().Perm()); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func writeCacheFile(target string, r io.Reader, mode fs.FileMode) error {
+	f, err := os.OpenFile(target, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, mode)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(f, r); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
@@ -0,0 +1,185 @@
+package worker
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/H3nSte1n/ci-orchestrator/internal/core/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeCacheService keeps archives in memory and only supports exact hits.
+type fakeCacheService struct {
+	archives map[string][]byte
+	saves    int
+}
+
+func (f *fakeCacheService) Restore(_ context.Context, scope string, key string, _ []string) (io.ReadCloser, string, error) {
+	data, ok := f.archives[scope+"|"+key]
+	if !ok {
+		return nil, "", domain.ErrCacheMiss
+	}
+	return io.NopCloser(bytes.NewReader(data)), key, nil
+}
+
+func (f *fakeCacheService) Save(_ context.Context, scope string, key string, r io.Reader, _ int64) error {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return err
+	}
+	if f.archives == nil {
+		f.archives = map[string][]byte{}
+	}
+	f.archives[scope+"|"+key] = data
+	f.saves++
+	return nil
+}
+
+// probeRunner records whether a file already exists when the command starts
+// and then writes it.
+type probeRunner struct {
+	file    string
+	existed bool
+}
+
+func (r *probeRunner) Start(_ context.Context, workdir, _ string, _ []string) (<-chan domain.LogEvent, func() (int, error), error) {
+	p := filepath.Join(workdir, filepath.FromSlash(r.file))
+	_, err := os.Stat(p)
+	r.existed = err == nil
+
+	if err := os.MkdirAll(filepath.Dir(p), 0o755); err != nil {
+		return nil, nil, err
+	}
+	if err := os.WriteFile(p, []byte("dependency"), 0o644); err != nil {
+		return nil, nil, err
+	}
+
+	ch := make(chan domain.LogEvent)
+	close(ch)
+	return ch, func() (int, error) { return 0, nil }, nil
+}
+
+func TestRenderCacheKey(t *testing.T) {
+	root := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(root, "go.sum"), []byte("sum"), 0o644))
+
+	key, err := renderCacheKey(`go-{{ os }}-{{ env "GO" }}-{{ hashFiles "go.sum" }}`, root, domain.StringMap{"GO": "1.25"})
+	require.NoError(t, err)
+
+	hash, err := hashFiles(root, []string{"go.sum"})
+	require.NoError(t, err)
+	assert.Len(t, hash, 64)
+	assert.Equal(t, "go-"+runtime.GOOS+"-1.25-"+hash, key)
+
+	require.NoError(t, os.WriteFile(filepath.Join(root, "go.sum"), []byte("changed"), 0o644))
+	changed, err := renderCacheKey(`go-{{ os }}-{{ env "GO" }}-{{ hashFiles "go.sum" }}`, root, domain.StringMap{"GO": "1.25"})
+	require.NoError(t, err)
+	assert.NotEqual(t, key, changed)
+}
+
+func TestRenderCacheKey_Invalid(t *testing.T) {
+	root := t.TempDir()
+
+	for _, key := range []string{"{{ unknown }}", "{{", `{{ hashFiles "../etc" }}`, "   "} {
+		_, err := renderCacheKey(key, root, nil)
+		assert.Error(t, err, key)
+	}
+}
+
+func TestCacheArchive_RoundTrip(t *testing.T) {
+	src := t.TempDir()
+	writeFiles(t, src, "node_modules/a/index.js", "node_modules/b/package.json", "src/main.js")
+	require.NoError(t, os.Symlink("/etc/passwd", filepath.Join(src, "node_modules", "passwd")))
+
+	var buf bytes.Buffer
+	require.NoError(t, writeCacheArchive(&buf, src, []string{"node_modules", "missing"}))
+
+	dst := t.TempDir()
+	require.NoError(t, extractCache(&buf, dst))
+
+	data, err := os.ReadFile(filepath.Join(dst, "node_modules", "a", "index.js"))
+	require.NoError(t, err)
+	assert.Equal(t, "node_modules/a/index.js", string(data))
+	assert.FileExists(t, filepath.Join(dst, "node_modules", "b", "package.json"))
+	assert.NoFileExists(t, filepath.Join(dst, "src", "main.js"))
+	_, err = os.Lstat(filepath.Join(dst, "node_modules", "passwd"))
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestExtractCache_RejectsEscapingEntries(t *testing.T) {
+	var buf bytes.Buffer
+	gw := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gw)
+	require.NoError(t, tw.WriteHeader(&tar.Header{Name: "../outside", Mode: 0o644, Size: 1, Typeflag: tar.TypeReg}))
+	_, err := tw.Write([]byte("x"))
+	require.NoError(t, err)
+	require.NoError(t, tw.Close())
+	require.NoError(t, gw.Close())
+
+	root := t.TempDir()
+	err = extractCache(&buf, filepath.Join(root, "workdir"))
+
+	assert.Error(t, err)
+	assert.NoFileExists(t, filepath.Join(root, "outside"))
+}
+
+func TestWorker_ClaimAndProcess_RestoresAndSavesCaches(t *testing.T) {
+	cacheService := &fakeCacheService{}
+	mockBuildLogService := new(mockBuildLogService)
+	mockBuildLogService.On("AppendLog", mock.Anything, "ci-id", "job-id", mock.Anything).Return(nil)
+
+	run := func() *probeRunner {
+		job := jobTestData()
+		job.Build.Caches = domain.CacheList{{Key: "deps", Paths: domain.StringList{"vendor"}}}
+
+		mockBuildService := new(mockBuildService)
+		mockBuildService.On("ClaimNext", mock.Anything, "worker-1").Return(job, nil)
+		mockBuildService.On("CompleteJob", mock.Anything, "job-id", "worker-1", 0, mock.Anything, nil).Return(nil)
+
+		runner := &probeRunner{file: "vendor/lib.txt"}
+		worker := NewWorker("worker-1", mockBuildService, mockBuildLogService, 100*time.Millisecond, 0, runner, &stubVCS{}, nil, cacheService)
+		require.NoError(t, worker.claimAndProcess(context.Background()))
+		mockBuildService.AssertExpectations(t)
+		return runner
+	}
+
+	first := run()
+	assert.False(t, first.existed)
+	assert.Equal(t, 1, cacheService.saves)
+
+	second := run()
+	assert.True(t, second.existed)
+	assert.Equal(t, 1, cacheService.saves, "an exact hit must not be saved again")
+
+	mockBuildLogService.AssertCalled(t, "AppendLog", mock.Anything, "ci-id", "job-id", mock.MatchedBy(func(ev domain.LogEvent) bool {
+		return strings.Contains(ev.Line, `cache "deps": restored from "deps"`)
+	}))
+}
+
+func TestWorker_ClaimAndProcess_SkipsCacheSaveOnFailure(t *testing.T) {
+	cacheService := &fakeCacheService{}
+	job := jobTestData()
+	job.Build.Caches = domain.CacheList{{Key: "deps", Paths: domain.StringList{"vendor"}}}
+
+	mockBuildLogService := new(mockBuildLogService)
+	mockBuildLogService.On("AppendLog", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	mockBuildService := new(mockBuildService)
+	mockBuildService.On("ClaimNext", mock.Anything, "worker-1").Return(job, nil)
+	mockBuildService.On("CompleteJob", mock.Anything, "job-id", "worker-1", 1, mock.Anything, nil).Return(nil)
+
+	worker := NewWorker("worker-1", mockBuildService, mockBuildLogService, 100*time.Millisecond, 0, &stubRunner{exitCode: 1}, &stubVCS{}, nil, cacheService)
+	require.NoError(t, worker.claimAndProcess(context.Background()))
+
+	assert.Equal(t, 0, cacheService.saves)
+}
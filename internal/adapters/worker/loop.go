@@ -2,109 +2,293 @@ package worker
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"github.com/H3nSte1n/ci-orchestrator/internal/core/domain"
 	"github.com/H3nSte1n/ci-orchestrator/internal/core/ports"
 	"os"
+	"sync/atomic"
 	"time"
 )
 
+// maxLogBatch caps how many log lines are sent in one AppendLogs call.
+const maxLogBatch = 100
+
 type worker struct {
 	workerId        string
 	buildService    ports.BuildService
 	buildLogService ports.BuildLogService
 	interval        time.Duration
+	heartbeat       time.Duration
 	runner          ports.Runner
 	vcs             ports.VCS
+	artifactService ports.ArtifactService
+	cacheService    ports.CacheService
+	wake            <-chan struct{}
+	connected       func() bool
+	fallback        time.Duration
+	workerService   ports.WorkerService
+	state           atomic.Value
 }
 
-func NewWorker(workerId string, buildService ports.BuildService, buildLogService ports.BuildLogService, interval time.Duration, runner ports.Runner, vcs ports.VCS) *worker {
+func NewWorker(workerId string, buildService ports.BuildService, buildLogService ports.BuildLogService, interval time.Duration, heartbeat time.Duration, runner ports.Runner, vcs ports.VCS, artifactService ports.ArtifactService, cacheService ports.CacheService) *worker {
 	return &worker{
 		workerId:        workerId,
 		buildService:    buildService,
 		buildLogService: buildLogService,
 		interval:        interval,
+		heartbeat:       heartbeat,
 		runner:          runner,
 		vcs:             vcs,
+		artifactService: artifactService,
+		cacheService:    cacheService,
 	}
 }
 
+// WakeOn makes Run claim as soon as wake receives instead of waiting for
+// the next poll. While connected reports true, wake-ups are reliable and Run
+// only polls every fallback; otherwise it polls at its interval. A nil
+// connected always polls at the interval.
+func (w *worker) WakeOn(wake <-chan struct{}, connected func() bool, fallback time.Duration) {
+	w.wake = wake
+	w.connected = connected
+	w.fallback = fallback
+}
+
+// pollInterval is how long Run waits for a wake-up before it claims anyway.
+func (w *worker) pollInterval() time.Duration {
+	if w.connected != nil && w.fallback > w.interval && w.connected() {
+		return w.fallback
+	}
+	return w.interval
+}
+
+// ReportTo makes Run send worker heartbeats to workerService and follow the
+// state they return: a paused worker stops claiming, a draining one returns
+// from Run with nil once its job is done.
+func (w *worker) ReportTo(workerService ports.WorkerService) {
+	w.workerService = workerService
+}
+
 func (w *worker) Run(ctx context.Context) error {
-	ticker := time.NewTicker(w.interval)
+	timer := time.NewTimer(w.pollInterval())
+
+	defer timer.Stop()
+
+	if w.workerService != nil && w.heartbeat > 0 {
+		reportCtx, stopReporting := context.WithCancel(ctx)
+		defer stopReporting()
+		go w.report(reportCtx)
+	}
+
+	for {
+		select {
+		case <-timer.C:
+		case <-w.wake:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+		timer.Reset(w.pollInterval())
+
+		switch w.currentState() {
+		case domain.WorkerStateDraining:
+			return nil
+		case domain.WorkerStatePaused:
+			continue
+		}
 
+		err := w.claimAndProcess(ctx)
+		switch {
+		case errors.Is(err, domain.ErrWorkerNotClaiming):
+			fmt.Println("Not claiming: the build service reports this worker as paused or draining")
+		case err != nil:
+			fmt.Println("Error claiming build:", err)
+		}
+	}
+}
+
+// report sends a worker heartbeat every heartbeat interval, also while a
+// job runs, and records the state the orchestrator answers with.
+func (w *worker) report(ctx context.Context) {
+	ticker := time.NewTicker(w.heartbeat)
 	defer ticker.Stop()
 
 	for {
 		select {
 		case <-ticker.C:
-			err := w.claimAndProcess(ctx)
+			registered, err := w.workerService.Heartbeat(ctx, w.workerId)
 			if err != nil {
-				fmt.Println("Error claiming build:", err)
+				if ctx.Err() == nil {
+					fmt.Println("Error sending worker heartbeat:", err)
+				}
+				continue
 			}
+			w.state.Store(registered.State)
 
 		case <-ctx.Done():
-			return ctx.Err()
+			return
 		}
 	}
 }
 
+func (w *worker) currentState() domain.WorkerState {
+	state, _ := w.state.Load().(domain.WorkerState)
+	return state
+}
+
 func (w *worker) claimAndProcess(ctx context.Context) error {
-	build, err := w.buildService.ClaimNext(ctx, w.workerId)
+	job, err := w.buildService.ClaimNext(ctx, w.workerId)
 	if err != nil {
 		return err
 	}
 
-	if build == nil {
+	if job == nil {
 		return nil
 	}
 
-	workdir := fmt.Sprintf("/tmp/ci-orchestrator/%s", build.ID)
+	exitCode, finishedAt, runErr := w.runJob(ctx, job)
+	return w.buildService.CompleteJob(ctx, job.ID, w.workerId, exitCode, &finishedAt, runErr)
+}
+
+// Execute runs job in a fresh workspace without claiming or completing it,
+// which lets a build run on a machine without the orchestrator. The worker
+// should have a zero heartbeat so it never asks the build service about the
+// job.
+func (w *worker) Execute(ctx context.Context, job *domain.Job) (int, error) {
+	exitCode, _, err := w.runJob(ctx, job)
+	return exitCode, err
+}
+
+// runJob checks out the job's repository, runs its command and collects
+// caches and artifacts. It returns what CompleteJob needs to finish the job.
+func (w *worker) runJob(ctx context.Context, job *domain.Job) (int, time.Time, error) {
+	runCtx, cancelRun := context.WithCancel(ctx)
+	defer cancelRun()
+	canceled := w.watchJob(runCtx, job, cancelRun)
+
+	workdir := fmt.Sprintf("/tmp/ci-orchestrator/%s", job.ID)
 	if err := os.MkdirAll(workdir, 0o755); err != nil {
-		finishedAt := time.Now()
-		runErr := fmt.Errorf("create workdir: %w", err)
-		return w.buildService.CompleteBuild(ctx, build.ID, -1, &finishedAt, runErr)
+		return -1, time.Now(), fmt.Errorf("create workdir: %w", err)
 	}
 	defer os.RemoveAll(workdir)
 
-	if err := w.vcs.CloneAndCheckout(ctx, build.RepoUrl, build.Ref, workdir); err != nil {
-		finishedAt := time.Now()
-		runErr := fmt.Errorf("checkout repo: %w", err)
-		return w.buildService.CompleteBuild(ctx, build.ID, -1, &finishedAt, runErr)
+	if err := w.vcs.CloneAndCheckout(runCtx, job.Build.RepoUrl, job.Build.Ref, workdir); err != nil {
+		return -1, time.Now(), fmt.Errorf("checkout repo: %w", err)
 	}
 
-	events, waitFn, err := w.runner.Start(ctx, workdir, build.Command, nil)
+	caches := w.restoreCaches(ctx, job, workdir)
+
+	events, waitFn, err := w.runner.Start(runCtx, workdir, job.Command, job.Build.Env.Environ())
 
 	if err != nil {
-		finishedAt := time.Now()
-		runErr := fmt.Errorf("start runner: %w", err)
-		return w.buildService.CompleteBuild(ctx, build.ID, -1, &finishedAt, runErr)
+		return -1, time.Now(), fmt.Errorf("start runner: %w", err)
 	}
 
 	logErrCh := make(chan error, 1)
-	go w.persistLogs(ctx, events, build.ID, logErrCh)
+	go w.persistLogs(ctx, events, job, logErrCh)
 
 	exitCode, runErr := waitFn()
 	finishedAt := time.Now()
+	if canceled.Load() {
+		runErr = domain.ErrJobCanceled
+	}
 	logErr := <-logErrCh
 	if logErr != nil && runErr == nil {
 		runErr = fmt.Errorf("persist logs: %w", logErr)
 	}
 
-	return w.buildService.CompleteBuild(ctx, build.ID, exitCode, &finishedAt, runErr)
+	// Collect before the deferred RemoveAll wipes the workspace.
+	if err := w.uploadArtifacts(ctx, job, workdir); err != nil && runErr == nil {
+		runErr = fmt.Errorf("upload artifacts: %w", err)
+	}
+
+	if exitCode == 0 && runErr == nil {
+		w.saveCaches(ctx, job, workdir, caches)
+	}
+
+	return exitCode, finishedAt, runErr
 }
 
-func (w *worker) persistLogs(ctx context.Context, events <-chan domain.LogEvent, buildId string, logErrCh chan<- error) {
+// persistLogs sends log lines in batches of whatever is buffered, so a busy
+// job needs far fewer round trips than it prints lines.
+func (w *worker) persistLogs(ctx context.Context, events <-chan domain.LogEvent, job *domain.Job, logErrCh chan<- error) {
 	var firstErr error
 
 	for ev := range events {
+		batch := []domain.LogEvent{ev}
+	drain:
+		for len(batch) < maxLogBatch {
+			select {
+			case next, ok := <-events:
+				if !ok {
+					break drain
+				}
+				batch = append(batch, next)
+			default:
+				break drain
+			}
+		}
+
 		if firstErr != nil {
 			continue
 		}
 
-		if err := w.buildLogService.AppendLog(ctx, buildId, ev); err != nil {
+		if err := w.buildLogService.AppendLogs(ctx, job.BuildID, job.ID, batch); err != nil {
 			firstErr = err
 		}
 	}
 
 	logErrCh <- firstErr
 }
+
+// watchJob sends heartbeats while the job runs and cancels it when a
+// cancellation is requested or the worker no longer holds the job. A zero
+// heartbeat interval disables both.
+func (w *worker) watchJob(ctx context.Context, job *domain.Job, cancel context.CancelFunc) *atomic.Bool {
+	canceled := &atomic.Bool{}
+	if w.heartbeat <= 0 {
+		return canceled
+	}
+
+	go func() {
+		ticker := time.NewTicker(w.heartbeat)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				err := w.buildService.Heartbeat(ctx, job.ID, w.workerId)
+				switch {
+				case ctx.Err() != nil:
+					return
+				case errors.Is(err, domain.ErrJobNotFound), errors.Is(err, domain.ErrJobLockLost):
+					// The lease expired and the job went back to the queue;
+					// whoever runs it now reports its result.
+					fmt.Printf("Lost job %s, stopping it: %v\n", job.ID, err)
+					cancel()
+					return
+				case err != nil:
+					fmt.Println("Error sending heartbeat:", err)
+				}
+
+				requested, err := w.buildService.CancelRequested(ctx, job.ID)
+				if err != nil {
+					if ctx.Err() == nil {
+						fmt.Println("Error checking cancellation:", err)
+					}
+					continue
+				}
+				if requested {
+					canceled.Store(true)
+					cancel()
+					return
+				}
+
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return canceled
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"strings"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -22,15 +23,37 @@ func buildTestData() *domain.Build {
 	}
 }
 
+func jobTestData() *domain.Job {
+	return &domain.Job{
+		ID:      "job-id",
+		BuildID: "ci-id",
+		Name:    domain.DefaultJobName,
+		Command: "npm test",
+		Status:  domain.JobStatusRunning,
+		Build:   buildTestData(),
+	}
+}
+
 type mockBuildLogService struct {
 	mock.Mock
 }
 
-func (m *mockBuildLogService) AppendLog(ctx context.Context, buildId string, ev domain.LogEvent) error {
-	args := m.Called(ctx, buildId, ev)
+func (m *mockBuildLogService) AppendLog(ctx context.Context, buildId string, jobId string, ev domain.LogEvent) error {
+	args := m.Called(ctx, buildId, jobId, ev)
 	return args.Error(0)
 }
 
+func (m *mockBuildLogService) AppendLogs(ctx context.Context, buildId string, jobId string, events []domain.LogEvent) error {
+	args := m.Called(ctx, buildId, jobId, events)
+	return args.Error(0)
+}
+
+func (m *mockBuildLogService) ListLogs(ctx context.Context, buildId string, afterSeq int64, limit int) ([]domain.BuildLog, error) {
+	args := m.Called(ctx, buildId, afterSeq, limit)
+	logs, _ := args.Get(0).([]domain.BuildLog)
+	return logs, args.Error(1)
+}
+
 type stubRunner struct {
 	exitCode int
 	runErr   error
@@ -72,12 +95,12 @@ type mockBuildService struct {
 	Error error
 }
 
-func (m *mockBuildService) ClaimNext(ctx context.Context, workerId string) (*domain.Build, error) {
+func (m *mockBuildService) ClaimNext(ctx context.Context, workerId string) (*domain.Job, error) {
 	args := m.Called(ctx, workerId)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
-	return args.Get(0).(*domain.Build), args.Error(1)
+	return args.Get(0).(*domain.Job), args.Error(1)
 }
 
 func (m *mockBuildService) CreateBuild(ctx context.Context, build *domain.Build) error {
@@ -85,6 +108,14 @@ func (m *mockBuildService) CreateBuild(ctx context.Context, build *domain.Build)
 	return args.Error(0)
 }
 
+func (m *mockBuildService) RetryBuild(ctx context.Context, buildId string, triggeredBy *string) (*domain.Build, error) {
+	args := m.Called(ctx, buildId, triggeredBy)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Build), args.Error(1)
+}
+
 func (m *mockBuildService) CancelBuild(ctx context.Context, buildId string) error {
 	args := m.Called(ctx, buildId)
 	return args.Error(0)
@@ -95,6 +126,14 @@ func (m *mockBuildService) UpdateStatus(ctx context.Context, buildId string, sta
 	return args.Error(0)
 }
 
+func (m *mockBuildService) UpdatePriority(ctx context.Context, buildId string, priority int) (*domain.Build, error) {
+	args := m.Called(ctx, buildId, priority)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Build), args.Error(1)
+}
+
 func (m *mockBuildService) GetBuild(ctx context.Context, buildId string) (*domain.Build, error) {
 	args := m.Called(ctx, buildId)
 	if args.Get(0) == nil {
@@ -103,35 +142,66 @@ func (m *mockBuildService) GetBuild(ctx context.Context, buildId string) (*domai
 	return args.Get(0).(*domain.Build), args.Error(1)
 }
 
-func (m *mockBuildService) CompleteBuild(ctx context.Context, buildId string, exitCode int, finishedAt *time.Time, error error) error {
-	args := m.Called(ctx, buildId, exitCode, finishedAt, error)
+func (m *mockBuildService) ListBuilds(ctx context.Context, filter domain.BuildFilter) (*domain.BuildPage, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.BuildPage), args.Error(1)
+}
+
+func (m *mockBuildService) GetJob(ctx context.Context, jobId string) (*domain.Job, error) {
+	args := m.Called(ctx, jobId)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Job), args.Error(1)
+}
+
+func (m *mockBuildService) Heartbeat(ctx context.Context, jobId string, workerId string) error {
+	args := m.Called(ctx, jobId, workerId)
+	return args.Error(0)
+}
+
+func (m *mockBuildService) CancelRequested(ctx context.Context, jobId string) (bool, error) {
+	args := m.Called(ctx, jobId)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *mockBuildService) CompleteJob(ctx context.Context, jobId string, workerId string, exitCode int, finishedAt *time.Time, error error) error {
+	args := m.Called(ctx, jobId, workerId, exitCode, finishedAt, error)
 	return args.Error(0)
 }
 
+func (m *mockBuildService) RequeueExpired(ctx context.Context, lease time.Duration) (int, error) {
+	args := m.Called(ctx, lease)
+	return args.Int(0), args.Error(1)
+}
+
 func (m *mockBuildService) GetError() error {
 	return m.Error
 }
 
 func TestWorker_ClaimAndProcess_Success(t *testing.T) {
 	mockBuildService := new(mockBuildService)
-	mockBuildService.On("ClaimNext", mock.Anything, "worker-1").Return(buildTestData(), nil)
-	mockBuildService.On("CompleteBuild", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	mockBuildService.On("ClaimNext", mock.Anything, "worker-1").Return(jobTestData(), nil)
+	mockBuildService.On("CompleteJob", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
 
 	mockBuildLogService := new(mockBuildLogService)
-	mockBuildLogService.On("AppendLog", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	mockBuildLogService.On("AppendLogs", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
 
 	runner := &stubRunner{exitCode: 0, runErr: nil, events: []domain.LogEvent{{Stream: domain.LogStdout, Line: "hello", Time: time.Now()}}}
 	vcs := &stubVCS{err: nil}
 
-	worker := NewWorker("worker-1", mockBuildService, mockBuildLogService, 100*time.Millisecond, runner, vcs)
+	worker := NewWorker("worker-1", mockBuildService, mockBuildLogService, 100*time.Millisecond, 0, runner, vcs, nil, nil)
 	err := worker.claimAndProcess(context.Background())
 
 	assert.NoError(t, err)
 	mockBuildService.AssertCalled(t, "ClaimNext", mock.Anything, "worker-1")
-	mockBuildLogService.AssertCalled(t, "AppendLog", mock.Anything, "ci-id", mock.MatchedBy(func(e domain.LogEvent) bool {
-		return e.Stream == domain.LogStdout && e.Line == "hello"
+	mockBuildLogService.AssertCalled(t, "AppendLogs", mock.Anything, "ci-id", "job-id", mock.MatchedBy(func(events []domain.LogEvent) bool {
+		return len(events) == 1 && events[0].Stream == domain.LogStdout && events[0].Line == "hello"
 	}))
-	mockBuildLogService.AssertNumberOfCalls(t, "AppendLog", 1)
+	mockBuildLogService.AssertNumberOfCalls(t, "AppendLogs", 1)
 }
 
 func TestWorker_ClaimAndProcess_Error(t *testing.T) {
@@ -143,7 +213,7 @@ func TestWorker_ClaimAndProcess_Error(t *testing.T) {
 	runner := &stubRunner{exitCode: 0, runErr: nil}
 	vcs := &stubVCS{err: nil}
 
-	worker := NewWorker("worker-1", mockBuildService, mockBuildLogService, 100*time.Millisecond, runner, vcs)
+	worker := NewWorker("worker-1", mockBuildService, mockBuildLogService, 100*time.Millisecond, 0, runner, vcs, nil, nil)
 	err := worker.claimAndProcess(context.Background())
 
 	assert.ErrorIs(t, err, expectedErr)
@@ -158,29 +228,51 @@ func TestWorker_ClaimAndProcess_NoBuilds(t *testing.T) {
 	runner := &stubRunner{exitCode: 0, runErr: nil}
 	vcs := &stubVCS{err: nil}
 
-	worker := NewWorker("worker-1", mockBuildService, mockBuildLogService, 100*time.Millisecond, runner, vcs)
+	worker := NewWorker("worker-1", mockBuildService, mockBuildLogService, 100*time.Millisecond, 0, runner, vcs, nil, nil)
 	err := worker.claimAndProcess(context.Background())
 
 	assert.NoError(t, err)
 	mockBuildService.AssertCalled(t, "ClaimNext", mock.Anything, "worker-1")
 }
 
-func TestWorker_ClaimAndProcess_CompleteBuildError(t *testing.T) {
+func TestWorker_ClaimAndProcess_CompleteJobError(t *testing.T) {
 	mockBuildService := new(mockBuildService)
 	expectedErr := errors.New("db error")
-	mockBuildService.On("ClaimNext", mock.Anything, "worker-1").Return(buildTestData(), nil)
-	mockBuildService.On("CompleteBuild", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(expectedErr)
+	mockBuildService.On("ClaimNext", mock.Anything, "worker-1").Return(jobTestData(), nil)
+	mockBuildService.On("CompleteJob", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(expectedErr)
 
 	mockBuildLogService := new(mockBuildLogService)
 	runner := &stubRunner{exitCode: 0, runErr: nil}
 	vcs := &stubVCS{err: nil}
 
-	worker := NewWorker("worker-1", mockBuildService, mockBuildLogService, 100*time.Millisecond, runner, vcs)
+	worker := NewWorker("worker-1", mockBuildService, mockBuildLogService, 100*time.Millisecond, 0, runner, vcs, nil, nil)
 	err := worker.claimAndProcess(context.Background())
 
 	assert.ErrorIs(t, err, expectedErr)
 	mockBuildService.AssertCalled(t, "ClaimNext", mock.Anything, "worker-1")
-	mockBuildService.AssertCalled(t, "CompleteBuild", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	mockBuildService.AssertCalled(t, "CompleteJob", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestWorker_Execute_RunsWithoutBuildService(t *testing.T) {
+	mockBuildLogService := new(mockBuildLogService)
+	mockBuildLogService.On("AppendLogs", mock.Anything, "ci-id", "job-id", mock.Anything).Return(nil)
+
+	runner := &stubRunner{exitCode: 3, events: []domain.LogEvent{{Stream: domain.LogStdout, Line: "hello", Time: time.Now()}}}
+
+	worker := NewWorker("local", nil, mockBuildLogService, 0, 0, runner, &stubVCS{}, nil, nil)
+	exitCode, err := worker.Execute(context.Background(), jobTestData())
+
+	assert.NoError(t, err)
+	assert.Equal(t, 3, exitCode)
+	mockBuildLogService.AssertNumberOfCalls(t, "AppendLogs", 1)
+}
+
+func TestWorker_Execute_VCSError(t *testing.T) {
+	worker := NewWorker("local", nil, new(mockBuildLogService), 0, 0, &stubRunner{}, &stubVCS{err: errors.New("no such ref")}, nil, nil)
+	exitCode, err := worker.Execute(context.Background(), jobTestData())
+
+	assert.ErrorContains(t, err, "checkout repo: no such ref")
+	assert.Equal(t, -1, exitCode)
 }
 
 func TestWorker_Run_ExitsOnContextCancel(t *testing.T) {
@@ -190,7 +282,7 @@ func TestWorker_Run_ExitsOnContextCancel(t *testing.T) {
 	runner := &stubRunner{exitCode: 0, runErr: nil}
 	vcs := &stubVCS{err: nil}
 
-	worker := NewWorker("worker-1", mockBuildService, mockBuildLogService, 100*time.Millisecond, runner, vcs)
+	worker := NewWorker("worker-1", mockBuildService, mockBuildLogService, 100*time.Millisecond, 0, runner, vcs, nil, nil)
 	ctx, cancel := context.WithTimeout(context.Background(), 250*time.Millisecond)
 	defer cancel()
 
@@ -198,13 +290,102 @@ func TestWorker_Run_ExitsOnContextCancel(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestWorker_Run_ClaimsOnWake(t *testing.T) {
+	claimed := make(chan struct{}, 1)
+	mockBuildService := new(mockBuildService)
+	mockBuildService.On("ClaimNext", mock.Anything, "worker-1").Return(nil, nil).Run(func(args mock.Arguments) {
+		claimed <- struct{}{}
+	})
+
+	worker := NewWorker("worker-1", mockBuildService, new(mockBuildLogService), time.Hour, 0, &stubRunner{}, &stubVCS{}, nil, nil)
+	wake := make(chan struct{}, 1)
+	worker.WakeOn(wake, nil, 0)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() { _ = worker.Run(ctx) }()
+
+	wake <- struct{}{}
+	select {
+	case <-claimed:
+	case <-time.After(time.Second):
+		t.Fatal("worker did not claim after wake-up")
+	}
+}
+
+func TestWorker_PollIntervalFollowsNotifications(t *testing.T) {
+	var connected atomic.Bool
+	worker := NewWorker("worker-1", new(mockBuildService), new(mockBuildLogService), 2*time.Second, 0, &stubRunner{}, &stubVCS{}, nil, nil)
+	worker.WakeOn(make(chan struct{}), connected.Load, 30*time.Second)
+
+	assert.Equal(t, 2*time.Second, worker.pollInterval(), "reconnecting")
+	connected.Store(true)
+	assert.Equal(t, 30*time.Second, worker.pollInterval(), "listening")
+}
+
+// stubWorkerService answers every worker heartbeat with state.
+type stubWorkerService struct {
+	state      domain.WorkerState
+	heartbeats atomic.Int32
+}
+
+func (s *stubWorkerService) Register(context.Context, *domain.Worker) error { return nil }
+
+func (s *stubWorkerService) Heartbeat(_ context.Context, workerId string) (*domain.Worker, error) {
+	s.heartbeats.Add(1)
+	return &domain.Worker{ID: workerId, State: s.state}, nil
+}
+
+func (s *stubWorkerService) Deregister(context.Context, string) error { return nil }
+
+func (s *stubWorkerService) ListWorkers(context.Context) ([]domain.Worker, error) { return nil, nil }
+
+func (s *stubWorkerService) SetState(context.Context, string, domain.WorkerState) (*domain.Worker, error) {
+	return nil, nil
+}
+
+func TestWorker_Run_ReturnsWhenDrained(t *testing.T) {
+	mockBuildService := new(mockBuildService)
+	mockBuildService.On("ClaimNext", mock.Anything, "worker-1").Return(nil, nil).Maybe()
+
+	worker := NewWorker("worker-1", mockBuildService, new(mockBuildLogService), 5*time.Millisecond, 5*time.Millisecond, &stubRunner{}, &stubVCS{}, nil, nil)
+	worker.ReportTo(&stubWorkerService{state: domain.WorkerStateDraining})
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	assert.NoError(t, worker.Run(ctx))
+}
+
+func TestWorker_Run_StopsClaimingWhilePaused(t *testing.T) {
+	var claims atomic.Int32
+	mockBuildService := new(mockBuildService)
+	mockBuildService.On("ClaimNext", mock.Anything, "worker-1").Return(nil, nil).Run(func(mock.Arguments) {
+		claims.Add(1)
+	}).Maybe()
+
+	workerService := &stubWorkerService{state: domain.WorkerStatePaused}
+	worker := NewWorker("worker-1", mockBuildService, new(mockBuildLogService), 5*time.Millisecond, time.Millisecond, &stubRunner{}, &stubVCS{}, nil, nil)
+	worker.ReportTo(workerService)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() { _ = worker.Run(ctx) }()
+
+	assert.Eventually(t, func() bool { return workerService.heartbeats.Load() > 0 }, time.Second, time.Millisecond)
+	before := claims.Load()
+	time.Sleep(50 * time.Millisecond)
+	assert.LessOrEqual(t, claims.Load(), before+1, "at most a claim already under way")
+}
+
 func TestWorker_ClaimAndProcess_RunnerStartError(t *testing.T) {
 	expectedErr := errors.New("start runner")
 	mockBuildService := new(mockBuildService)
-	mockBuildService.On("ClaimNext", mock.Anything, "worker-1").Return(buildTestData(), nil)
-	mockBuildService.On("CompleteBuild",
+	mockBuildService.On("ClaimNext", mock.Anything, "worker-1").Return(jobTestData(), nil)
+	mockBuildService.On("CompleteJob",
 		mock.Anything,
-		"ci-id",
+		"job-id",
+		"worker-1",
 		-1,
 		mock.Anything,
 		mock.MatchedBy(func(err error) bool {
@@ -216,7 +397,7 @@ func TestWorker_ClaimAndProcess_RunnerStartError(t *testing.T) {
 	runner := &stubRunnerWithError{startErr: expectedErr}
 	vcs := &stubVCS{err: nil}
 
-	worker := NewWorker("worker-1", mockBuildService, mockBuildLogService, 100*time.Millisecond, runner, vcs)
+	worker := NewWorker("worker-1", mockBuildService, mockBuildLogService, 100*time.Millisecond, 0, runner, vcs, nil, nil)
 	err := worker.claimAndProcess(context.Background())
 
 	assert.NoError(t, err)
@@ -225,32 +406,33 @@ func TestWorker_ClaimAndProcess_RunnerStartError(t *testing.T) {
 func TestWorker_ClaimAndProcess_RunnerExitError(t *testing.T) {
 	expectedErr := errors.New("exec error")
 	mockBuildService := new(mockBuildService)
-	mockBuildService.On("ClaimNext", mock.Anything, "worker-1").Return(buildTestData(), nil)
-	mockBuildService.On("CompleteBuild", mock.Anything, "ci-id", 1, mock.Anything, mock.MatchedBy(func(err error) bool {
+	mockBuildService.On("ClaimNext", mock.Anything, "worker-1").Return(jobTestData(), nil)
+	mockBuildService.On("CompleteJob", mock.Anything, "job-id", "worker-1", 1, mock.Anything, mock.MatchedBy(func(err error) bool {
 		return err != nil
 	})).Return(nil)
 
 	mockBuildLogService := new(mockBuildLogService)
-	mockBuildLogService.On("AppendLog", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	mockBuildLogService.On("AppendLog", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
 
 	runner := &stubRunner{exitCode: 1, runErr: expectedErr, events: []domain.LogEvent{}}
 	vcs := &stubVCS{err: nil}
 
-	worker := NewWorker("worker-1", mockBuildService, mockBuildLogService, 100*time.Millisecond, runner, vcs)
+	worker := NewWorker("worker-1", mockBuildService, mockBuildLogService, 100*time.Millisecond, 0, runner, vcs, nil, nil)
 	err := worker.claimAndProcess(context.Background())
 
 	assert.NoError(t, err)
-	mockBuildService.AssertCalled(t, "CompleteBuild", mock.Anything, "ci-id", 1, mock.Anything, expectedErr)
+	mockBuildService.AssertCalled(t, "CompleteJob", mock.Anything, "job-id", "worker-1", 1, mock.Anything, expectedErr)
 }
 
 func TestWorker_ClaimAndProcess_VCSError(t *testing.T) {
 	expectedErr := errors.New("checkout failed")
 
 	mockBuildService := new(mockBuildService)
-	mockBuildService.On("ClaimNext", mock.Anything, "worker-1").Return(buildTestData(), nil)
-	mockBuildService.On("CompleteBuild",
+	mockBuildService.On("ClaimNext", mock.Anything, "worker-1").Return(jobTestData(), nil)
+	mockBuildService.On("CompleteJob",
 		mock.Anything,
-		"ci-id",
+		"job-id",
+		"worker-1",
 		-1,
 		mock.Anything,
 		mock.MatchedBy(func(err error) bool {
@@ -262,8 +444,95 @@ func TestWorker_ClaimAndProcess_VCSError(t *testing.T) {
 	runner := &stubRunner{exitCode: 0, runErr: nil}
 	vcs := &stubVCS{err: expectedErr}
 
-	worker := NewWorker("worker-1", mockBuildService, mockBuildLogService, 100*time.Millisecond, runner, vcs)
+	worker := NewWorker("worker-1", mockBuildService, mockBuildLogService, 100*time.Millisecond, 0, runner, vcs, nil, nil)
 	err := worker.claimAndProcess(context.Background())
 
 	assert.NoError(t, err)
 }
+
+func TestWorker_ClaimAndProcess_BatchesLogs(t *testing.T) {
+	mockBuildService := new(mockBuildService)
+	mockBuildService.On("ClaimNext", mock.Anything, "worker-1").Return(jobTestData(), nil)
+	mockBuildService.On("CompleteJob", mock.Anything, "job-id", "worker-1", 0, mock.Anything, nil).Return(nil)
+
+	var received int
+	mockBuildLogService := new(mockBuildLogService)
+	mockBuildLogService.On("AppendLogs", mock.Anything, "ci-id", "job-id", mock.Anything).Run(func(args mock.Arguments) {
+		batch := args.Get(3).([]domain.LogEvent)
+		assert.LessOrEqual(t, len(batch), maxLogBatch)
+		received += len(batch)
+	}).Return(nil)
+
+	events := make([]domain.LogEvent, 250)
+	for i := range events {
+		events[i] = domain.LogEvent{Stream: domain.LogStdout, Line: "line"}
+	}
+
+	worker := NewWorker("worker-1", mockBuildService, mockBuildLogService, 100*time.Millisecond, 0, &stubRunner{events: events}, &stubVCS{}, nil, nil)
+	err := worker.claimAndProcess(context.Background())
+
+	assert.NoError(t, err)
+	assert.Equal(t, 250, received)
+	mockBuildLogService.AssertNumberOfCalls(t, "AppendLogs", 3)
+}
+
+// blockingRunner runs until its context is canceled.
+type blockingRunner struct{}
+
+func (r *blockingRunner) Start(ctx context.Context, _, _ string, _ []string) (<-chan domain.LogEvent, func() (int, error), error) {
+	ch := make(chan domain.LogEvent)
+	close(ch)
+
+	waitFn := func() (int, error) {
+		<-ctx.Done()
+		return -1, ctx.Err()
+	}
+
+	return ch, waitFn, nil
+}
+
+func TestWorker_ClaimAndProcess_HeartbeatsAndStopsOnCancel(t *testing.T) {
+	mockBuildService := new(mockBuildService)
+	mockBuildService.On("ClaimNext", mock.Anything, "worker-1").Return(jobTestData(), nil)
+	mockBuildService.On("Heartbeat", mock.Anything, "job-id", "worker-1").Return(nil)
+	mockBuildService.On("CancelRequested", mock.Anything, "job-id").Return(false, nil).Once()
+	mockBuildService.On("CancelRequested", mock.Anything, "job-id").Return(true, nil)
+	mockBuildService.On("CompleteJob", mock.Anything, "job-id", "worker-1", -1, mock.Anything, domain.ErrJobCanceled).Return(nil)
+
+	worker := NewWorker("worker-1", mockBuildService, new(mockBuildLogService), 100*time.Millisecond, 10*time.Millisecond, &blockingRunner{}, &stubVCS{}, nil, nil)
+
+	done := make(chan error, 1)
+	go func() { done <- worker.claimAndProcess(context.Background()) }()
+
+	select {
+	case err := <-done:
+		assert.NoError(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("job was not canceled")
+	}
+
+	mockBuildService.AssertExpectations(t)
+	mockBuildService.AssertNumberOfCalls(t, "Heartbeat", 2)
+}
+
+func TestWorker_ClaimAndProcess_StopsWhenLeaseIsLost(t *testing.T) {
+	mockBuildService := new(mockBuildService)
+	mockBuildService.On("ClaimNext", mock.Anything, "worker-1").Return(jobTestData(), nil)
+	mockBuildService.On("Heartbeat", mock.Anything, "job-id", "worker-1").Return(domain.ErrJobNotFound)
+	mockBuildService.On("CompleteJob", mock.Anything, "job-id", "worker-1", -1, mock.Anything, mock.Anything).Return(domain.ErrJobLockLost)
+
+	worker := NewWorker("worker-1", mockBuildService, new(mockBuildLogService), 100*time.Millisecond, 10*time.Millisecond, &blockingRunner{}, &stubVCS{}, nil, nil)
+
+	done := make(chan error, 1)
+	go func() { done <- worker.claimAndProcess(context.Background()) }()
+
+	select {
+	case err := <-done:
+		assert.ErrorIs(t, err, domain.ErrJobLockLost)
+	case <-time.After(5 * time.Second):
+		t.Fatal("job kept running after its lease was lost")
+	}
+
+	mockBuildService.AssertNotCalled(t, "CancelRequested", mock.Anything, mock.Anything)
+	mockBuildService.AssertNumberOfCalls(t, "Heartbeat", 1)
+}
@@ -0,0 +1,65 @@
+package workerapi
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/H3nSte1n/ci-orchestrator/internal/core/domain"
+	"github.com/H3nSte1n/ci-orchestrator/internal/core/ports"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+type artifactService struct {
+	client *Client
+}
+
+// NewArtifactService returns an ArtifactService that uploads artifacts
+// through the worker API, so workers need no storage credentials. Reading
+// artifacts is left to the public API.
+func NewArtifactService(client *Client) ports.ArtifactService {
+	return &artifactService{client: client}
+}
+
+func (s *artifactService) Store(ctx context.Context, job *domain.Job, name string, r io.Reader, size int64) (*domain.Artifact, error) {
+	segments := strings.Split(name, "/")
+	for i, segment := range segments {
+		segments[i] = url.PathEscape(segment)
+	}
+
+	req, err := s.client.newRequest(ctx, http.MethodPut, jobPath(job.ID, "/artifacts/"+strings.Join(segments, "/")), r)
+	if err != nil {
+		return nil, err
+	}
+	req.ContentLength = size
+	req.Header.Set("Content-Type", "application/octet-stream")
+
+	resp, err := s.client.do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var artifact domain.Artifact
+	if err := json.NewDecoder(resp.Body).Decode(&artifact); err != nil {
+		return nil, err
+	}
+	return &artifact, nil
+}
+
+func (s *artifactService) List(context.Context, string) ([]domain.Artifact, error) {
+	return nil, ErrUnsupported
+}
+
+func (s *artifactService) Get(context.Context, string, string) (*domain.Artifact, error) {
+	return nil, ErrUnsupported
+}
+
+func (s *artifactService) Open(context.Context, *domain.Artifact) (io.ReadSeekCloser, error) {
+	return nil, ErrUnsupported
+}
+
+func (s *artifactService) WriteArchive(context.Context, string, domain.ArchiveFormat, io.Writer) error {
+	return ErrUnsupported
+}
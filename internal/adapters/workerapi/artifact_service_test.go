@@ -0,0 +1,45 @@
+package workerapi
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/H3nSte1n/ci-orchestrator/internal/core/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestArtifactService_Store(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPut, r.Method)
+		assert.Equal(t, "/internal/v1/jobs/job-id/artifacts/dist/my app.txt", r.URL.Path)
+		assert.Equal(t, int64(6), r.ContentLength)
+
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		assert.Equal(t, "result", string(body))
+
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"id":"artifact-id","name":"dist/my app.txt","size":6}`))
+	})
+
+	artifact, err := NewArtifactService(client).Store(context.Background(), &domain.Job{ID: "job-id"}, "dist/my app.txt", strings.NewReader("result"), 6)
+
+	require.NoError(t, err)
+	assert.Equal(t, "artifact-id", artifact.ID)
+	assert.Equal(t, int64(6), artifact.Size)
+}
+
+func TestArtifactService_Store_Error(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"error":{"code":"not_found","message":"job not found"}}`))
+	})
+
+	_, err := NewArtifactService(client).Store(context.Background(), &domain.Job{ID: "job-id"}, "out.txt", strings.NewReader("x"), 1)
+
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+}
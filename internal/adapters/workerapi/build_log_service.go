@@ -0,0 +1,39 @@
+package workerapi
+
+import (
+	"context"
+	"github.com/H3nSte1n/ci-orchestrator/internal/core/domain"
+	"github.com/H3nSte1n/ci-orchestrator/internal/core/ports"
+	"net/http"
+)
+
+type buildLogService struct {
+	client *Client
+}
+
+// NewBuildLogService returns a BuildLogService backed by the worker API. The
+// server derives the build from the job, so buildId is not sent.
+func NewBuildLogService(client *Client) ports.BuildLogService {
+	return &buildLogService{client: client}
+}
+
+func (s *buildLogService) AppendLog(ctx context.Context, buildId string, jobId string, logEvent domain.LogEvent) error {
+	return s.AppendLogs(ctx, buildId, jobId, []domain.LogEvent{logEvent})
+}
+
+func (s *buildLogService) AppendLogs(ctx context.Context, _ string, jobId string, logEvents []domain.LogEvent) error {
+	if len(logEvents) == 0 {
+		return nil
+	}
+
+	req := struct {
+		Events []domain.LogEvent `json:"events"`
+	}{Events: logEvents}
+
+	_, err := s.client.doJSON(ctx, http.MethodPost, jobPath(jobId, "/logs"), req, nil)
+	return err
+}
+
+func (s *buildLogService) ListLogs(context.Context, string, int64, int) ([]domain.BuildLog, error) {
+	return nil, ErrUnsupported
+}
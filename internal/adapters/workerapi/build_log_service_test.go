@@ -0,0 +1,45 @@
+package workerapi
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/H3nSte1n/ci-orchestrator/internal/core/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildLogService_AppendLogs(t *testing.T) {
+	var received []domain.LogEvent
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/internal/v1/jobs/job-id/logs", r.URL.Path)
+
+		var body struct {
+			Events []domain.LogEvent `json:"events"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		received = append(received, body.Events...)
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	svc := NewBuildLogService(client)
+	require.NoError(t, svc.AppendLogs(context.Background(), "ci-id", "job-id", []domain.LogEvent{
+		{Stream: domain.LogStdout, Line: "one"},
+		{Stream: domain.LogStderr, Line: "two"},
+	}))
+	require.NoError(t, svc.AppendLog(context.Background(), "ci-id", "job-id", domain.LogEvent{Stream: domain.LogStdout, Line: "three"}))
+
+	require.Len(t, received, 3)
+	assert.Equal(t, domain.LogStderr, received[1].Stream)
+	assert.Equal(t, "three", received[2].Line)
+}
+
+func TestBuildLogService_AppendLogs_EmptyBatch(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		t.Error("no request expected for an empty batch")
+	})
+
+	assert.NoError(t, NewBuildLogService(client).AppendLogs(context.Background(), "ci-id", "job-id", nil))
+}
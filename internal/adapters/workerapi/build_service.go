@@ -0,0 +1,103 @@
+package workerapi
+
+import (
+	"context"
+	"errors"
+	"github.com/H3nSte1n/ci-orchestrator/internal/core/domain"
+	"github.com/H3nSte1n/ci-orchestrator/internal/core/ports"
+	"net/http"
+	"time"
+)
+
+type buildService struct {
+	client *Client
+}
+
+// NewBuildService returns a BuildService backed by the worker API. Only the
+// operations a worker needs are available; the worker id is taken from the
+// client's token by the server.
+func NewBuildService(client *Client) ports.BuildService {
+	return &buildService{client: client}
+}
+
+type claimedJob struct {
+	Job   *domain.Job   `json:"job"`
+	Build *domain.Build `json:"build"`
+}
+
+func (s *buildService) ClaimNext(ctx context.Context, _ string) (*domain.Job, error) {
+	var claimed claimedJob
+	found, err := s.client.doJSON(ctx, http.MethodPost, "/jobs/claim", nil, &claimed)
+	if err != nil || !found || claimed.Job == nil {
+		return nil, err
+	}
+
+	claimed.Job.Build = claimed.Build
+	return claimed.Job, nil
+}
+
+func (s *buildService) Heartbeat(ctx context.Context, jobId string, _ string) error {
+	_, err := s.client.doJSON(ctx, http.MethodPost, jobPath(jobId, "/heartbeat"), nil, nil)
+	return err
+}
+
+func (s *buildService) CancelRequested(ctx context.Context, jobId string) (bool, error) {
+	var out struct {
+		CancelRequested bool `json:"cancel_requested"`
+	}
+	if _, err := s.client.doJSON(ctx, http.MethodGet, jobPath(jobId, "/cancel"), nil, &out); err != nil {
+		return false, err
+	}
+	return out.CancelRequested, nil
+}
+
+func (s *buildService) CompleteJob(ctx context.Context, jobId string, _ string, exitCode int, finishedAt *time.Time, error error) error {
+	req := struct {
+		ExitCode   int        `json:"exit_code"`
+		FinishedAt *time.Time `json:"finished_at"`
+		Error      string     `json:"error,omitempty"`
+		Canceled   bool       `json:"canceled,omitempty"`
+	}{ExitCode: exitCode, FinishedAt: finishedAt, Canceled: errors.Is(error, domain.ErrJobCanceled)}
+	if error != nil {
+		req.Error = error.Error()
+	}
+
+	_, err := s.client.doJSON(ctx, http.MethodPost, jobPath(jobId, "/complete"), req, nil)
+	return err
+}
+
+func (s *buildService) RequeueExpired(context.Context, time.Duration) (int, error) {
+	return 0, ErrUnsupported
+}
+
+func (s *buildService) CreateBuild(context.Context, *domain.Build) error {
+	return ErrUnsupported
+}
+
+func (s *buildService) RetryBuild(context.Context, string, *string) (*domain.Build, error) {
+	return nil, ErrUnsupported
+}
+
+func (s *buildService) CancelBuild(context.Context, string) error {
+	return ErrUnsupported
+}
+
+func (s *buildService) UpdateStatus(context.Context, string, domain.BuildStatus) error {
+	return ErrUnsupported
+}
+
+func (s *buildService) UpdatePriority(context.Context, string, int) (*domain.Build, error) {
+	return nil, ErrUnsupported
+}
+
+func (s *buildService) GetBuild(context.Context, string) (*domain.Build, error) {
+	return nil, ErrUnsupported
+}
+
+func (s *buildService) ListBuilds(context.Context, domain.BuildFilter) (*domain.BuildPage, error) {
+	return nil, ErrUnsupported
+}
+
+func (s *buildService) GetJob(context.Context, string) (*domain.Job, error) {
+	return nil, ErrUnsupported
+}
@@ -0,0 +1,102 @@
+package workerapi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/H3nSte1n/ci-orchestrator/internal/core/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildService_ClaimNext(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "/internal/v1/jobs/claim", r.URL.Path)
+		_, _ = w.Write([]byte(`{"job":{"id":"job-id","build_id":"ci-id","command":"make"},"build":{"id":"ci-id","repo_url":"https://github.com/test/repo","env":{"CI":"true"}}}`))
+	})
+
+	job, err := NewBuildService(client).ClaimNext(context.Background(), "ignored")
+
+	require.NoError(t, err)
+	assert.Equal(t, "job-id", job.ID)
+	require.NotNil(t, job.Build)
+	assert.Equal(t, "https://github.com/test/repo", job.Build.RepoUrl)
+	assert.Equal(t, domain.StringMap{"CI": "true"}, job.Build.Env)
+}
+
+func TestBuildService_ClaimNext_NothingQueued(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	job, err := NewBuildService(client).ClaimNext(context.Background(), "ignored")
+
+	assert.NoError(t, err)
+	assert.Nil(t, job)
+}
+
+func TestBuildService_Heartbeat(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/internal/v1/jobs/job-id/heartbeat", r.URL.Path)
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	assert.NoError(t, NewBuildService(client).Heartbeat(context.Background(), "job-id", "ignored"))
+}
+
+func TestBuildService_CancelRequested(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodGet, r.Method)
+		assert.Equal(t, "/internal/v1/jobs/job-id/cancel", r.URL.Path)
+		_, _ = w.Write([]byte(`{"cancel_requested":true}`))
+	})
+
+	requested, err := NewBuildService(client).CancelRequested(context.Background(), "job-id")
+
+	require.NoError(t, err)
+	assert.True(t, requested)
+}
+
+func TestBuildService_CompleteJob(t *testing.T) {
+	finishedAt := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/internal/v1/jobs/job-id/complete", r.URL.Path)
+
+		var body map[string]interface{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		assert.Equal(t, float64(2), body["exit_code"])
+		assert.Equal(t, "2025-01-01T00:00:00Z", body["finished_at"])
+		assert.Equal(t, "exit status 2", body["error"])
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	err := NewBuildService(client).CompleteJob(context.Background(), "job-id", "worker-1", 2, &finishedAt, errors.New("exit status 2"))
+
+	assert.NoError(t, err)
+}
+
+func TestBuildService_CompleteJob_Canceled(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]interface{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		assert.Equal(t, true, body["canceled"])
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	err := NewBuildService(client).CompleteJob(context.Background(), "job-id", "worker-1", -1, nil, domain.ErrJobCanceled)
+
+	assert.NoError(t, err)
+}
+
+func TestBuildService_UnsupportedOperations(t *testing.T) {
+	svc := NewBuildService(NewClient("http://localhost", "token"))
+
+	assert.ErrorIs(t, svc.CreateBuild(context.Background(), &domain.Build{}), ErrUnsupported)
+	_, err := svc.GetBuild(context.Background(), "ci-id")
+	assert.ErrorIs(t, err, ErrUnsupported)
+}
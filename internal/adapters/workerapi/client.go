@@ -0,0 +1,135 @@
+package workerapi
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/H3nSte1n/ci-orchestrator/internal/core/domain"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// ErrUnsupported is returned by operations the worker API does not offer.
+var ErrUnsupported = errors.New("not supported by the worker API")
+
+// requestTimeout bounds JSON calls; artifact uploads are only bounded by
+// their context.
+const requestTimeout = 30 * time.Second
+
+// Client talks to the internal worker API of the API server.
+type Client struct {
+	baseURL    string
+	token      string
+	httpClient *http.Client
+}
+
+func NewClient(baseURL string, token string) *Client {
+	return &Client{
+		baseURL:    strings.TrimRight(baseURL, "/") + "/internal/v1",
+		token:      token,
+		httpClient: &http.Client{},
+	}
+}
+
+// doJSON sends in as a JSON body (if not nil) and decodes the response into
+// out (if not nil). It reports whether the server answered with content.
+func (c *Client) doJSON(ctx context.Context, method string, path string, in interface{}, out interface{}) (bool, error) {
+	ctx, cancel := context.WithTimeout(ctx, requestTimeout)
+	defer cancel()
+
+	var body io.Reader
+	if in != nil {
+		b, err := json.Marshal(in)
+		if err != nil {
+			return false, err
+		}
+		body = bytes.NewReader(b)
+	}
+
+	req, err := c.newRequest(ctx, method, path, body)
+	if err != nil {
+		return false, err
+	}
+	if in != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.do(req)
+	if err != nil {
+		return false, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNoContent || out == nil {
+		return resp.StatusCode != http.StatusNoContent, nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return false, fmt.Errorf("decode %s %s: %w", method, path, err)
+	}
+	return true, nil
+}
+
+func (c *Client) newRequest(ctx context.Context, method string, path string, body io.Reader) (*http.Request, error) {
+	req, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, body)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", "Bearer "+c.token)
+	return req, nil
+}
+
+// do sends req and turns error responses into errors.
+func (c *Client) do(req *http.Request) (*http.Response, error) {
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		defer resp.Body.Close()
+		return nil, decodeError(resp)
+	}
+	return resp, nil
+}
+
+type errorEnvelope struct {
+	Error struct {
+		Code    string `json:"code"`
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+// decodeError maps the server's error envelope back onto domain error kinds
+// so that callers can match them with errors.Is.
+func decodeError(resp *http.Response) error {
+	var envelope errorEnvelope
+	b, _ := io.ReadAll(io.LimitReader(resp.Body, 64<<10))
+	if err := json.Unmarshal(b, &envelope); err != nil || envelope.Error.Message == "" {
+		return fmt.Errorf("worker api: %s", resp.Status)
+	}
+
+	message := envelope.Error.Message
+	switch envelope.Error.Code {
+	case "invalid_argument":
+		return domain.NewError(domain.ErrInvalidArgument, message)
+	case "not_found":
+		return domain.NewError(domain.ErrNotFound, message)
+	case "conflict":
+		return domain.NewError(domain.ErrConflict, message)
+	case "unauthenticated":
+		return domain.NewError(domain.ErrUnauthenticated, message)
+	case "permission_denied":
+		return domain.NewError(domain.ErrPermissionDenied, message)
+	default:
+		return fmt.Errorf("worker api: %s: %s", resp.Status, message)
+	}
+}
+
+func jobPath(jobId string, suffix string) string {
+	return "/jobs/" + url.PathEscape(jobId) + suffix
+}
@@ -0,0 +1,69 @@
+package workerapi
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/H3nSte1n/ci-orchestrator/internal/core/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestClient starts a server with handler and returns a client for it.
+func newTestClient(t *testing.T, handler http.HandlerFunc) *Client {
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	return NewClient(server.URL+"/", "cio_secret")
+}
+
+func TestClient_SendsTokenAndPrefix(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer cio_secret", r.Header.Get("Authorization"))
+		assert.Equal(t, "/internal/v1/jobs/claim", r.URL.Path)
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	found, err := client.doJSON(context.Background(), http.MethodPost, "/jobs/claim", nil, &struct{}{})
+
+	require.NoError(t, err)
+	assert.False(t, found)
+}
+
+func TestClient_MapsErrorEnvelope(t *testing.T) {
+	tests := []struct {
+		status int
+		code   string
+		kind   error
+	}{
+		{http.StatusNotFound, "not_found", domain.ErrNotFound},
+		{http.StatusBadRequest, "invalid_argument", domain.ErrInvalidArgument},
+		{http.StatusUnauthorized, "unauthenticated", domain.ErrUnauthenticated},
+		{http.StatusForbidden, "permission_denied", domain.ErrPermissionDenied},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.code, func(t *testing.T) {
+			client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.status)
+				_, _ = w.Write([]byte(`{"error":{"code":"` + tt.code + `","message":"job not found"}}`))
+			})
+
+			_, err := client.doJSON(context.Background(), http.MethodGet, "/jobs/x/cancel", nil, nil)
+
+			assert.ErrorIs(t, err, tt.kind)
+			assert.EqualError(t, err, "job not found")
+		})
+	}
+}
+
+func TestClient_UnstructuredError(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "bad gateway", http.StatusBadGateway)
+	})
+
+	_, err := client.doJSON(context.Background(), http.MethodGet, "/jobs/x/cancel", nil, nil)
+
+	assert.EqualError(t, err, "worker api: 502 Bad Gateway")
+}
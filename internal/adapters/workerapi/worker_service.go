@@ -0,0 +1,51 @@
+package workerapi
+
+import (
+	"context"
+	"github.com/H3nSte1n/ci-orchestrator/internal/core/domain"
+	"github.com/H3nSte1n/ci-orchestrator/internal/core/ports"
+	"net/http"
+)
+
+type workerService struct {
+	client *Client
+}
+
+// NewWorkerService returns a WorkerService backed by the worker API. The
+// server registers the worker under the name of the client's token.
+func NewWorkerService(client *Client) ports.WorkerService {
+	return &workerService{client: client}
+}
+
+func (s *workerService) Register(ctx context.Context, worker *domain.Worker) error {
+	req := struct {
+		Hostname string   `json:"hostname"`
+		Version  string   `json:"version"`
+		Labels   []string `json:"labels"`
+		Slots    int      `json:"slots"`
+	}{Hostname: worker.Hostname, Version: worker.Version, Labels: worker.Labels, Slots: worker.Slots}
+
+	_, err := s.client.doJSON(ctx, http.MethodPost, "/workers/register", req, nil)
+	return err
+}
+
+func (s *workerService) Heartbeat(ctx context.Context, _ string) (*domain.Worker, error) {
+	var worker domain.Worker
+	if _, err := s.client.doJSON(ctx, http.MethodPost, "/workers/heartbeat", nil, &worker); err != nil {
+		return nil, err
+	}
+	return &worker, nil
+}
+
+func (s *workerService) Deregister(ctx context.Context, _ string) error {
+	_, err := s.client.doJSON(ctx, http.MethodPost, "/workers/deregister", nil, nil)
+	return err
+}
+
+func (s *workerService) ListWorkers(context.Context) ([]domain.Worker, error) {
+	return nil, ErrUnsupported
+}
+
+func (s *workerService) SetState(context.Context, string, domain.WorkerState) (*domain.Worker, error) {
+	return nil, ErrUnsupported
+}
@@ -0,0 +1,67 @@
+package workerapi
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/H3nSte1n/ci-orchestrator/internal/core/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWorkerService_Register(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "/internal/v1/workers/register", r.URL.Path)
+		var body struct {
+			Hostname string   `json:"hostname"`
+			Version  string   `json:"version"`
+			Labels   []string `json:"labels"`
+			Slots    int      `json:"slots"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		assert.Equal(t, "host-1", body.Hostname)
+		assert.Equal(t, "v1.2.0", body.Version)
+		assert.Equal(t, []string{"linux", "arm64"}, body.Labels)
+		assert.Equal(t, 1, body.Slots)
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	err := NewWorkerService(client).Register(context.Background(), &domain.Worker{
+		ID:       "ignored",
+		Hostname: "host-1",
+		Version:  "v1.2.0",
+		Labels:   domain.StringList{"linux", "arm64"},
+		Slots:    1,
+	})
+
+	assert.NoError(t, err)
+}
+
+func TestWorkerService_Heartbeat(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "/internal/v1/workers/heartbeat", r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"id":"worker-1","state":"paused"}`))
+	})
+
+	worker, err := NewWorkerService(client).Heartbeat(context.Background(), "ignored")
+
+	require.NoError(t, err)
+	assert.Equal(t, domain.WorkerStatePaused, worker.State)
+}
+
+func TestWorkerService_Deregister(t *testing.T) {
+	called := false
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		called = true
+		assert.Equal(t, "/internal/v1/workers/deregister", r.URL.Path)
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	require.NoError(t, NewWorkerService(client).Deregister(context.Background(), "ignored"))
+	assert.True(t, called)
+}
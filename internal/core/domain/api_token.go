@@ -0,0 +1,71 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+type Scope string
+
+const (
+	ScopeBuildsRead   Scope = "builds:read"
+	ScopeBuildsWrite  Scope = "builds:write"
+	ScopeBuildsCancel Scope = "builds:cancel"
+	ScopeAdmin        Scope = "admin"
+	// ScopeWorker grants the internal worker API; the token name is the
+	// worker id.
+	ScopeWorker Scope = "worker"
+)
+
+var knownScopes = map[Scope]bool{
+	ScopeBuildsRead:   true,
+	ScopeBuildsWrite:  true,
+	ScopeBuildsCancel: true,
+	ScopeAdmin:        true,
+	ScopeWorker:       true,
+}
+
+// APIToken authenticates API clients. Only the SHA-256 hash of the secret is
+// stored; Prefix keeps the first characters so tokens can be told apart. The
+// unique name identifies the token as the trigger of the builds it creates.
+type APIToken struct {
+	ID         string     `json:"id" gorm:"primaryKey;type:uuid;default:uuid_generate_v4()"`
+	Name       string     `json:"name" gorm:"not null;uniqueIndex"`
+	Prefix     string     `json:"prefix" gorm:"not null"`
+	TokenHash  string     `json:"-" gorm:"not null;uniqueIndex"`
+	Scopes     StringList `json:"scopes" gorm:"type:jsonb"`
+	LastUsedAt *time.Time `json:"last_used_at"`
+	RevokedAt  *time.Time `json:"revoked_at"`
+	CreatedAt  time.Time  `json:"created_at" gorm:"autoCreateTime"`
+}
+
+var (
+	ErrTokenNotFound     = NewError(ErrNotFound, "token not found")
+	ErrInvalidScope      = NewError(ErrInvalidArgument, "invalid scope")
+	ErrInvalidToken      = NewError(ErrUnauthenticated, "invalid or revoked token")
+	ErrMissingToken      = NewError(ErrUnauthenticated, "missing bearer token")
+	ErrInsufficientScope = NewError(ErrPermissionDenied, "token lacks the required scope")
+)
+
+// HasScope reports whether the token grants scope. The admin scope grants
+// every other scope.
+func (t *APIToken) HasScope(scope Scope) bool {
+	for _, s := range t.Scopes {
+		if Scope(s) == scope || Scope(s) == ScopeAdmin {
+			return true
+		}
+	}
+	return false
+}
+
+func ValidateScopes(scopes []string) error {
+	if len(scopes) == 0 {
+		return fmt.Errorf("%w: at least one scope is required", ErrInvalidScope)
+	}
+	for _, s := range scopes {
+		if !knownScopes[Scope(s)] {
+			return fmt.Errorf("%w: %q", ErrInvalidScope, s)
+		}
+	}
+	return nil
+}
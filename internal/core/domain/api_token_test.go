@@ -0,0 +1,30 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAPIToken_HasScope(t *testing.T) {
+	token := &APIToken{Scopes: StringList{"builds:read", "builds:cancel"}}
+
+	assert.True(t, token.HasScope(ScopeBuildsRead))
+	assert.True(t, token.HasScope(ScopeBuildsCancel))
+	assert.False(t, token.HasScope(ScopeBuildsWrite))
+	assert.False(t, token.HasScope(ScopeAdmin))
+}
+
+func TestAPIToken_AdminGrantsEveryScope(t *testing.T) {
+	token := &APIToken{Scopes: StringList{"admin"}}
+
+	assert.True(t, token.HasScope(ScopeBuildsRead))
+	assert.True(t, token.HasScope(ScopeBuildsWrite))
+	assert.True(t, token.HasScope(ScopeAdmin))
+}
+
+func TestValidateScopes(t *testing.T) {
+	assert.NoError(t, ValidateScopes([]string{"builds:read", "admin"}))
+	assert.ErrorIs(t, ValidateScopes(nil), ErrInvalidScope)
+	assert.ErrorIs(t, ValidateScopes([]string{"builds:delete"}), ErrInvalidScope)
+}
@@ -0,0 +1,27 @@
+package domain
+
+import "time"
+
+type Artifact struct {
+	ID          string    `json:"id" gorm:"primaryKey;type:uuid;default:uuid_generate_v4()"`
+	BuildID     string    `json:"build_id" gorm:"type:uuid;not null;index"`
+	JobID       string    `json:"job_id" gorm:"type:uuid;not null"`
+	Name        string    `json:"name" gorm:"not null"`
+	Size        int64     `json:"size"`
+	Checksum    string    `json:"checksum"`
+	ContentType string    `json:"content_type"`
+	StorageKey  string    `json:"-" gorm:"not null"`
+	CreatedAt   time.Time `json:"created_at" gorm:"autoCreateTime"`
+}
+
+type ArchiveFormat string
+
+const (
+	ArchiveZip   ArchiveFormat = "zip"
+	ArchiveTarGz ArchiveFormat = "tar.gz"
+)
+
+var (
+	ErrArtifactNotFound   = NewError(ErrNotFound, "artifact not found")
+	ErrUnsupportedArchive = NewError(ErrInvalidArgument, "unsupported archive format")
+)
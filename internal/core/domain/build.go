@@ -12,28 +12,106 @@ const (
 	BuildStatusCanceled BuildStatus = "canceled"
 )
 
+func (s BuildStatus) IsTerminal() bool {
+	switch s {
+	case BuildStatusSuccess, BuildStatusFailed, BuildStatusCanceled:
+		return true
+	}
+	return false
+}
+
 type Build struct {
-	ID                string      `json:"id" gorm:"primaryKey;type:uuid;default:uuid_generate_v4()"`
-	RepoUrl           string      `json:"repo_url" validate:"required,url"`
-	Ref               string      `json:"ref" validate:"required"`
-	Command           string      `json:"command" validate:"required"`
-	Status            BuildStatus `json:"status" gorm:"type:varchar(20);default:'pending'"`
-	FinishedAt        *time.Time  `json:"finished_at"`
-	Attempts          int         `json:"attempts" gorm:"default:0"`
-	LockedBy          *string     `json:"locked_by" gorm:"type:text"`
-	LockedAt          *time.Time  `json:"locked_at"`
-	CancelRequestedAt *time.Time  `json:"cancel_requested_at"`
-	CreatedAt         time.Time   `json:"created_at" gorm:"autoCreateTime"`
-	UpdatedAt         time.Time   `json:"updated_at" gorm:"autoUpdateTime"`
-	ExitCode          int         `json:"exit_code"`
-	Error             string      `json:"error"`
+	ID                string            `json:"id" gorm:"primaryKey;type:uuid;default:uuid_generate_v4()"`
+	RepoUrl           string            `json:"repo_url"`
+	Ref               string            `json:"ref"`
+	Command           string            `json:"command"`
+	Status            BuildStatus       `json:"status" gorm:"type:varchar(20);default:'pending'"`
+	Priority          int               `json:"priority" gorm:"not null;default:0"`
+	NotBefore         *time.Time        `json:"not_before"`
+	FinishedAt        *time.Time        `json:"finished_at"`
+	Attempts          int               `json:"attempts" gorm:"default:0"`
+	LockedBy          *string           `json:"locked_by" gorm:"type:text"`
+	LockedAt          *time.Time        `json:"locked_at"`
+	CancelRequestedAt *time.Time        `json:"cancel_requested_at"`
+	CreatedAt         time.Time         `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt         time.Time         `json:"updated_at" gorm:"autoUpdateTime"`
+	ExitCode          int               `json:"exit_code"`
+	Error             string            `json:"error"`
+	Env               StringMap         `json:"env" gorm:"type:jsonb"`
+	Artifacts         StringList        `json:"artifacts" gorm:"type:jsonb"`
+	Caches            CacheList         `json:"caches" gorm:"type:jsonb"`
+	RunsOn            StringList        `json:"runs_on" gorm:"type:jsonb"`
+	ConcurrencyGroup  *string           `json:"concurrency_group" gorm:"type:text"`
+	MaxConcurrency    int               `json:"max_concurrency" gorm:"not null;default:1"`
+	ConcurrencyPolicy ConcurrencyPolicy `json:"concurrency_policy" gorm:"type:text;not null;default:queue"`
+	TriggeredBy       *string           `json:"triggered_by" gorm:"type:text"`
+	ParentID          *string           `json:"parent_id" gorm:"type:uuid"`
+	Matrix            *Matrix           `json:"matrix,omitempty" gorm:"type:jsonb"`
+	MatrixValues      StringMap         `json:"matrix_values,omitempty" gorm:"type:jsonb"`
+	Jobs              []Job             `json:"jobs" gorm:"foreignKey:BuildID"`
+	Children          []Build           `json:"children,omitempty" gorm:"foreignKey:ParentID"`
+
+	// Unschedulable is set on pending builds whose runs_on no registered
+	// worker satisfies. It is computed when the build is read.
+	Unschedulable bool `json:"unschedulable,omitempty" gorm:"-"`
 }
 
 type BuildLog struct {
 	ID        string    `json:"id" gorm:"primaryKey;type:uuid;default:uuid_generate_v4()"`
 	BuildID   string    `json:"build_id" gorm:"type:uuid;not null;index"`
+	JobID     *string   `json:"job_id" gorm:"type:uuid"`
 	Stream    LogStream `json:"stream" gorm:"type:varchar(10);not null"`
-	Seq       int64     `json:"seq"`
+	Seq       int64     `json:"seq" gorm:"default:(-)"`
 	Content   string    `json:"content" gorm:"type:text;not null"`
 	CreatedAt time.Time `json:"created_at" gorm:"autoCreateTime"`
 }
+
+var ErrBuildNotFinished = NewError(ErrConflict, "build has not finished")
+
+// ErrBuildNotDue is returned when a queued job belongs to a build whose
+// not_before lies in the future.
+var ErrBuildNotDue = NewError(ErrConflict, "build is not due yet")
+
+// Due reports whether b may start at now.
+func (b *Build) Due(now time.Time) bool {
+	return b.NotBefore == nil || !b.NotBefore.After(now)
+}
+
+// Rerun returns a new build with the settings a client submitted for b.
+// Server managed fields and matrix children are left for CreateBuild to fill.
+func (b *Build) Rerun() *Build {
+	rerun := &Build{
+		RepoUrl:           b.RepoUrl,
+		Ref:               b.Ref,
+		Command:           b.Command,
+		Priority:          b.Priority,
+		Env:               b.Env,
+		Artifacts:         b.Artifacts,
+		Caches:            b.Caches,
+		RunsOn:            b.RunsOn,
+		Matrix:            b.Matrix,
+		ConcurrencyGroup:  b.ConcurrencyGroup,
+		MaxConcurrency:    b.MaxConcurrency,
+		ConcurrencyPolicy: b.ConcurrencyPolicy,
+	}
+	if b.Matrix == nil {
+		for _, job := range b.Jobs {
+			rerun.Jobs = append(rerun.Jobs, Job{
+				Name:      job.Name,
+				Command:   job.Command,
+				Needs:     job.Needs,
+				Artifacts: job.Artifacts,
+			})
+		}
+	}
+	return rerun
+}
+
+// RollUpChildren aggregates the statuses of a matrix build's children.
+func RollUpChildren(children []Build) (BuildStatus, bool) {
+	statuses := make([]JobStatus, len(children))
+	for i, child := range children {
+		statuses[i] = JobStatus(child.Status)
+	}
+	return aggregateStatuses(statuses)
+}
@@ -0,0 +1,66 @@
+package domain
+
+import (
+	"encoding/base64"
+	"strings"
+	"time"
+)
+
+const (
+	DefaultBuildPageSize = 20
+	MaxBuildPageSize     = 100
+)
+
+var ErrInvalidCursor = NewError(ErrInvalidArgument, "invalid cursor")
+
+// BuildFilter selects builds for listing. Zero values mean "no constraint".
+// Results are ordered newest first by (created_at, id).
+type BuildFilter struct {
+	Statuses       []BuildStatus
+	RepoUrl        string
+	Ref            string
+	LockedBy       string
+	CreatedAfter   *time.Time
+	CreatedBefore  *time.Time
+	FinishedAfter  *time.Time
+	FinishedBefore *time.Time
+	Query          string
+	After          *BuildCursor
+	Limit          int
+}
+
+// BuildCursor points at the last build of a page. Because ids break ties
+// between equal timestamps, paging is stable while new builds arrive.
+type BuildCursor struct {
+	CreatedAt time.Time
+	ID        string
+}
+
+type BuildPage struct {
+	Builds     []Build
+	NextCursor string
+}
+
+func (c BuildCursor) Encode() string {
+	raw := c.CreatedAt.UTC().Format(time.RFC3339Nano) + "|" + c.ID
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+func DecodeBuildCursor(s string) (*BuildCursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	createdAt, id, ok := strings.Cut(string(raw), "|")
+	if !ok || id == "" {
+		return nil, ErrInvalidCursor
+	}
+
+	t, err := time.Parse(time.RFC3339Nano, createdAt)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	return &BuildCursor{CreatedAt: t, ID: id}, nil
+}
@@ -0,0 +1,29 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildCursor_RoundTrip(t *testing.T) {
+	cursor := BuildCursor{
+		CreatedAt: time.Date(2025, 3, 4, 5, 6, 7, 123456000, time.UTC),
+		ID:        "6f1c2a9e-1b6f-4c55-9d3b-7b2f8a0d2c11",
+	}
+
+	decoded, err := DecodeBuildCursor(cursor.Encode())
+
+	require.NoError(t, err)
+	assert.True(t, cursor.CreatedAt.Equal(decoded.CreatedAt))
+	assert.Equal(t, cursor.ID, decoded.ID)
+}
+
+func TestDecodeBuildCursor_Invalid(t *testing.T) {
+	for _, value := range []string{"not base64!", "bm9waXBl", "MjAyNXxpZA"} {
+		_, err := DecodeBuildCursor(value)
+		assert.ErrorIs(t, err, ErrInvalidCursor, value)
+	}
+}
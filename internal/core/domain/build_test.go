@@ -0,0 +1,69 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuild_Rerun(t *testing.T) {
+	finished := time.Now()
+	worker := "worker-1"
+	group := "deploy-prod"
+	build := &Build{
+		ID:                "b1",
+		RepoUrl:           "https://github.com/test/repo",
+		Ref:               "main",
+		Command:           "make",
+		Status:            BuildStatusFailed,
+		FinishedAt:        &finished,
+		LockedBy:          &worker,
+		ExitCode:          2,
+		Env:               StringMap{"GOFLAGS": "-v"},
+		ConcurrencyGroup:  &group,
+		MaxConcurrency:    1,
+		ConcurrencyPolicy: ConcurrencyCancelPending,
+		Jobs: []Job{
+			{ID: "j1", BuildID: "b1", Name: "build", Command: "make", Status: JobStatusFailed, ExitCode: 2},
+			{ID: "j2", BuildID: "b1", Name: "test", Command: "make test", Needs: StringList{"build"}, Status: JobStatusSkipped},
+		},
+	}
+
+	rerun := build.Rerun()
+
+	assert.Empty(t, rerun.ID)
+	assert.Empty(t, rerun.Status)
+	assert.Nil(t, rerun.FinishedAt)
+	assert.Nil(t, rerun.LockedBy)
+	assert.Zero(t, rerun.ExitCode)
+	assert.Equal(t, build.Env, rerun.Env)
+	assert.Equal(t, build.ConcurrencyGroup, rerun.ConcurrencyGroup)
+	assert.Equal(t, ConcurrencyCancelPending, rerun.ConcurrencyPolicy)
+	require.Len(t, rerun.Jobs, 2)
+	assert.Equal(t, Job{Name: "test", Command: "make test", Needs: StringList{"build"}}, rerun.Jobs[1])
+}
+
+func TestBuild_RerunMatrix(t *testing.T) {
+	build := &Build{
+		RepoUrl:  "https://github.com/test/repo",
+		Matrix:   &Matrix{Axes: map[string][]string{"go": {"1.24", "1.25"}}},
+		Children: []Build{{ID: "c1"}, {ID: "c2"}},
+	}
+
+	rerun := build.Rerun()
+
+	assert.Equal(t, build.Matrix, rerun.Matrix)
+	assert.Empty(t, rerun.Children)
+	assert.Empty(t, rerun.Jobs)
+}
+
+func TestBuild_Due(t *testing.T) {
+	now := time.Now()
+	later := now.Add(time.Hour)
+
+	assert.True(t, (&Build{}).Due(now))
+	assert.True(t, (&Build{NotBefore: &now}).Due(now))
+	assert.False(t, (&Build{NotBefore: &later}).Due(now))
+}
@@ -0,0 +1,162 @@
+package domain
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+const (
+	MaxCommandLength = 16 * 1024
+	MaxRefLength     = 255
+)
+
+// allowedRepoSchemes excludes file:// and friends so builds cannot check out
+// paths from the worker's own filesystem.
+var allowedRepoSchemes = map[string]bool{"https": true, "ssh": true, "git": true}
+
+// scpLikeRepo matches the scp style shorthand for ssh, e.g. git@github.com:org/repo.git.
+var scpLikeRepo = regexp.MustCompile(`^[A-Za-z0-9._-]+@[A-Za-z0-9.-]+:[^/\\][^\\]*$`)
+
+var envName = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// FieldError describes why one field of a request is invalid.
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// ValidateNewBuild checks a build submitted by a client before it is
+// created and reports every invalid field.
+func ValidateNewBuild(build *Build) []FieldError {
+	var errs []FieldError
+	add := func(field, message string) {
+		errs = append(errs, FieldError{Field: field, Message: message})
+	}
+
+	if msg := validateRepoUrl(build.RepoUrl); msg != "" {
+		add("repo_url", msg)
+	}
+	if msg := validateRef(build.Ref); msg != "" {
+		add("ref", msg)
+	}
+
+	if len(build.Jobs) == 0 && strings.TrimSpace(build.Command) == "" {
+		add("command", "is required unless jobs are given")
+	}
+	if len(build.Command) > MaxCommandLength {
+		add("command", fmt.Sprintf("must be at most %d bytes", MaxCommandLength))
+	}
+
+	if msg := validatePriority(build.Priority); msg != "" {
+		add("priority", msg)
+	}
+
+	for name := range build.Env {
+		if !envName.MatchString(name) {
+			add("env."+name, "is not a valid environment variable name")
+		}
+	}
+
+	for i, pattern := range build.Artifacts {
+		if msg := validateArtifactPattern(pattern); msg != "" {
+			add(fmt.Sprintf("artifacts[%d]", i), msg)
+		}
+	}
+
+	for i, label := range build.RunsOn {
+		if msg := validateLabel(label); msg != "" {
+			add(fmt.Sprintf("runs_on[%d]", i), msg)
+		}
+	}
+
+	errs = append(errs, validateConcurrency(build)...)
+
+	for i, job := range build.Jobs {
+		if len(job.Command) > MaxCommandLength {
+			add(fmt.Sprintf("jobs[%d].command", i), fmt.Sprintf("must be at most %d bytes", MaxCommandLength))
+		}
+		for j, pattern := range job.Artifacts {
+			if msg := validateArtifactPattern(pattern); msg != "" {
+				add(fmt.Sprintf("jobs[%d].artifacts[%d]", i, j), msg)
+			}
+		}
+	}
+
+	if err := ValidateJobs(build.Jobs); err != nil {
+		add("jobs", err.Error())
+	}
+	if err := ValidateCaches(build.Caches); err != nil {
+		add("caches", err.Error())
+	}
+	if build.Matrix != nil {
+		if _, err := build.Matrix.Expand(); err != nil {
+			add("matrix", err.Error())
+		}
+	}
+
+	return errs
+}
+
+func validateRepoUrl(repoUrl string) string {
+	if repoUrl == "" {
+		return "is required"
+	}
+	if scpLikeRepo.MatchString(repoUrl) {
+		return ""
+	}
+
+	u, err := url.Parse(repoUrl)
+	if err != nil || u.Scheme == "" {
+		return "must be a URL"
+	}
+	if !allowedRepoSchemes[strings.ToLower(u.Scheme)] {
+		return fmt.Sprintf("scheme %q is not allowed", u.Scheme)
+	}
+	if u.Host == "" {
+		return "must include a host"
+	}
+	return ""
+}
+
+// validateRef applies the rules of git check-ref-format to a branch, tag or
+// commit name.
+func validateRef(ref string) string {
+	switch {
+	case ref == "":
+		return "is required"
+	case len(ref) > MaxRefLength:
+		return fmt.Sprintf("must be at most %d characters", MaxRefLength)
+	case ref == "@",
+		strings.HasPrefix(ref, "/"), strings.HasSuffix(ref, "/"),
+		strings.HasPrefix(ref, "-"),
+		strings.HasSuffix(ref, "."), strings.HasSuffix(ref, ".lock"),
+		strings.Contains(ref, ".."), strings.Contains(ref, "//"), strings.Contains(ref, "@{"):
+		return "is not a valid git ref"
+	}
+
+	for _, r := range ref {
+		if r < 0x20 || r == 0x7f || strings.ContainsRune(" ~^:?*[\\", r) {
+			return "is not a valid git ref"
+		}
+	}
+	for _, component := range strings.Split(ref, "/") {
+		if strings.HasPrefix(component, ".") {
+			return "is not a valid git ref"
+		}
+	}
+	return ""
+}
+
+func validateArtifactPattern(pattern string) string {
+	if pattern == "" || strings.HasPrefix(pattern, "/") {
+		return "must be a relative path"
+	}
+	for _, segment := range strings.Split(pattern, "/") {
+		if segment == ".." {
+			return "must not leave the workspace"
+		}
+	}
+	return ""
+}
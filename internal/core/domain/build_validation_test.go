@@ -0,0 +1,84 @@
+package domain
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateNewBuild(t *testing.T) {
+	valid := func() Build {
+		return Build{RepoUrl: "https://github.com/test/repo", Ref: "main", Command: "make"}
+	}
+
+	tests := []struct {
+		name   string
+		modify func(b *Build)
+		field  string
+	}{
+		{"file scheme", func(b *Build) { b.RepoUrl = "file:///etc" }, "repo_url"},
+		{"missing host", func(b *Build) { b.RepoUrl = "https:///repo" }, "repo_url"},
+		{"not a url", func(b *Build) { b.RepoUrl = "repo" }, "repo_url"},
+		{"ref with double dot", func(b *Build) { b.Ref = "main..dev" }, "ref"},
+		{"ref with space", func(b *Build) { b.Ref = "my branch" }, "ref"},
+		{"ref ending in lock", func(b *Build) { b.Ref = "main.lock" }, "ref"},
+		{"ref starting with dash", func(b *Build) { b.Ref = "-main" }, "ref"},
+		{"ref with hidden component", func(b *Build) { b.Ref = "feature/.hidden" }, "ref"},
+		{"ref too long", func(b *Build) { b.Ref = strings.Repeat("a", MaxRefLength+1) }, "ref"},
+		{"priority too high", func(b *Build) { b.Priority = MaxPriority + 1 }, "priority"},
+		{"invalid runs_on label", func(b *Build) { b.RunsOn = StringList{"gpu", "has space"} }, "runs_on[1]"},
+		{"empty concurrency group", func(b *Build) { b.ConcurrencyGroup = new(string) }, "concurrency_group"},
+		{"max_concurrency without group", func(b *Build) { b.MaxConcurrency = 2 }, "max_concurrency"},
+		{"unknown concurrency policy", func(b *Build) {
+			group := "deploy-prod"
+			b.ConcurrencyGroup = &group
+			b.ConcurrencyPolicy = "replace"
+		}, "concurrency_policy"},
+		{"command too long", func(b *Build) { b.Command = strings.Repeat("a", MaxCommandLength+1) }, "command"},
+		{"invalid env name", func(b *Build) { b.Env = StringMap{"1FOO": "x"} }, "env.1FOO"},
+		{"absolute artifact", func(b *Build) { b.Artifacts = StringList{"/etc/passwd"} }, "artifacts[0]"},
+		{"escaping artifact", func(b *Build) { b.Artifacts = StringList{"dist/../../x"} }, "artifacts[0]"},
+		{"job command too long", func(b *Build) {
+			b.Jobs = []Job{{Name: "build", Command: strings.Repeat("a", MaxCommandLength+1)}}
+		}, "jobs[0].command"},
+		{"invalid job graph", func(b *Build) {
+			b.Jobs = []Job{{Name: "test", Command: "go test", Needs: StringList{"build"}}}
+		}, "jobs"},
+		{"invalid cache", func(b *Build) { b.Caches = CacheList{{Key: "go"}} }, "caches"},
+		{"invalid matrix", func(b *Build) {
+			b.Matrix = &Matrix{Axes: map[string][]string{"go": {}}}
+		}, "matrix"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			build := valid()
+			tt.modify(&build)
+
+			fieldErrors := ValidateNewBuild(&build)
+
+			require.Len(t, fieldErrors, 1)
+			assert.Equal(t, tt.field, fieldErrors[0].Field)
+		})
+	}
+}
+
+func TestValidateNewBuild_AcceptsRepoForms(t *testing.T) {
+	for _, repoUrl := range []string{
+		"https://github.com/test/repo.git",
+		"ssh://git@github.com/test/repo.git",
+		"git://example.com/repo.git",
+		"git@github.com:test/repo.git",
+	} {
+		build := Build{RepoUrl: repoUrl, Ref: "refs/heads/feature/x-1", Command: "make"}
+		assert.Empty(t, ValidateNewBuild(&build), repoUrl)
+	}
+}
+
+func TestValidateNewBuild_RequiresCommandWithoutJobs(t *testing.T) {
+	build := Build{RepoUrl: "https://github.com/test/repo", Ref: "main"}
+
+	assert.Equal(t, []FieldError{{Field: "command", Message: "is required unless jobs are given"}}, ValidateNewBuild(&build))
+}
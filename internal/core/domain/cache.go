@@ -0,0 +1,74 @@
+package domain
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path"
+	"strings"
+	"time"
+)
+
+var (
+	ErrCacheMiss    = errors.New("cache miss")
+	ErrInvalidCache = NewError(ErrInvalidArgument, "invalid cache")
+)
+
+// Cache declares workspace paths that are restored before and saved after a
+// job. Key is a template such as `go-{{ hashFiles "go.sum" }}`; RestoreKeys
+// are prefixes tried in order when there is no exact hit.
+type Cache struct {
+	Key         string     `json:"key"`
+	Paths       StringList `json:"paths"`
+	RestoreKeys StringList `json:"restore_keys,omitempty"`
+}
+
+// CacheList is persisted as a JSON array so it works with any SQL backend.
+type CacheList []Cache
+
+func (l CacheList) Value() (driver.Value, error) {
+	if l == nil {
+		return "[]", nil
+	}
+	b, err := json.Marshal([]Cache(l))
+	if err != nil {
+		return nil, err
+	}
+	return string(b), nil
+}
+
+func (l *CacheList) Scan(value interface{}) error {
+	return scanJSON(value, l)
+}
+
+// CacheEntry describes a stored cache archive.
+type CacheEntry struct {
+	Key      string
+	Size     int64
+	LastUsed time.Time
+}
+
+// ValidateCaches checks that every cache has a key and only names paths
+// inside the workspace.
+func ValidateCaches(caches []Cache) error {
+	for i, cache := range caches {
+		if strings.TrimSpace(cache.Key) == "" {
+			return fmt.Errorf("%w: cache %d has no key", ErrInvalidCache, i)
+		}
+		if len(cache.Paths) == 0 {
+			return fmt.Errorf("%w: cache %q has no paths", ErrInvalidCache, cache.Key)
+		}
+		for _, p := range cache.Paths {
+			if p == "" || path.IsAbs(p) {
+				return fmt.Errorf("%w: cache %q has invalid path %q", ErrInvalidCache, cache.Key, p)
+			}
+			for _, segment := range strings.Split(p, "/") {
+				if segment == ".." {
+					return fmt.Errorf("%w: cache path %q escapes the workspace", ErrInvalidCache, p)
+				}
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,40 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateCaches(t *testing.T) {
+	assert.NoError(t, ValidateCaches([]Cache{
+		{Key: `go-{{ hashFiles "go.sum" }}`, Paths: StringList{".cache/go-build", "vendor"}, RestoreKeys: StringList{"go-"}},
+	}))
+
+	invalid := []Cache{
+		{Paths: StringList{"vendor"}},
+		{Key: "deps"},
+		{Key: "deps", Paths: StringList{"/root/.cache"}},
+		{Key: "deps", Paths: StringList{"../outside"}},
+		{Key: "deps", Paths: StringList{""}},
+	}
+	for _, cache := range invalid {
+		assert.ErrorIs(t, ValidateCaches([]Cache{cache}), ErrInvalidCache, "%+v", cache)
+	}
+}
+
+func TestCacheList_ValueScan(t *testing.T) {
+	caches := CacheList{{Key: "deps", Paths: StringList{"vendor"}, RestoreKeys: StringList{"deps-"}}}
+
+	value, err := caches.Value()
+	require.NoError(t, err)
+
+	var scanned CacheList
+	require.NoError(t, scanned.Scan(value))
+	assert.Equal(t, caches, scanned)
+
+	empty, err := CacheList(nil).Value()
+	require.NoError(t, err)
+	assert.Equal(t, "[]", empty)
+}
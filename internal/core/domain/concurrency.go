@@ -0,0 +1,86 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+)
+
+const MaxConcurrencyGroupLength = 255
+
+// ConcurrencyPolicy decides what a new build does to the older builds of its
+// concurrency group.
+type ConcurrencyPolicy string
+
+const (
+	// ConcurrencyQueue lets the new build wait for a free slot.
+	ConcurrencyQueue ConcurrencyPolicy = "queue"
+	// ConcurrencyCancelPending cancels older builds that have not started.
+	ConcurrencyCancelPending ConcurrencyPolicy = "cancel_pending"
+	// ConcurrencyCancelInProgress cancels older builds that have not
+	// finished, including running ones.
+	ConcurrencyCancelInProgress ConcurrencyPolicy = "cancel_in_progress"
+)
+
+// ErrConcurrencyLimit is returned when a queued job belongs to a build whose
+// concurrency group already runs max_concurrency builds.
+var ErrConcurrencyLimit = NewError(ErrConflict, "concurrency group is at its limit")
+
+// Supersedes returns the statuses of the older builds in the group that a
+// new build with policy p cancels.
+func (p ConcurrencyPolicy) Supersedes() []BuildStatus {
+	switch p {
+	case ConcurrencyCancelPending:
+		return []BuildStatus{BuildStatusPending}
+	case ConcurrencyCancelInProgress:
+		return []BuildStatus{BuildStatusPending, BuildStatusRunning}
+	}
+	return nil
+}
+
+// DefaultConcurrency fills in max_concurrency and the policy of b and its
+// matrix children when the client left them out.
+func (b *Build) DefaultConcurrency() {
+	if b.MaxConcurrency == 0 {
+		b.MaxConcurrency = 1
+	}
+	if b.ConcurrencyPolicy == "" {
+		b.ConcurrencyPolicy = ConcurrencyQueue
+	}
+	for i := range b.Children {
+		b.Children[i].DefaultConcurrency()
+	}
+}
+
+// validateConcurrency returns the field errors of the concurrency settings.
+func validateConcurrency(build *Build) []FieldError {
+	var errs []FieldError
+	add := func(field, message string) {
+		errs = append(errs, FieldError{Field: field, Message: message})
+	}
+
+	if build.ConcurrencyGroup == nil {
+		if build.MaxConcurrency != 0 {
+			add("max_concurrency", "requires concurrency_group")
+		}
+		if build.ConcurrencyPolicy != "" {
+			add("concurrency_policy", "requires concurrency_group")
+		}
+		return errs
+	}
+
+	switch group := *build.ConcurrencyGroup; {
+	case strings.TrimSpace(group) == "":
+		add("concurrency_group", "must not be empty")
+	case len(group) > MaxConcurrencyGroupLength:
+		add("concurrency_group", fmt.Sprintf("must be at most %d characters", MaxConcurrencyGroupLength))
+	}
+	if build.MaxConcurrency < 0 {
+		add("max_concurrency", "must be at least 1")
+	}
+	switch build.ConcurrencyPolicy {
+	case "", ConcurrencyQueue, ConcurrencyCancelPending, ConcurrencyCancelInProgress:
+	default:
+		add("concurrency_policy", fmt.Sprintf("must be %s, %s or %s", ConcurrencyQueue, ConcurrencyCancelPending, ConcurrencyCancelInProgress))
+	}
+	return errs
+}
@@ -0,0 +1,37 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConcurrencyPolicy_Supersedes(t *testing.T) {
+	assert.Empty(t, ConcurrencyQueue.Supersedes())
+	assert.Equal(t, []BuildStatus{BuildStatusPending}, ConcurrencyCancelPending.Supersedes())
+	assert.Equal(t, []BuildStatus{BuildStatusPending, BuildStatusRunning}, ConcurrencyCancelInProgress.Supersedes())
+}
+
+func TestBuild_DefaultConcurrency(t *testing.T) {
+	build := &Build{Children: []Build{{}}}
+
+	build.DefaultConcurrency()
+
+	assert.Equal(t, 1, build.MaxConcurrency)
+	assert.Equal(t, ConcurrencyQueue, build.ConcurrencyPolicy)
+	assert.Equal(t, 1, build.Children[0].MaxConcurrency)
+
+	build = &Build{MaxConcurrency: 3, ConcurrencyPolicy: ConcurrencyCancelInProgress}
+	build.DefaultConcurrency()
+	assert.Equal(t, 3, build.MaxConcurrency)
+	assert.Equal(t, ConcurrencyCancelInProgress, build.ConcurrencyPolicy)
+}
+
+func TestValidateConcurrency(t *testing.T) {
+	group := "deploy-prod"
+	build := &Build{ConcurrencyGroup: &group, MaxConcurrency: 2, ConcurrencyPolicy: ConcurrencyCancelPending}
+	assert.Empty(t, validateConcurrency(build))
+
+	build.MaxConcurrency = -1
+	assert.Equal(t, []FieldError{{Field: "max_concurrency", Message: "must be at least 1"}}, validateConcurrency(build))
+}
@@ -0,0 +1,71 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseCron_Invalid(t *testing.T) {
+	for _, expr := range []string{
+		"",
+		"* * * *",
+		"* * * * * *",
+		"60 * * * *",
+		"* 24 * * *",
+		"* * 0 * *",
+		"* * * 13 *",
+		"* * * * 8",
+		"*/0 * * * *",
+		"5-1 * * * *",
+		"a * * * *",
+		"@every 5m",
+	} {
+		_, err := ParseCron(expr)
+		assert.ErrorIs(t, err, ErrInvalidCron, expr)
+	}
+}
+
+func TestCron_Next(t *testing.T) {
+	from := time.Date(2026, 10, 19, 12, 34, 56, 0, time.UTC) // a Monday
+
+	for _, tc := range []struct {
+		expr string
+		want time.Time
+	}{
+		{"* * * * *", time.Date(2026, 10, 19, 12, 35, 0, 0, time.UTC)},
+		{"*/15 * * * *", time.Date(2026, 10, 19, 12, 45, 0, 0, time.UTC)},
+		{"0 3 * * *", time.Date(2026, 10, 20, 3, 0, 0, 0, time.UTC)},
+		{"30 9 * * mon-fri", time.Date(2026, 10, 20, 9, 30, 0, 0, time.UTC)},
+		{"0 0 * * 7", time.Date(2026, 10, 25, 0, 0, 0, 0, time.UTC)},
+		{"0 0 1 JAN *", time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{"0 0 29 2 *", time.Date(2028, 2, 29, 0, 0, 0, 0, time.UTC)},
+		{"10-20/5,50 12 * * *", time.Date(2026, 10, 19, 12, 50, 0, 0, time.UTC)},
+		{"@hourly", time.Date(2026, 10, 19, 13, 0, 0, 0, time.UTC)},
+		// Restricted day of month and day of week match either.
+		{"0 0 1 * fri", time.Date(2026, 10, 23, 0, 0, 0, 0, time.UTC)},
+		{"0 0 30 2 *", time.Time{}},
+	} {
+		cron, err := ParseCron(tc.expr)
+		require.NoError(t, err, tc.expr)
+		assert.Equal(t, tc.want, cron.Next(from, time.UTC), tc.expr)
+	}
+}
+
+func TestCron_Next_TimeZone(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	require.NoError(t, err)
+	cron, err := ParseCron("0 9 * * *")
+	require.NoError(t, err)
+
+	// 09:00 in Berlin is 07:00 UTC in summer and 08:00 UTC in winter.
+	assert.Equal(t, time.Date(2026, 10, 24, 7, 0, 0, 0, time.UTC), cron.Next(time.Date(2026, 10, 23, 12, 0, 0, 0, time.UTC), berlin))
+	assert.Equal(t, time.Date(2026, 10, 25, 8, 0, 0, 0, time.UTC), cron.Next(time.Date(2026, 10, 24, 12, 0, 0, 0, time.UTC), berlin))
+
+	// 02:30 does not exist on the day clocks go forward.
+	cron, err = ParseCron("30 2 * * *")
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2026, 3, 30, 0, 30, 0, 0, time.UTC), cron.Next(time.Date(2026, 3, 28, 12, 0, 0, 0, time.UTC), berlin))
+}
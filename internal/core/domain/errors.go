@@ -0,0 +1,51 @@
+package domain
+
+import "errors"
+
+// Error kinds. Adapters map them onto transport status codes; match them
+// with errors.Is.
+var (
+	ErrNotFound         = errors.New("not found")
+	ErrInvalidArgument  = errors.New("invalid argument")
+	ErrConflict         = errors.New("conflict")
+	ErrUnauthenticated  = errors.New("unauthenticated")
+	ErrPermissionDenied = errors.New("permission denied")
+)
+
+var (
+	ErrBuildNotFound = NewError(ErrNotFound, "build not found")
+	ErrJobNotFound   = NewError(ErrNotFound, "job not found")
+)
+
+// Error is a domain error of a given kind. Its message is safe to show to
+// clients; the optional cause is kept for logs only.
+type Error struct {
+	Kind    error
+	Message string
+	Cause   error
+}
+
+func NewError(kind error, message string) *Error {
+	return &Error{Kind: kind, Message: message}
+}
+
+// WithCause returns a copy of e that records the underlying error.
+func (e *Error) WithCause(cause error) *Error {
+	return &Error{Kind: e.Kind, Message: e.Message, Cause: cause}
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+func (e *Error) Unwrap() []error {
+	if e.Cause == nil {
+		return []error{e.Kind}
+	}
+	return []error{e.Kind, e.Cause}
+}
+
+func (e *Error) Is(target error) bool {
+	t, ok := target.(*Error)
+	return ok && t.Kind == e.Kind && t.Message == e.Message
+}
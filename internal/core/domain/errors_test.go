@@ -0,0 +1,33 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestError_MatchesKindAndSentinel(t *testing.T) {
+	cause := errors.New("record not found")
+	err := fmt.Errorf("load: %w", ErrBuildNotFound.WithCause(cause))
+
+	assert.ErrorIs(t, err, ErrNotFound)
+	assert.ErrorIs(t, err, ErrBuildNotFound)
+	assert.ErrorIs(t, err, cause)
+	assert.NotErrorIs(t, err, ErrJobNotFound)
+	assert.NotErrorIs(t, err, ErrInvalidArgument)
+	assert.Equal(t, "load: build not found", err.Error())
+}
+
+func TestError_SentinelKinds(t *testing.T) {
+	assert.ErrorIs(t, ErrArtifactNotFound, ErrNotFound)
+	assert.ErrorIs(t, ErrUnsupportedArchive, ErrInvalidArgument)
+	assert.ErrorIs(t, ErrInvalidJobGraph, ErrInvalidArgument)
+	assert.ErrorIs(t, ErrInvalidMatrix, ErrInvalidArgument)
+	assert.ErrorIs(t, ErrInvalidCache, ErrInvalidArgument)
+	assert.ErrorIs(t, ErrInvalidCursor, ErrInvalidArgument)
+	assert.ErrorIs(t, ErrTokenNotFound, ErrNotFound)
+	assert.ErrorIs(t, ErrInvalidToken, ErrUnauthenticated)
+	assert.ErrorIs(t, ErrInsufficientScope, ErrPermissionDenied)
+}
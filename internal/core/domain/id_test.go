@@ -0,0 +1,26 @@
+package domain
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidID(t *testing.T) {
+	assert.True(t, ValidID("3f2c1a9e-8b7d-4c6e-9a5f-0123456789ab"))
+	assert.True(t, ValidID("3F2C1A9E-8B7D-4C6E-9A5F-0123456789AB"))
+
+	for _, id := range []string{"", "ci-id", "3f2c1a9e8b7d4c6e9a5f0123456789ab", "3f2c1a9e-8b7d-4c6e-9a5f-0123456789ag", "3f2c1a9e-8b7d-4c6e-9a5f_0123456789ab"} {
+		assert.False(t, ValidID(id), id)
+	}
+}
+
+func TestValidRequestID(t *testing.T) {
+	assert.True(t, ValidRequestID("req-1_a.B"))
+	assert.True(t, ValidRequestID(strings.Repeat("a", 128)))
+
+	for _, id := range []string{"", strings.Repeat("a", 129), "req 1", "req\n1", "réq"} {
+		assert.False(t, ValidRequestID(id), id)
+	}
+}
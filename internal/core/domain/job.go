@@ -0,0 +1,227 @@
+package domain
+
+import (
+	"fmt"
+	"slices"
+	"time"
+)
+
+type JobStatus string
+
+const (
+	JobStatusPending  JobStatus = "pending"
+	JobStatusRunning  JobStatus = "running"
+	JobStatusSuccess  JobStatus = "success"
+	JobStatusFailed   JobStatus = "failed"
+	JobStatusCanceled JobStatus = "canceled"
+	JobStatusSkipped  JobStatus = "skipped"
+)
+
+// DefaultJobName is used for builds that only declare a single command.
+const DefaultJobName = "default"
+
+type Job struct {
+	ID                string     `json:"id" gorm:"primaryKey;type:uuid;default:uuid_generate_v4()"`
+	BuildID           string     `json:"build_id" gorm:"type:uuid;not null;index"`
+	Name              string     `json:"name"`
+	Command           string     `json:"command"`
+	Needs             StringList `json:"needs" gorm:"type:jsonb"`
+	Artifacts         StringList `json:"artifacts" gorm:"type:jsonb"`
+	Status            JobStatus  `json:"status" gorm:"type:varchar(20);default:'pending'"`
+	LockedBy          *string    `json:"locked_by" gorm:"type:text"`
+	LockedAt          *time.Time `json:"locked_at"`
+	CancelRequestedAt *time.Time `json:"cancel_requested_at"`
+	FinishedAt        *time.Time `json:"finished_at"`
+	ExitCode          int        `json:"exit_code"`
+	Error             string     `json:"error"`
+	CreatedAt         time.Time  `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt         time.Time  `json:"updated_at" gorm:"autoUpdateTime"`
+	// RankedAt orders pending jobs for claiming, see RankAt.
+	RankedAt time.Time `json:"-"`
+
+	Build *Build `json:"-" gorm:"-"`
+}
+
+func (s JobStatus) IsTerminal() bool {
+	switch s {
+	case JobStatusSuccess, JobStatusFailed, JobStatusCanceled, JobStatusSkipped:
+		return true
+	}
+	return false
+}
+
+var ErrInvalidJobGraph = NewError(ErrInvalidArgument, "invalid job graph")
+
+// ErrJobNotRunning is returned when a worker reports on a job that already
+// finished.
+var ErrJobNotRunning = NewError(ErrConflict, "job is not running")
+
+// ErrJobLockLost is returned when a worker reports the outcome of a job it no
+// longer holds: the job finished, or its lease expired and another worker
+// took it over.
+var ErrJobLockLost = NewError(ErrConflict, "job is no longer held by this worker")
+
+// ErrJobCanceled is reported by workers that stopped a job because its build
+// was canceled; the job is recorded as canceled rather than failed.
+var ErrJobCanceled = NewError(ErrConflict, "job canceled")
+
+// ValidateJobs checks that job names are unique, every need refers to a
+// declared job and the dependencies form a DAG.
+func ValidateJobs(jobs []Job) error {
+	byName := make(map[string]*Job, len(jobs))
+	for i := range jobs {
+		job := &jobs[i]
+		if job.Name == "" {
+			return fmt.Errorf("%w: job %d has no name", ErrInvalidJobGraph, i)
+		}
+		if job.Command == "" {
+			return fmt.Errorf("%w: job %q has no command", ErrInvalidJobGraph, job.Name)
+		}
+		if _, ok := byName[job.Name]; ok {
+			return fmt.Errorf("%w: duplicate job %q", ErrInvalidJobGraph, job.Name)
+		}
+		byName[job.Name] = job
+	}
+
+	for _, job := range jobs {
+		for _, need := range job.Needs {
+			if _, ok := byName[need]; !ok {
+				return fmt.Errorf("%w: job %q needs unknown job %q", ErrInvalidJobGraph, job.Name, need)
+			}
+		}
+	}
+
+	const (
+		unvisited = iota
+		visiting
+		done
+	)
+	state := make(map[string]int, len(jobs))
+
+	var visit func(name string) error
+	visit = func(name string) error {
+		switch state[name] {
+		case visiting:
+			return fmt.Errorf("%w: dependency cycle through %q", ErrInvalidJobGraph, name)
+		case done:
+			return nil
+		}
+		state[name] = visiting
+		for _, need := range byName[name].Needs {
+			if err := visit(need); err != nil {
+				return err
+			}
+		}
+		state[name] = done
+		return nil
+	}
+
+	for _, job := range jobs {
+		if err := visit(job.Name); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Downstream returns the names of all jobs that transitively need the given job.
+func Downstream(jobs []Job, name string) []string {
+	dependents := make(map[string][]string)
+	for _, job := range jobs {
+		for _, need := range job.Needs {
+			dependents[need] = append(dependents[need], job.Name)
+		}
+	}
+
+	seen := make(map[string]bool)
+	var out []string
+	queue := []string{name}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		for _, dep := range dependents[current] {
+			if seen[dep] {
+				continue
+			}
+			seen[dep] = true
+			out = append(out, dep)
+			queue = append(queue, dep)
+		}
+	}
+
+	return out
+}
+
+// Unblocked returns the pending jobs that need the given job and whose needs
+// have now all succeeded.
+func Unblocked(jobs []Job, name string) []Job {
+	status := make(map[string]JobStatus, len(jobs))
+	for _, job := range jobs {
+		status[job.Name] = job.Status
+	}
+
+	var out []Job
+	for _, job := range jobs {
+		if job.Status != JobStatusPending || !slices.Contains(job.Needs, name) {
+			continue
+		}
+		ready := true
+		for _, need := range job.Needs {
+			if status[need] != JobStatusSuccess {
+				ready = false
+				break
+			}
+		}
+		if ready {
+			out = append(out, job)
+		}
+	}
+	return out
+}
+
+// AggregateStatus rolls job outcomes up into a build status. The second
+// return value reports whether every job has reached a terminal state.
+func AggregateStatus(jobs []Job) (BuildStatus, bool) {
+	statuses := make([]JobStatus, len(jobs))
+	for i, job := range jobs {
+		statuses[i] = job.Status
+	}
+	return aggregateStatuses(statuses)
+}
+
+func aggregateStatuses(statuses []JobStatus) (BuildStatus, bool) {
+	if len(statuses) == 0 {
+		return BuildStatusPending, false
+	}
+
+	var anyStarted, anyFailed, anyCanceled bool
+	finished := true
+	for _, status := range statuses {
+		if status != JobStatusPending {
+			anyStarted = true
+		}
+		if !status.IsTerminal() {
+			finished = false
+		}
+		switch status {
+		case JobStatusFailed:
+			anyFailed = true
+		case JobStatusCanceled:
+			anyCanceled = true
+		}
+	}
+
+	switch {
+	case !finished && anyStarted:
+		return BuildStatusRunning, false
+	case !finished:
+		return BuildStatusPending, false
+	case anyFailed:
+		return BuildStatusFailed, true
+	case anyCanceled:
+		return BuildStatusCanceled, true
+	default:
+		return BuildStatusSuccess, true
+	}
+}
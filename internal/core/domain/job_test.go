@@ -0,0 +1,119 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateJobs_Valid(t *testing.T) {
+	jobs := []Job{
+		{Name: "build", Command: "make"},
+		{Name: "test", Command: "make test", Needs: StringList{"build"}},
+		{Name: "lint", Command: "make lint", Needs: StringList{"build"}},
+		{Name: "deploy", Command: "make deploy", Needs: StringList{"test", "lint"}},
+	}
+
+	assert.NoError(t, ValidateJobs(jobs))
+}
+
+func TestValidateJobs_Invalid(t *testing.T) {
+	tests := []struct {
+		name string
+		jobs []Job
+	}{
+		{
+			name: "missing name",
+			jobs: []Job{{Command: "make"}},
+		},
+		{
+			name: "missing command",
+			jobs: []Job{{Name: "build"}},
+		},
+		{
+			name: "duplicate name",
+			jobs: []Job{{Name: "build", Command: "make"}, {Name: "build", Command: "make"}},
+		},
+		{
+			name: "unknown need",
+			jobs: []Job{{Name: "test", Command: "make test", Needs: StringList{"build"}}},
+		},
+		{
+			name: "cycle",
+			jobs: []Job{
+				{Name: "a", Command: "a", Needs: StringList{"c"}},
+				{Name: "b", Command: "b", Needs: StringList{"a"}},
+				{Name: "c", Command: "c", Needs: StringList{"b"}},
+			},
+		},
+		{
+			name: "self dependency",
+			jobs: []Job{{Name: "a", Command: "a", Needs: StringList{"a"}}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.ErrorIs(t, ValidateJobs(tt.jobs), ErrInvalidJobGraph)
+		})
+	}
+}
+
+func TestDownstream(t *testing.T) {
+	jobs := []Job{
+		{Name: "build"},
+		{Name: "test", Needs: StringList{"build"}},
+		{Name: "deploy", Needs: StringList{"test"}},
+		{Name: "lint"},
+	}
+
+	assert.ElementsMatch(t, []string{"test", "deploy"}, Downstream(jobs, "build"))
+	assert.Empty(t, Downstream(jobs, "lint"))
+}
+
+func TestUnblocked(t *testing.T) {
+	jobs := []Job{
+		{Name: "build", Status: JobStatusSuccess},
+		{Name: "lint", Status: JobStatusRunning},
+		{Name: "test", Needs: StringList{"build"}, Status: JobStatusPending},
+		{Name: "package", Needs: StringList{"build", "lint"}, Status: JobStatusPending},
+		{Name: "docs", Needs: StringList{"build"}, Status: JobStatusRunning},
+		{Name: "deploy", Needs: StringList{"test"}, Status: JobStatusPending},
+	}
+
+	unblocked := Unblocked(jobs, "build")
+
+	require.Len(t, unblocked, 1)
+	assert.Equal(t, "test", unblocked[0].Name)
+	assert.Empty(t, Unblocked(jobs, "deploy"))
+}
+
+func TestAggregateStatus(t *testing.T) {
+	tests := []struct {
+		name     string
+		statuses []JobStatus
+		want     BuildStatus
+		finished bool
+	}{
+		{"all pending", []JobStatus{JobStatusPending, JobStatusPending}, BuildStatusPending, false},
+		{"one running", []JobStatus{JobStatusRunning, JobStatusPending}, BuildStatusRunning, false},
+		{"one done one pending", []JobStatus{JobStatusSuccess, JobStatusPending}, BuildStatusRunning, false},
+		{"all success", []JobStatus{JobStatusSuccess, JobStatusSuccess}, BuildStatusSuccess, true},
+		{"failed and skipped", []JobStatus{JobStatusFailed, JobStatusSkipped}, BuildStatusFailed, true},
+		{"canceled", []JobStatus{JobStatusSuccess, JobStatusCanceled}, BuildStatusCanceled, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			jobs := make([]Job, len(tt.statuses))
+			for i, s := range tt.statuses {
+				jobs[i] = Job{Status: s}
+			}
+
+			got, finished := AggregateStatus(jobs)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.finished, finished)
+		})
+	}
+}
@@ -10,7 +10,10 @@ const (
 )
 
 type LogEvent struct {
-	Stream LogStream
-	Line   string
-	Time   time.Time
+	Stream LogStream `json:"stream"`
+	Line   string    `json:"line"`
+	Time   time.Time `json:"time"`
 }
+
+// MaxLogPageSize bounds how many log lines are read in one query.
+const MaxLogPageSize = 1000
@@ -0,0 +1,224 @@
+package domain
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// MaxMatrixCombinations caps the fan-out of a single matrix build.
+const MaxMatrixCombinations = 256
+
+var ErrInvalidMatrix = NewError(ErrInvalidArgument, "invalid matrix")
+
+// Matrix is declared like `{"go": ["1.24", "1.25"], "exclude": [...], "fail_fast": true}`:
+// every key other than include, exclude and fail_fast is an axis.
+type Matrix struct {
+	Axes     map[string][]string
+	Include  []StringMap
+	Exclude  []StringMap
+	FailFast bool
+}
+
+func (m Matrix) MarshalJSON() ([]byte, error) {
+	out := make(map[string]interface{}, len(m.Axes)+3)
+	for k, v := range m.Axes {
+		out[k] = v
+	}
+	if len(m.Include) > 0 {
+		out["include"] = m.Include
+	}
+	if len(m.Exclude) > 0 {
+		out["exclude"] = m.Exclude
+	}
+	out["fail_fast"] = m.FailFast
+	return json.Marshal(out)
+}
+
+func (m *Matrix) UnmarshalJSON(data []byte) error {
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	*m = Matrix{Axes: map[string][]string{}}
+	for key, value := range raw {
+		switch key {
+		case "fail_fast":
+			if err := json.Unmarshal(value, &m.FailFast); err != nil {
+				return fmt.Errorf("fail_fast: %w", err)
+			}
+		case "include", "exclude":
+			var entries []map[string]json.RawMessage
+			if err := json.Unmarshal(value, &entries); err != nil {
+				return fmt.Errorf("%s: %w", key, err)
+			}
+			for _, entry := range entries {
+				combo := StringMap{}
+				for k, v := range entry {
+					s, err := scalarString(v)
+					if err != nil {
+						return fmt.Errorf("%s.%s: %w", key, k, err)
+					}
+					combo[k] = s
+				}
+				if key == "include" {
+					m.Include = append(m.Include, combo)
+				} else {
+					m.Exclude = append(m.Exclude, combo)
+				}
+			}
+		default:
+			var values []json.RawMessage
+			if err := json.Unmarshal(value, &values); err != nil {
+				return fmt.Errorf("axis %q must be a list: %w", key, err)
+			}
+			for _, v := range values {
+				s, err := scalarString(v)
+				if err != nil {
+					return fmt.Errorf("axis %q: %w", key, err)
+				}
+				m.Axes[key] = append(m.Axes[key], s)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (m Matrix) Value() (driver.Value, error) {
+	b, err := json.Marshal(m)
+	if err != nil {
+		return nil, err
+	}
+	return string(b), nil
+}
+
+func (m *Matrix) Scan(value interface{}) error {
+	return scanJSON(value, m)
+}
+
+// Expand returns one value set per matrix combination: the cartesian product
+// of all axes, minus excluded combinations, plus included ones. An include
+// entry whose axis values match existing combinations extends them with its
+// extra keys; otherwise it is added as a combination of its own.
+func (m Matrix) Expand() ([]StringMap, error) {
+	keys := make([]string, 0, len(m.Axes))
+	for k, values := range m.Axes {
+		if len(values) == 0 {
+			return nil, fmt.Errorf("%w: axis %q has no values", ErrInvalidMatrix, k)
+		}
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var combos []StringMap
+	if len(keys) > 0 {
+		combos = []StringMap{{}}
+		for _, k := range keys {
+			next := make([]StringMap, 0, len(combos)*len(m.Axes[k]))
+			for _, combo := range combos {
+				for _, v := range m.Axes[k] {
+					c := combo.clone()
+					c[k] = v
+					next = append(next, c)
+				}
+			}
+			combos = next
+			if len(combos) > MaxMatrixCombinations {
+				return nil, fmt.Errorf("%w: more than %d combinations", ErrInvalidMatrix, MaxMatrixCombinations)
+			}
+		}
+	}
+
+	filtered := combos[:0]
+	for _, combo := range combos {
+		excluded := false
+		for _, ex := range m.Exclude {
+			if combo.matches(ex) {
+				excluded = true
+				break
+			}
+		}
+		if !excluded {
+			filtered = append(filtered, combo)
+		}
+	}
+	combos = filtered
+
+	for _, inc := range m.Include {
+		axisValues := StringMap{}
+		for k, v := range inc {
+			if _, ok := m.Axes[k]; ok {
+				axisValues[k] = v
+			}
+		}
+
+		extended := false
+		if len(axisValues) > 0 {
+			for _, combo := range combos {
+				if combo.matches(axisValues) {
+					for k, v := range inc {
+						combo[k] = v
+					}
+					extended = true
+				}
+			}
+		}
+		if !extended {
+			combos = append(combos, inc.clone())
+		}
+	}
+
+	if len(combos) == 0 {
+		return nil, fmt.Errorf("%w: no combinations", ErrInvalidMatrix)
+	}
+	if len(combos) > MaxMatrixCombinations {
+		return nil, fmt.Errorf("%w: more than %d combinations", ErrInvalidMatrix, MaxMatrixCombinations)
+	}
+
+	return combos, nil
+}
+
+// MatrixEnv turns matrix values into MATRIX_<KEY> environment variables.
+func MatrixEnv(values StringMap) StringMap {
+	env := make(StringMap, len(values))
+	for k, v := range values {
+		name := strings.Map(func(r rune) rune {
+			switch {
+			case r >= 'a' && r <= 'z':
+				return r - 'a' + 'A'
+			case r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+				return r
+			default:
+				return '_'
+			}
+		}, k)
+		env["MATRIX_"+name] = v
+	}
+	return env
+}
+
+func scalarString(raw json.RawMessage) (string, error) {
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.UseNumber()
+
+	var v interface{}
+	if err := dec.Decode(&v); err != nil {
+		return "", err
+	}
+
+	switch val := v.(type) {
+	case string:
+		return val, nil
+	case json.Number:
+		return val.String(), nil
+	case bool:
+		return fmt.Sprint(val), nil
+	default:
+		return "", fmt.Errorf("value must be a string, number or bool")
+	}
+}
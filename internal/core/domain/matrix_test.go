@@ -0,0 +1,105 @@
+package domain
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMatrix_UnmarshalJSON(t *testing.T) {
+	var m Matrix
+	err := json.Unmarshal([]byte(`{
+		"go": [1.24, "1.25"],
+		"os_image": ["debian", "alpine"],
+		"exclude": [{"go": 1.24, "os_image": "alpine"}],
+		"include": [{"go": "1.23", "os_image": "debian"}],
+		"fail_fast": true
+	}`), &m)
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"1.24", "1.25"}, m.Axes["go"])
+	assert.Equal(t, []string{"debian", "alpine"}, m.Axes["os_image"])
+	assert.Equal(t, []StringMap{{"go": "1.24", "os_image": "alpine"}}, m.Exclude)
+	assert.Equal(t, []StringMap{{"go": "1.23", "os_image": "debian"}}, m.Include)
+	assert.True(t, m.FailFast)
+}
+
+func TestMatrix_UnmarshalJSON_InvalidAxis(t *testing.T) {
+	var m Matrix
+	err := json.Unmarshal([]byte(`{"go": "1.24"}`), &m)
+
+	assert.Error(t, err)
+}
+
+func TestMatrix_RoundTrip(t *testing.T) {
+	m := Matrix{
+		Axes:     map[string][]string{"go": {"1.24"}},
+		Exclude:  []StringMap{{"go": "1.24"}},
+		FailFast: true,
+	}
+
+	value, err := m.Value()
+	require.NoError(t, err)
+
+	var scanned Matrix
+	require.NoError(t, scanned.Scan(value))
+	assert.Equal(t, m, scanned)
+}
+
+func TestMatrix_Expand(t *testing.T) {
+	m := Matrix{
+		Axes: map[string][]string{
+			"go":       {"1.24", "1.25"},
+			"os_image": {"debian", "alpine"},
+		},
+		Exclude: []StringMap{{"go": "1.24", "os_image": "alpine"}},
+		Include: []StringMap{
+			{"go": "1.25", "experimental": "true"},
+			{"go": "1.23", "os_image": "debian"},
+		},
+	}
+
+	combos, err := m.Expand()
+
+	require.NoError(t, err)
+	assert.Equal(t, []StringMap{
+		{"go": "1.24", "os_image": "debian"},
+		{"go": "1.25", "os_image": "debian", "experimental": "true"},
+		{"go": "1.25", "os_image": "alpine", "experimental": "true"},
+		{"go": "1.23", "os_image": "debian"},
+	}, combos)
+}
+
+func TestMatrix_Expand_Errors(t *testing.T) {
+	tests := []struct {
+		name   string
+		matrix Matrix
+	}{
+		{"empty axis", Matrix{Axes: map[string][]string{"go": {}}}},
+		{"everything excluded", Matrix{
+			Axes:    map[string][]string{"go": {"1.24"}},
+			Exclude: []StringMap{{"go": "1.24"}},
+		}},
+		{"too many combinations", Matrix{Axes: map[string][]string{
+			"a": {"1", "2", "3", "4", "5", "6", "7", "8"},
+			"b": {"1", "2", "3", "4", "5", "6", "7", "8"},
+			"c": {"1", "2", "3", "4", "5"},
+		}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := tt.matrix.Expand()
+			assert.ErrorIs(t, err, ErrInvalidMatrix)
+		})
+	}
+}
+
+func TestMatrixEnv(t *testing.T) {
+	env := MatrixEnv(StringMap{"go": "1.24", "os-image": "debian"})
+
+	assert.Equal(t, StringMap{"MATRIX_GO": "1.24", "MATRIX_OS_IMAGE": "debian"}, env)
+	assert.Equal(t, []string{"MATRIX_GO=1.24", "MATRIX_OS_IMAGE=debian"}, env.Environ())
+}
@@ -0,0 +1,72 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+const (
+	MinPriority = -1000
+	MaxPriority = 1000
+)
+
+// PriorityAging is the queue time one priority point is worth. A job ranks as
+// if it had been queued that much earlier per point, so higher priorities
+// jump ahead while jobs that waited long enough still overtake them.
+const PriorityAging = time.Minute
+
+// RepoShareAging is the rank a job gives up for each top-level build its
+// repository already runs. Busy repositories let the others go first, unless
+// their jobs have enough priority or waited long enough to make up for it.
+const RepoShareAging = 10 * PriorityAging
+
+var ErrBuildNotPending = NewError(ErrConflict, "build is not pending")
+
+// ErrPriorityUnsupported is returned for builds with a priority when the
+// configured queue hands jobs out in the order they were enqueued.
+var ErrPriorityUnsupported = NewError(ErrInvalidArgument, "priority is not supported by the configured queue")
+
+// RankAt returns the time a job queued at queuedAt is ordered by.
+func RankAt(queuedAt time.Time, priority int) time.Time {
+	return queuedAt.Add(-time.Duration(priority) * PriorityAging)
+}
+
+// ShareRank returns the time a job ranked at rankedAt is claimed by while its
+// repository runs running top-level builds.
+func ShareRank(rankedAt time.Time, running int) time.Time {
+	return rankedAt.Add(time.Duration(running) * RepoShareAging)
+}
+
+// RankJobs sets RankedAt on the jobs of b and its matrix children that have
+// none yet, from their creation time or now.
+func (b *Build) RankJobs(now time.Time) {
+	for i := range b.Jobs {
+		job := &b.Jobs[i]
+		if !job.RankedAt.IsZero() {
+			continue
+		}
+		queuedAt := job.CreatedAt
+		if queuedAt.IsZero() {
+			queuedAt = now
+		}
+		job.RankedAt = RankAt(queuedAt, b.Priority)
+	}
+	for i := range b.Children {
+		b.Children[i].RankJobs(now)
+	}
+}
+
+func validatePriority(priority int) string {
+	if priority < MinPriority || priority > MaxPriority {
+		return fmt.Sprintf("must be between %d and %d", MinPriority, MaxPriority)
+	}
+	return ""
+}
+
+// ValidatePriority checks a priority given for an existing build.
+func ValidatePriority(priority int) error {
+	if msg := validatePriority(priority); msg != "" {
+		return NewError(ErrInvalidArgument, "priority "+msg)
+	}
+	return nil
+}
@@ -0,0 +1,41 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRankAt(t *testing.T) {
+	queued := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	assert.Equal(t, queued, RankAt(queued, 0))
+	assert.Equal(t, queued.Add(-5*PriorityAging), RankAt(queued, 5))
+	assert.Equal(t, queued.Add(3*PriorityAging), RankAt(queued, -3))
+}
+
+func TestBuild_RankJobs(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	created := now.Add(-time.Hour)
+	ranked := now.Add(-time.Minute)
+	build := &Build{
+		Priority: 2,
+		Jobs:     []Job{{Name: "a"}, {Name: "b", CreatedAt: created}, {Name: "c", RankedAt: ranked}},
+		Children: []Build{{Priority: 1, Jobs: []Job{{Name: "a"}}}},
+	}
+
+	build.RankJobs(now)
+
+	assert.Equal(t, now.Add(-2*PriorityAging), build.Jobs[0].RankedAt)
+	assert.Equal(t, created.Add(-2*PriorityAging), build.Jobs[1].RankedAt)
+	assert.Equal(t, ranked, build.Jobs[2].RankedAt)
+	assert.Equal(t, now.Add(-PriorityAging), build.Children[0].Jobs[0].RankedAt)
+}
+
+func TestValidatePriority(t *testing.T) {
+	assert.NoError(t, ValidatePriority(MinPriority))
+	assert.NoError(t, ValidatePriority(MaxPriority))
+	assert.ErrorIs(t, ValidatePriority(MaxPriority+1), ErrInvalidArgument)
+	assert.ErrorIs(t, ValidatePriority(MinPriority-1), ErrInvalidArgument)
+}
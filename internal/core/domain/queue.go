@@ -0,0 +1,41 @@
+package domain
+
+import "time"
+
+// Lease is a job handed to one worker by a queue. The worker keeps it by
+// extending it until the job completes; an expired lease lets the queue hand
+// the job out again.
+type Lease struct {
+	JobID     string
+	WorkerID  string
+	ExpiresAt time.Time
+	// Job is set by queues that already started the job while claiming it.
+	Job *Job
+	// Deliveries counts how often the queue handed the job out, this time
+	// included. Queues that do not track it leave it zero.
+	Deliveries int
+}
+
+const (
+	minHoldBack = time.Second
+	maxHoldBack = 30 * time.Second
+)
+
+// HoldBack returns how long a job that could not start yet stays out of the
+// queue after its nth delivery: one second, doubling per delivery up to 30
+// seconds.
+func HoldBack(deliveries int) time.Duration {
+	delay := minHoldBack
+	for i := 1; i < deliveries && delay < maxHoldBack; i++ {
+		delay *= 2
+	}
+	return min(delay, maxHoldBack)
+}
+
+// ErrJobNotClaimable is returned when a queued job can no longer be started:
+// another worker holds it, it was canceled, or its needs are not met.
+var ErrJobNotClaimable = NewError(ErrConflict, "job cannot be claimed")
+
+// ErrJobNotForWorker is returned when a queued job needs labels the claiming
+// worker does not have; another worker has to take it.
+var ErrJobNotForWorker = NewError(ErrConflict, "worker labels do not satisfy runs_on")
@@ -0,0 +1,16 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHoldBack(t *testing.T) {
+	assert.Equal(t, time.Second, HoldBack(0))
+	assert.Equal(t, time.Second, HoldBack(1))
+	assert.Equal(t, 4*time.Second, HoldBack(3))
+	assert.Equal(t, 30*time.Second, HoldBack(6))
+	assert.Equal(t, 30*time.Second, HoldBack(1000))
+}
@@ -0,0 +1,36 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+// ErrRepoLimit is returned when a queued job belongs to a build whose
+// repository already runs its maximum number of builds.
+var ErrRepoLimit = NewError(ErrConflict, "repository runs its maximum number of builds")
+
+var (
+	ErrRepoLimitNotFound = NewError(ErrNotFound, "repository limit not found")
+	ErrInvalidRepoLimit  = NewError(ErrInvalidArgument, "invalid repository limit")
+)
+
+// RepoLimit caps the top-level builds of one repository that run at once.
+// Claims skip the repository's other builds until one finishes. A limit of 0
+// removes the cap.
+type RepoLimit struct {
+	RepoUrl          string    `json:"repo_url" gorm:"primaryKey;type:text"`
+	MaxRunningBuilds int       `json:"max_running_builds" gorm:"not null"`
+	CreatedAt        time.Time `json:"created_at"`
+	UpdatedAt        time.Time `json:"updated_at"`
+}
+
+// Validate checks a limit set by an admin.
+func (l *RepoLimit) Validate() error {
+	if msg := validateRepoUrl(l.RepoUrl); msg != "" {
+		return fmt.Errorf("%w: repo_url %s", ErrInvalidRepoLimit, msg)
+	}
+	if l.MaxRunningBuilds < 0 {
+		return fmt.Errorf("%w: max_running_builds must not be negative", ErrInvalidRepoLimit)
+	}
+	return nil
+}
@@ -0,0 +1,20 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRepoLimit_Validate(t *testing.T) {
+	assert.NoError(t, (&RepoLimit{RepoUrl: "https://github.com/test/repo", MaxRunningBuilds: 2}).Validate())
+	assert.NoError(t, (&RepoLimit{RepoUrl: "git@github.com:test/repo.git"}).Validate(), "0 removes the cap")
+
+	for _, limit := range []RepoLimit{
+		{RepoUrl: "", MaxRunningBuilds: 1},
+		{RepoUrl: "file:///etc", MaxRunningBuilds: 1},
+		{RepoUrl: "https://github.com/test/repo", MaxRunningBuilds: -1},
+	} {
+		assert.ErrorIs(t, limit.Validate(), ErrInvalidRepoLimit, "%+v", limit)
+	}
+}
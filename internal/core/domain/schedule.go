@@ -0,0 +1,209 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	// Schedules name IANA time zones; embedding the database keeps them
+	// working on hosts and images without one.
+	_ "time/tzdata"
+)
+
+const MaxScheduleNameLength = 255
+
+// MaxMissedRuns caps the builds a run_all schedule catches up with at once;
+// older missed runs are dropped.
+const MaxMissedRuns = 100
+
+// MissedRunPolicy decides what a schedule does about runs that passed while
+// no scheduler was running.
+type MissedRunPolicy string
+
+const (
+	// MissedRunSkip drops missed runs.
+	MissedRunSkip MissedRunPolicy = "skip"
+	// MissedRunOnce starts one build for all missed runs.
+	MissedRunOnce MissedRunPolicy = "run_once"
+	// MissedRunAll starts one build per missed run.
+	MissedRunAll MissedRunPolicy = "run_all"
+)
+
+var (
+	ErrScheduleNotFound = NewError(ErrNotFound, "schedule not found")
+	ErrInvalidSchedule  = NewError(ErrInvalidArgument, "invalid schedule")
+)
+
+// Schedule creates a build of a repository whenever its cron expression
+// matches in its time zone. NextRunAt is the earliest run that has not been
+// turned into a build yet.
+type Schedule struct {
+	ID              string          `json:"id" gorm:"primaryKey;type:uuid;default:uuid_generate_v4()"`
+	Name            string          `json:"name" gorm:"type:text;not null"`
+	RepoUrl         string          `json:"repo_url" gorm:"type:text;not null"`
+	Ref             string          `json:"ref" gorm:"type:text;not null"`
+	Command         string          `json:"command" gorm:"type:text;not null"`
+	Env             StringMap       `json:"env" gorm:"type:jsonb"`
+	Cron            string          `json:"cron" gorm:"type:text;not null"`
+	Timezone        string          `json:"timezone" gorm:"type:text;not null;default:UTC"`
+	MissedRunPolicy MissedRunPolicy `json:"missed_run_policy" gorm:"type:text;not null;default:run_once"`
+	NextRunAt       time.Time       `json:"next_run_at" gorm:"not null"`
+	LastRunAt       *time.Time      `json:"last_run_at"`
+	CreatedAt       time.Time       `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt       time.Time       `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+// ValidateNewSchedule checks a schedule submitted by a client before it is
+// created and reports every invalid field.
+func ValidateNewSchedule(schedule *Schedule) []FieldError {
+	var errs []FieldError
+	add := func(field, message string) {
+		errs = append(errs, FieldError{Field: field, Message: message})
+	}
+
+	switch name := schedule.Name; {
+	case strings.TrimSpace(name) == "":
+		add("name", "is required")
+	case len(name) > MaxScheduleNameLength:
+		add("name", fmt.Sprintf("must be at most %d characters", MaxScheduleNameLength))
+	}
+	if msg := validateRepoUrl(schedule.RepoUrl); msg != "" {
+		add("repo_url", msg)
+	}
+	if msg := validateRef(schedule.Ref); msg != "" {
+		add("ref", msg)
+	}
+	switch {
+	case strings.TrimSpace(schedule.Command) == "":
+		add("command", "is required")
+	case len(schedule.Command) > MaxCommandLength:
+		add("command", fmt.Sprintf("must be at most %d bytes", MaxCommandLength))
+	}
+	for name := range schedule.Env {
+		if !envName.MatchString(name) {
+			add("env."+name, "is not a valid environment variable name")
+		}
+	}
+
+	cron, err := ParseCron(schedule.Cron)
+	if err != nil {
+		add("cron", err.Error())
+	}
+	loc, err := loadTimezone(schedule.Timezone)
+	if err != nil {
+		add("timezone", err.Error())
+	}
+	if cron != nil && loc != nil && cron.Next(time.Now(), loc).IsZero() {
+		add("cron", "never matches")
+	}
+
+	switch schedule.MissedRunPolicy {
+	case "", MissedRunSkip, MissedRunOnce, MissedRunAll:
+	default:
+		add("missed_run_policy", fmt.Sprintf("must be %s, %s or %s", MissedRunSkip, MissedRunOnce, MissedRunAll))
+	}
+
+	return errs
+}
+
+// loadTimezone loads an IANA time zone; empty means UTC. The server's local
+// zone is refused so that a schedule means the same on every replica.
+func loadTimezone(name string) (*time.Location, error) {
+	if name == "Local" {
+		return nil, fmt.Errorf("must name an IANA time zone")
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, fmt.Errorf("is not a known time zone")
+	}
+	return loc, nil
+}
+
+// SetDefaults fills in the time zone and missed-run policy when the client
+// left them out.
+func (s *Schedule) SetDefaults() {
+	if s.Timezone == "" {
+		s.Timezone = "UTC"
+	}
+	if s.MissedRunPolicy == "" {
+		s.MissedRunPolicy = MissedRunOnce
+	}
+}
+
+// NextRun returns the first run of s after t, in UTC.
+func (s *Schedule) NextRun(t time.Time) (time.Time, error) {
+	next, err := s.runs()
+	if err != nil {
+		return time.Time{}, err
+	}
+	return next(t)
+}
+
+// runs parses the cron expression and time zone of s once and returns a
+// function stepping from one run to the next.
+func (s *Schedule) runs() (func(t time.Time) (time.Time, error), error) {
+	cron, err := ParseCron(s.Cron)
+	if err != nil {
+		return nil, err
+	}
+	loc, err := loadTimezone(s.Timezone)
+	if err != nil {
+		return nil, fmt.Errorf("%w: timezone %v", ErrInvalidSchedule, err)
+	}
+	return func(t time.Time) (time.Time, error) {
+		next := cron.Next(t, loc)
+		if next.IsZero() {
+			return time.Time{}, fmt.Errorf("%w: cron never matches", ErrInvalidSchedule)
+		}
+		return next, nil
+	}, nil
+}
+
+// Due returns the runs of s from NextRunAt up to now that should create a
+// build, oldest first, and the run after now. Runs more than grace before
+// now were missed and are kept as the missed-run policy says.
+func (s *Schedule) Due(now time.Time, grace time.Duration) ([]time.Time, time.Time, error) {
+	nextRun, err := s.runs()
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+
+	var missed, due []time.Time
+	at := s.NextRunAt
+	for !at.After(now) {
+		if now.Sub(at) > grace {
+			missed = append(missed, at)
+			if len(missed) > MaxMissedRuns {
+				missed = missed[1:]
+			}
+		} else {
+			due = append(due, at)
+		}
+
+		if at, err = nextRun(at); err != nil {
+			return nil, time.Time{}, err
+		}
+	}
+
+	switch s.MissedRunPolicy {
+	case MissedRunSkip:
+		missed = nil
+	case MissedRunAll:
+	default:
+		if len(missed) > 1 {
+			missed = missed[len(missed)-1:]
+		}
+	}
+	return append(missed, due...), at, nil
+}
+
+// NewBuild returns the build one run of s creates.
+func (s *Schedule) NewBuild() *Build {
+	triggeredBy := "schedule:" + s.Name
+	return &Build{
+		RepoUrl:     s.RepoUrl,
+		Ref:         s.Ref,
+		Command:     s.Command,
+		Env:         s.Env.clone(),
+		TriggeredBy: &triggeredBy,
+	}
+}
@@ -0,0 +1,102 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func validSchedule() *Schedule {
+	return &Schedule{
+		Name:     "nightly",
+		RepoUrl:  "https://github.com/test/repo",
+		Ref:      "main",
+		Command:  "make test",
+		Cron:     "0 3 * * *",
+		Timezone: "Europe/Berlin",
+	}
+}
+
+func TestValidateNewSchedule(t *testing.T) {
+	assert.Empty(t, ValidateNewSchedule(validSchedule()))
+
+	for field, mutate := range map[string]func(s *Schedule){
+		"name":              func(s *Schedule) { s.Name = " " },
+		"repo_url":          func(s *Schedule) { s.RepoUrl = "file:///etc" },
+		"ref":               func(s *Schedule) { s.Ref = "a..b" },
+		"command":           func(s *Schedule) { s.Command = "" },
+		"env.1X":            func(s *Schedule) { s.Env = StringMap{"1X": "y"} },
+		"cron":              func(s *Schedule) { s.Cron = "0 0 30 2 *" },
+		"timezone":          func(s *Schedule) { s.Timezone = "Mars/Olympus" },
+		"missed_run_policy": func(s *Schedule) { s.MissedRunPolicy = "later" },
+	} {
+		schedule := validSchedule()
+		mutate(schedule)
+		errs := ValidateNewSchedule(schedule)
+		require.Len(t, errs, 1, field)
+		assert.Equal(t, field, errs[0].Field)
+	}
+}
+
+func TestSchedule_Due(t *testing.T) {
+	schedule := validSchedule()
+	schedule.Cron = "0 * * * *"
+	schedule.Timezone = "UTC"
+	schedule.NextRunAt = time.Date(2026, 10, 19, 9, 0, 0, 0, time.UTC)
+	now := time.Date(2026, 10, 19, 12, 0, 30, 0, time.UTC)
+	next := time.Date(2026, 10, 19, 13, 0, 0, 0, time.UTC)
+
+	hours := func(hs ...int) []time.Time {
+		var runs []time.Time
+		for _, h := range hs {
+			runs = append(runs, time.Date(2026, 10, 19, h, 0, 0, 0, time.UTC))
+		}
+		return runs
+	}
+
+	for policy, want := range map[MissedRunPolicy][]time.Time{
+		MissedRunSkip: hours(12),
+		MissedRunOnce: hours(11, 12),
+		MissedRunAll:  hours(9, 10, 11, 12),
+	} {
+		schedule.MissedRunPolicy = policy
+		runs, nextRun, err := schedule.Due(now, time.Minute)
+		require.NoError(t, err)
+		assert.Equal(t, want, runs, policy)
+		assert.Equal(t, next, nextRun)
+	}
+
+	schedule.NextRunAt = next
+	runs, nextRun, err := schedule.Due(now, time.Minute)
+	require.NoError(t, err)
+	assert.Empty(t, runs)
+	assert.Equal(t, next, nextRun)
+}
+
+func TestSchedule_Due_CapsMissedRuns(t *testing.T) {
+	schedule := validSchedule()
+	schedule.Cron = "* * * * *"
+	schedule.MissedRunPolicy = MissedRunAll
+	schedule.NextRunAt = time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+
+	runs, _, err := schedule.Due(now, 30*time.Second)
+	require.NoError(t, err)
+	require.Len(t, runs, MaxMissedRuns+1)
+	assert.Equal(t, now, runs[len(runs)-1])
+}
+
+func TestSchedule_NewBuild(t *testing.T) {
+	schedule := validSchedule()
+	schedule.Env = StringMap{"A": "1"}
+
+	build := schedule.NewBuild()
+
+	assert.Equal(t, schedule.RepoUrl, build.RepoUrl)
+	assert.Equal(t, schedule.Command, build.Command)
+	assert.Equal(t, "schedule:nightly", *build.TriggeredBy)
+	build.Env["A"] = "2"
+	assert.Equal(t, "1", schedule.Env["A"])
+}
@@ -0,0 +1,89 @@
+package domain
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"sort"
+)
+
+// StringList is persisted as a JSON array so it works with any SQL backend.
+type StringList []string
+
+func (l StringList) Value() (driver.Value, error) {
+	if l == nil {
+		return "[]", nil
+	}
+	b, err := json.Marshal([]string(l))
+	if err != nil {
+		return nil, err
+	}
+	return string(b), nil
+}
+
+func (l *StringList) Scan(value interface{}) error {
+	return scanJSON(value, l)
+}
+
+func scanJSON(value interface{}, dest interface{}) error {
+	switch v := value.(type) {
+	case nil:
+		return nil
+	case []byte:
+		return json.Unmarshal(v, dest)
+	case string:
+		return json.Unmarshal([]byte(v), dest)
+	default:
+		return fmt.Errorf("unsupported type %T for json column", value)
+	}
+}
+
+// StringMap is persisted as a JSON object so it works with any SQL backend.
+type StringMap map[string]string
+
+func (m StringMap) Value() (driver.Value, error) {
+	if m == nil {
+		return "{}", nil
+	}
+	b, err := json.Marshal(map[string]string(m))
+	if err != nil {
+		return nil, err
+	}
+	return string(b), nil
+}
+
+func (m *StringMap) Scan(value interface{}) error {
+	return scanJSON(value, m)
+}
+
+// Environ renders the map as sorted KEY=VALUE pairs.
+func (m StringMap) Environ() []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	env := make([]string, 0, len(keys))
+	for _, k := range keys {
+		env = append(env, k+"="+m[k])
+	}
+	return env
+}
+
+func (m StringMap) clone() StringMap {
+	c := make(StringMap, len(m))
+	for k, v := range m {
+		c[k] = v
+	}
+	return c
+}
+
+func (m StringMap) matches(subset StringMap) bool {
+	for k, v := range subset {
+		if m[k] != v {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,123 @@
+package domain
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"time"
+)
+
+const MaxLabelLength = 63
+
+// WorkerOfflineAfter is how long a worker may go without a heartbeat before
+// it is reported offline.
+const WorkerOfflineAfter = time.Minute
+
+var labelPattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._:=/-]*$`)
+
+var (
+	ErrWorkerNotFound     = NewError(ErrNotFound, "worker not found")
+	ErrInvalidLabels      = NewError(ErrInvalidArgument, "invalid labels")
+	ErrInvalidWorkerState = NewError(ErrInvalidArgument, "invalid worker state")
+	ErrWorkerNotClaiming  = NewError(ErrConflict, "worker is paused or draining")
+)
+
+// WorkerState is what an admin set for a worker. Paused and draining
+// workers claim no new jobs; a draining worker exits once it is idle.
+type WorkerState string
+
+const (
+	WorkerStateActive   WorkerState = "active"
+	WorkerStateDraining WorkerState = "draining"
+	WorkerStatePaused   WorkerState = "paused"
+)
+
+// WorkerStatus is how a worker is reported in the fleet status.
+type WorkerStatus string
+
+const (
+	WorkerStatusOnline   WorkerStatus = "online"
+	WorkerStatusDraining WorkerStatus = "draining"
+	WorkerStatusPaused   WorkerStatus = "paused"
+	WorkerStatusOffline  WorkerStatus = "offline"
+)
+
+// Worker is a worker process that registered with the orchestrator. Jobs
+// are only handed to workers whose labels satisfy the runs_on of the build.
+type Worker struct {
+	ID             string      `json:"id" gorm:"primaryKey;type:text"`
+	Hostname       string      `json:"hostname"`
+	Version        string      `json:"version"`
+	Labels         StringList  `json:"labels" gorm:"type:jsonb"`
+	Slots          int         `json:"slots" gorm:"not null;default:1"`
+	State          WorkerState `json:"state" gorm:"type:text;not null;default:active"`
+	LastSeenAt     time.Time   `json:"last_seen_at"`
+	DeregisteredAt *time.Time  `json:"deregistered_at,omitempty"`
+	CreatedAt      time.Time   `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt      time.Time   `json:"updated_at" gorm:"autoUpdateTime"`
+
+	// Computed on read.
+	Status        WorkerStatus `json:"status,omitempty" gorm:"-"`
+	CurrentBuilds []string     `json:"current_builds,omitempty" gorm:"-"`
+}
+
+// StatusAt returns the status of w at now. Workers that deregistered or
+// missed their heartbeats for WorkerOfflineAfter are offline.
+func (w *Worker) StatusAt(now time.Time) WorkerStatus {
+	if w.DeregisteredAt != nil || now.Sub(w.LastSeenAt) > WorkerOfflineAfter {
+		return WorkerStatusOffline
+	}
+	switch w.State {
+	case WorkerStateDraining:
+		return WorkerStatusDraining
+	case WorkerStatePaused:
+		return WorkerStatusPaused
+	}
+	return WorkerStatusOnline
+}
+
+// Claims reports whether a worker in state s takes new jobs.
+func (s WorkerState) Claims() bool {
+	return s != WorkerStateDraining && s != WorkerStatePaused
+}
+
+// ValidateWorkerState checks a state requested by an admin.
+func ValidateWorkerState(state WorkerState) error {
+	switch state {
+	case WorkerStateActive, WorkerStateDraining, WorkerStatePaused:
+		return nil
+	}
+	return fmt.Errorf("%w: %q", ErrInvalidWorkerState, state)
+}
+
+// Satisfies reports whether labels contain every label in runsOn.
+func Satisfies(labels []string, runsOn []string) bool {
+	for _, label := range runsOn {
+		if !slices.Contains(labels, label) {
+			return false
+		}
+	}
+	return true
+}
+
+// ValidateLabels checks worker labels and the runs_on of a build.
+func ValidateLabels(labels []string) error {
+	for _, label := range labels {
+		if msg := validateLabel(label); msg != "" {
+			return fmt.Errorf("%w: label %q %s", ErrInvalidLabels, label, msg)
+		}
+	}
+	return nil
+}
+
+func validateLabel(label string) string {
+	switch {
+	case label == "":
+		return "is empty"
+	case len(label) > MaxLabelLength:
+		return fmt.Sprintf("must be at most %d characters", MaxLabelLength)
+	case !labelPattern.MatchString(label):
+		return "may only contain letters, digits and . _ : = / -"
+	}
+	return ""
+}
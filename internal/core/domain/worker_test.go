@@ -0,0 +1,60 @@
+package domain
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSatisfies(t *testing.T) {
+	labels := []string{"linux", "arm64", "docker"}
+
+	assert.True(t, Satisfies(labels, nil))
+	assert.True(t, Satisfies(labels, []string{"arm64", "linux"}))
+	assert.False(t, Satisfies(labels, []string{"arm64", "gpu"}))
+	assert.False(t, Satisfies(nil, []string{"linux"}))
+}
+
+func TestValidateLabels(t *testing.T) {
+	assert.NoError(t, ValidateLabels([]string{"linux", "arch=arm64", "mem/64g", "docker:24"}))
+
+	for _, label := range []string{"", "has space", "-leading", strings.Repeat("a", MaxLabelLength+1)} {
+		assert.ErrorIs(t, ValidateLabels([]string{"linux", label}), ErrInvalidLabels, label)
+	}
+}
+
+func TestWorker_StatusAt(t *testing.T) {
+	now := time.Now()
+	deregisteredAt := now
+
+	cases := []struct {
+		name   string
+		worker Worker
+		want   WorkerStatus
+	}{
+		{"online", Worker{State: WorkerStateActive, LastSeenAt: now}, WorkerStatusOnline},
+		{"draining", Worker{State: WorkerStateDraining, LastSeenAt: now}, WorkerStatusDraining},
+		{"paused", Worker{State: WorkerStatePaused, LastSeenAt: now}, WorkerStatusPaused},
+		{"missed heartbeats", Worker{State: WorkerStateActive, LastSeenAt: now.Add(-2 * WorkerOfflineAfter)}, WorkerStatusOffline},
+		{"deregistered", Worker{State: WorkerStatePaused, LastSeenAt: now, DeregisteredAt: &deregisteredAt}, WorkerStatusOffline},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, tc.worker.StatusAt(now))
+		})
+	}
+}
+
+func TestWorkerState_Claims(t *testing.T) {
+	assert.True(t, WorkerStateActive.Claims())
+	assert.True(t, WorkerState("").Claims())
+	assert.False(t, WorkerStateDraining.Claims())
+	assert.False(t, WorkerStatePaused.Claims())
+}
+
+func TestValidateWorkerState(t *testing.T) {
+	assert.NoError(t, ValidateWorkerState(WorkerStatePaused))
+	assert.ErrorIs(t, ValidateWorkerState("stopped"), ErrInvalidWorkerState)
+}
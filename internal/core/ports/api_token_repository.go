@@ -0,0 +1,15 @@
+package ports
+
+import (
+	"context"
+	"github.com/H3nSte1n/ci-orchestrator/internal/core/domain"
+	"time"
+)
+
+type APITokenRepository interface {
+	Save(ctx context.Context, token *domain.APIToken) error
+	FindByHash(ctx context.Context, tokenHash string) (*domain.APIToken, error)
+	List(ctx context.Context) ([]domain.APIToken, error)
+	Revoke(ctx context.Context, tokenId string, at time.Time) error
+	TouchLastUsed(ctx context.Context, tokenId string, at time.Time) error
+}
@@ -0,0 +1,15 @@
+package ports
+
+import (
+	"context"
+	"github.com/H3nSte1n/ci-orchestrator/internal/core/domain"
+)
+
+type APITokenService interface {
+	// Create issues a token and returns its secret, which is not stored and
+	// cannot be retrieved again.
+	Create(ctx context.Context, name string, scopes []string) (*domain.APIToken, string, error)
+	Authenticate(ctx context.Context, secret string) (*domain.APIToken, error)
+	List(ctx context.Context) ([]domain.APIToken, error)
+	Revoke(ctx context.Context, tokenId string) error
+}
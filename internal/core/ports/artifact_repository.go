@@ -0,0 +1,12 @@
+package ports
+
+import (
+	"context"
+	"github.com/H3nSte1n/ci-orchestrator/internal/core/domain"
+)
+
+type ArtifactRepository interface {
+	Save(ctx context.Context, artifact *domain.Artifact) error
+	FindByBuildID(ctx context.Context, buildId string) ([]domain.Artifact, error)
+	FindByName(ctx context.Context, buildId string, name string) (*domain.Artifact, error)
+}
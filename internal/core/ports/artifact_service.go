@@ -0,0 +1,15 @@
+package ports
+
+import (
+	"context"
+	"github.com/H3nSte1n/ci-orchestrator/internal/core/domain"
+	"io"
+)
+
+type ArtifactService interface {
+	Store(ctx context.Context, job *domain.Job, name string, r io.Reader, size int64) (*domain.Artifact, error)
+	List(ctx context.Context, buildId string) ([]domain.Artifact, error)
+	Get(ctx context.Context, buildId string, name string) (*domain.Artifact, error)
+	Open(ctx context.Context, artifact *domain.Artifact) (io.ReadSeekCloser, error)
+	WriteArchive(ctx context.Context, buildId string, format domain.ArchiveFormat, w io.Writer) error
+}
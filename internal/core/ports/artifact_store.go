@@ -0,0 +1,15 @@
+package ports
+
+import (
+	"context"
+	"io"
+)
+
+type ArtifactStore interface {
+	Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) error
+	Open(ctx context.Context, key string) (io.ReadCloser, error)
+	// OpenRange reads length bytes starting at offset; a negative length
+	// reads to the end of the object.
+	OpenRange(ctx context.Context, key string, offset, length int64) (io.ReadCloser, error)
+	Delete(ctx context.Context, key string) error
+}
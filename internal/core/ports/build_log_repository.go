@@ -7,4 +7,9 @@ import (
 
 type BuildLogRepository interface {
 	Save(ctx context.Context, buildLog *domain.BuildLog) error
+	SaveAll(ctx context.Context, buildLogs []domain.BuildLog) error
+	// FindByBuildID returns up to limit lines with a seq greater than
+	// afterSeq, oldest first. The lines of a build commit in seq order, so
+	// paging by the last seq seen skips none.
+	FindByBuildID(ctx context.Context, buildId string, afterSeq int64, limit int) ([]domain.BuildLog, error)
 }
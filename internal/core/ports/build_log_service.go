@@ -6,5 +6,7 @@ import (
 )
 
 type BuildLogService interface {
-	AppendLog(ctx context.Context, buildId string, logEvent domain.LogEvent) error
+	AppendLog(ctx context.Context, buildId string, jobId string, logEvent domain.LogEvent) error
+	AppendLogs(ctx context.Context, buildId string, jobId string, logEvents []domain.LogEvent) error
+	ListLogs(ctx context.Context, buildId string, afterSeq int64, limit int) ([]domain.BuildLog, error)
 }
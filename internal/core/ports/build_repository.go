@@ -3,11 +3,54 @@ package ports
 import (
 	"context"
 	"github.com/H3nSte1n/ci-orchestrator/internal/core/domain"
+	"time"
 )
 
 type BuildRepository interface {
 	Save(ctx context.Context, build *domain.Build) error
 	Update(ctx context.Context, build *domain.Build) error
 	FindByID(ctx context.Context, buildId string) (*domain.Build, error)
-	ClaimNext(ctx context.Context, workerId string) (*domain.Build, error)
+	List(ctx context.Context, filter domain.BuildFilter) ([]domain.Build, error)
+	// UpdatePriority changes the priority of a pending build and its matrix
+	// children and reranks their pending jobs. Builds that have started
+	// yield domain.ErrBuildNotPending.
+	UpdatePriority(ctx context.Context, buildId string, priority int) error
+	// Cancel cancels an unfinished build and its matrix children in one go:
+	// their pending jobs are canceled and their running jobs are asked to
+	// stop. Canceling a finished build does nothing.
+	Cancel(ctx context.Context, buildId string) error
+	// CancelSuperseded cancels the top-level builds of build's concurrency
+	// group, and their matrix children, that were created before it and are
+	// in one of statuses, like Cancel does. A canceled build keeps its group
+	// slot until its running jobs have stopped.
+	CancelSuperseded(ctx context.Context, build *domain.Build, statuses []domain.BuildStatus) error
+	// ClaimNext starts the next ready job of a due build whose runs_on the
+	// labels registered for workerId satisfy and whose repository and
+	// concurrency group have room, earliest domain.ShareRank first: each
+	// build its repository runs pushes a job back by domain.RepoShareAging.
+	// Claims of builds in the same limited repository or group are
+	// serialized, so neither runs more builds than its limit.
+	ClaimNext(ctx context.Context, workerId string) (*domain.Job, error)
+	// StartJob marks a job handed out by a queue as running for workerId.
+	// Starting a job the worker already runs returns it again; any other job
+	// that is not pending, not ready or belongs to a finished build yields
+	// domain.ErrJobNotClaimable, one whose build's not_before lies ahead
+	// domain.ErrBuildNotDue, one the worker lacks the labels for
+	// domain.ErrJobNotForWorker, one whose concurrency group is full
+	// domain.ErrConcurrencyLimit and one whose repository runs its maximum
+	// domain.ErrRepoLimit.
+	StartJob(ctx context.Context, jobId string, workerId string) (*domain.Job, error)
+	// ReleaseJob puts a running job held by workerId back to pending.
+	ReleaseJob(ctx context.Context, jobId string, workerId string) error
+	// RequeueExpired puts running jobs whose lock was last refreshed before
+	// before back to pending and returns their ids. Expired jobs of builds
+	// that already finished are canceled instead.
+	RequeueExpired(ctx context.Context, before time.Time) ([]string, error)
+	FindJobByID(ctx context.Context, jobId string) (*domain.Job, error)
+	Heartbeat(ctx context.Context, jobId string, workerId string, at time.Time) error
+	// CompleteJob records the outcome of a running job held by workerId. It
+	// returns domain.ErrJobLockLost when the job is no longer running for
+	// workerId, so a worker whose lease expired cannot overwrite the result
+	// of the worker that took the job over.
+	CompleteJob(ctx context.Context, job *domain.Job, workerId string) error
 }
@@ -8,9 +8,23 @@ import (
 
 type BuildService interface {
 	CreateBuild(ctx context.Context, build *domain.Build) error
+	// RetryBuild creates a new build from the settings of a finished one.
+	RetryBuild(ctx context.Context, buildId string, triggeredBy *string) (*domain.Build, error)
 	CancelBuild(ctx context.Context, buildId string) error
 	UpdateStatus(ctx context.Context, buildId string, status domain.BuildStatus) error
+	// UpdatePriority changes the priority of a build that has not started.
+	UpdatePriority(ctx context.Context, buildId string, priority int) (*domain.Build, error)
 	GetBuild(ctx context.Context, buildId string) (*domain.Build, error)
-	ClaimNext(ctx context.Context, workerId string) (*domain.Build, error)
-	CompleteBuild(ctx context.Context, buildId string, exitCode int, finishedAt *time.Time, error error) error
+	ListBuilds(ctx context.Context, filter domain.BuildFilter) (*domain.BuildPage, error)
+	ClaimNext(ctx context.Context, workerId string) (*domain.Job, error)
+	GetJob(ctx context.Context, jobId string) (*domain.Job, error)
+	Heartbeat(ctx context.Context, jobId string, workerId string) error
+	CancelRequested(ctx context.Context, jobId string) (bool, error)
+	// CompleteJob records the outcome of a job held by workerId, see
+	// BuildRepository.CompleteJob.
+	CompleteJob(ctx context.Context, jobId string, workerId string, exitCode int, finishedAt *time.Time, error error) error
+	// RequeueExpired hands the running jobs of workers that sent no heartbeat
+	// for longer than lease to other workers and returns how many it
+	// requeued.
+	RequeueExpired(ctx context.Context, lease time.Duration) (int, error)
 }
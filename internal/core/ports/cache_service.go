@@ -0,0 +1,14 @@
+package ports
+
+import (
+	"context"
+	"io"
+)
+
+type CacheService interface {
+	// Restore opens the archive stored for key or, failing that, the most
+	// recently used archive matching one of the restore key prefixes. It
+	// returns the key that matched.
+	Restore(ctx context.Context, scope string, key string, restoreKeys []string) (io.ReadCloser, string, error)
+	Save(ctx context.Context, scope string, key string, r io.Reader, size int64) error
+}
@@ -0,0 +1,18 @@
+package ports
+
+import (
+	"context"
+	"github.com/H3nSte1n/ci-orchestrator/internal/core/domain"
+	"io"
+)
+
+type CacheStore interface {
+	// Open returns domain.ErrCacheMiss when no archive is stored under key.
+	Open(ctx context.Context, key string) (io.ReadCloser, error)
+	// Put stores an archive and evicts the least recently used entries once
+	// the store grows beyond its size limit.
+	Put(ctx context.Context, key string, r io.Reader, size int64) error
+	// List returns the entries whose key starts with prefix.
+	List(ctx context.Context, prefix string) ([]domain.CacheEntry, error)
+	Delete(ctx context.Context, key string) error
+}
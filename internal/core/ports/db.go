@@ -8,11 +8,17 @@ type DB interface {
 	WithContext(ctx context.Context) DB
 	Create(value interface{}) DB
 	Where(query interface{}, args ...interface{}) DB
+	Joins(query string, args ...interface{}) DB
 	Updates(value interface{}) DB
+	Delete(value interface{}) DB
 	First(value interface{}) DB
+	Find(dest interface{}) DB
 	GetError() error
+	GetRowsAffected() int64
 	Transaction(f func(tx DB) error) error
 	Order(value string) DB
+	Limit(limit int) DB
 	Clauses(conds ...interface{}) DB
 	Model(value interface{}) DB
+	Preload(query string, args ...interface{}) DB
 }
@@ -0,0 +1,14 @@
+package ports
+
+import "context"
+
+// JobNotifier wakes idle workers when a job may have become claimable, so
+// they do not wait for their next poll. Notifications are best effort;
+// workers keep polling as a fallback.
+type JobNotifier interface {
+	Notify(ctx context.Context)
+	// Subscribe returns a channel that receives after every Notify until ctx
+	// is done. Notifications sent while the subscriber is busy collapse into
+	// one.
+	Subscribe(ctx context.Context) <-chan struct{}
+}
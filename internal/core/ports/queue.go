@@ -0,0 +1,28 @@
+package ports
+
+import (
+	"context"
+	"github.com/H3nSte1n/ci-orchestrator/internal/core/domain"
+	"time"
+)
+
+// Queue hands claimable jobs to workers. Jobs are enqueued once their needs
+// have succeeded; a claimed job is leased to one worker until it is acked,
+// nacked or its lease expires. Delivery is at least once, so the build
+// service starts every claimed job through BuildRepository.StartJob.
+type Queue interface {
+	Enqueue(ctx context.Context, jobIds ...string) error
+	// Claim returns nil when no job is waiting.
+	Claim(ctx context.Context, workerId string) (*domain.Lease, error)
+	// Ack removes a finished job from the queue.
+	Ack(ctx context.Context, jobId string) error
+	// Nack hands the job out again.
+	Nack(ctx context.Context, jobId string) error
+	// Defer hands the job out again no earlier than until.
+	Defer(ctx context.Context, jobId string, until time.Time) error
+	// Extend renews the lease of a running job.
+	Extend(ctx context.Context, jobId string) error
+	// Ranked reports whether claims follow the priority rank of jobs rather
+	// than the order they were enqueued in.
+	Ranked() bool
+}
@@ -0,0 +1,13 @@
+package ports
+
+import (
+	"context"
+	"github.com/H3nSte1n/ci-orchestrator/internal/core/domain"
+)
+
+type RepoLimitRepository interface {
+	// Save creates the limit of a repository or replaces its maximum.
+	Save(ctx context.Context, limit *domain.RepoLimit) error
+	// List returns the limits ordered by repository URL.
+	List(ctx context.Context) ([]domain.RepoLimit, error)
+}
@@ -0,0 +1,13 @@
+package ports
+
+import (
+	"context"
+	"github.com/H3nSte1n/ci-orchestrator/internal/core/domain"
+)
+
+type RepoLimitService interface {
+	// SetRepoLimit caps the builds of a repository that run at once; a
+	// maximum of 0 removes the cap.
+	SetRepoLimit(ctx context.Context, limit *domain.RepoLimit) error
+	ListRepoLimits(ctx context.Context) ([]domain.RepoLimit, error)
+}
@@ -0,0 +1,22 @@
+package ports
+
+import (
+	"context"
+	"github.com/H3nSte1n/ci-orchestrator/internal/core/domain"
+	"time"
+)
+
+type ScheduleRepository interface {
+	Save(ctx context.Context, schedule *domain.Schedule) error
+	FindByID(ctx context.Context, scheduleId string) (*domain.Schedule, error)
+	// List returns the schedules ordered by name.
+	List(ctx context.Context) ([]domain.Schedule, error)
+	Delete(ctx context.Context, scheduleId string) error
+	// ListDue returns the schedules whose next run is at or before now.
+	ListDue(ctx context.Context, now time.Time) ([]domain.Schedule, error)
+	// Advance moves the next run of a schedule from from to next and sets its
+	// last run to lastRunAt, which may be nil. It reports false, and changes
+	// nothing, when the next run is no longer from because another scheduler
+	// advanced it first.
+	Advance(ctx context.Context, scheduleId string, from time.Time, next time.Time, lastRunAt *time.Time) (bool, error)
+}
@@ -0,0 +1,18 @@
+package ports
+
+import (
+	"context"
+	"github.com/H3nSte1n/ci-orchestrator/internal/core/domain"
+	"time"
+)
+
+type ScheduleService interface {
+	CreateSchedule(ctx context.Context, schedule *domain.Schedule) error
+	GetSchedule(ctx context.Context, scheduleId string) (*domain.Schedule, error)
+	ListSchedules(ctx context.Context) ([]domain.Schedule, error)
+	DeleteSchedule(ctx context.Context, scheduleId string) error
+	// RunDue creates the builds of the schedule runs due at now. Runs more
+	// than grace overdue count as missed. It returns the number of builds
+	// created.
+	RunDue(ctx context.Context, now time.Time, grace time.Duration) (int, error)
+}
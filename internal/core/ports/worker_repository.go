@@ -0,0 +1,22 @@
+package ports
+
+import (
+	"context"
+	"github.com/H3nSte1n/ci-orchestrator/internal/core/domain"
+	"time"
+)
+
+type WorkerRepository interface {
+	// Register creates the worker or updates the details and last seen time
+	// of a known one and clears its deregistration. A paused worker stays
+	// paused; a draining one becomes active again.
+	Register(ctx context.Context, worker *domain.Worker) error
+	FindByID(ctx context.Context, workerId string) (*domain.Worker, error)
+	List(ctx context.Context) ([]domain.Worker, error)
+	Heartbeat(ctx context.Context, workerId string, at time.Time) error
+	Deregister(ctx context.Context, workerId string, at time.Time) error
+	UpdateState(ctx context.Context, workerId string, state domain.WorkerState) error
+	// RunningJobs returns the running jobs, which carry the worker holding
+	// them in LockedBy.
+	RunningJobs(ctx context.Context) ([]domain.Job, error)
+}
@@ -0,0 +1,19 @@
+package ports
+
+import (
+	"context"
+	"github.com/H3nSte1n/ci-orchestrator/internal/core/domain"
+)
+
+type WorkerService interface {
+	// Register records a worker, its details and the labels it offers.
+	Register(ctx context.Context, worker *domain.Worker) error
+	// Heartbeat marks the worker as seen and returns it, so the worker
+	// learns whether it was paused or drained.
+	Heartbeat(ctx context.Context, workerId string) (*domain.Worker, error)
+	// Deregister marks the worker as offline when it shuts down.
+	Deregister(ctx context.Context, workerId string) error
+	// ListWorkers returns all workers with their status and current builds.
+	ListWorkers(ctx context.Context) ([]domain.Worker, error)
+	SetState(ctx context.Context, workerId string, state domain.WorkerState) (*domain.Worker, error)
+}
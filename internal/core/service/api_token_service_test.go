@@ -0,0 +1,159 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/H3nSte1n/ci-orchestrator/internal/core/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type mockAPITokenRepository struct {
+	mock.Mock
+}
+
+func (m *mockAPITokenRepository) Save(ctx context.Context, token *domain.APIToken) error {
+	args := m.Called(ctx, token)
+	return args.Error(0)
+}
+
+func (m *mockAPITokenRepository) FindByHash(ctx context.Context, tokenHash string) (*domain.APIToken, error) {
+	args := m.Called(ctx, tokenHash)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.APIToken), args.Error(1)
+}
+
+func (m *mockAPITokenRepository) List(ctx context.Context) ([]domain.APIToken, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.APIToken), args.Error(1)
+}
+
+func (m *mockAPITokenRepository) Revoke(ctx context.Context, tokenId string, at time.Time) error {
+	args := m.Called(ctx, tokenId, at)
+	return args.Error(0)
+}
+
+func (m *mockAPITokenRepository) TouchLastUsed(ctx context.Context, tokenId string, at time.Time) error {
+	args := m.Called(ctx, tokenId, at)
+	return args.Error(0)
+}
+
+func TestAPITokenService_Create_StoresOnlyTheHash(t *testing.T) {
+	repo := new(mockAPITokenRepository)
+	var saved *domain.APIToken
+	repo.On("Save", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		saved = args.Get(1).(*domain.APIToken)
+	}).Return(nil)
+
+	svc := NewAPITokenService(repo)
+	token, secret, err := svc.Create(context.Background(), "deploy-bot", []string{"builds:read", "builds:write"})
+
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(secret, tokenSecretPrefix))
+	assert.Same(t, saved, token)
+	assert.Equal(t, hashToken(secret), saved.TokenHash)
+	assert.NotContains(t, saved.TokenHash, secret)
+	assert.Equal(t, secret[:tokenPrefixLength], saved.Prefix)
+	assert.Equal(t, domain.StringList{"builds:read", "builds:write"}, saved.Scopes)
+}
+
+func TestAPITokenService_Create_RejectsInvalidInput(t *testing.T) {
+	repo := new(mockAPITokenRepository)
+	svc := NewAPITokenService(repo)
+
+	_, _, err := svc.Create(context.Background(), " ", []string{"admin"})
+	assert.ErrorIs(t, err, domain.ErrInvalidArgument)
+
+	_, _, err = svc.Create(context.Background(), "bot", []string{"builds:delete"})
+	assert.ErrorIs(t, err, domain.ErrInvalidScope)
+
+	repo.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+}
+
+func TestAPITokenService_Authenticate_Success(t *testing.T) {
+	repo := new(mockAPITokenRepository)
+	secret := tokenSecretPrefix + "abc"
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	repo.On("FindByHash", mock.Anything, hashToken(secret)).Return(&domain.APIToken{ID: "token-id"}, nil)
+	repo.On("TouchLastUsed", mock.Anything, "token-id", now).Return(nil)
+
+	svc := &apiTokenService{tokenRepo: repo, now: func() time.Time { return now }}
+	token, err := svc.Authenticate(context.Background(), secret)
+
+	require.NoError(t, err)
+	assert.Equal(t, "token-id", token.ID)
+	assert.Equal(t, now, *token.LastUsedAt)
+	repo.AssertExpectations(t)
+}
+
+func TestAPITokenService_Authenticate_SkipsRecentLastUsedUpdate(t *testing.T) {
+	repo := new(mockAPITokenRepository)
+	secret := tokenSecretPrefix + "abc"
+	now := time.Now()
+	lastUsed := now.Add(-time.Second)
+	repo.On("FindByHash", mock.Anything, hashToken(secret)).Return(&domain.APIToken{ID: "token-id", LastUsedAt: &lastUsed}, nil)
+
+	svc := &apiTokenService{tokenRepo: repo, now: func() time.Time { return now }}
+	_, err := svc.Authenticate(context.Background(), secret)
+
+	require.NoError(t, err)
+	repo.AssertNotCalled(t, "TouchLastUsed", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestAPITokenService_Authenticate_Rejects(t *testing.T) {
+	revokedAt := time.Now()
+	tests := []struct {
+		name   string
+		secret string
+		token  *domain.APIToken
+		err    error
+	}{
+		{"wrong prefix", "abc", nil, nil},
+		{"unknown token", tokenSecretPrefix + "unknown", nil, domain.ErrTokenNotFound},
+		{"revoked token", tokenSecretPrefix + "revoked", &domain.APIToken{ID: "token-id", RevokedAt: &revokedAt}, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := new(mockAPITokenRepository)
+			if tt.token != nil || tt.err != nil {
+				repo.On("FindByHash", mock.Anything, hashToken(tt.secret)).Return(tt.token, tt.err)
+			}
+
+			_, err := NewAPITokenService(repo).Authenticate(context.Background(), tt.secret)
+
+			assert.ErrorIs(t, err, domain.ErrInvalidToken)
+			repo.AssertNotCalled(t, "TouchLastUsed", mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestAPITokenService_Authenticate_RepositoryError(t *testing.T) {
+	repo := new(mockAPITokenRepository)
+	expectedErr := errors.New("connection refused")
+	repo.On("FindByHash", mock.Anything, mock.Anything).Return(nil, expectedErr)
+
+	_, err := NewAPITokenService(repo).Authenticate(context.Background(), tokenSecretPrefix+"abc")
+
+	assert.Equal(t, expectedErr, err)
+}
+
+func TestAPITokenService_Revoke(t *testing.T) {
+	repo := new(mockAPITokenRepository)
+	repo.On("Revoke", mock.Anything, "token-id", mock.Anything).Return(domain.ErrTokenNotFound)
+
+	err := NewAPITokenService(repo).Revoke(context.Background(), "token-id")
+
+	assert.ErrorIs(t, err, domain.ErrTokenNotFound)
+	repo.AssertExpectations(t)
+}
@@ -0,0 +1,231 @@
+package service
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bufio"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"github.com/H3nSte1n/ci-orchestrator/internal/core/domain"
+	"github.com/H3nSte1n/ci-orchestrator/internal/core/ports"
+	"io"
+	"mime"
+	"net/http"
+	"path"
+)
+
+type artifactService struct {
+	artifactRepo  ports.ArtifactRepository
+	artifactStore ports.ArtifactStore
+}
+
+func NewArtifactService(repo ports.ArtifactRepository, store ports.ArtifactStore) ports.ArtifactService {
+	return &artifactService{
+		artifactRepo:  repo,
+		artifactStore: store,
+	}
+}
+
+// Store uploads an artifact produced by a job and records it on the build.
+// Artifacts of named jobs are namespaced by the job name so that jobs of the
+// same build cannot overwrite each other.
+func (s *artifactService) Store(ctx context.Context, job *domain.Job, name string, r io.Reader, size int64) (*domain.Artifact, error) {
+	if job.Name != "" && job.Name != domain.DefaultJobName {
+		name = path.Join(job.Name, name)
+	}
+
+	br := bufio.NewReader(r)
+	head, err := br.Peek(512)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return nil, err
+	}
+
+	contentType := detectContentType(name, head)
+	key := path.Join("builds", job.BuildID, name)
+
+	hasher := sha256.New()
+	if err := s.artifactStore.Put(ctx, key, io.TeeReader(br, hasher), size, contentType); err != nil {
+		return nil, err
+	}
+
+	artifact := &domain.Artifact{
+		BuildID:     job.BuildID,
+		JobID:       job.ID,
+		Name:        name,
+		Size:        size,
+		Checksum:    "sha256:" + hex.EncodeToString(hasher.Sum(nil)),
+		ContentType: contentType,
+		StorageKey:  key,
+	}
+
+	if err := s.artifactRepo.Save(ctx, artifact); err != nil {
+		return nil, err
+	}
+
+	return artifact, nil
+}
+
+func detectContentType(name string, head []byte) string {
+	if contentType := mime.TypeByExtension(path.Ext(name)); contentType != "" {
+		return contentType
+	}
+	return http.DetectContentType(head)
+}
+
+func (s *artifactService) List(ctx context.Context, buildId string) ([]domain.Artifact, error) {
+	return s.artifactRepo.FindByBuildID(ctx, buildId)
+}
+
+func (s *artifactService) Get(ctx context.Context, buildId string, name string) (*domain.Artifact, error) {
+	return s.artifactRepo.FindByName(ctx, buildId, name)
+}
+
+func (s *artifactService) Open(ctx context.Context, artifact *domain.Artifact) (io.ReadSeekCloser, error) {
+	return &artifactReader{
+		ctx:   ctx,
+		store: s.artifactStore,
+		key:   artifact.StorageKey,
+		size:  artifact.Size,
+	}, nil
+}
+
+// WriteArchive streams every artifact of a build into a zip or tar.gz archive
+// without buffering whole files.
+func (s *artifactService) WriteArchive(ctx context.Context, buildId string, format domain.ArchiveFormat, w io.Writer) error {
+	if format != domain.ArchiveZip && format != domain.ArchiveTarGz {
+		return fmt.Errorf("%w: %q", domain.ErrUnsupportedArchive, format)
+	}
+
+	artifacts, err := s.artifactRepo.FindByBuildID(ctx, buildId)
+	if err != nil {
+		return err
+	}
+
+	if format == domain.ArchiveZip {
+		return s.writeZip(ctx, artifacts, w)
+	}
+	return s.writeTarGz(ctx, artifacts, w)
+}
+
+func (s *artifactService) writeZip(ctx context.Context, artifacts []domain.Artifact, w io.Writer) error {
+	zw := zip.NewWriter(w)
+
+	for _, artifact := range artifacts {
+		entry, err := zw.CreateHeader(&zip.FileHeader{
+			Name:     artifact.Name,
+			Method:   zip.Deflate,
+			Modified: artifact.CreatedAt,
+		})
+		if err != nil {
+			return err
+		}
+		if err := s.copyArtifact(ctx, artifact, entry); err != nil {
+			return err
+		}
+	}
+
+	return zw.Close()
+}
+
+func (s *artifactService) writeTarGz(ctx context.Context, artifacts []domain.Artifact, w io.Writer) error {
+	gw := gzip.NewWriter(w)
+	tw := tar.NewWriter(gw)
+
+	for _, artifact := range artifacts {
+		if err := tw.WriteHeader(&tar.Header{
+			Name:    artifact.Name,
+			Mode:    0o644,
+			Size:    artifact.Size,
+			ModTime: artifact.CreatedAt,
+		}); err != nil {
+			return err
+		}
+		if err := s.copyArtifact(ctx, artifact, tw); err != nil {
+			return err
+		}
+	}
+
+	if err := tw.Close(); err != nil {
+		return err
+	}
+	return gw.Close()
+}
+
+func (s *artifactService) copyArtifact(ctx context.Context, artifact domain.Artifact, w io.Writer) error {
+	rc, err := s.artifactStore.Open(ctx, artifact.StorageKey)
+	if err != nil {
+		return fmt.Errorf("%s: %w", artifact.Name, err)
+	}
+	defer rc.Close()
+
+	if _, err := io.Copy(w, rc); err != nil {
+		return fmt.Errorf("%s: %w", artifact.Name, err)
+	}
+	return nil
+}
+
+// artifactReader implements io.ReadSeeker on top of ranged store reads, so
+// range requests against remote stores only fetch the bytes asked for.
+type artifactReader struct {
+	ctx    context.Context
+	store  ports.ArtifactStore
+	key    string
+	size   int64
+	offset int64
+	body   io.ReadCloser
+}
+
+func (r *artifactReader) Read(p []byte) (int, error) {
+	if r.offset >= r.size {
+		return 0, io.EOF
+	}
+
+	if r.body == nil {
+		body, err := r.store.OpenRange(r.ctx, r.key, r.offset, -1)
+		if err != nil {
+			return 0, err
+		}
+		r.body = body
+	}
+
+	n, err := r.body.Read(p)
+	r.offset += int64(n)
+	return n, err
+}
+
+func (r *artifactReader) Seek(offset int64, whence int) (int64, error) {
+	var abs int64
+	switch whence {
+	case io.SeekStart:
+		abs = offset
+	case io.SeekCurrent:
+		abs = r.offset + offset
+	case io.SeekEnd:
+		abs = r.size + offset
+	default:
+		return 0, errors.New("artifact reader: invalid whence")
+	}
+	if abs < 0 {
+		return 0, errors.New("artifact reader: negative position")
+	}
+
+	if abs != r.offset && r.body != nil {
+		r.body.Close()
+		r.body = nil
+	}
+	r.offset = abs
+	return abs, nil
+}
+
+func (r *artifactReader) Close() error {
+	if r.body == nil {
+		return nil
+	}
+	err := r.body.Close()
+	r.body = nil
+	return err
+}
@@ -0,0 +1,263 @@
+package service
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/H3nSte1n/ci-orchestrator/internal/core/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type mockArtifactRepository struct {
+	mock.Mock
+}
+
+func (m *mockArtifactRepository) Save(ctx context.Context, artifact *domain.Artifact) error {
+	args := m.Called(ctx, artifact)
+	return args.Error(0)
+}
+
+func (m *mockArtifactRepository) FindByBuildID(ctx context.Context, buildId string) ([]domain.Artifact, error) {
+	args := m.Called(ctx, buildId)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.Artifact), args.Error(1)
+}
+
+func (m *mockArtifactRepository) FindByName(ctx context.Context, buildId string, name string) (*domain.Artifact, error) {
+	args := m.Called(ctx, buildId, name)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Artifact), args.Error(1)
+}
+
+type mockArtifactStore struct {
+	mock.Mock
+	stored map[string]string
+}
+
+func (m *mockArtifactStore) Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) error {
+	args := m.Called(ctx, key, size, contentType)
+	if args.Error(0) != nil {
+		return args.Error(0)
+	}
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return err
+	}
+	if m.stored == nil {
+		m.stored = map[string]string{}
+	}
+	m.stored[key] = string(data)
+	return nil
+}
+
+func (m *mockArtifactStore) Open(ctx context.Context, key string) (io.ReadCloser, error) {
+	args := m.Called(ctx, key)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(io.ReadCloser), args.Error(1)
+}
+
+func (m *mockArtifactStore) OpenRange(ctx context.Context, key string, offset int64, length int64) (io.ReadCloser, error) {
+	args := m.Called(ctx, key, offset, length)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(io.ReadCloser), args.Error(1)
+}
+
+func (m *mockArtifactStore) Delete(ctx context.Context, key string) error {
+	args := m.Called(ctx, key)
+	return args.Error(0)
+}
+
+func TestArtifactService_Store_Success(t *testing.T) {
+	mockRepo := new(mockArtifactRepository)
+	mockStore := new(mockArtifactStore)
+	job := jobTestData()
+
+	mockStore.On("Put", mock.Anything, "builds/ci-id/report.json", int64(11), "application/json").Return(nil)
+	mockRepo.On("Save", mock.Anything, mock.Anything).Return(nil)
+
+	service := NewArtifactService(mockRepo, mockStore)
+	artifact, err := service.Store(context.Background(), job, "report.json", strings.NewReader(`{"ok":true}`), 11)
+
+	require.NoError(t, err)
+	assert.Equal(t, "report.json", artifact.Name)
+	assert.Equal(t, "ci-id", artifact.BuildID)
+	assert.Equal(t, "job-id", artifact.JobID)
+	assert.Equal(t, int64(11), artifact.Size)
+	assert.Equal(t, "application/json", artifact.ContentType)
+	assert.Equal(t, "sha256:4062edaf750fb8074e7e83e0c9028c94e32468a8b6f1614774328ef045150f93", artifact.Checksum)
+	assert.Equal(t, `{"ok":true}`, mockStore.stored["builds/ci-id/report.json"])
+	mockRepo.AssertExpectations(t)
+	mockStore.AssertExpectations(t)
+}
+
+func TestArtifactService_Store_NamespacesNamedJobs(t *testing.T) {
+	mockRepo := new(mockArtifactRepository)
+	mockStore := new(mockArtifactStore)
+	job := jobTestData()
+	job.Name = "build"
+
+	mockStore.On("Put", mock.Anything, "builds/ci-id/build/bin/app", int64(4), mock.Anything).Return(nil)
+	mockRepo.On("Save", mock.Anything, mock.MatchedBy(func(a *domain.Artifact) bool {
+		return a.Name == "build/bin/app"
+	})).Return(nil)
+
+	service := NewArtifactService(mockRepo, mockStore)
+	_, err := service.Store(context.Background(), job, "bin/app", strings.NewReader("\x7fELF"), 4)
+
+	assert.NoError(t, err)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestArtifactService_Store_UploadError(t *testing.T) {
+	mockRepo := new(mockArtifactRepository)
+	mockStore := new(mockArtifactStore)
+	expectedErr := errors.New("upload failed")
+
+	mockStore.On("Put", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(expectedErr)
+
+	service := NewArtifactService(mockRepo, mockStore)
+	_, err := service.Store(context.Background(), jobTestData(), "out.txt", strings.NewReader("hello"), 5)
+
+	assert.Equal(t, expectedErr, err)
+	mockRepo.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+}
+
+func TestArtifactService_Store_SaveError(t *testing.T) {
+	mockRepo := new(mockArtifactRepository)
+	mockStore := new(mockArtifactStore)
+	expectedErr := errors.New("database error")
+
+	mockStore.On("Put", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	mockRepo.On("Save", mock.Anything, mock.Anything).Return(expectedErr)
+
+	service := NewArtifactService(mockRepo, mockStore)
+	_, err := service.Store(context.Background(), jobTestData(), "out.txt", strings.NewReader("hello"), 5)
+
+	assert.Equal(t, expectedErr, err)
+}
+
+func TestArtifactService_Get_NotFound(t *testing.T) {
+	mockRepo := new(mockArtifactRepository)
+	mockRepo.On("FindByName", mock.Anything, "ci-id", "missing").Return(nil, domain.ErrArtifactNotFound)
+
+	service := NewArtifactService(mockRepo, new(mockArtifactStore))
+	artifact, err := service.Get(context.Background(), "ci-id", "missing")
+
+	assert.Nil(t, artifact)
+	assert.ErrorIs(t, err, domain.ErrArtifactNotFound)
+}
+
+func TestArtifactService_Open_ReadsFromOffset(t *testing.T) {
+	mockStore := new(mockArtifactStore)
+	artifact := &domain.Artifact{StorageKey: "builds/ci-id/out.txt", Size: 11}
+
+	mockStore.On("OpenRange", mock.Anything, "builds/ci-id/out.txt", int64(6), int64(-1)).
+		Return(io.NopCloser(strings.NewReader("world")), nil)
+
+	service := NewArtifactService(new(mockArtifactRepository), mockStore)
+	reader, err := service.Open(context.Background(), artifact)
+	require.NoError(t, err)
+	defer reader.Close()
+
+	size, err := reader.Seek(0, io.SeekEnd)
+	require.NoError(t, err)
+	assert.Equal(t, int64(11), size)
+
+	_, err = reader.Seek(6, io.SeekStart)
+	require.NoError(t, err)
+
+	data, err := io.ReadAll(reader)
+	require.NoError(t, err)
+	assert.Equal(t, "world", string(data))
+	mockStore.AssertExpectations(t)
+}
+
+func archiveTestData() []domain.Artifact {
+	return []domain.Artifact{
+		{Name: "a.txt", Size: 5, StorageKey: "builds/ci-id/a.txt"},
+		{Name: "dir/b.txt", Size: 3, StorageKey: "builds/ci-id/dir/b.txt"},
+	}
+}
+
+func archiveTestStore() *mockArtifactStore {
+	mockStore := new(mockArtifactStore)
+	mockStore.On("Open", mock.Anything, "builds/ci-id/a.txt").Return(io.NopCloser(strings.NewReader("hello")), nil)
+	mockStore.On("Open", mock.Anything, "builds/ci-id/dir/b.txt").Return(io.NopCloser(strings.NewReader("bye")), nil)
+	return mockStore
+}
+
+func TestArtifactService_WriteArchive_Zip(t *testing.T) {
+	mockRepo := new(mockArtifactRepository)
+	mockRepo.On("FindByBuildID", mock.Anything, "ci-id").Return(archiveTestData(), nil)
+
+	var buf bytes.Buffer
+	service := NewArtifactService(mockRepo, archiveTestStore())
+	require.NoError(t, service.WriteArchive(context.Background(), "ci-id", domain.ArchiveZip, &buf))
+
+	zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	require.NoError(t, err)
+
+	contents := map[string]string{}
+	for _, f := range zr.File {
+		rc, err := f.Open()
+		require.NoError(t, err)
+		data, err := io.ReadAll(rc)
+		require.NoError(t, err)
+		rc.Close()
+		contents[f.Name] = string(data)
+	}
+	assert.Equal(t, map[string]string{"a.txt": "hello", "dir/b.txt": "bye"}, contents)
+}
+
+func TestArtifactService_WriteArchive_TarGz(t *testing.T) {
+	mockRepo := new(mockArtifactRepository)
+	mockRepo.On("FindByBuildID", mock.Anything, "ci-id").Return(archiveTestData(), nil)
+
+	var buf bytes.Buffer
+	service := NewArtifactService(mockRepo, archiveTestStore())
+	require.NoError(t, service.WriteArchive(context.Background(), "ci-id", domain.ArchiveTarGz, &buf))
+
+	gr, err := gzip.NewReader(&buf)
+	require.NoError(t, err)
+	tr := tar.NewReader(gr)
+
+	contents := map[string]string{}
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		data, err := io.ReadAll(tr)
+		require.NoError(t, err)
+		contents[header.Name] = string(data)
+	}
+	assert.Equal(t, map[string]string{"a.txt": "hello", "dir/b.txt": "bye"}, contents)
+}
+
+func TestArtifactService_WriteArchive_UnsupportedFormat(t *testing.T) {
+	mockRepo := new(mockArtifactRepository)
+
+	service := NewArtifactService(mockRepo, new(mockArtifactStore))
+	err := service.WriteArchive(context.Background(), "ci-id", domain.ArchiveFormat("rar"), io.Discard)
+
+	assert.ErrorIs(t, err, domain.ErrUnsupportedArchive)
+	mockRepo.AssertNotCalled(t, "FindByBuildID", mock.Anything, mock.Anything)
+}
@@ -16,9 +16,10 @@ func NewBuildLogService(repo ports.BuildLogRepository) ports.BuildLogService {
 	}
 }
 
-func (s *buildLogService) AppendLog(ctx context.Context, buildId string, logEvent domain.LogEvent) error {
+func (s *buildLogService) AppendLog(ctx context.Context, buildId string, jobId string, logEvent domain.LogEvent) error {
 	b := domain.BuildLog{
 		BuildID: buildId,
+		JobID:   &jobId,
 		Stream:  logEvent.Stream,
 		Content: logEvent.Line,
 	}
@@ -29,3 +30,24 @@ func (s *buildLogService) AppendLog(ctx context.Context, buildId string, logEven
 
 	return nil
 }
+
+func (s *buildLogService) AppendLogs(ctx context.Context, buildId string, jobId string, logEvents []domain.LogEvent) error {
+	logs := make([]domain.BuildLog, 0, len(logEvents))
+	for _, ev := range logEvents {
+		logs = append(logs, domain.BuildLog{
+			BuildID: buildId,
+			JobID:   &jobId,
+			Stream:  ev.Stream,
+			Content: ev.Line,
+		})
+	}
+
+	return s.buildLogRepo.SaveAll(ctx, logs)
+}
+
+func (s *buildLogService) ListLogs(ctx context.Context, buildId string, afterSeq int64, limit int) ([]domain.BuildLog, error) {
+	if limit <= 0 || limit > domain.MaxLogPageSize {
+		limit = domain.MaxLogPageSize
+	}
+	return s.buildLogRepo.FindByBuildID(ctx, buildId, afterSeq, limit)
+}
@@ -18,6 +18,17 @@ func (m *mockBuildLogRepository) Save(ctx context.Context, buildLog *domain.Buil
 	return args.Error(0)
 }
 
+func (m *mockBuildLogRepository) SaveAll(ctx context.Context, buildLogs []domain.BuildLog) error {
+	args := m.Called(ctx, buildLogs)
+	return args.Error(0)
+}
+
+func (m *mockBuildLogRepository) FindByBuildID(ctx context.Context, buildId string, afterSeq int64, limit int) ([]domain.BuildLog, error) {
+	args := m.Called(ctx, buildId, afterSeq, limit)
+	logs, _ := args.Get(0).([]domain.BuildLog)
+	return logs, args.Error(1)
+}
+
 func logEventTestData() domain.LogEvent {
 	return domain.LogEvent{
 		Stream: domain.LogStdout,
@@ -34,7 +45,7 @@ func TestBuildLogService_AppendLog_Success(t *testing.T) {
 	mockDB.On("Save", mock.Anything, mock.Anything).Return(nil)
 
 	service := NewBuildLogService(mockDB)
-	err := service.AppendLog(ctx, buildId, buildLog)
+	err := service.AppendLog(ctx, buildId, "1", buildLog)
 
 	assert.NoError(t, err)
 	mockDB.AssertExpectations(t)
@@ -50,8 +61,50 @@ func TestBuildLogService_AppendLog_Error(t *testing.T) {
 	mockDB.On("Save", mock.Anything, mock.Anything).Return(expectedErr)
 
 	service := NewBuildLogService(mockDB)
-	err := service.AppendLog(ctx, buildId, buildLog)
+	err := service.AppendLog(ctx, buildId, "1", buildLog)
 
 	assert.Error(t, err)
 	assert.Equal(t, expectedErr, err)
 }
+
+func TestBuildLogService_AppendLogs(t *testing.T) {
+	mockDB := new(mockBuildLogRepository)
+	events := []domain.LogEvent{logEventTestData(), {Stream: domain.LogStderr, Line: "warning"}}
+
+	mockDB.On("SaveAll", mock.Anything, mock.MatchedBy(func(logs []domain.BuildLog) bool {
+		return len(logs) == 2 &&
+			logs[0].BuildID == "0" && *logs[0].JobID == "1" && logs[0].Content == "The first line" &&
+			logs[1].Stream == domain.LogStderr
+	})).Return(nil)
+
+	service := NewBuildLogService(mockDB)
+	err := service.AppendLogs(context.Background(), "0", "1", events)
+
+	assert.NoError(t, err)
+	mockDB.AssertExpectations(t)
+}
+
+func TestBuildLogService_ListLogs(t *testing.T) {
+	mockDB := new(mockBuildLogRepository)
+	logs := []domain.BuildLog{{BuildID: "0", Seq: 4, Content: "hello"}}
+
+	mockDB.On("FindByBuildID", mock.Anything, "0", int64(3), 50).Return(logs, nil)
+
+	service := NewBuildLogService(mockDB)
+	result, err := service.ListLogs(context.Background(), "0", 3, 50)
+
+	assert.NoError(t, err)
+	assert.Equal(t, logs, result)
+}
+
+func TestBuildLogService_ListLogs_CapsLimit(t *testing.T) {
+	mockDB := new(mockBuildLogRepository)
+
+	mockDB.On("FindByBuildID", mock.Anything, "0", int64(0), domain.MaxLogPageSize).Return([]domain.BuildLog{}, nil)
+
+	service := NewBuildLogService(mockDB)
+	_, err := service.ListLogs(context.Background(), "0", 0, 0)
+
+	assert.NoError(t, err)
+	mockDB.AssertExpectations(t)
+}
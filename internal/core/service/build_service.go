@@ -2,40 +2,196 @@ package service
 
 import (
 	"context"
+	"errors"
 	"github.com/H3nSte1n/ci-orchestrator/internal/core/domain"
 	"github.com/H3nSte1n/ci-orchestrator/internal/core/ports"
+	"slices"
 	"time"
 )
 
 type buildService struct {
-	buildRepo ports.BuildRepository
+	buildRepo  ports.BuildRepository
+	workerRepo ports.WorkerRepository
+	queue      ports.Queue
 }
 
-func NewBuildService(buildRepository ports.BuildRepository) ports.BuildService {
+func NewBuildService(buildRepository ports.BuildRepository, workerRepository ports.WorkerRepository, queue ports.Queue) ports.BuildService {
 	return &buildService{
-		buildRepo: buildRepository,
+		buildRepo:  buildRepository,
+		workerRepo: workerRepository,
+		queue:      queue,
 	}
 }
 
 func (s *buildService) CreateBuild(ctx context.Context, build *domain.Build) error {
+	if build.Priority != 0 && !s.queue.Ranked() {
+		return domain.ErrPriorityUnsupported
+	}
+	build.DefaultConcurrency()
+	if build.NotBefore != nil {
+		// SQLite compares times as text, so every stored time shares a zone.
+		notBefore := build.NotBefore.UTC()
+		build.NotBefore = &notBefore
+	}
+	if build.Matrix != nil {
+		children, err := expandMatrix(build)
+		if err != nil {
+			return err
+		}
+		build.Children = children
+		build.Jobs = nil
+	} else if err := prepareJobs(build); err != nil {
+		return err
+	}
+
 	if err := s.buildRepo.Save(ctx, build); err != nil {
 		return err
 	}
+	if superseded := build.ConcurrencyPolicy.Supersedes(); build.ConcurrencyGroup != nil && len(superseded) > 0 {
+		if err := s.buildRepo.CancelSuperseded(ctx, build, superseded); err != nil {
+			return err
+		}
+	}
+	if err := s.queue.Enqueue(ctx, readyJobs(build)...); err != nil {
+		return err
+	}
 
+	return s.flagUnschedulable(ctx, build)
+}
+
+// flagUnschedulable marks the pending builds, and their matrix children,
+// whose runs_on no worker that is online, paused or draining satisfies.
+func (s *buildService) flagUnschedulable(ctx context.Context, builds ...*domain.Build) error {
+	var workers []domain.Worker
+	loaded := false
+
+	var flag func(build *domain.Build) error
+	flag = func(build *domain.Build) error {
+		for i := range build.Children {
+			if err := flag(&build.Children[i]); err != nil {
+				return err
+			}
+		}
+		if build.Status != domain.BuildStatusPending || len(build.RunsOn) == 0 {
+			return nil
+		}
+		if !loaded {
+			var err error
+			if workers, err = s.workerRepo.List(ctx); err != nil {
+				return err
+			}
+			loaded = true
+		}
+		now := time.Now()
+		build.Unschedulable = !slices.ContainsFunc(workers, func(w domain.Worker) bool {
+			return w.StatusAt(now) != domain.WorkerStatusOffline && domain.Satisfies(w.Labels, build.RunsOn)
+		})
+		return nil
+	}
+
+	for _, build := range builds {
+		if err := flag(build); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
-func (s *buildService) CancelBuild(ctx context.Context, buildId string) error {
-	err := s.buildRepo.Update(ctx, &domain.Build{
-		ID:     buildId,
-		Status: domain.BuildStatusCanceled,
-	})
+// readyJobs returns the ids of the jobs of build and its matrix children
+// that need nothing and can be claimed right away.
+func readyJobs(build *domain.Build) []string {
+	var ids []string
+	for _, job := range build.Jobs {
+		if len(job.Needs) == 0 {
+			ids = append(ids, job.ID)
+		}
+	}
+	for i := range build.Children {
+		ids = append(ids, readyJobs(&build.Children[i])...)
+	}
+	return ids
+}
+
+func prepareJobs(build *domain.Build) error {
+	if len(build.Jobs) == 0 {
+		build.Jobs = []domain.Job{{
+			Name:      domain.DefaultJobName,
+			Command:   build.Command,
+			Artifacts: build.Artifacts,
+		}}
+	}
+
+	return domain.ValidateJobs(build.Jobs)
+}
 
+// expandMatrix creates one child build per matrix combination. The parent
+// carries no jobs of its own; its status is rolled up from the children.
+func expandMatrix(parent *domain.Build) ([]domain.Build, error) {
+	combos, err := parent.Matrix.Expand()
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	return nil
+	children := make([]domain.Build, 0, len(combos))
+	for _, combo := range combos {
+		env := domain.StringMap{}
+		for k, v := range parent.Env {
+			env[k] = v
+		}
+		for k, v := range domain.MatrixEnv(combo) {
+			env[k] = v
+		}
+
+		child := domain.Build{
+			RepoUrl:           parent.RepoUrl,
+			Ref:               parent.Ref,
+			Command:           parent.Command,
+			Priority:          parent.Priority,
+			NotBefore:         parent.NotBefore,
+			RunsOn:            parent.RunsOn,
+			Env:               env,
+			ConcurrencyGroup:  parent.ConcurrencyGroup,
+			MaxConcurrency:    parent.MaxConcurrency,
+			ConcurrencyPolicy: parent.ConcurrencyPolicy,
+			Artifacts:         parent.Artifacts,
+			Caches:            parent.Caches,
+			TriggeredBy:       parent.TriggeredBy,
+			MatrixValues:      combo,
+			Jobs:              make([]domain.Job, len(parent.Jobs)),
+		}
+		copy(child.Jobs, parent.Jobs)
+
+		if err := prepareJobs(&child); err != nil {
+			return nil, err
+		}
+		children = append(children, child)
+	}
+
+	return children, nil
+}
+
+func (s *buildService) RetryBuild(ctx context.Context, buildId string, triggeredBy *string) (*domain.Build, error) {
+	original, err := s.buildRepo.FindByID(ctx, buildId)
+	if err != nil {
+		return nil, err
+	}
+	if !original.Status.IsTerminal() {
+		return nil, domain.ErrBuildNotFinished
+	}
+
+	build := original.Rerun()
+	build.TriggeredBy = triggeredBy
+	if err := s.CreateBuild(ctx, build); err != nil {
+		return nil, err
+	}
+
+	return build, nil
+}
+
+// CancelBuild cancels a build with its matrix children and jobs. Workers
+// running one of its jobs see the cancel request and stop it.
+func (s *buildService) CancelBuild(ctx context.Context, buildId string) error {
+	return s.buildRepo.Cancel(ctx, buildId)
 }
 
 func (s *buildService) UpdateStatus(ctx context.Context, buildId string, status domain.BuildStatus) error {
@@ -51,38 +207,222 @@ func (s *buildService) UpdateStatus(ctx context.Context, buildId string, status
 	return nil
 }
 
+// UpdatePriority changes the priority of a pending build. Jobs already in an
+// external queue keep their place there.
+func (s *buildService) UpdatePriority(ctx context.Context, buildId string, priority int) (*domain.Build, error) {
+	if err := domain.ValidatePriority(priority); err != nil {
+		return nil, err
+	}
+	if !s.queue.Ranked() {
+		return nil, domain.ErrPriorityUnsupported
+	}
+	if err := s.buildRepo.UpdatePriority(ctx, buildId, priority); err != nil {
+		return nil, err
+	}
+	build, err := s.buildRepo.FindByID(ctx, buildId)
+	if err != nil {
+		return nil, err
+	}
+	return build, s.flagUnschedulable(ctx, build)
+}
+
 func (s *buildService) GetBuild(ctx context.Context, buildId string) (*domain.Build, error) {
 	build, err := s.buildRepo.FindByID(ctx, buildId)
 	if err != nil {
 		return nil, err
 	}
 
-	return build, nil
+	return build, s.flagUnschedulable(ctx, build)
 }
 
-func (s *buildService) ClaimNext(ctx context.Context, workerId string) (*domain.Build, error) {
-	build, err := s.buildRepo.ClaimNext(ctx, workerId)
+// ListBuilds fetches one row more than the page size to find out whether
+// another page follows without a separate count query.
+func (s *buildService) ListBuilds(ctx context.Context, filter domain.BuildFilter) (*domain.BuildPage, error) {
+	limit := filter.Limit
+	if limit <= 0 {
+		limit = domain.DefaultBuildPageSize
+	}
+	if limit > domain.MaxBuildPageSize {
+		limit = domain.MaxBuildPageSize
+	}
+	filter.Limit = limit + 1
 
+	builds, err := s.buildRepo.List(ctx, filter)
 	if err != nil {
 		return nil, err
 	}
 
-	return build, nil
+	page := &domain.BuildPage{Builds: builds}
+	if len(builds) > limit {
+		page.Builds = builds[:limit]
+		last := page.Builds[limit-1]
+		page.NextCursor = domain.BuildCursor{CreatedAt: last.CreatedAt, ID: last.ID}.Encode()
+	}
+
+	refs := make([]*domain.Build, len(page.Builds))
+	for i := range page.Builds {
+		refs[i] = &page.Builds[i]
+	}
+	if err := s.flagUnschedulable(ctx, refs...); err != nil {
+		return nil, err
+	}
+
+	return page, nil
+}
+
+// ClaimNext takes the next job off the queue and starts it. Queued jobs that
+// can no longer run (canceled, deleted, or already started by another
+// worker after a redelivery) are dropped. Jobs of builds that are not due go
+// back to the queue until their not_before, and jobs the worker lacks the
+// labels for or whose repository or concurrency group is full for
+// domain.HoldBack, so they do not block the jobs behind them; the claim
+// moves on to the next job. Paused and draining workers get
+// domain.ErrWorkerNotClaiming; workers that never registered claim like
+// active ones.
+func (s *buildService) ClaimNext(ctx context.Context, workerId string) (*domain.Job, error) {
+	worker, err := s.workerRepo.FindByID(ctx, workerId)
+	switch {
+	case errors.Is(err, domain.ErrWorkerNotFound):
+	case err != nil:
+		return nil, err
+	case !worker.State.Claims():
+		return nil, domain.ErrWorkerNotClaiming
+	}
+
+	for {
+		lease, err := s.queue.Claim(ctx, workerId)
+		if err != nil || lease == nil {
+			return nil, err
+		}
+		if lease.Job != nil {
+			return lease.Job, nil
+		}
+
+		job, err := s.buildRepo.StartJob(ctx, lease.JobID, workerId)
+		switch {
+		case err == nil:
+			return job, nil
+		case errors.Is(err, domain.ErrJobNotClaimable), errors.Is(err, domain.ErrJobNotFound):
+			if err := s.queue.Ack(ctx, lease.JobID); err != nil {
+				return nil, err
+			}
+		case errors.Is(err, domain.ErrBuildNotDue):
+			if err := s.deferJob(ctx, lease); err != nil {
+				return nil, err
+			}
+		case errors.Is(err, domain.ErrJobNotForWorker), errors.Is(err, domain.ErrConcurrencyLimit), errors.Is(err, domain.ErrRepoLimit):
+			if err := s.queue.Defer(ctx, lease.JobID, time.Now().Add(domain.HoldBack(lease.Deliveries))); err != nil {
+				return nil, err
+			}
+		default:
+			_ = s.queue.Nack(ctx, lease.JobID)
+			return nil, err
+		}
+	}
+}
+
+// deferJob hands a job of a delayed build back to the queue until the build
+// is due, or for domain.HoldBack when its not_before is gone meanwhile.
+func (s *buildService) deferJob(ctx context.Context, lease *domain.Lease) error {
+	job, err := s.buildRepo.FindJobByID(ctx, lease.JobID)
+	if err != nil {
+		_ = s.queue.Nack(ctx, lease.JobID)
+		return err
+	}
+	if job.Build == nil || job.Build.NotBefore == nil {
+		return s.queue.Defer(ctx, lease.JobID, time.Now().Add(domain.HoldBack(lease.Deliveries)))
+	}
+	return s.queue.Defer(ctx, lease.JobID, *job.Build.NotBefore)
+}
+
+func (s *buildService) GetJob(ctx context.Context, jobId string) (*domain.Job, error) {
+	return s.buildRepo.FindJobByID(ctx, jobId)
+}
+
+func (s *buildService) Heartbeat(ctx context.Context, jobId string, workerId string) error {
+	if err := s.buildRepo.Heartbeat(ctx, jobId, workerId, time.Now()); err != nil {
+		return err
+	}
+	return s.queue.Extend(ctx, jobId)
 }
 
-func (s *buildService) CompleteBuild(ctx context.Context, buildId string, exitCode int, finishedAt *time.Time, error error) error {
-	build := &domain.Build{
-		ID:         buildId,
-		Status:     domain.BuildStatusSuccess,
+// CancelRequested reports whether the job or its build has been canceled
+// since the job was claimed.
+func (s *buildService) CancelRequested(ctx context.Context, jobId string) (bool, error) {
+	job, err := s.buildRepo.FindJobByID(ctx, jobId)
+	if err != nil {
+		return false, err
+	}
+
+	if job.Status == domain.JobStatusCanceled || job.CancelRequestedAt != nil {
+		return true, nil
+	}
+	return job.Build != nil && (job.Build.Status == domain.BuildStatusCanceled || job.Build.CancelRequestedAt != nil), nil
+}
+
+func (s *buildService) CompleteJob(ctx context.Context, jobId string, workerId string, exitCode int, finishedAt *time.Time, error error) error {
+	job := &domain.Job{
+		ID:         jobId,
+		Status:     domain.JobStatusSuccess,
 		ExitCode:   exitCode,
 		FinishedAt: finishedAt,
 	}
 
+	switch {
+	case errors.Is(error, domain.ErrJobCanceled):
+		job.Status = domain.JobStatusCanceled
+	case error != nil || exitCode != 0:
+		job.Status = domain.JobStatusFailed
+	}
+
 	if error != nil {
-		build.Error = error.Error()
+		job.Error = error.Error()
+	}
+
+	if err := s.buildRepo.CompleteJob(ctx, job, workerId); err != nil {
+		return err
+	}
+	if err := s.queue.Ack(ctx, jobId); err != nil {
+		return err
+	}
+
+	if job.Status != domain.JobStatusSuccess {
+		return nil
 	}
+	return s.enqueueUnblocked(ctx, jobId)
+}
 
-	err := s.buildRepo.Update(ctx, build)
+// RequeueExpired puts the jobs of workers whose lease ran out back on the
+// queue. Queues that already handed the job out again find it pending and
+// start it; the duplicate is acked as not claimable.
+func (s *buildService) RequeueExpired(ctx context.Context, lease time.Duration) (int, error) {
+	jobIds, err := s.buildRepo.RequeueExpired(ctx, time.Now().Add(-lease))
+	if err != nil || len(jobIds) == 0 {
+		return 0, err
+	}
+	if err := s.queue.Enqueue(ctx, jobIds...); err != nil {
+		return 0, err
+	}
+	return len(jobIds), nil
+}
+
+// enqueueUnblocked enqueues the jobs whose last outstanding need was jobId.
+func (s *buildService) enqueueUnblocked(ctx context.Context, jobId string) error {
+	job, err := s.buildRepo.FindJobByID(ctx, jobId)
+	if err != nil {
+		return err
+	}
+	build, err := s.buildRepo.FindByID(ctx, job.BuildID)
+	if err != nil {
+		return err
+	}
 
-	return err
+	var ids []string
+	for _, unblocked := range domain.Unblocked(build.Jobs, job.Name) {
+		ids = append(ids, unblocked.ID)
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+	return s.queue.Enqueue(ctx, ids...)
 }
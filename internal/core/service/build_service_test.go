@@ -6,9 +6,13 @@ import (
 	"testing"
 	"time"
 
+	"github.com/H3nSte1n/ci-orchestrator/internal/adapters/memory"
+	"github.com/H3nSte1n/ci-orchestrator/internal/adapters/queue"
 	"github.com/H3nSte1n/ci-orchestrator/internal/core/domain"
+	"github.com/H3nSte1n/ci-orchestrator/internal/core/ports"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 )
 
 func buildTestData() *domain.Build {
@@ -21,6 +25,17 @@ func buildTestData() *domain.Build {
 	}
 }
 
+func jobTestData() *domain.Job {
+	return &domain.Job{
+		ID:      "job-id",
+		BuildID: "ci-id",
+		Name:    domain.DefaultJobName,
+		Command: "npm test",
+		Status:  domain.JobStatusRunning,
+		Build:   buildTestData(),
+	}
+}
+
 type MockBuildRepository struct {
 	mock.Mock
 }
@@ -43,12 +58,84 @@ func (m *MockBuildRepository) FindByID(ctx context.Context, buildId string) (*do
 	return args.Get(0).(*domain.Build), args.Error(1)
 }
 
-func (m *MockBuildRepository) ClaimNext(ctx context.Context, workerId string) (*domain.Build, error) {
+func (m *MockBuildRepository) UpdatePriority(ctx context.Context, buildId string, priority int) error {
+	args := m.Called(ctx, buildId, priority)
+	return args.Error(0)
+}
+
+func (m *MockBuildRepository) Cancel(ctx context.Context, buildId string) error {
+	args := m.Called(ctx, buildId)
+	return args.Error(0)
+}
+
+func (m *MockBuildRepository) CancelSuperseded(ctx context.Context, build *domain.Build, statuses []domain.BuildStatus) error {
+	args := m.Called(ctx, build, statuses)
+	return args.Error(0)
+}
+
+func (m *MockBuildRepository) List(ctx context.Context, filter domain.BuildFilter) ([]domain.Build, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.Build), args.Error(1)
+}
+
+func (m *MockBuildRepository) ClaimNext(ctx context.Context, workerId string) (*domain.Job, error) {
 	args := m.Called(ctx, workerId)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
-	return args.Get(0).(*domain.Build), args.Error(1)
+	return args.Get(0).(*domain.Job), args.Error(1)
+}
+
+func (m *MockBuildRepository) FindJobByID(ctx context.Context, jobId string) (*domain.Job, error) {
+	args := m.Called(ctx, jobId)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Job), args.Error(1)
+}
+
+func (m *MockBuildRepository) Heartbeat(ctx context.Context, jobId string, workerId string, at time.Time) error {
+	args := m.Called(ctx, jobId, workerId, at)
+	return args.Error(0)
+}
+
+func (m *MockBuildRepository) CompleteJob(ctx context.Context, job *domain.Job, workerId string) error {
+	args := m.Called(ctx, job, workerId)
+	return args.Error(0)
+}
+
+func (m *MockBuildRepository) StartJob(ctx context.Context, jobId string, workerId string) (*domain.Job, error) {
+	args := m.Called(ctx, jobId, workerId)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Job), args.Error(1)
+}
+
+func (m *MockBuildRepository) ReleaseJob(ctx context.Context, jobId string, workerId string) error {
+	args := m.Called(ctx, jobId, workerId)
+	return args.Error(0)
+}
+
+func (m *MockBuildRepository) RequeueExpired(ctx context.Context, before time.Time) ([]string, error) {
+	args := m.Called(ctx, before)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]string), args.Error(1)
+}
+
+// newBuildService claims from the repository, as the database queue does.
+func newBuildService(repo ports.BuildRepository) ports.BuildService {
+	return NewBuildService(repo, memory.NewWorkerRepository(memory.NewStore()), queue.NewRepositoryQueue(repo))
+}
+
+// newMemoryBuildService runs against an in-memory store with q as queue.
+func newMemoryBuildService(store *memory.Store, q ports.Queue) ports.BuildService {
+	return NewBuildService(memory.NewBuildRepository(store), memory.NewWorkerRepository(store), q)
 }
 
 func TestBuildService_CreateBuild_Success(t *testing.T) {
@@ -57,7 +144,7 @@ func TestBuildService_CreateBuild_Success(t *testing.T) {
 	build := buildTestData()
 	mockRepo.On("Save", mock.Anything, mock.Anything).Return(nil)
 
-	service := NewBuildService(mockRepo)
+	service := newBuildService(mockRepo)
 	err := service.CreateBuild(ctx, build)
 
 	assert.NoError(t, err)
@@ -72,7 +159,7 @@ func TestBuildService_CreateBuild_Error(t *testing.T) {
 	expectedErr := errors.New("database error")
 	mockRepo.On("Save", mock.Anything, mock.Anything).Return(expectedErr)
 
-	service := NewBuildService(mockRepo)
+	service := newBuildService(mockRepo)
 	err := service.CreateBuild(ctx, build)
 
 	assert.Error(t, err)
@@ -83,9 +170,9 @@ func TestBuildService_CancelBuild_Success(t *testing.T) {
 	mockRepo := new(MockBuildRepository)
 	ctx := context.Background()
 	buildId := "test-build-id"
-	mockRepo.On("Update", mock.Anything, mock.Anything).Return(nil)
+	mockRepo.On("Cancel", mock.Anything, buildId).Return(nil)
 
-	service := NewBuildService(mockRepo)
+	service := newBuildService(mockRepo)
 	err := service.CancelBuild(ctx, buildId)
 
 	assert.NoError(t, err)
@@ -97,10 +184,10 @@ func TestBuildService_CancelBuild_Error(t *testing.T) {
 	ctx := context.Background()
 	buildId := "test-build-id"
 
-	expectedErr := errors.New("failed to update build")
-	mockRepo.On("Update", mock.Anything, mock.Anything).Return(expectedErr)
+	expectedErr := errors.New("failed to cancel build")
+	mockRepo.On("Cancel", mock.Anything, buildId).Return(expectedErr)
 
-	service := NewBuildService(mockRepo)
+	service := newBuildService(mockRepo)
 	err := service.CancelBuild(ctx, buildId)
 
 	assert.Error(t, err)
@@ -115,7 +202,7 @@ func TestBuildService_UpdateStatus_Success(t *testing.T) {
 
 	mockRepo.On("Update", mock.Anything, mock.Anything).Return(nil)
 
-	service := NewBuildService(mockRepo)
+	service := newBuildService(mockRepo)
 	err := service.UpdateStatus(ctx, buildId, newStatus)
 
 	assert.NoError(t, err)
@@ -131,13 +218,66 @@ func TestBuildService_UpdateStatus_Error(t *testing.T) {
 	expectedErr := errors.New("database error")
 	mockRepo.On("Update", mock.Anything, mock.Anything).Return(expectedErr)
 
-	service := NewBuildService(mockRepo)
+	service := newBuildService(mockRepo)
 	err := service.UpdateStatus(ctx, buildId, newStatus)
 
 	assert.Error(t, err)
 	assert.Equal(t, expectedErr, err)
 }
 
+func TestBuildService_UpdatePriority_Success(t *testing.T) {
+	mockRepo := new(MockBuildRepository)
+	ctx := context.Background()
+	updated := buildTestData()
+	updated.Priority = 10
+
+	mockRepo.On("UpdatePriority", mock.Anything, "b1", 10).Return(nil)
+	mockRepo.On("FindByID", mock.Anything, "b1").Return(updated, nil)
+
+	service := newBuildService(mockRepo)
+	build, err := service.UpdatePriority(ctx, "b1", 10)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 10, build.Priority)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestBuildService_UpdatePriority_OutOfRange(t *testing.T) {
+	mockRepo := new(MockBuildRepository)
+
+	service := newBuildService(mockRepo)
+	_, err := service.UpdatePriority(context.Background(), "b1", domain.MaxPriority+1)
+
+	assert.ErrorIs(t, err, domain.ErrInvalidArgument)
+	mockRepo.AssertNotCalled(t, "UpdatePriority", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestBuildService_Priority_RejectedByUnrankedQueue(t *testing.T) {
+	ctx := context.Background()
+	store := memory.NewStore()
+	svc := newMemoryBuildService(store, &fakeQueue{unranked: true})
+
+	build := buildTestData()
+	build.ID = ""
+	build.Priority = 10
+	assert.ErrorIs(t, svc.CreateBuild(ctx, build), domain.ErrPriorityUnsupported)
+
+	build.Priority = 0
+	require.NoError(t, svc.CreateBuild(ctx, build))
+	_, err := svc.UpdatePriority(ctx, build.ID, 10)
+	assert.ErrorIs(t, err, domain.ErrPriorityUnsupported)
+}
+
+func TestBuildService_UpdatePriority_NotPending(t *testing.T) {
+	mockRepo := new(MockBuildRepository)
+	mockRepo.On("UpdatePriority", mock.Anything, "b1", 1).Return(domain.ErrBuildNotPending)
+
+	service := newBuildService(mockRepo)
+	_, err := service.UpdatePriority(context.Background(), "b1", 1)
+
+	assert.ErrorIs(t, err, domain.ErrBuildNotPending)
+}
+
 func TestBuildService_GetBuild_Success(t *testing.T) {
 	mockRepo := new(MockBuildRepository)
 	ctx := context.Background()
@@ -146,7 +286,7 @@ func TestBuildService_GetBuild_Success(t *testing.T) {
 
 	mockRepo.On("FindByID", mock.Anything, mock.Anything).Return(expectedBuild, nil)
 
-	service := NewBuildService(mockRepo)
+	service := newBuildService(mockRepo)
 	build, err := service.GetBuild(ctx, buildId)
 
 	assert.NoError(t, err)
@@ -154,6 +294,59 @@ func TestBuildService_GetBuild_Success(t *testing.T) {
 	mockRepo.AssertExpectations(t)
 }
 
+func TestBuildService_ListBuilds_ReturnsNextCursor(t *testing.T) {
+	mockRepo := new(MockBuildRepository)
+	createdAt := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	builds := []domain.Build{
+		{ID: "b3", CreatedAt: createdAt.Add(2 * time.Minute)},
+		{ID: "b2", CreatedAt: createdAt.Add(time.Minute)},
+		{ID: "b1", CreatedAt: createdAt},
+	}
+
+	mockRepo.On("List", mock.Anything, mock.MatchedBy(func(f domain.BuildFilter) bool {
+		return f.Limit == 3 && f.Ref == "main"
+	})).Return(builds, nil)
+
+	service := newBuildService(mockRepo)
+	page, err := service.ListBuilds(context.Background(), domain.BuildFilter{Ref: "main", Limit: 2})
+
+	require.NoError(t, err)
+	assert.Len(t, page.Builds, 2)
+	assert.Equal(t, "b2", page.Builds[1].ID)
+
+	cursor, err := domain.DecodeBuildCursor(page.NextCursor)
+	require.NoError(t, err)
+	assert.Equal(t, "b2", cursor.ID)
+	assert.True(t, cursor.CreatedAt.Equal(createdAt.Add(time.Minute)))
+}
+
+func TestBuildService_ListBuilds_LastPage(t *testing.T) {
+	mockRepo := new(MockBuildRepository)
+	mockRepo.On("List", mock.Anything, mock.MatchedBy(func(f domain.BuildFilter) bool {
+		return f.Limit == domain.DefaultBuildPageSize+1
+	})).Return([]domain.Build{*buildTestData()}, nil)
+
+	service := newBuildService(mockRepo)
+	page, err := service.ListBuilds(context.Background(), domain.BuildFilter{})
+
+	require.NoError(t, err)
+	assert.Len(t, page.Builds, 1)
+	assert.Empty(t, page.NextCursor)
+}
+
+func TestBuildService_ListBuilds_ClampsLimit(t *testing.T) {
+	mockRepo := new(MockBuildRepository)
+	mockRepo.On("List", mock.Anything, mock.MatchedBy(func(f domain.BuildFilter) bool {
+		return f.Limit == domain.MaxBuildPageSize+1
+	})).Return([]domain.Build{}, nil)
+
+	service := newBuildService(mockRepo)
+	_, err := service.ListBuilds(context.Background(), domain.BuildFilter{Limit: 10000})
+
+	assert.NoError(t, err)
+	mockRepo.AssertExpectations(t)
+}
+
 func TestBuildService_GetBuild_Error(t *testing.T) {
 	mockRepo := new(MockBuildRepository)
 	ctx := context.Background()
@@ -162,7 +355,7 @@ func TestBuildService_GetBuild_Error(t *testing.T) {
 
 	mockRepo.On("FindByID", mock.Anything, mock.Anything).Return(nil, expectedErr)
 
-	service := NewBuildService(mockRepo)
+	service := newBuildService(mockRepo)
 	_, err := service.GetBuild(ctx, buildId)
 
 	assert.Error(t, err)
@@ -173,15 +366,15 @@ func TestBuildService_ClaimNext_Success(t *testing.T) {
 	mockRepo := new(MockBuildRepository)
 	ctx := context.Background()
 	workerId := "worker-1"
-	expectedBuild := buildTestData()
+	expectedJob := jobTestData()
 
-	mockRepo.On("ClaimNext", mock.Anything, mock.Anything).Return(expectedBuild, nil)
+	mockRepo.On("ClaimNext", mock.Anything, mock.Anything).Return(expectedJob, nil)
 
-	service := NewBuildService(mockRepo)
-	build, err := service.ClaimNext(ctx, workerId)
+	service := newBuildService(mockRepo)
+	job, err := service.ClaimNext(ctx, workerId)
 
 	assert.NoError(t, err)
-	assert.Equal(t, expectedBuild, build)
+	assert.Equal(t, expectedJob, job)
 	mockRepo.AssertExpectations(t)
 }
 
@@ -193,43 +386,759 @@ func TestBuildService_ClaimNext_Error(t *testing.T) {
 
 	mockRepo.On("ClaimNext", mock.Anything, mock.Anything).Return(nil, expectedErr)
 
-	service := NewBuildService(mockRepo)
+	service := newBuildService(mockRepo)
 	_, err := service.ClaimNext(ctx, workerId)
 
 	assert.Error(t, err)
 	assert.Equal(t, expectedErr, err)
 }
 
-func TestBuildService_CompleteBuild(t *testing.T) {
+func TestBuildService_CreateBuild_AddsDefaultJob(t *testing.T) {
 	mockRepo := new(MockBuildRepository)
 	ctx := context.Background()
-	buildId := "test-build-id"
+	build := buildTestData()
+	mockRepo.On("Save", mock.Anything, mock.MatchedBy(func(b *domain.Build) bool {
+		return len(b.Jobs) == 1 &&
+			b.Jobs[0].Name == domain.DefaultJobName &&
+			b.Jobs[0].Command == "npm test"
+	})).Return(nil)
+
+	service := newBuildService(mockRepo)
+	err := service.CreateBuild(ctx, build)
+
+	assert.NoError(t, err)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestBuildService_CreateBuild_InvalidJobGraph(t *testing.T) {
+	mockRepo := new(MockBuildRepository)
+	ctx := context.Background()
+	build := buildTestData()
+	build.Jobs = []domain.Job{
+		{Name: "build", Command: "make", Needs: domain.StringList{"test"}},
+		{Name: "test", Command: "make test", Needs: domain.StringList{"build"}},
+	}
+
+	service := newBuildService(mockRepo)
+	err := service.CreateBuild(ctx, build)
+
+	assert.ErrorIs(t, err, domain.ErrInvalidJobGraph)
+	mockRepo.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+}
+
+func TestBuildService_CreateBuild_ExpandsMatrix(t *testing.T) {
+	mockRepo := new(MockBuildRepository)
+	ctx := context.Background()
+	build := buildTestData()
+	build.Env = domain.StringMap{"CI": "true"}
+	triggeredBy := "deploy-bot"
+	build.TriggeredBy = &triggeredBy
+	group := "deploy"
+	build.ConcurrencyGroup = &group
+	build.Matrix = &domain.Matrix{
+		Axes: map[string][]string{"go": {"1.24", "1.25"}},
+	}
+
+	mockRepo.On("Save", mock.Anything, mock.Anything).Return(nil)
+
+	service := newBuildService(mockRepo)
+	err := service.CreateBuild(ctx, build)
+
+	assert.NoError(t, err)
+	assert.Empty(t, build.Jobs)
+	if assert.Len(t, build.Children, 2) {
+		child := build.Children[0]
+		assert.Equal(t, domain.StringMap{"go": "1.24"}, child.MatrixValues)
+		assert.Equal(t, domain.StringMap{"CI": "true", "MATRIX_GO": "1.24"}, child.Env)
+		assert.Equal(t, "npm test", child.Jobs[0].Command)
+		assert.Equal(t, &triggeredBy, child.TriggeredBy)
+		assert.Equal(t, &group, child.ConcurrencyGroup)
+		assert.Equal(t, 1, child.MaxConcurrency)
+		assert.Equal(t, domain.ConcurrencyQueue, child.ConcurrencyPolicy)
+		assert.Equal(t, "1.25", build.Children[1].MatrixValues["go"])
+	}
+	mockRepo.AssertExpectations(t)
+}
+
+func TestBuildService_CreateBuild_InvalidMatrix(t *testing.T) {
+	mockRepo := new(MockBuildRepository)
+	ctx := context.Background()
+	build := buildTestData()
+	build.Matrix = &domain.Matrix{
+		Axes: map[string][]string{"go": {}},
+	}
+
+	service := newBuildService(mockRepo)
+	err := service.CreateBuild(ctx, build)
+
+	assert.ErrorIs(t, err, domain.ErrInvalidMatrix)
+	mockRepo.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+}
+
+func TestBuildService_CompleteJob(t *testing.T) {
+	mockRepo := new(MockBuildRepository)
+	ctx := context.Background()
+	jobId := "job-id"
 	exitCode := 0
 	var finishedAt *time.Time
 	var expectedErr error
 
-	mockRepo.On("Update", mock.Anything, mock.Anything).Return(nil)
+	mockRepo.On("CompleteJob", mock.Anything, mock.MatchedBy(func(j *domain.Job) bool {
+		return j.ID == jobId && j.Status == domain.JobStatusSuccess
+	}), "worker-1").Return(nil)
+	mockRepo.On("FindJobByID", mock.Anything, jobId).Return(jobTestData(), nil)
+	mockRepo.On("FindByID", mock.Anything, "ci-id").Return(buildTestData(), nil)
 
-	service := NewBuildService(mockRepo)
-	err := service.CompleteBuild(ctx, buildId, exitCode, finishedAt, expectedErr)
+	service := newBuildService(mockRepo)
+	err := service.CompleteJob(ctx, jobId, "worker-1", exitCode, finishedAt, expectedErr)
 
 	assert.NoError(t, err)
 	mockRepo.AssertExpectations(t)
 }
 
-func TestBuildService_CompleteBuild_Error(t *testing.T) {
+func TestBuildService_CompleteJob_NonZeroExitFails(t *testing.T) {
 	mockRepo := new(MockBuildRepository)
 	ctx := context.Background()
-	buildId := "test-build-id"
+	runErr := errors.New("exit status 2")
+
+	mockRepo.On("CompleteJob", mock.Anything, mock.MatchedBy(func(j *domain.Job) bool {
+		return j.Status == domain.JobStatusFailed && j.ExitCode == 2 && j.Error == "exit status 2"
+	}), "worker-1").Return(nil)
+
+	service := newBuildService(mockRepo)
+	err := service.CompleteJob(ctx, "job-id", "worker-1", 2, nil, runErr)
+
+	assert.NoError(t, err)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestBuildService_CompleteJob_CanceledRollsUpToCanceled(t *testing.T) {
+	ctx := context.Background()
+	store := memory.NewStore()
+	svc := newMemoryBuildService(store, queue.NewRepositoryQueue(memory.NewBuildRepository(store)))
+
+	build := buildTestData()
+	build.ID = ""
+	require.NoError(t, svc.CreateBuild(ctx, build))
+	job, err := svc.ClaimNext(ctx, "worker-1")
+	require.NoError(t, err)
+	require.NotNil(t, job)
+
+	finishedAt := time.Now()
+	require.NoError(t, svc.CompleteJob(ctx, job.ID, "worker-1", -1, &finishedAt, domain.ErrJobCanceled))
+
+	got, err := svc.GetBuild(ctx, build.ID)
+	require.NoError(t, err)
+	assert.Equal(t, domain.BuildStatusCanceled, got.Status)
+	assert.Equal(t, domain.JobStatusCanceled, got.Jobs[0].Status)
+}
+
+func TestBuildService_CompleteJob_Error(t *testing.T) {
+	mockRepo := new(MockBuildRepository)
+	ctx := context.Background()
+	jobId := "job-id"
 	exitCode := 1
 	var finishedAt *time.Time
 	expectedErr := errors.New("build failed")
 
-	mockRepo.On("Update", mock.Anything, mock.Anything).Return(expectedErr)
+	mockRepo.On("CompleteJob", mock.Anything, mock.Anything, mock.Anything).Return(expectedErr)
 
-	service := NewBuildService(mockRepo)
-	err := service.CompleteBuild(ctx, buildId, exitCode, finishedAt, expectedErr)
+	service := newBuildService(mockRepo)
+	err := service.CompleteJob(ctx, jobId, "worker-1", exitCode, finishedAt, expectedErr)
 
 	assert.Error(t, err)
 	assert.Equal(t, expectedErr, err)
 }
+
+func TestBuildService_Heartbeat(t *testing.T) {
+	mockRepo := new(MockBuildRepository)
+	mockRepo.On("Heartbeat", mock.Anything, "job-id", "worker-1", mock.Anything).Return(domain.ErrJobNotFound)
+
+	service := newBuildService(mockRepo)
+	err := service.Heartbeat(context.Background(), "job-id", "worker-1")
+
+	assert.ErrorIs(t, err, domain.ErrJobNotFound)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestBuildService_CancelRequested(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		name      string
+		jobStatus domain.JobStatus
+		build     domain.Build
+		want      bool
+	}{
+		{"running", domain.JobStatusRunning, domain.Build{Status: domain.BuildStatusRunning}, false},
+		{"build canceled", domain.JobStatusRunning, domain.Build{Status: domain.BuildStatusCanceled}, true},
+		{"cancel requested", domain.JobStatusRunning, domain.Build{Status: domain.BuildStatusRunning, CancelRequestedAt: &now}, true},
+		{"job canceled", domain.JobStatusCanceled, domain.Build{Status: domain.BuildStatusRunning}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			build := tt.build
+			mockRepo := new(MockBuildRepository)
+			mockRepo.On("FindJobByID", mock.Anything, "job-id").Return(&domain.Job{ID: "job-id", Status: tt.jobStatus, Build: &build}, nil)
+
+			requested, err := newBuildService(mockRepo).CancelRequested(context.Background(), "job-id")
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, requested)
+		})
+	}
+}
+
+func TestBuildService_CancelRequested_NotFound(t *testing.T) {
+	mockRepo := new(MockBuildRepository)
+	mockRepo.On("FindJobByID", mock.Anything, "job-id").Return(nil, domain.ErrJobNotFound)
+
+	requested, err := newBuildService(mockRepo).CancelRequested(context.Background(), "job-id")
+
+	assert.False(t, requested)
+	assert.ErrorIs(t, err, domain.ErrJobNotFound)
+}
+
+func TestBuildService_RetryBuild(t *testing.T) {
+	mockRepo := new(MockBuildRepository)
+	original := buildTestData()
+	original.Status = domain.BuildStatusFailed
+	original.Jobs = []domain.Job{{ID: "j1", Name: domain.DefaultJobName, Command: "npm test", Status: domain.JobStatusFailed}}
+	triggeredBy := "ci-bot"
+
+	mockRepo.On("FindByID", mock.Anything, "b1").Return(original, nil)
+	mockRepo.On("Save", mock.Anything, mock.MatchedBy(func(b *domain.Build) bool {
+		return b.ID == "" && b.RepoUrl == original.RepoUrl && *b.TriggeredBy == "ci-bot" &&
+			len(b.Jobs) == 1 && b.Jobs[0].ID == "" && b.Jobs[0].Status == ""
+	})).Return(nil)
+
+	service := newBuildService(mockRepo)
+	build, err := service.RetryBuild(context.Background(), "b1", &triggeredBy)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "npm test", build.Jobs[0].Command)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestBuildService_RetryBuild_NotFinished(t *testing.T) {
+	mockRepo := new(MockBuildRepository)
+	original := buildTestData()
+	original.Status = domain.BuildStatusRunning
+
+	mockRepo.On("FindByID", mock.Anything, "b1").Return(original, nil)
+
+	service := newBuildService(mockRepo)
+	_, err := service.RetryBuild(context.Background(), "b1", nil)
+
+	assert.ErrorIs(t, err, domain.ErrBuildNotFinished)
+	mockRepo.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+}
+
+func TestBuildService_RetryBuild_NotFound(t *testing.T) {
+	mockRepo := new(MockBuildRepository)
+	mockRepo.On("FindByID", mock.Anything, "b1").Return(nil, domain.ErrBuildNotFound)
+
+	service := newBuildService(mockRepo)
+	_, err := service.RetryBuild(context.Background(), "b1", nil)
+
+	assert.ErrorIs(t, err, domain.ErrBuildNotFound)
+}
+
+func TestBuildService_RunsBuildAgainstMemoryStore(t *testing.T) {
+	ctx := context.Background()
+	service := newBuildService(memory.NewBuildRepository(memory.NewStore()))
+
+	build := &domain.Build{
+		RepoUrl: "https://github.com/test/repo",
+		Ref:     "main",
+		Command: "make",
+		Jobs: []domain.Job{
+			{Name: "build", Command: "make build"},
+			{Name: "test", Command: "make test", Needs: domain.StringList{"build"}},
+		},
+	}
+	require.NoError(t, service.CreateBuild(ctx, build))
+
+	for _, name := range []string{"build", "test"} {
+		job, err := service.ClaimNext(ctx, "worker-1")
+		require.NoError(t, err)
+		require.NotNil(t, job)
+		assert.Equal(t, name, job.Name)
+
+		finishedAt := time.Now()
+		require.NoError(t, service.CompleteJob(ctx, job.ID, "worker-1", 0, &finishedAt, nil))
+	}
+
+	finished, err := service.GetBuild(ctx, build.ID)
+	require.NoError(t, err)
+	assert.Equal(t, domain.BuildStatusSuccess, finished.Status)
+
+	retry, err := service.RetryBuild(ctx, build.ID, nil)
+	require.NoError(t, err)
+	assert.NotEqual(t, build.ID, retry.ID)
+	assert.Len(t, retry.Jobs, 2)
+}
+
+// fakeQueue hands out the job ids in leases in order and records the rest.
+type fakeQueue struct {
+	leases   []string
+	enqueued []string
+	acked    []string
+	nacked   []string
+	deferred map[string]time.Time
+	extended []string
+	// unranked makes the queue behave like one in enqueue order.
+	unranked bool
+}
+
+func (q *fakeQueue) Enqueue(ctx context.Context, jobIds ...string) error {
+	q.enqueued = append(q.enqueued, jobIds...)
+	return nil
+}
+
+func (q *fakeQueue) Claim(ctx context.Context, workerId string) (*domain.Lease, error) {
+	if len(q.leases) == 0 {
+		return nil, nil
+	}
+	jobId := q.leases[0]
+	q.leases = q.leases[1:]
+	return &domain.Lease{JobID: jobId, WorkerID: workerId}, nil
+}
+
+func (q *fakeQueue) Ack(ctx context.Context, jobId string) error {
+	q.acked = append(q.acked, jobId)
+	return nil
+}
+
+func (q *fakeQueue) Nack(ctx context.Context, jobId string) error {
+	q.nacked = append(q.nacked, jobId)
+	return nil
+}
+
+func (q *fakeQueue) Defer(ctx context.Context, jobId string, until time.Time) error {
+	if q.deferred == nil {
+		q.deferred = map[string]time.Time{}
+	}
+	q.deferred[jobId] = until
+	return nil
+}
+
+func (q *fakeQueue) Extend(ctx context.Context, jobId string) error {
+	q.extended = append(q.extended, jobId)
+	return nil
+}
+
+func (q *fakeQueue) Ranked() bool {
+	return !q.unranked
+}
+
+func pipelineBuild() *domain.Build {
+	return &domain.Build{
+		RepoUrl: "https://github.com/test/repo",
+		Ref:     "main",
+		Command: "make",
+		Jobs: []domain.Job{
+			{Name: "build", Command: "make"},
+			{Name: "test", Command: "make test", Needs: domain.StringList{"build"}},
+		},
+	}
+}
+
+func TestBuildService_Queue_RunsPipeline(t *testing.T) {
+	ctx := context.Background()
+	q := &fakeQueue{}
+	svc := newMemoryBuildService(memory.NewStore(), q)
+
+	build := pipelineBuild()
+	require.NoError(t, svc.CreateBuild(ctx, build))
+	buildJob, testJob := build.Jobs[0], build.Jobs[1]
+	assert.Equal(t, []string{buildJob.ID}, q.enqueued, "test waits for build")
+
+	q.leases = q.enqueued
+	job, err := svc.ClaimNext(ctx, "worker-1")
+	require.NoError(t, err)
+	require.NotNil(t, job)
+	assert.Equal(t, buildJob.ID, job.ID)
+	assert.Equal(t, domain.JobStatusRunning, job.Status)
+
+	require.NoError(t, svc.Heartbeat(ctx, job.ID, "worker-1"))
+	assert.Equal(t, []string{buildJob.ID}, q.extended)
+
+	finishedAt := time.Now()
+	require.NoError(t, svc.CompleteJob(ctx, job.ID, "worker-1", 0, &finishedAt, nil))
+	assert.Equal(t, []string{buildJob.ID}, q.acked)
+	assert.Equal(t, []string{buildJob.ID, testJob.ID}, q.enqueued)
+}
+
+func TestBuildService_Queue_FailedJobEnqueuesNothing(t *testing.T) {
+	ctx := context.Background()
+	q := &fakeQueue{}
+	svc := newMemoryBuildService(memory.NewStore(), q)
+
+	build := pipelineBuild()
+	require.NoError(t, svc.CreateBuild(ctx, build))
+	q.leases = q.enqueued
+	job, err := svc.ClaimNext(ctx, "worker-1")
+	require.NoError(t, err)
+
+	finishedAt := time.Now()
+	require.NoError(t, svc.CompleteJob(ctx, job.ID, "worker-1", 1, &finishedAt, nil))
+	assert.Len(t, q.enqueued, 1)
+}
+
+func TestBuildService_Queue_DropsJobsThatCannotStart(t *testing.T) {
+	ctx := context.Background()
+	q := &fakeQueue{}
+	svc := newMemoryBuildService(memory.NewStore(), q)
+
+	canceled := buildTestData()
+	canceled.ID = ""
+	require.NoError(t, svc.CreateBuild(ctx, canceled))
+	require.NoError(t, svc.CancelBuild(ctx, canceled.ID))
+	build := buildTestData()
+	build.ID = ""
+	require.NoError(t, svc.CreateBuild(ctx, build))
+
+	q.leases = []string{canceled.Jobs[0].ID, build.Jobs[0].ID}
+	job, err := svc.ClaimNext(ctx, "worker-1")
+
+	require.NoError(t, err)
+	require.NotNil(t, job)
+	assert.Equal(t, build.Jobs[0].ID, job.ID)
+	assert.Equal(t, []string{canceled.Jobs[0].ID}, q.acked)
+}
+
+func TestBuildService_Queue_NacksOnStartError(t *testing.T) {
+	mockRepo := new(MockBuildRepository)
+	mockRepo.On("StartJob", mock.Anything, "job-id", "worker-1").Return(nil, errors.New("db down"))
+	q := &fakeQueue{leases: []string{"job-id"}}
+
+	_, err := NewBuildService(mockRepo, memory.NewWorkerRepository(memory.NewStore()), q).ClaimNext(context.Background(), "worker-1")
+
+	assert.Error(t, err)
+	assert.Equal(t, []string{"job-id"}, q.nacked)
+	assert.Empty(t, q.acked)
+}
+
+func TestBuildService_Queue_DefersJobsForOtherWorkers(t *testing.T) {
+	ctx := context.Background()
+	store := memory.NewStore()
+	require.NoError(t, memory.NewWorkerRepository(store).Register(ctx, &domain.Worker{ID: "amd", Labels: domain.StringList{"amd64"}}))
+	q := &fakeQueue{}
+	svc := newMemoryBuildService(store, q)
+
+	build := buildTestData()
+	build.ID = ""
+	build.RunsOn = domain.StringList{"arm64"}
+	require.NoError(t, svc.CreateBuild(ctx, build))
+	other := buildTestData()
+	other.ID = ""
+	require.NoError(t, svc.CreateBuild(ctx, other))
+
+	q.leases = q.enqueued
+	before := time.Now()
+	job, err := svc.ClaimNext(ctx, "amd")
+
+	require.NoError(t, err)
+	require.NotNil(t, job)
+	assert.Equal(t, other.Jobs[0].ID, job.ID, "the claim moves on to the next job")
+	require.Contains(t, q.deferred, build.Jobs[0].ID)
+	assert.False(t, q.deferred[build.Jobs[0].ID].Before(before.Add(domain.HoldBack(0))))
+	assert.Empty(t, q.nacked)
+	assert.Empty(t, q.acked)
+}
+
+func TestBuildService_FlagsUnschedulableBuilds(t *testing.T) {
+	ctx := context.Background()
+	store := memory.NewStore()
+	workers := memory.NewWorkerRepository(store)
+	svc := newMemoryBuildService(store, &fakeQueue{})
+
+	build := buildTestData()
+	build.ID = ""
+	build.RunsOn = domain.StringList{"gpu"}
+	require.NoError(t, svc.CreateBuild(ctx, build))
+	assert.True(t, build.Unschedulable, "no worker is registered")
+
+	require.NoError(t, workers.Register(ctx, &domain.Worker{ID: "cpu", Labels: domain.StringList{"linux"}, LastSeenAt: time.Now()}))
+	page, err := svc.ListBuilds(ctx, domain.BuildFilter{})
+	require.NoError(t, err)
+	require.Len(t, page.Builds, 1)
+	assert.True(t, page.Builds[0].Unschedulable)
+
+	require.NoError(t, workers.Register(ctx, &domain.Worker{ID: "gpu", Labels: domain.StringList{"linux", "gpu"}, LastSeenAt: time.Now()}))
+	found, err := svc.GetBuild(ctx, build.ID)
+	require.NoError(t, err)
+	assert.False(t, found.Unschedulable)
+
+	require.NoError(t, workers.Deregister(ctx, "gpu", time.Now()))
+	found, err = svc.GetBuild(ctx, build.ID)
+	require.NoError(t, err)
+	assert.True(t, found.Unschedulable, "the only gpu worker is offline")
+}
+
+func TestBuildService_ClaimNext_SkipsPausedAndDrainingWorkers(t *testing.T) {
+	ctx := context.Background()
+	store := memory.NewStore()
+	workers := memory.NewWorkerRepository(store)
+	repo := memory.NewBuildRepository(store)
+	svc := newMemoryBuildService(store, queue.NewRepositoryQueue(repo))
+
+	build := buildTestData()
+	build.ID = ""
+	require.NoError(t, svc.CreateBuild(ctx, build))
+	require.NoError(t, workers.Register(ctx, &domain.Worker{ID: "worker-1", LastSeenAt: time.Now()}))
+
+	for _, state := range []domain.WorkerState{domain.WorkerStatePaused, domain.WorkerStateDraining} {
+		require.NoError(t, workers.UpdateState(ctx, "worker-1", state))
+		job, err := svc.ClaimNext(ctx, "worker-1")
+		assert.ErrorIs(t, err, domain.ErrWorkerNotClaiming, state)
+		assert.Nil(t, job, state)
+	}
+
+	require.NoError(t, workers.UpdateState(ctx, "worker-1", domain.WorkerStateActive))
+	job, err := svc.ClaimNext(ctx, "worker-1")
+	require.NoError(t, err)
+	require.NotNil(t, job)
+	assert.Equal(t, build.Jobs[0].ID, job.ID)
+}
+
+func TestBuildService_RequeueExpired_RecoversJobOfDeadWorker(t *testing.T) {
+	ctx := context.Background()
+	store := memory.NewStore()
+	q := &fakeQueue{}
+	svc := newMemoryBuildService(store, q)
+
+	build := buildTestData()
+	build.ID = ""
+	require.NoError(t, svc.CreateBuild(ctx, build))
+	jobId := build.Jobs[0].ID
+
+	q.leases = []string{jobId}
+	job, err := svc.ClaimNext(ctx, "worker-1")
+	require.NoError(t, err)
+	require.NotNil(t, job)
+
+	// worker-1 dies; the queue redelivers its job, which is still running.
+	q.leases = []string{jobId}
+	job, err = svc.ClaimNext(ctx, "worker-2")
+	require.NoError(t, err)
+	assert.Nil(t, job)
+
+	// A lease in the past expires the running job right away.
+	n, err := svc.RequeueExpired(ctx, -time.Second)
+	require.NoError(t, err)
+	assert.Equal(t, 1, n)
+	assert.Equal(t, jobId, q.enqueued[len(q.enqueued)-1])
+
+	q.leases = []string{jobId}
+	job, err = svc.ClaimNext(ctx, "worker-2")
+	require.NoError(t, err)
+	require.NotNil(t, job)
+	assert.Equal(t, jobId, job.ID)
+	assert.Equal(t, "worker-2", *job.LockedBy)
+}
+
+func groupedBuild(group string, policy domain.ConcurrencyPolicy) *domain.Build {
+	build := buildTestData()
+	build.ID = ""
+	build.ConcurrencyGroup = &group
+	build.ConcurrencyPolicy = policy
+	return build
+}
+
+func TestBuildService_ConcurrencyGroup_SerializesBuilds(t *testing.T) {
+	ctx := context.Background()
+	store := memory.NewStore()
+	svc := newMemoryBuildService(store, queue.NewRepositoryQueue(memory.NewBuildRepository(store)))
+
+	first := groupedBuild("deploy-prod", "")
+	second := groupedBuild("deploy-prod", "")
+	other := groupedBuild("deploy-staging", "")
+	for _, build := range []*domain.Build{first, second, other} {
+		require.NoError(t, svc.CreateBuild(ctx, build))
+	}
+	assert.Equal(t, 1, first.MaxConcurrency)
+	assert.Equal(t, domain.ConcurrencyQueue, first.ConcurrencyPolicy)
+
+	job, err := svc.ClaimNext(ctx, "worker-1")
+	require.NoError(t, err)
+	assert.Equal(t, first.Jobs[0].ID, job.ID)
+
+	job, err = svc.ClaimNext(ctx, "worker-2")
+	require.NoError(t, err)
+	assert.Equal(t, other.Jobs[0].ID, job.ID, "deploy-prod is full")
+
+	job, err = svc.ClaimNext(ctx, "worker-2")
+	require.NoError(t, err)
+	assert.Nil(t, job)
+
+	finishedAt := time.Now()
+	require.NoError(t, svc.CompleteJob(ctx, first.Jobs[0].ID, "worker-1", 0, &finishedAt, nil))
+	job, err = svc.ClaimNext(ctx, "worker-2")
+	require.NoError(t, err)
+	require.NotNil(t, job)
+	assert.Equal(t, second.Jobs[0].ID, job.ID)
+}
+
+func TestBuildService_ConcurrencyGroup_CancelsSupersededBuilds(t *testing.T) {
+	tests := []struct {
+		policy          domain.ConcurrencyPolicy
+		runningCanceled bool
+	}{
+		{domain.ConcurrencyQueue, false},
+		{domain.ConcurrencyCancelPending, false},
+		{domain.ConcurrencyCancelInProgress, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.policy), func(t *testing.T) {
+			ctx := context.Background()
+			store := memory.NewStore()
+			svc := newMemoryBuildService(store, queue.NewRepositoryQueue(memory.NewBuildRepository(store)))
+
+			running := groupedBuild("deploy", tt.policy)
+			require.NoError(t, svc.CreateBuild(ctx, running))
+			_, err := svc.ClaimNext(ctx, "worker-1")
+			require.NoError(t, err)
+			pending := groupedBuild("deploy", tt.policy)
+			require.NoError(t, svc.CreateBuild(ctx, pending))
+
+			latest := groupedBuild("deploy", tt.policy)
+			require.NoError(t, svc.CreateBuild(ctx, latest))
+
+			found, err := svc.GetBuild(ctx, pending.ID)
+			require.NoError(t, err)
+			assert.Equal(t, tt.policy != domain.ConcurrencyQueue, found.Status == domain.BuildStatusCanceled)
+
+			found, err = svc.GetBuild(ctx, running.ID)
+			require.NoError(t, err)
+			assert.Equal(t, tt.runningCanceled, found.Status == domain.BuildStatusCanceled)
+
+			found, err = svc.GetBuild(ctx, latest.ID)
+			require.NoError(t, err)
+			assert.Equal(t, domain.BuildStatusPending, found.Status)
+		})
+	}
+}
+
+func TestBuildService_Queue_DefersJobsOverConcurrencyLimit(t *testing.T) {
+	ctx := context.Background()
+	store := memory.NewStore()
+	q := &fakeQueue{}
+	svc := newMemoryBuildService(store, q)
+
+	first := groupedBuild("deploy", "")
+	second := groupedBuild("deploy", "")
+	require.NoError(t, svc.CreateBuild(ctx, first))
+	require.NoError(t, svc.CreateBuild(ctx, second))
+
+	q.leases = []string{first.Jobs[0].ID, second.Jobs[0].ID}
+	_, err := svc.ClaimNext(ctx, "worker-1")
+	require.NoError(t, err)
+	job, err := svc.ClaimNext(ctx, "worker-2")
+
+	require.NoError(t, err)
+	assert.Nil(t, job)
+	assert.Contains(t, q.deferred, second.Jobs[0].ID)
+	assert.Empty(t, q.nacked)
+	assert.Empty(t, q.acked)
+}
+
+func TestBuildService_SharesWorkersBetweenRepositories(t *testing.T) {
+	ctx := context.Background()
+	store := memory.NewStore()
+	svc := newMemoryBuildService(store, queue.NewRepositoryQueue(memory.NewBuildRepository(store)))
+	busy, quiet := "https://github.com/test/busy", "https://github.com/test/quiet"
+	require.NoError(t, NewRepoLimitService(memory.NewRepoLimitRepository(store)).
+		SetRepoLimit(ctx, &domain.RepoLimit{RepoUrl: busy, MaxRunningBuilds: 2}))
+
+	for i := 0; i < 50; i++ {
+		build := buildTestData()
+		build.ID = ""
+		build.RepoUrl = busy
+		require.NoError(t, svc.CreateBuild(ctx, build))
+	}
+	for i := 0; i < 3; i++ {
+		build := buildTestData()
+		build.ID = ""
+		build.RepoUrl = quiet
+		require.NoError(t, svc.CreateBuild(ctx, build))
+	}
+
+	// Four workers claim in rounds and finish their jobs after each round.
+	started := map[string]int{}
+	for round := 0; round < 5; round++ {
+		var jobs []*domain.Job
+		for w := 0; w < 4; w++ {
+			job, err := svc.ClaimNext(ctx, "worker")
+			require.NoError(t, err)
+			if job != nil {
+				jobs = append(jobs, job)
+				started[job.Build.RepoUrl]++
+			}
+		}
+
+		running := map[string]int{}
+		for _, job := range jobs {
+			running[job.Build.RepoUrl]++
+		}
+		assert.LessOrEqual(t, running[busy], 2, "round %d", round)
+
+		finishedAt := time.Now()
+		for _, job := range jobs {
+			require.NoError(t, svc.CompleteJob(ctx, job.ID, "worker", 0, &finishedAt, nil))
+		}
+	}
+
+	assert.Equal(t, 3, started[quiet], "the quiet repository does not wait behind the busy one")
+	assert.Equal(t, 10, started[busy])
+}
+
+func TestBuildService_Queue_DefersBuildsNotDue(t *testing.T) {
+	ctx := context.Background()
+	q := &fakeQueue{}
+	svc := newMemoryBuildService(memory.NewStore(), q)
+
+	notBefore := time.Now().Add(time.Hour)
+	build := buildTestData()
+	build.ID = ""
+	build.NotBefore = &notBefore
+	require.NoError(t, svc.CreateBuild(ctx, build))
+
+	q.leases = q.enqueued
+	job, err := svc.ClaimNext(ctx, "worker-1")
+
+	require.NoError(t, err)
+	assert.Nil(t, job)
+	require.Contains(t, q.deferred, build.Jobs[0].ID)
+	assert.True(t, notBefore.Equal(q.deferred[build.Jobs[0].ID]))
+	assert.Empty(t, q.acked)
+	assert.Empty(t, q.nacked)
+}
+
+func TestBuildService_ClaimNext_WaitsForNotBefore(t *testing.T) {
+	ctx := context.Background()
+	store := memory.NewStore()
+	svc := newMemoryBuildService(store, queue.NewRepositoryQueue(memory.NewBuildRepository(store)))
+
+	later := time.Now().Add(time.Hour)
+	delayed := buildTestData()
+	delayed.ID = ""
+	delayed.NotBefore = &later
+	require.NoError(t, svc.CreateBuild(ctx, delayed))
+	earlier := time.Now().Add(-time.Minute)
+	due := buildTestData()
+	due.ID = ""
+	due.NotBefore = &earlier
+	require.NoError(t, svc.CreateBuild(ctx, due))
+
+	job, err := svc.ClaimNext(ctx, "worker-1")
+	require.NoError(t, err)
+	require.NotNil(t, job)
+	assert.Equal(t, due.ID, job.BuildID)
+
+	job, err = svc.ClaimNext(ctx, "worker-2")
+	require.NoError(t, err)
+	assert.Nil(t, job, "the delayed build is not due yet")
+}
@@ -0,0 +1,76 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"github.com/H3nSte1n/ci-orchestrator/internal/core/domain"
+	"github.com/H3nSte1n/ci-orchestrator/internal/core/ports"
+	"io"
+	"strings"
+)
+
+type cacheService struct {
+	cacheStore ports.CacheStore
+}
+
+func NewCacheService(store ports.CacheStore) ports.CacheService {
+	return &cacheService{
+		cacheStore: store,
+	}
+}
+
+// Restore looks up an exact match first and then walks the restore keys in
+// order, picking the most recently used entry for the first prefix that has
+// any. Entries are namespaced by scope (the repository) so builds of one
+// repository can never read another repository's caches.
+func (s *cacheService) Restore(ctx context.Context, scope string, key string, restoreKeys []string) (io.ReadCloser, string, error) {
+	namespace := cacheNamespace(scope)
+
+	rc, err := s.cacheStore.Open(ctx, namespace+key)
+	if err == nil {
+		return rc, key, nil
+	}
+	if !errors.Is(err, domain.ErrCacheMiss) {
+		return nil, "", err
+	}
+
+	for _, prefix := range restoreKeys {
+		entries, err := s.cacheStore.List(ctx, namespace+prefix)
+		if err != nil {
+			return nil, "", err
+		}
+
+		var best *domain.CacheEntry
+		for i := range entries {
+			if best == nil || entries[i].LastUsed.After(best.LastUsed) {
+				best = &entries[i]
+			}
+		}
+		if best == nil {
+			continue
+		}
+
+		rc, err := s.cacheStore.Open(ctx, best.Key)
+		if errors.Is(err, domain.ErrCacheMiss) {
+			// Evicted between List and Open.
+			continue
+		}
+		if err != nil {
+			return nil, "", err
+		}
+		return rc, strings.TrimPrefix(best.Key, namespace), nil
+	}
+
+	return nil, "", domain.ErrCacheMiss
+}
+
+func (s *cacheService) Save(ctx context.Context, scope string, key string, r io.Reader, size int64) error {
+	return s.cacheStore.Put(ctx, cacheNamespace(scope)+key, r, size)
+}
+
+func cacheNamespace(scope string) string {
+	sum := sha256.Sum256([]byte(scope))
+	return hex.EncodeToString(sum[:8]) + "/"
+}
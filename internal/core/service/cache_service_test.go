@@ -0,0 +1,117 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/H3nSte1n/ci-orchestrator/internal/core/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type mockCacheStore struct {
+	mock.Mock
+}
+
+func (m *mockCacheStore) Open(ctx context.Context, key string) (io.ReadCloser, error) {
+	args := m.Called(ctx, key)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(io.ReadCloser), args.Error(1)
+}
+
+func (m *mockCacheStore) Put(ctx context.Context, key string, r io.Reader, size int64) error {
+	args := m.Called(ctx, key, size)
+	return args.Error(0)
+}
+
+func (m *mockCacheStore) List(ctx context.Context, prefix string) ([]domain.CacheEntry, error) {
+	args := m.Called(ctx, prefix)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.CacheEntry), args.Error(1)
+}
+
+func (m *mockCacheStore) Delete(ctx context.Context, key string) error {
+	args := m.Called(ctx, key)
+	return args.Error(0)
+}
+
+const cacheTestRepo = "https://github.com/test/repo"
+
+func TestCacheService_Restore_ExactHit(t *testing.T) {
+	ns := cacheNamespace(cacheTestRepo)
+	mockStore := new(mockCacheStore)
+	mockStore.On("Open", mock.Anything, ns+"go-abc").Return(io.NopCloser(strings.NewReader("archive")), nil)
+
+	service := NewCacheService(mockStore)
+	rc, matched, err := service.Restore(context.Background(), cacheTestRepo, "go-abc", []string{"go-"})
+
+	require.NoError(t, err)
+	rc.Close()
+	assert.Equal(t, "go-abc", matched)
+	mockStore.AssertNotCalled(t, "List", mock.Anything, mock.Anything)
+}
+
+func TestCacheService_Restore_FallsBackToMostRecentPrefixMatch(t *testing.T) {
+	ns := cacheNamespace(cacheTestRepo)
+	now := time.Now()
+	mockStore := new(mockCacheStore)
+	mockStore.On("Open", mock.Anything, ns+"go-linux-abc").Return(nil, domain.ErrCacheMiss)
+	mockStore.On("List", mock.Anything, ns+"go-linux-").Return([]domain.CacheEntry{}, nil)
+	mockStore.On("List", mock.Anything, ns+"go-").Return([]domain.CacheEntry{
+		{Key: ns + "go-darwin-old", LastUsed: now.Add(-time.Hour)},
+		{Key: ns + "go-linux-new", LastUsed: now},
+	}, nil)
+	mockStore.On("Open", mock.Anything, ns+"go-linux-new").Return(io.NopCloser(strings.NewReader("archive")), nil)
+
+	service := NewCacheService(mockStore)
+	rc, matched, err := service.Restore(context.Background(), cacheTestRepo, "go-linux-abc", []string{"go-linux-", "go-"})
+
+	require.NoError(t, err)
+	rc.Close()
+	assert.Equal(t, "go-linux-new", matched)
+	mockStore.AssertExpectations(t)
+}
+
+func TestCacheService_Restore_Miss(t *testing.T) {
+	ns := cacheNamespace(cacheTestRepo)
+	mockStore := new(mockCacheStore)
+	mockStore.On("Open", mock.Anything, ns+"go-abc").Return(nil, domain.ErrCacheMiss)
+	mockStore.On("List", mock.Anything, ns+"go-").Return(nil, nil)
+
+	service := NewCacheService(mockStore)
+	_, _, err := service.Restore(context.Background(), cacheTestRepo, "go-abc", []string{"go-"})
+
+	assert.ErrorIs(t, err, domain.ErrCacheMiss)
+}
+
+func TestCacheService_Restore_StoreError(t *testing.T) {
+	expectedErr := errors.New("connection refused")
+	mockStore := new(mockCacheStore)
+	mockStore.On("Open", mock.Anything, mock.Anything).Return(nil, expectedErr)
+
+	service := NewCacheService(mockStore)
+	_, _, err := service.Restore(context.Background(), cacheTestRepo, "go-abc", nil)
+
+	assert.Equal(t, expectedErr, err)
+}
+
+func TestCacheService_Save_IsScopedByRepository(t *testing.T) {
+	mockStore := new(mockCacheStore)
+	mockStore.On("Put", mock.Anything, cacheNamespace(cacheTestRepo)+"go-abc", int64(7)).Return(nil)
+
+	service := NewCacheService(mockStore)
+	err := service.Save(context.Background(), cacheTestRepo, "go-abc", strings.NewReader("archive"), 7)
+
+	assert.NoError(t, err)
+	assert.NotEqual(t, cacheNamespace(cacheTestRepo), cacheNamespace("https://github.com/other/repo"))
+	mockStore.AssertExpectations(t)
+}
@@ -0,0 +1,70 @@
+package service
+
+import (
+	"context"
+	"github.com/H3nSte1n/ci-orchestrator/internal/core/domain"
+	"github.com/H3nSte1n/ci-orchestrator/internal/core/ports"
+	"time"
+)
+
+// notifyingBuildService wakes workers whenever a call may have made a job
+// claimable: a new build, a delayed build that became due, a retry, a
+// finished job whose dependents are now unblocked, or a requeued job of a
+// lost worker.
+type notifyingBuildService struct {
+	ports.BuildService
+	notifier ports.JobNotifier
+}
+
+func NewNotifyingBuildService(buildService ports.BuildService, notifier ports.JobNotifier) ports.BuildService {
+	return &notifyingBuildService{
+		BuildService: buildService,
+		notifier:     notifier,
+	}
+}
+
+func (s *notifyingBuildService) CreateBuild(ctx context.Context, build *domain.Build) error {
+	if err := s.BuildService.CreateBuild(ctx, build); err != nil {
+		return err
+	}
+	s.notifier.Notify(ctx)
+	s.notifyWhenDue(ctx, build)
+	return nil
+}
+
+// notifyWhenDue wakes workers again once a delayed build reaches its
+// not_before, since no other call announces that its jobs became claimable.
+func (s *notifyingBuildService) notifyWhenDue(ctx context.Context, build *domain.Build) {
+	if build.Due(time.Now()) {
+		return
+	}
+	ctx = context.WithoutCancel(ctx)
+	time.AfterFunc(time.Until(*build.NotBefore), func() {
+		s.notifier.Notify(ctx)
+	})
+}
+
+func (s *notifyingBuildService) RetryBuild(ctx context.Context, buildId string, triggeredBy *string) (*domain.Build, error) {
+	build, err := s.BuildService.RetryBuild(ctx, buildId, triggeredBy)
+	if err != nil {
+		return nil, err
+	}
+	s.notifier.Notify(ctx)
+	return build, nil
+}
+
+func (s *notifyingBuildService) CompleteJob(ctx context.Context, jobId string, workerId string, exitCode int, finishedAt *time.Time, error error) error {
+	if err := s.BuildService.CompleteJob(ctx, jobId, workerId, exitCode, finishedAt, error); err != nil {
+		return err
+	}
+	s.notifier.Notify(ctx)
+	return nil
+}
+
+func (s *notifyingBuildService) RequeueExpired(ctx context.Context, lease time.Duration) (int, error) {
+	n, err := s.BuildService.RequeueExpired(ctx, lease)
+	if n > 0 {
+		s.notifier.Notify(ctx)
+	}
+	return n, err
+}
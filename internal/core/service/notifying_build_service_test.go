@@ -0,0 +1,93 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/H3nSte1n/ci-orchestrator/internal/adapters/memory"
+	"github.com/H3nSte1n/ci-orchestrator/internal/core/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type countingNotifier struct {
+	notified int
+}
+
+func (n *countingNotifier) Notify(ctx context.Context) {
+	n.notified++
+}
+
+func (n *countingNotifier) Subscribe(ctx context.Context) <-chan struct{} {
+	return nil
+}
+
+func TestNotifyingBuildService_NotifiesWhenJobsBecomeClaimable(t *testing.T) {
+	ctx := context.Background()
+	notifier := &countingNotifier{}
+	svc := NewNotifyingBuildService(newBuildService(memory.NewBuildRepository(memory.NewStore())), notifier)
+
+	build := &domain.Build{RepoUrl: "https://github.com/test/repo", Ref: "main", Command: "make"}
+	require.NoError(t, svc.CreateBuild(ctx, build))
+	assert.Equal(t, 1, notifier.notified)
+
+	job, err := svc.ClaimNext(ctx, "worker-1")
+	require.NoError(t, err)
+	require.NotNil(t, job)
+	assert.Equal(t, 1, notifier.notified, "claiming frees nothing")
+
+	finishedAt := time.Now()
+	require.NoError(t, svc.CompleteJob(ctx, job.ID, "worker-1", 0, &finishedAt, nil))
+	assert.Equal(t, 2, notifier.notified)
+
+	_, err = svc.RetryBuild(ctx, build.ID, nil)
+	require.NoError(t, err)
+	assert.Equal(t, 3, notifier.notified)
+
+	require.NoError(t, svc.CancelBuild(ctx, build.ID))
+	assert.Equal(t, 3, notifier.notified)
+}
+
+type channelNotifier chan struct{}
+
+func (n channelNotifier) Notify(ctx context.Context) {
+	n <- struct{}{}
+}
+
+func (n channelNotifier) Subscribe(ctx context.Context) <-chan struct{} {
+	return n
+}
+
+func TestNotifyingBuildService_NotifiesWhenDelayedBuildIsDue(t *testing.T) {
+	reqCtx, cancel := context.WithCancel(context.Background())
+	notifier := make(channelNotifier, 2)
+	svc := NewNotifyingBuildService(newBuildService(memory.NewBuildRepository(memory.NewStore())), notifier)
+
+	notBefore := time.Now().Add(50 * time.Millisecond)
+	build := &domain.Build{RepoUrl: "https://github.com/test/repo", Ref: "main", Command: "make", NotBefore: &notBefore}
+	require.NoError(t, svc.CreateBuild(reqCtx, build))
+	cancel()
+	<-notifier
+
+	select {
+	case <-notifier:
+		assert.False(t, time.Now().Before(notBefore), "woken before the build was due")
+	case <-time.After(5 * time.Second):
+		t.Fatal("no wake-up once the build was due")
+	}
+}
+
+func TestNotifyingBuildService_DoesNotNotifyOnError(t *testing.T) {
+	repo := new(MockBuildRepository)
+	repo.On("Save", mock.Anything, mock.Anything).Return(errors.New("db down"))
+	notifier := &countingNotifier{}
+	svc := NewNotifyingBuildService(newBuildService(repo), notifier)
+
+	err := svc.CreateBuild(context.Background(), buildTestData())
+
+	assert.Error(t, err)
+	assert.Zero(t, notifier.notified)
+}
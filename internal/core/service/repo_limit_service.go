@@ -0,0 +1,26 @@
+package service
+
+import (
+	"context"
+	"github.com/H3nSte1n/ci-orchestrator/internal/core/domain"
+	"github.com/H3nSte1n/ci-orchestrator/internal/core/ports"
+)
+
+type repoLimitService struct {
+	limitRepo ports.RepoLimitRepository
+}
+
+func NewRepoLimitService(repo ports.RepoLimitRepository) ports.RepoLimitService {
+	return &repoLimitService{limitRepo: repo}
+}
+
+func (s *repoLimitService) SetRepoLimit(ctx context.Context, limit *domain.RepoLimit) error {
+	if err := limit.Validate(); err != nil {
+		return err
+	}
+	return s.limitRepo.Save(ctx, limit)
+}
+
+func (s *repoLimitService) ListRepoLimits(ctx context.Context) ([]domain.RepoLimit, error) {
+	return s.limitRepo.List(ctx)
+}
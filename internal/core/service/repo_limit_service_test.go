@@ -0,0 +1,36 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/H3nSte1n/ci-orchestrator/internal/adapters/memory"
+	"github.com/H3nSte1n/ci-orchestrator/internal/core/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepoLimitService_SetAndList(t *testing.T) {
+	ctx := context.Background()
+	svc := NewRepoLimitService(memory.NewRepoLimitRepository(memory.NewStore()))
+
+	require.NoError(t, svc.SetRepoLimit(ctx, &domain.RepoLimit{RepoUrl: "https://github.com/test/repo", MaxRunningBuilds: 2}))
+
+	limits, err := svc.ListRepoLimits(ctx)
+	require.NoError(t, err)
+	require.Len(t, limits, 1)
+	assert.Equal(t, 2, limits[0].MaxRunningBuilds)
+}
+
+func TestRepoLimitService_SetInvalid(t *testing.T) {
+	ctx := context.Background()
+	svc := NewRepoLimitService(memory.NewRepoLimitRepository(memory.NewStore()))
+
+	err := svc.SetRepoLimit(ctx, &domain.RepoLimit{RepoUrl: "https://github.com/test/repo", MaxRunningBuilds: -1})
+	assert.ErrorIs(t, err, domain.ErrInvalidRepoLimit)
+	assert.ErrorIs(t, err, domain.ErrInvalidArgument)
+
+	limits, err := svc.ListRepoLimits(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, limits)
+}
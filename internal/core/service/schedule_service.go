@@ -0,0 +1,108 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/H3nSte1n/ci-orchestrator/internal/core/domain"
+	"github.com/H3nSte1n/ci-orchestrator/internal/core/ports"
+	"time"
+)
+
+type scheduleService struct {
+	scheduleRepo ports.ScheduleRepository
+	buildService ports.BuildService
+}
+
+func NewScheduleService(scheduleRepository ports.ScheduleRepository, buildService ports.BuildService) ports.ScheduleService {
+	return &scheduleService{
+		scheduleRepo: scheduleRepository,
+		buildService: buildService,
+	}
+}
+
+// CreateSchedule stores a schedule whose first run is the next match of its
+// cron expression.
+func (s *scheduleService) CreateSchedule(ctx context.Context, schedule *domain.Schedule) error {
+	if errs := domain.ValidateNewSchedule(schedule); len(errs) > 0 {
+		return fmt.Errorf("%w: %s %s", domain.ErrInvalidSchedule, errs[0].Field, errs[0].Message)
+	}
+	schedule.SetDefaults()
+
+	next, err := schedule.NextRun(time.Now())
+	if err != nil {
+		return err
+	}
+	schedule.NextRunAt = next
+	schedule.LastRunAt = nil
+
+	return s.scheduleRepo.Save(ctx, schedule)
+}
+
+func (s *scheduleService) GetSchedule(ctx context.Context, scheduleId string) (*domain.Schedule, error) {
+	return s.scheduleRepo.FindByID(ctx, scheduleId)
+}
+
+func (s *scheduleService) ListSchedules(ctx context.Context) ([]domain.Schedule, error) {
+	return s.scheduleRepo.List(ctx)
+}
+
+func (s *scheduleService) DeleteSchedule(ctx context.Context, scheduleId string) error {
+	return s.scheduleRepo.Delete(ctx, scheduleId)
+}
+
+// RunDue creates the builds of the due schedule runs. Every API replica runs
+// it; a replica takes a schedule's runs by advancing its next run before it
+// creates their builds, so each run creates its builds at most once even
+// when replicas overlap. A failing schedule does not hold up the others.
+func (s *scheduleService) RunDue(ctx context.Context, now time.Time, grace time.Duration) (int, error) {
+	schedules, err := s.scheduleRepo.ListDue(ctx, now)
+	if err != nil {
+		return 0, err
+	}
+
+	created := 0
+	var errs []error
+	for i := range schedules {
+		n, err := s.run(ctx, &schedules[i], now, grace)
+		created += n
+		if err != nil {
+			errs = append(errs, fmt.Errorf("schedule %s: %w", schedules[i].ID, err))
+		}
+	}
+	return created, errors.Join(errs...)
+}
+
+// run takes the due runs of schedule and creates one build per run. It
+// creates nothing when another replica took the runs first. When a build
+// cannot be created, its run and the later ones are handed back, so the next
+// tick tries them again.
+func (s *scheduleService) run(ctx context.Context, schedule *domain.Schedule, now time.Time, grace time.Duration) (int, error) {
+	runs, next, err := schedule.Due(now, grace)
+	if err != nil {
+		return 0, err
+	}
+
+	lastRunAt := schedule.LastRunAt
+	if len(runs) > 0 {
+		lastRunAt = &runs[len(runs)-1]
+	}
+	taken, err := s.scheduleRepo.Advance(ctx, schedule.ID, schedule.NextRunAt, next, lastRunAt)
+	if err != nil || !taken {
+		return 0, err
+	}
+
+	for i := range runs {
+		if err := s.buildService.CreateBuild(ctx, schedule.NewBuild()); err != nil {
+			lastRunAt = schedule.LastRunAt
+			if i > 0 {
+				lastRunAt = &runs[i-1]
+			}
+			if _, rollbackErr := s.scheduleRepo.Advance(ctx, schedule.ID, next, runs[i], lastRunAt); rollbackErr != nil {
+				err = errors.Join(err, rollbackErr)
+			}
+			return i, err
+		}
+	}
+	return len(runs), nil
+}
@@ -0,0 +1,241 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/H3nSte1n/ci-orchestrator/internal/adapters/memory"
+	"github.com/H3nSte1n/ci-orchestrator/internal/core/domain"
+	"github.com/H3nSte1n/ci-orchestrator/internal/core/ports"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newScheduleService(store *memory.Store) (ports.ScheduleService, ports.BuildService) {
+	builds := newMemoryBuildService(store, &fakeQueue{})
+	return NewScheduleService(memory.NewScheduleRepository(store), builds), builds
+}
+
+func hourlySchedule(policy domain.MissedRunPolicy) *domain.Schedule {
+	return &domain.Schedule{
+		Name:            "hourly",
+		RepoUrl:         "https://github.com/test/repo",
+		Ref:             "main",
+		Command:         "make test",
+		Cron:            "0 * * * *",
+		MissedRunPolicy: policy,
+	}
+}
+
+func TestScheduleService_CreateSchedule(t *testing.T) {
+	ctx := context.Background()
+	svc, _ := newScheduleService(memory.NewStore())
+
+	schedule := hourlySchedule("")
+	before := time.Now()
+	require.NoError(t, svc.CreateSchedule(ctx, schedule))
+
+	found, err := svc.GetSchedule(ctx, schedule.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "UTC", found.Timezone)
+	assert.Equal(t, domain.MissedRunOnce, found.MissedRunPolicy)
+	assert.True(t, found.NextRunAt.After(before))
+	assert.LessOrEqual(t, found.NextRunAt.Sub(before), time.Hour)
+	assert.Zero(t, found.NextRunAt.Minute())
+	assert.Nil(t, found.LastRunAt)
+}
+
+func TestScheduleService_CreateSchedule_Invalid(t *testing.T) {
+	svc, _ := newScheduleService(memory.NewStore())
+
+	schedule := hourlySchedule("")
+	schedule.Cron = "every hour"
+	err := svc.CreateSchedule(context.Background(), schedule)
+
+	assert.ErrorIs(t, err, domain.ErrInvalidSchedule)
+	assert.ErrorIs(t, err, domain.ErrInvalidArgument)
+}
+
+func TestScheduleService_DeleteSchedule(t *testing.T) {
+	ctx := context.Background()
+	svc, _ := newScheduleService(memory.NewStore())
+
+	schedule := hourlySchedule("")
+	require.NoError(t, svc.CreateSchedule(ctx, schedule))
+	require.NoError(t, svc.DeleteSchedule(ctx, schedule.ID))
+
+	schedules, err := svc.ListSchedules(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, schedules)
+	assert.ErrorIs(t, svc.DeleteSchedule(ctx, schedule.ID), domain.ErrScheduleNotFound)
+}
+
+func TestScheduleService_RunDue(t *testing.T) {
+	ctx := context.Background()
+	svc, builds := newScheduleService(memory.NewStore())
+
+	schedule := hourlySchedule("")
+	schedule.Env = domain.StringMap{"SUITE": "full"}
+	require.NoError(t, svc.CreateSchedule(ctx, schedule))
+	runAt := schedule.NextRunAt
+
+	created, err := svc.RunDue(ctx, runAt.Add(-time.Second), time.Minute)
+	require.NoError(t, err)
+	assert.Zero(t, created, "the first run is not due yet")
+
+	now := runAt.Add(10 * time.Second)
+	created, err = svc.RunDue(ctx, now, time.Minute)
+	require.NoError(t, err)
+	assert.Equal(t, 1, created)
+
+	created, err = svc.RunDue(ctx, now, time.Minute)
+	require.NoError(t, err)
+	assert.Zero(t, created, "a run creates its build once")
+
+	page, err := builds.ListBuilds(ctx, domain.BuildFilter{})
+	require.NoError(t, err)
+	require.Len(t, page.Builds, 1)
+	build := page.Builds[0]
+	assert.Equal(t, "make test", build.Command)
+	assert.Equal(t, domain.StringMap{"SUITE": "full"}, build.Env)
+	require.NotNil(t, build.TriggeredBy)
+	assert.Equal(t, "schedule:hourly", *build.TriggeredBy)
+
+	found, err := svc.GetSchedule(ctx, schedule.ID)
+	require.NoError(t, err)
+	assert.True(t, runAt.Add(time.Hour).Equal(found.NextRunAt))
+	require.NotNil(t, found.LastRunAt)
+	assert.True(t, runAt.Equal(*found.LastRunAt))
+}
+
+func TestScheduleService_RunDue_MissedRuns(t *testing.T) {
+	tests := []struct {
+		policy domain.MissedRunPolicy
+		want   int
+	}{
+		{domain.MissedRunSkip, 0},
+		{domain.MissedRunOnce, 1},
+		{domain.MissedRunAll, 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.policy), func(t *testing.T) {
+			ctx := context.Background()
+			svc, _ := newScheduleService(memory.NewStore())
+
+			schedule := hourlySchedule(tt.policy)
+			require.NoError(t, svc.CreateSchedule(ctx, schedule))
+
+			// The scheduler was down for the first three runs.
+			created, err := svc.RunDue(ctx, schedule.NextRunAt.Add(2*time.Hour+30*time.Minute), time.Minute)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, created)
+
+			found, err := svc.GetSchedule(ctx, schedule.ID)
+			require.NoError(t, err)
+			assert.True(t, schedule.NextRunAt.Add(3*time.Hour).Equal(found.NextRunAt))
+		})
+	}
+}
+
+// failingBuildService fails to create the build of the given call.
+type failingBuildService struct {
+	ports.BuildService
+	call, failOn int
+}
+
+func (s *failingBuildService) CreateBuild(ctx context.Context, build *domain.Build) error {
+	s.call++
+	if s.call == s.failOn {
+		return errors.New("database is down")
+	}
+	return s.BuildService.CreateBuild(ctx, build)
+}
+
+func TestScheduleService_RunDue_HandsBackRunsOfFailedBuilds(t *testing.T) {
+	tests := []struct {
+		name    string
+		failOn  int
+		created int
+	}{
+		{"first run", 1, 0},
+		{"later run", 2, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			store := memory.NewStore()
+			schedules := memory.NewScheduleRepository(store)
+			builds := &failingBuildService{BuildService: newMemoryBuildService(store, &fakeQueue{}), failOn: tt.failOn}
+			svc := NewScheduleService(schedules, builds)
+
+			schedule := hourlySchedule(domain.MissedRunAll)
+			require.NoError(t, svc.CreateSchedule(ctx, schedule))
+			runs := []time.Time{schedule.NextRunAt, schedule.NextRunAt.Add(time.Hour), schedule.NextRunAt.Add(2 * time.Hour)}
+			now := runs[2].Add(time.Second)
+
+			created, err := svc.RunDue(ctx, now, time.Minute)
+			assert.Error(t, err)
+			assert.Equal(t, tt.created, created)
+
+			found, err := svc.GetSchedule(ctx, schedule.ID)
+			require.NoError(t, err)
+			assert.True(t, runs[tt.created].Equal(found.NextRunAt), "the failed run is handed back")
+			if tt.created == 0 {
+				assert.Nil(t, found.LastRunAt)
+			} else {
+				require.NotNil(t, found.LastRunAt)
+				assert.True(t, runs[tt.created-1].Equal(*found.LastRunAt))
+			}
+
+			created, err = svc.RunDue(ctx, now, time.Minute)
+			require.NoError(t, err)
+			assert.Equal(t, len(runs)-tt.created, created, "the next tick retries the remaining runs")
+
+			page, err := builds.ListBuilds(ctx, domain.BuildFilter{})
+			require.NoError(t, err)
+			assert.Len(t, page.Builds, len(runs))
+		})
+	}
+}
+
+// TestScheduleService_RunDue_Replicas runs the scheduler of several API
+// replicas against one store at the same time.
+func TestScheduleService_RunDue_Replicas(t *testing.T) {
+	const replicas = 4
+	ctx := context.Background()
+	store := memory.NewStore()
+	schedules := memory.NewScheduleRepository(store)
+	builds := newMemoryBuildService(store, &fakeQueue{})
+
+	schedule := hourlySchedule("")
+	require.NoError(t, NewScheduleService(schedules, builds).CreateSchedule(ctx, schedule))
+	now := schedule.NextRunAt.Add(time.Second)
+
+	var (
+		wg      sync.WaitGroup
+		mu      sync.Mutex
+		created int
+	)
+	for i := 0; i < replicas; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			n, err := NewScheduleService(schedules, builds).RunDue(ctx, now, time.Minute)
+			assert.NoError(t, err)
+			mu.Lock()
+			created += n
+			mu.Unlock()
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, 1, created)
+	page, err := builds.ListBuilds(ctx, domain.BuildFilter{})
+	require.NoError(t, err)
+	assert.Len(t, page.Builds, 1)
+}
@@ -0,0 +1,103 @@
+package service
+
+import (
+	"context"
+	"github.com/H3nSte1n/ci-orchestrator/internal/core/domain"
+	"github.com/H3nSte1n/ci-orchestrator/internal/core/ports"
+	"slices"
+	"strings"
+	"time"
+)
+
+type workerService struct {
+	workerRepo ports.WorkerRepository
+	now        func() time.Time
+}
+
+func NewWorkerService(repo ports.WorkerRepository) ports.WorkerService {
+	return &workerService{
+		workerRepo: repo,
+		now:        time.Now,
+	}
+}
+
+func (s *workerService) Register(ctx context.Context, worker *domain.Worker) error {
+	if strings.TrimSpace(worker.ID) == "" {
+		return domain.NewError(domain.ErrInvalidArgument, "worker id is required")
+	}
+	if worker.Slots < 0 {
+		return domain.NewError(domain.ErrInvalidArgument, "slots must not be negative")
+	}
+	if err := domain.ValidateLabels(worker.Labels); err != nil {
+		return err
+	}
+
+	if worker.Slots == 0 {
+		worker.Slots = 1
+	}
+	worker.State = domain.WorkerStateActive
+	worker.LastSeenAt = s.now()
+	worker.DeregisteredAt = nil
+	return s.workerRepo.Register(ctx, worker)
+}
+
+func (s *workerService) Heartbeat(ctx context.Context, workerId string) (*domain.Worker, error) {
+	if err := s.workerRepo.Heartbeat(ctx, workerId, s.now()); err != nil {
+		return nil, err
+	}
+	return s.findWorker(ctx, workerId)
+}
+
+func (s *workerService) Deregister(ctx context.Context, workerId string) error {
+	return s.workerRepo.Deregister(ctx, workerId, s.now())
+}
+
+func (s *workerService) ListWorkers(ctx context.Context) ([]domain.Worker, error) {
+	workers, err := s.workerRepo.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	jobs, err := s.workerRepo.RunningJobs(ctx)
+	if err != nil {
+		return nil, err
+	}
+	builds := map[string][]string{}
+	for _, job := range jobs {
+		if job.LockedBy == nil {
+			continue
+		}
+		workerId := *job.LockedBy
+		if !slices.Contains(builds[workerId], job.BuildID) {
+			builds[workerId] = append(builds[workerId], job.BuildID)
+		}
+	}
+
+	now := s.now()
+	for i := range workers {
+		workers[i].Status = workers[i].StatusAt(now)
+		workers[i].CurrentBuilds = builds[workers[i].ID]
+	}
+	return workers, nil
+}
+
+// SetState pauses, drains or resumes a worker. The worker picks the state up
+// with its next heartbeat; until then the claim path already enforces it.
+func (s *workerService) SetState(ctx context.Context, workerId string, state domain.WorkerState) (*domain.Worker, error) {
+	if err := domain.ValidateWorkerState(state); err != nil {
+		return nil, err
+	}
+	if err := s.workerRepo.UpdateState(ctx, workerId, state); err != nil {
+		return nil, err
+	}
+	return s.findWorker(ctx, workerId)
+}
+
+func (s *workerService) findWorker(ctx context.Context, workerId string) (*domain.Worker, error) {
+	worker, err := s.workerRepo.FindByID(ctx, workerId)
+	if err != nil {
+		return nil, err
+	}
+	worker.Status = worker.StatusAt(s.now())
+	return worker, nil
+}
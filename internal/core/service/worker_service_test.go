@@ -0,0 +1,111 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/H3nSte1n/ci-orchestrator/internal/adapters/memory"
+	"github.com/H3nSte1n/ci-orchestrator/internal/core/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWorkerService_Register(t *testing.T) {
+	repo := memory.NewWorkerRepository(memory.NewStore())
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	svc := &workerService{workerRepo: repo, now: func() time.Time { return now }}
+
+	err := svc.Register(context.Background(), &domain.Worker{ID: "worker-1", Labels: domain.StringList{"linux", "arm64"}})
+
+	require.NoError(t, err)
+	workers, err := repo.List(context.Background())
+	require.NoError(t, err)
+	require.Len(t, workers, 1)
+	assert.Equal(t, domain.StringList{"linux", "arm64"}, workers[0].Labels)
+	assert.Equal(t, now, workers[0].LastSeenAt)
+}
+
+func TestWorkerService_Register_Invalid(t *testing.T) {
+	svc := NewWorkerService(memory.NewWorkerRepository(memory.NewStore()))
+
+	err := svc.Register(context.Background(), &domain.Worker{ID: " "})
+	assert.ErrorIs(t, err, domain.ErrInvalidArgument)
+
+	err = svc.Register(context.Background(), &domain.Worker{ID: "worker-1", Labels: domain.StringList{"has space"}})
+	assert.ErrorIs(t, err, domain.ErrInvalidLabels)
+}
+
+func TestWorkerService_Register_DefaultsSlots(t *testing.T) {
+	repo := memory.NewWorkerRepository(memory.NewStore())
+	svc := NewWorkerService(repo)
+
+	require.NoError(t, svc.Register(context.Background(), &domain.Worker{ID: "worker-1", Hostname: "host-1", Version: "v1.0.0"}))
+
+	worker, err := repo.FindByID(context.Background(), "worker-1")
+	require.NoError(t, err)
+	assert.Equal(t, 1, worker.Slots)
+	assert.Equal(t, "host-1", worker.Hostname)
+	assert.Equal(t, domain.WorkerStateActive, worker.State)
+
+	err = svc.Register(context.Background(), &domain.Worker{ID: "worker-1", Slots: -1})
+	assert.ErrorIs(t, err, domain.ErrInvalidArgument)
+}
+
+func TestWorkerService_HeartbeatReturnsState(t *testing.T) {
+	ctx := context.Background()
+	repo := memory.NewWorkerRepository(memory.NewStore())
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	svc := &workerService{workerRepo: repo, now: func() time.Time { return now }}
+	require.NoError(t, svc.Register(ctx, &domain.Worker{ID: "worker-1"}))
+
+	_, err := svc.SetState(ctx, "worker-1", domain.WorkerStateDraining)
+	require.NoError(t, err)
+	now = now.Add(time.Minute)
+	worker, err := svc.Heartbeat(ctx, "worker-1")
+
+	require.NoError(t, err)
+	assert.Equal(t, domain.WorkerStateDraining, worker.State)
+	assert.Equal(t, domain.WorkerStatusDraining, worker.Status)
+	assert.Equal(t, now, worker.LastSeenAt)
+
+	_, err = svc.Heartbeat(ctx, "missing")
+	assert.ErrorIs(t, err, domain.ErrWorkerNotFound)
+}
+
+func TestWorkerService_SetState_Invalid(t *testing.T) {
+	svc := NewWorkerService(memory.NewWorkerRepository(memory.NewStore()))
+
+	_, err := svc.SetState(context.Background(), "worker-1", "stopped")
+	assert.ErrorIs(t, err, domain.ErrInvalidWorkerState)
+
+	_, err = svc.SetState(context.Background(), "missing", domain.WorkerStatePaused)
+	assert.ErrorIs(t, err, domain.ErrWorkerNotFound)
+}
+
+func TestWorkerService_ListWorkers(t *testing.T) {
+	ctx := context.Background()
+	store := memory.NewStore()
+	repo := memory.NewWorkerRepository(store)
+	now := time.Now()
+	svc := &workerService{workerRepo: repo, now: func() time.Time { return now }}
+	require.NoError(t, svc.Register(ctx, &domain.Worker{ID: "busy"}))
+	require.NoError(t, svc.Register(ctx, &domain.Worker{ID: "gone"}))
+	require.NoError(t, svc.Deregister(ctx, "gone"))
+
+	builds := memory.NewBuildRepository(store)
+	build := &domain.Build{RepoUrl: "https://github.com/test/repo", Ref: "main", Command: "make", Status: domain.BuildStatusPending,
+		Jobs: []domain.Job{{Name: domain.DefaultJobName, Command: "make", Status: domain.JobStatusPending}}}
+	require.NoError(t, builds.Save(ctx, build))
+	_, err := builds.ClaimNext(ctx, "busy")
+	require.NoError(t, err)
+
+	workers, err := svc.ListWorkers(ctx)
+
+	require.NoError(t, err)
+	require.Len(t, workers, 2)
+	assert.Equal(t, domain.WorkerStatusOnline, workers[0].Status)
+	assert.Equal(t, []string{build.ID}, workers[0].CurrentBuilds)
+	assert.Equal(t, domain.WorkerStatusOffline, workers[1].Status)
+	assert.Empty(t, workers[1].CurrentBuilds)
+}
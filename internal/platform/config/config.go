@@ -5,12 +5,18 @@ import (
 	"github.com/spf13/viper"
 	"os"
 	"strings"
+	"time"
 )
 
 type Config struct {
 	App              AppConfig        `mapstructure:"app"`
 	ApiServiceConfig ApiServiceConfig `mapstructure:"api_service"`
 	DB               DBConfig         `mapstructure:"db"`
+	Worker           WorkerConfig     `mapstructure:"worker"`
+	Artifacts        StorageConfig    `mapstructure:"artifacts"`
+	Cache            CacheConfig      `mapstructure:"cache"`
+	Queue            QueueConfig      `mapstructure:"queue"`
+	Scheduler        SchedulerConfig  `mapstructure:"scheduler"`
 }
 
 type AppConfig struct {
@@ -19,10 +25,15 @@ type AppConfig struct {
 }
 
 type ApiServiceConfig struct {
-	Port string `mapstructure:"port"`
+	Port     string `mapstructure:"port"`
+	GrpcPort string `mapstructure:"grpc_port"`
 }
 
 type DBConfig struct {
+	// Driver is postgres (the default) or sqlite, which keeps everything in
+	// the file at Path.
+	Driver   string `mapstructure:"driver"`
+	Path     string `mapstructure:"path"`
 	Host     string `mapstructure:"host"`
 	Port     string `mapstructure:"port"`
 	User     string `mapstructure:"user"`
@@ -32,6 +43,68 @@ type DBConfig struct {
 	Quiet    bool   `mapstructure:"quiet"`
 }
 
+type WorkerConfig struct {
+	ID                string        `mapstructure:"id"`
+	PollInterval      time.Duration `mapstructure:"poll_interval"`
+	HeartbeatInterval time.Duration `mapstructure:"heartbeat_interval"`
+	// FallbackPollInterval replaces PollInterval while job notifications
+	// are connected and polling only catches what they missed.
+	FallbackPollInterval time.Duration `mapstructure:"fallback_poll_interval"`
+	// ApiURL switches the worker to the internal worker API; it then needs
+	// ApiToken (a token with the worker scope) instead of database access.
+	ApiURL   string `mapstructure:"api_url"`
+	ApiToken string `mapstructure:"api_token"`
+	// Slots is the number of jobs the all-in-one binary runs at once.
+	Slots int `mapstructure:"slots"`
+	// Labels are registered for the worker; it only claims builds whose
+	// runs_on they satisfy. In the environment, separate them with commas.
+	Labels []string `mapstructure:"labels"`
+}
+
+type QueueConfig struct {
+	// Driver is database (the default), which claims straight from the job
+	// rows, or nats for a JetStream work queue.
+	Driver string `mapstructure:"driver"`
+	// Lease is how long a running job may go without a heartbeat before it
+	// is requeued for another worker; keep it well above the worker
+	// heartbeat interval.
+	Lease time.Duration `mapstructure:"lease"`
+	NATS  NATSConfig    `mapstructure:"nats"`
+}
+
+type NATSConfig struct {
+	URL string `mapstructure:"url"`
+}
+
+type SchedulerConfig struct {
+	// Interval is how often the API checks for due schedule runs.
+	Interval time.Duration `mapstructure:"interval"`
+}
+
+type StorageConfig struct {
+	Driver string             `mapstructure:"driver"`
+	Local  LocalStorageConfig `mapstructure:"local"`
+	S3     S3StorageConfig    `mapstructure:"s3"`
+}
+
+type CacheConfig struct {
+	StorageConfig `mapstructure:",squash"`
+	MaxSizeMB     int64 `mapstructure:"max_size_mb"`
+}
+
+type LocalStorageConfig struct {
+	Path string `mapstructure:"path"`
+}
+
+type S3StorageConfig struct {
+	Endpoint     string `mapstructure:"endpoint"`
+	Region       string `mapstructure:"region"`
+	Bucket       string `mapstructure:"bucket"`
+	AccessKey    string `mapstructure:"access_key"`
+	SecretKey    string `mapstructure:"secret_key"`
+	UsePathStyle bool   `mapstructure:"use_path_style"`
+}
+
 func LoadConfig(env string) (config *Config, err error) {
 	v := viper.New()
 	v.SetConfigName(fmt.Sprintf(".env.%s", env))
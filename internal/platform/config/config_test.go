@@ -3,6 +3,7 @@ package config
 import (
 	"os"
 	"testing"
+	"time"
 )
 
 func TestLoadConfig(t *testing.T) {
@@ -31,6 +32,22 @@ func TestLoadConfig(t *testing.T) {
 	}) {
 		t.Errorf("DB config mismatch. Got: %+v", cfg.DB)
 	}
+
+	if cfg.Worker.PollInterval != 2*time.Second || cfg.Worker.HeartbeatInterval != 10*time.Second {
+		t.Errorf("Worker config mismatch. Got: %+v", cfg.Worker)
+	}
+
+	if cfg.Artifacts.Driver != "local" || cfg.Artifacts.Local.Path == "" {
+		t.Errorf("Artifacts config mismatch. Got: %+v", cfg.Artifacts)
+	}
+
+	if cfg.Cache.Driver != "local" || cfg.Cache.Local.Path == "" || cfg.Cache.MaxSizeMB != 5120 {
+		t.Errorf("Cache config mismatch. Got: %+v", cfg.Cache)
+	}
+
+	if cfg.Scheduler.Interval != 15*time.Second {
+		t.Errorf("Scheduler config mismatch. Got: %+v", cfg.Scheduler)
+	}
 }
 
 func TestLoadConfigNotFound(t *testing.T) {
@@ -0,0 +1,19 @@
+package db
+
+import (
+	"fmt"
+	"github.com/H3nSte1n/ci-orchestrator/internal/platform/config"
+	"gorm.io/gorm"
+)
+
+// NewConnection opens the database selected by db.driver.
+func NewConnection(cfg *config.Config) (*gorm.DB, error) {
+	switch cfg.DB.Driver {
+	case "", "postgres":
+		return NewPostgresConnection(cfg)
+	case "sqlite":
+		return NewSQLiteConnection(cfg)
+	default:
+		return nil, fmt.Errorf("unknown database driver %q", cfg.DB.Driver)
+	}
+}
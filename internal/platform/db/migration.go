@@ -5,13 +5,28 @@ import (
 	"fmt"
 	"github.com/H3nSte1n/ci-orchestrator/internal/platform/config"
 	"github.com/golang-migrate/migrate/v4"
+	"github.com/golang-migrate/migrate/v4/database"
 	"github.com/golang-migrate/migrate/v4/database/postgres"
+	"github.com/golang-migrate/migrate/v4/database/sqlite3"
 	_ "github.com/golang-migrate/migrate/v4/source/file"
 	"os"
 	"path/filepath"
 )
 
+// Migrate applies the migrations of the configured driver. SQLite has its
+// own migration set in migrations/sqlite.
 func Migrate(cfg *config.Config) error {
+	switch cfg.DB.Driver {
+	case "", "postgres":
+		return migratePostgres(cfg)
+	case "sqlite":
+		return migrateSQLite(cfg)
+	default:
+		return fmt.Errorf("unknown database driver %q", cfg.DB.Driver)
+	}
+}
+
+func migratePostgres(cfg *config.Config) error {
 	db, err := sql.Open("postgres", fmt.Sprintf(
 		"postgres://%s:%s@%s:%s/%s?sslmode=%s",
 		cfg.DB.User,
@@ -33,16 +48,40 @@ func Migrate(cfg *config.Config) error {
 		return fmt.Errorf("could not create migration driver: %v", err)
 	}
 
+	return runMigrations("migrations", "postgres", driver)
+}
+
+func migrateSQLite(cfg *config.Config) error {
+	if cfg.DB.Path == "" {
+		return fmt.Errorf("db.path is required for the sqlite driver")
+	}
+
+	db, err := sql.Open("sqlite3", sqliteDSN(cfg.DB.Path))
+	if err != nil {
+		return fmt.Errorf("could not connect to database: %v", err)
+	}
+
+	defer db.Close()
+
+	driver, err := sqlite3.WithInstance(db, &sqlite3.Config{})
+	if err != nil {
+		return fmt.Errorf("could not create migration driver: %v", err)
+	}
+
+	return runMigrations(filepath.Join("migrations", "sqlite"), "sqlite3", driver)
+}
+
+func runMigrations(dir string, databaseName string, driver database.Driver) error {
 	configDir := os.Getenv("BASE_DIR")
 	if configDir == "" {
 		configDir = "."
 	}
-	migrationsPath := filepath.Join(configDir, "migrations")
+	migrationsPath := filepath.Join(configDir, dir)
 	sourceURL := fmt.Sprintf("file://%s", migrationsPath)
 
 	m, err := migrate.NewWithDatabaseInstance(
 		sourceURL,
-		"postgres",
+		databaseName,
 		driver,
 	)
 	if err != nil {
@@ -0,0 +1,153 @@
+package db
+
+import (
+	"errors"
+	"fmt"
+	"github.com/H3nSte1n/ci-orchestrator/internal/platform/config"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/logger"
+	"reflect"
+	"time"
+)
+
+// sqliteDSN opens path in WAL mode so readers never wait for the writer.
+// Every transaction starts with BEGIN IMMEDIATE, which takes the write lock
+// up front: claims serialize on it instead of on row locks, and a transaction
+// never fails half way because another writer upgraded first.
+func sqliteDSN(path string) string {
+	return fmt.Sprintf("file:%s?_txlock=immediate&_busy_timeout=5000&_journal_mode=WAL&_foreign_keys=on", path)
+}
+
+func NewSQLiteConnection(cfg *config.Config) (*gorm.DB, error) {
+	if cfg.DB.Path == "" {
+		return nil, errors.New("db.path is required for the sqlite driver")
+	}
+
+	logMode := logger.Error
+	if cfg.DB.Quiet {
+		logMode = logger.Silent
+	}
+
+	db, err := gorm.Open(sqlite.Open(sqliteDSN(cfg.DB.Path)), &gorm.Config{
+		Logger:         logger.Default.LogMode(logMode),
+		NowFunc:        func() time.Time { return time.Now().UTC() },
+		TranslateError: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := registerUTCCallbacks(db); err != nil {
+		return nil, err
+	}
+
+	return db, nil
+}
+
+// SQLite keeps timestamps as text and compares them as text, which only
+// orders correctly if every value has the same offset. These callbacks move
+// all times written or compared to UTC.
+func registerUTCCallbacks(db *gorm.DB) error {
+	callbacks := db.Callback()
+	if err := callbacks.Create().Before("gorm:create").Register("ci:utc", writeUTC); err != nil {
+		return err
+	}
+	if err := callbacks.Update().Before("gorm:update").Register("ci:utc", writeUTC); err != nil {
+		return err
+	}
+	if err := callbacks.Query().Before("gorm:query").Register("ci:utc", whereUTC); err != nil {
+		return err
+	}
+	return callbacks.Delete().Before("gorm:delete").Register("ci:utc", whereUTC)
+}
+
+func writeUTC(db *gorm.DB) {
+	whereUTC(db)
+
+	stmt := db.Statement
+	if dest, ok := stmt.Dest.(map[string]interface{}); ok {
+		for k, v := range dest {
+			dest[k] = valueToUTC(v)
+		}
+	} else if stmt.Schema != nil && stmt.ReflectValue.IsValid() {
+		structsToUTC(stmt.ReflectValue)
+	}
+}
+
+func whereUTC(db *gorm.DB) {
+	where, ok := db.Statement.Clauses["WHERE"]
+	if !ok {
+		return
+	}
+	if w, ok := where.Expression.(clause.Where); ok {
+		where.Expression = clause.Where{Exprs: exprsToUTC(w.Exprs)}
+		db.Statement.Clauses["WHERE"] = where
+	}
+}
+
+func exprsToUTC(exprs []clause.Expression) []clause.Expression {
+	out := make([]clause.Expression, len(exprs))
+	for i, expr := range exprs {
+		switch e := expr.(type) {
+		case clause.Expr:
+			vars := make([]interface{}, len(e.Vars))
+			for j, v := range e.Vars {
+				vars[j] = valueToUTC(v)
+			}
+			e.Vars = vars
+			out[i] = e
+		case clause.AndConditions:
+			out[i] = clause.AndConditions{Exprs: exprsToUTC(e.Exprs)}
+		case clause.OrConditions:
+			out[i] = clause.OrConditions{Exprs: exprsToUTC(e.Exprs)}
+		case clause.NotConditions:
+			out[i] = clause.NotConditions{Exprs: exprsToUTC(e.Exprs)}
+		default:
+			out[i] = expr
+		}
+	}
+	return out
+}
+
+func valueToUTC(v interface{}) interface{} {
+	switch t := v.(type) {
+	case time.Time:
+		return t.UTC()
+	case *time.Time:
+		if t != nil {
+			utc := t.UTC()
+			return &utc
+		}
+	}
+	return v
+}
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// structsToUTC converts the time fields of a struct, or of every struct in a
+// slice, in place. Nested associations are handled by their own statements.
+func structsToUTC(v reflect.Value) {
+	v = reflect.Indirect(v)
+	switch v.Kind() {
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			structsToUTC(v.Index(i))
+		}
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			field := v.Field(i)
+			if !field.CanSet() {
+				continue
+			}
+			switch {
+			case field.Type() == timeType:
+				field.Set(reflect.ValueOf(field.Interface().(time.Time).UTC()))
+			case field.Type() == reflect.PointerTo(timeType) && !field.IsNil():
+				utc := field.Elem().Interface().(time.Time).UTC()
+				field.Set(reflect.ValueOf(&utc))
+			}
+		}
+	}
+}
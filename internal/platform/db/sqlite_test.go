@@ -0,0 +1,69 @@
+package db
+
+import (
+	"github.com/H3nSte1n/ci-orchestrator/internal/platform/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func sqliteConfig(t *testing.T) *config.Config {
+	t.Setenv("BASE_DIR", "../../../")
+	return &config.Config{DB: config.DBConfig{Driver: "sqlite", Path: filepath.Join(t.TempDir(), "ci.db"), Quiet: true}}
+}
+
+func TestMigrate_SQLite(t *testing.T) {
+	cfg := sqliteConfig(t)
+
+	require.NoError(t, Migrate(cfg))
+	require.NoError(t, Migrate(cfg), "second migration should be a no-op")
+
+	conn, err := NewConnection(cfg)
+	require.NoError(t, err)
+
+	var tables []string
+	require.NoError(t, conn.Raw("SELECT name FROM sqlite_master WHERE type = 'table' AND name NOT LIKE 'sqlite_%' ORDER BY name").Scan(&tables).Error)
+	assert.Equal(t, []string{
+		"api_tokens", "artifacts", "build_logs", "builds", "concurrency_groups", "jobs", "repo_limits", "schedules", "schema_migrations", "workers",
+	}, tables)
+}
+
+func TestNewSQLiteConnection_RequiresPath(t *testing.T) {
+	_, err := NewSQLiteConnection(&config.Config{DB: config.DBConfig{Driver: "sqlite"}})
+	assert.Error(t, err)
+}
+
+func TestNewConnection_UnknownDriver(t *testing.T) {
+	_, err := NewConnection(&config.Config{DB: config.DBConfig{Driver: "oracle"}})
+	assert.ErrorContains(t, err, "unknown database driver")
+
+	assert.ErrorContains(t, Migrate(&config.Config{DB: config.DBConfig{Driver: "oracle"}}), "unknown database driver")
+}
+
+func TestNewSQLiteConnection_StoresUTC(t *testing.T) {
+	cfg := sqliteConfig(t)
+	require.NoError(t, Migrate(cfg))
+	conn, err := NewSQLiteConnection(cfg)
+	require.NoError(t, err)
+
+	type build struct {
+		ID        string `gorm:"primaryKey"`
+		RepoUrl   string
+		Ref       string
+		Command   string
+		CreatedAt time.Time
+	}
+	berlin := time.FixedZone("CEST", 2*60*60)
+	at := time.Date(2024, 6, 1, 12, 0, 0, 0, berlin)
+	require.NoError(t, conn.Create(&build{ID: "00000000-0000-4000-8000-000000000001", RepoUrl: "r", Ref: "main", Command: "make", CreatedAt: at}).Error)
+
+	var stored string
+	require.NoError(t, conn.Raw("SELECT CAST(created_at AS TEXT) FROM builds").Row().Scan(&stored))
+	assert.Contains(t, stored, "2024-06-01 10:00:00")
+
+	var count int64
+	require.NoError(t, conn.Model(&build{}).Where("created_at >= ?", at).Count(&count).Error)
+	assert.Equal(t, int64(1), count)
+}
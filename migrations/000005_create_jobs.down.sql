@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_build_logs_job_id_seq;
+ALTER TABLE build_logs DROP COLUMN job_id;
+
+DROP TABLE IF EXISTS jobs CASCADE;
@@ -0,0 +1,38 @@
+CREATE TABLE jobs
+(
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    build_id UUID NOT NULL,
+    name TEXT NOT NULL,
+    command TEXT NOT NULL,
+    needs JSONB NOT NULL DEFAULT '[]',
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    exit_code INT DEFAULT 0,
+    error TEXT,
+    locked_by TEXT,
+    locked_at TIMESTAMPTZ,
+    finished_at TIMESTAMPTZ,
+
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    FOREIGN KEY (build_id) REFERENCES builds (id) ON DELETE CASCADE,
+    UNIQUE (build_id, name)
+);
+
+CREATE INDEX idx_jobs_status_created_at ON jobs(status, created_at);
+
+ALTER TABLE build_logs ADD COLUMN job_id UUID REFERENCES jobs (id) ON DELETE CASCADE;
+
+CREATE INDEX idx_build_logs_job_id_seq ON build_logs(job_id, seq);
+
+-- Builds queued before jobs existed run as a single default job.
+INSERT INTO jobs (build_id, name, command, status)
+SELECT id, 'default', command, 'pending' FROM builds WHERE status = 'pending';
+
+-- Builds running across the upgrade were claimed by workers that cannot report
+-- a job back; fail them explicitly so they can be retried.
+INSERT INTO jobs (build_id, name, command, status, exit_code, error, locked_by, locked_at, finished_at)
+SELECT id, 'default', command, 'failed', -1, 'interrupted by upgrade', locked_by, locked_at, NOW()
+FROM builds WHERE status = 'running';
+
+UPDATE builds SET status = 'failed', exit_code = -1, error = 'interrupted by upgrade', finished_at = NOW()
+WHERE status = 'running';
@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS idx_builds_parent_id;
+
+ALTER TABLE builds DROP COLUMN matrix_values;
+ALTER TABLE builds DROP COLUMN matrix;
+ALTER TABLE builds DROP COLUMN parent_id;
+ALTER TABLE builds DROP COLUMN env;
@@ -0,0 +1,6 @@
+ALTER TABLE builds ADD COLUMN env JSONB NOT NULL DEFAULT '{}';
+ALTER TABLE builds ADD COLUMN parent_id UUID REFERENCES builds (id) ON DELETE CASCADE;
+ALTER TABLE builds ADD COLUMN matrix JSONB;
+ALTER TABLE builds ADD COLUMN matrix_values JSONB;
+
+CREATE INDEX idx_builds_parent_id ON builds(parent_id);
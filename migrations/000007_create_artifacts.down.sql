@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS artifacts CASCADE;
+
+ALTER TABLE jobs DROP COLUMN artifacts;
+ALTER TABLE builds DROP COLUMN artifacts;
@@ -0,0 +1,18 @@
+ALTER TABLE builds ADD COLUMN artifacts JSONB NOT NULL DEFAULT '[]';
+ALTER TABLE jobs ADD COLUMN artifacts JSONB NOT NULL DEFAULT '[]';
+
+CREATE TABLE artifacts
+(
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    build_id UUID NOT NULL,
+    job_id UUID NOT NULL,
+    name TEXT NOT NULL,
+    size BIGINT NOT NULL DEFAULT 0,
+    checksum TEXT NOT NULL,
+    content_type TEXT NOT NULL,
+    storage_key TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    FOREIGN KEY (build_id) REFERENCES builds (id) ON DELETE CASCADE,
+    FOREIGN KEY (job_id) REFERENCES jobs (id) ON DELETE CASCADE,
+    UNIQUE (build_id, name)
+);
@@ -0,0 +1 @@
+ALTER TABLE builds ADD COLUMN caches JSONB NOT NULL DEFAULT '[]';
@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS idx_builds_command_trgm;
+DROP INDEX IF EXISTS idx_builds_finished_at;
+DROP INDEX IF EXISTS idx_builds_locked_by_created_at;
+DROP INDEX IF EXISTS idx_builds_ref_created_at;
+DROP INDEX IF EXISTS idx_builds_repo_url_created_at;
+DROP INDEX IF EXISTS idx_builds_status_created_at;
+DROP INDEX IF EXISTS idx_builds_created_at_id;
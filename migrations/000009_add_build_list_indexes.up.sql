@@ -0,0 +1,9 @@
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+CREATE INDEX idx_builds_created_at_id ON builds(created_at DESC, id DESC);
+CREATE INDEX idx_builds_status_created_at ON builds(status, created_at DESC, id DESC);
+CREATE INDEX idx_builds_repo_url_created_at ON builds(repo_url, created_at DESC, id DESC);
+CREATE INDEX idx_builds_ref_created_at ON builds(ref, created_at DESC, id DESC);
+CREATE INDEX idx_builds_locked_by_created_at ON builds(locked_by, created_at DESC, id DESC);
+CREATE INDEX idx_builds_finished_at ON builds(finished_at);
+CREATE INDEX idx_builds_command_trgm ON builds USING gin (LOWER(command) gin_trgm_ops);
@@ -0,0 +1,3 @@
+ALTER TABLE builds DROP COLUMN triggered_by;
+
+DROP TABLE IF EXISTS api_tokens CASCADE;
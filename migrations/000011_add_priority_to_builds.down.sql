@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_jobs_status_ranked_at;
+
+ALTER TABLE jobs DROP COLUMN IF EXISTS ranked_at;
+
+ALTER TABLE builds DROP COLUMN IF EXISTS priority;
@@ -0,0 +1,10 @@
+ALTER TABLE builds ADD COLUMN priority INTEGER NOT NULL DEFAULT 0;
+
+-- ranked_at is created_at moved earlier by the build's priority; pending jobs
+-- are claimed in ranked_at order.
+ALTER TABLE jobs ADD COLUMN ranked_at TIMESTAMPTZ;
+UPDATE jobs SET ranked_at = created_at;
+ALTER TABLE jobs ALTER COLUMN ranked_at SET NOT NULL;
+ALTER TABLE jobs ALTER COLUMN ranked_at SET DEFAULT NOW();
+
+CREATE INDEX idx_jobs_status_ranked_at ON jobs(status, ranked_at, created_at);
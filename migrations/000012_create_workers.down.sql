@@ -0,0 +1,3 @@
+ALTER TABLE builds DROP COLUMN IF EXISTS runs_on;
+
+DROP TABLE IF EXISTS workers;
@@ -0,0 +1,11 @@
+CREATE TABLE workers
+(
+    id TEXT PRIMARY KEY,
+    labels JSONB NOT NULL DEFAULT '[]',
+    last_seen_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- runs_on lists the labels a worker needs to claim the build's jobs.
+ALTER TABLE builds ADD COLUMN runs_on JSONB NOT NULL DEFAULT '[]';
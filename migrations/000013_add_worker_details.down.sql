@@ -0,0 +1,5 @@
+ALTER TABLE workers DROP COLUMN IF EXISTS deregistered_at;
+ALTER TABLE workers DROP COLUMN IF EXISTS state;
+ALTER TABLE workers DROP COLUMN IF EXISTS slots;
+ALTER TABLE workers DROP COLUMN IF EXISTS version;
+ALTER TABLE workers DROP COLUMN IF EXISTS hostname;
@@ -0,0 +1,6 @@
+ALTER TABLE workers ADD COLUMN hostname TEXT NOT NULL DEFAULT '';
+ALTER TABLE workers ADD COLUMN version TEXT NOT NULL DEFAULT '';
+ALTER TABLE workers ADD COLUMN slots INTEGER NOT NULL DEFAULT 1;
+-- state is set by admins: active, draining or paused.
+ALTER TABLE workers ADD COLUMN state TEXT NOT NULL DEFAULT 'active';
+ALTER TABLE workers ADD COLUMN deregistered_at TIMESTAMPTZ;
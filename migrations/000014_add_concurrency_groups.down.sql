@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS concurrency_groups;
+DROP INDEX IF EXISTS idx_builds_concurrency_group;
+ALTER TABLE builds DROP COLUMN IF EXISTS concurrency_policy;
+ALTER TABLE builds DROP COLUMN IF EXISTS max_concurrency;
+ALTER TABLE builds DROP COLUMN IF EXISTS concurrency_group;
@@ -0,0 +1,11 @@
+ALTER TABLE builds ADD COLUMN concurrency_group TEXT;
+ALTER TABLE builds ADD COLUMN max_concurrency INTEGER NOT NULL DEFAULT 1;
+-- concurrency_policy is queue, cancel_pending or cancel_in_progress.
+ALTER TABLE builds ADD COLUMN concurrency_policy TEXT NOT NULL DEFAULT 'queue';
+
+CREATE INDEX IF NOT EXISTS idx_builds_concurrency_group ON builds (concurrency_group, status) WHERE concurrency_group IS NOT NULL;
+
+-- One row per group; claims lock it to count running builds consistently.
+CREATE TABLE IF NOT EXISTS concurrency_groups (
+    name TEXT PRIMARY KEY
+);
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_builds_repo_url_status;
+DROP TABLE IF EXISTS repo_limits;
@@ -0,0 +1,12 @@
+-- max_running_builds caps the running top-level builds of a repository;
+-- 0 removes the cap. Claims lock the row while they count.
+CREATE TABLE IF NOT EXISTS repo_limits (
+    repo_url TEXT PRIMARY KEY,
+    max_running_builds INTEGER NOT NULL DEFAULT 0,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- Claims count the running builds of a repository to take turns between
+-- repositories.
+CREATE INDEX IF NOT EXISTS idx_builds_repo_url_status ON builds (repo_url, status) WHERE parent_id IS NULL;
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS schedules;
+ALTER TABLE builds DROP COLUMN IF EXISTS not_before;
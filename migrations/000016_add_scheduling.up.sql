@@ -0,0 +1,23 @@
+-- Jobs of a build with not_before are not claimed before that time.
+ALTER TABLE builds ADD COLUMN not_before TIMESTAMPTZ;
+
+-- next_run_at is the earliest run that has not created a build yet;
+-- schedulers advance it with a compare-and-set, so each run is taken once.
+CREATE TABLE IF NOT EXISTS schedules (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    name TEXT NOT NULL,
+    repo_url TEXT NOT NULL,
+    ref TEXT NOT NULL,
+    command TEXT NOT NULL,
+    env JSONB NOT NULL DEFAULT '{}',
+    cron TEXT NOT NULL,
+    timezone TEXT NOT NULL DEFAULT 'UTC',
+    -- missed_run_policy is skip, run_once or run_all.
+    missed_run_policy TEXT NOT NULL DEFAULT 'run_once',
+    next_run_at TIMESTAMPTZ NOT NULL,
+    last_run_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_schedules_next_run_at ON schedules (next_run_at);
@@ -0,0 +1,3 @@
+-- Running jobs of a canceled build are asked to stop; the worker running the
+-- job sees cancel_requested_at and kills it.
+ALTER TABLE jobs ADD COLUMN cancel_requested_at TIMESTAMPTZ;
@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS api_tokens;
+DROP TABLE IF EXISTS artifacts;
+DROP TABLE IF EXISTS build_logs;
+DROP TABLE IF EXISTS jobs;
+DROP TABLE IF EXISTS builds;
@@ -0,0 +1,102 @@
+-- The SQLite schema matches the Postgres migrations in the parent directory
+-- up to 000010. Ids are random v4 UUIDs stored as text, JSON columns are text.
+CREATE TABLE builds
+(
+    id TEXT PRIMARY KEY DEFAULT (lower(hex(randomblob(4))) || '-' || lower(hex(randomblob(2))) || '-4' || substr(lower(hex(randomblob(2))), 2) || '-' || substr('89ab', 1 + (abs(random()) % 4), 1) || substr(lower(hex(randomblob(2))), 2) || '-' || lower(hex(randomblob(6)))),
+    repo_url TEXT NOT NULL,
+    ref TEXT NOT NULL,
+    command TEXT NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    exit_code INTEGER DEFAULT 0,
+    error TEXT,
+    locked_by TEXT,
+    locked_at DATETIME,
+    finished_at DATETIME,
+    cancel_requested_at DATETIME,
+    attempts INTEGER NOT NULL DEFAULT 0,
+    env TEXT NOT NULL DEFAULT '{}',
+    parent_id TEXT REFERENCES builds (id) ON DELETE CASCADE,
+    matrix TEXT,
+    matrix_values TEXT,
+    artifacts TEXT NOT NULL DEFAULT '[]',
+    caches TEXT NOT NULL DEFAULT '[]',
+    triggered_by TEXT,
+
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_builds_parent_id ON builds(parent_id);
+CREATE INDEX idx_builds_created_at_id ON builds(created_at DESC, id DESC);
+CREATE INDEX idx_builds_status_created_at ON builds(status, created_at DESC, id DESC);
+CREATE INDEX idx_builds_repo_url_created_at ON builds(repo_url, created_at DESC, id DESC);
+CREATE INDEX idx_builds_ref_created_at ON builds(ref, created_at DESC, id DESC);
+CREATE INDEX idx_builds_locked_by_created_at ON builds(locked_by, created_at DESC, id DESC);
+CREATE INDEX idx_builds_finished_at ON builds(finished_at);
+
+CREATE TABLE jobs
+(
+    id TEXT PRIMARY KEY DEFAULT (lower(hex(randomblob(4))) || '-' || lower(hex(randomblob(2))) || '-4' || substr(lower(hex(randomblob(2))), 2) || '-' || substr('89ab', 1 + (abs(random()) % 4), 1) || substr(lower(hex(randomblob(2))), 2) || '-' || lower(hex(randomblob(6)))),
+    build_id TEXT NOT NULL,
+    name TEXT NOT NULL,
+    command TEXT NOT NULL,
+    needs TEXT NOT NULL DEFAULT '[]',
+    artifacts TEXT NOT NULL DEFAULT '[]',
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    exit_code INTEGER DEFAULT 0,
+    error TEXT,
+    locked_by TEXT,
+    locked_at DATETIME,
+    finished_at DATETIME,
+
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (build_id) REFERENCES builds (id) ON DELETE CASCADE,
+    UNIQUE (build_id, name)
+);
+
+CREATE INDEX idx_jobs_status_created_at ON jobs(status, created_at);
+
+-- seq is the rowid, so it grows with every insert like the Postgres sequence.
+CREATE TABLE build_logs
+(
+    seq INTEGER PRIMARY KEY AUTOINCREMENT,
+    id TEXT NOT NULL UNIQUE DEFAULT (lower(hex(randomblob(4))) || '-' || lower(hex(randomblob(2))) || '-4' || substr(lower(hex(randomblob(2))), 2) || '-' || substr('89ab', 1 + (abs(random()) % 4), 1) || substr(lower(hex(randomblob(2))), 2) || '-' || lower(hex(randomblob(6)))),
+    build_id TEXT NOT NULL,
+    job_id TEXT REFERENCES jobs (id) ON DELETE CASCADE,
+    stream VARCHAR(10) NOT NULL DEFAULT 'stdout',
+    content TEXT NOT NULL,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (build_id) REFERENCES builds (id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_build_logs_build_id_seq ON build_logs(build_id, seq);
+CREATE INDEX idx_build_logs_job_id_seq ON build_logs(job_id, seq);
+
+CREATE TABLE artifacts
+(
+    id TEXT PRIMARY KEY DEFAULT (lower(hex(randomblob(4))) || '-' || lower(hex(randomblob(2))) || '-4' || substr(lower(hex(randomblob(2))), 2) || '-' || substr('89ab', 1 + (abs(random()) % 4), 1) || substr(lower(hex(randomblob(2))), 2) || '-' || lower(hex(randomblob(6)))),
+    build_id TEXT NOT NULL,
+    job_id TEXT NOT NULL,
+    name TEXT NOT NULL,
+    size INTEGER NOT NULL DEFAULT 0,
+    checksum TEXT NOT NULL,
+    content_type TEXT NOT NULL,
+    storage_key TEXT NOT NULL,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (build_id) REFERENCES builds (id) ON DELETE CASCADE,
+    FOREIGN KEY (job_id) REFERENCES jobs (id) ON DELETE CASCADE,
+    UNIQUE (build_id, name)
+);
+
+CREATE TABLE api_tokens
+(
+    id TEXT PRIMARY KEY DEFAULT (lower(hex(randomblob(4))) || '-' || lower(hex(randomblob(2))) || '-4' || substr(lower(hex(randomblob(2))), 2) || '-' || substr('89ab', 1 + (abs(random()) % 4), 1) || substr(lower(hex(randomblob(2))), 2) || '-' || lower(hex(randomblob(6)))),
+    name TEXT NOT NULL UNIQUE,
+    prefix TEXT NOT NULL,
+    token_hash TEXT NOT NULL UNIQUE,
+    scopes TEXT NOT NULL DEFAULT '[]',
+    last_used_at DATETIME,
+    revoked_at DATETIME,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
@@ -0,0 +1,6 @@
+ALTER TABLE builds ADD COLUMN priority INTEGER NOT NULL DEFAULT 0;
+
+ALTER TABLE jobs ADD COLUMN ranked_at DATETIME;
+UPDATE jobs SET ranked_at = created_at;
+
+CREATE INDEX idx_jobs_status_ranked_at ON jobs(status, ranked_at, created_at);
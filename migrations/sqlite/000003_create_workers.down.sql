@@ -0,0 +1,3 @@
+ALTER TABLE builds DROP COLUMN runs_on;
+
+DROP TABLE IF EXISTS workers;
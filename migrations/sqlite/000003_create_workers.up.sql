@@ -0,0 +1,10 @@
+CREATE TABLE workers
+(
+    id TEXT PRIMARY KEY,
+    labels TEXT NOT NULL DEFAULT '[]',
+    last_seen_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+ALTER TABLE builds ADD COLUMN runs_on TEXT NOT NULL DEFAULT '[]';
@@ -0,0 +1,5 @@
+ALTER TABLE workers DROP COLUMN deregistered_at;
+ALTER TABLE workers DROP COLUMN state;
+ALTER TABLE workers DROP COLUMN slots;
+ALTER TABLE workers DROP COLUMN version;
+ALTER TABLE workers DROP COLUMN hostname;
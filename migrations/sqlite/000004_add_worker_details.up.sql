@@ -0,0 +1,5 @@
+ALTER TABLE workers ADD COLUMN hostname TEXT NOT NULL DEFAULT '';
+ALTER TABLE workers ADD COLUMN version TEXT NOT NULL DEFAULT '';
+ALTER TABLE workers ADD COLUMN slots INTEGER NOT NULL DEFAULT 1;
+ALTER TABLE workers ADD COLUMN state TEXT NOT NULL DEFAULT 'active';
+ALTER TABLE workers ADD COLUMN deregistered_at DATETIME;
@@ -0,0 +1,5 @@
+DROP TABLE concurrency_groups;
+DROP INDEX idx_builds_concurrency_group;
+ALTER TABLE builds DROP COLUMN concurrency_policy;
+ALTER TABLE builds DROP COLUMN max_concurrency;
+ALTER TABLE builds DROP COLUMN concurrency_group;
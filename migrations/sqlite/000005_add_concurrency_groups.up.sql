@@ -0,0 +1,9 @@
+ALTER TABLE builds ADD COLUMN concurrency_group TEXT;
+ALTER TABLE builds ADD COLUMN max_concurrency INTEGER NOT NULL DEFAULT 1;
+ALTER TABLE builds ADD COLUMN concurrency_policy TEXT NOT NULL DEFAULT 'queue';
+
+CREATE INDEX idx_builds_concurrency_group ON builds (concurrency_group, status);
+
+CREATE TABLE concurrency_groups (
+    name TEXT PRIMARY KEY
+);
@@ -0,0 +1,2 @@
+DROP INDEX idx_builds_repo_url_status;
+DROP TABLE repo_limits;
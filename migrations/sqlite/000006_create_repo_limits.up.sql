@@ -0,0 +1,8 @@
+CREATE TABLE repo_limits (
+    repo_url TEXT PRIMARY KEY,
+    max_running_builds INTEGER NOT NULL DEFAULT 0,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_builds_repo_url_status ON builds (repo_url, status) WHERE parent_id IS NULL;
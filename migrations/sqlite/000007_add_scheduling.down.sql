@@ -0,0 +1,2 @@
+DROP TABLE schedules;
+ALTER TABLE builds DROP COLUMN not_before;
@@ -0,0 +1,19 @@
+ALTER TABLE builds ADD COLUMN not_before DATETIME;
+
+CREATE TABLE schedules (
+    id TEXT PRIMARY KEY DEFAULT (lower(hex(randomblob(4))) || '-' || lower(hex(randomblob(2))) || '-4' || substr(lower(hex(randomblob(2))), 2) || '-' || substr('89ab', 1 + (abs(random()) % 4), 1) || substr(lower(hex(randomblob(2))), 2) || '-' || lower(hex(randomblob(6)))),
+    name TEXT NOT NULL,
+    repo_url TEXT NOT NULL,
+    ref TEXT NOT NULL,
+    command TEXT NOT NULL,
+    env TEXT NOT NULL DEFAULT '{}',
+    cron TEXT NOT NULL,
+    timezone TEXT NOT NULL DEFAULT 'UTC',
+    missed_run_policy TEXT NOT NULL DEFAULT 'run_once',
+    next_run_at DATETIME NOT NULL,
+    last_run_at DATETIME,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_schedules_next_run_at ON schedules (next_run_at);
@@ -0,0 +1 @@
+ALTER TABLE jobs ADD COLUMN cancel_requested_at DATETIME;